@@ -6,17 +6,25 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/server/proto"
 	"github.com/tarality/tan-network/types"
 	"github.com/hashicorp/go-hclog"
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+var (
+	ErrNothingToBackup   = errors.New("all the blocks in the range are backed up already")
+	ErrBackupNotLinked   = errors.New("the last backed up block is not in the chain of the node")
+	ErrBackupInterrupted = errors.New("backup was interrupted before the last block")
+)
+
 // CreateBackup fetches blockchain data with the specific range via gRPC
 // and save this data as binary archive to given path
 func CreateBackup(
@@ -25,11 +33,129 @@ func CreateBackup(
 	from uint64,
 	to *uint64,
 	outPath string,
+	compression Compression,
 ) (uint64, uint64, error) {
+	ctx, cancelFn := backupContext(logger)
+	defer cancelFn()
+
+	segment, _, err := createBackup(ctx, proto.NewSystemClient(conn), logger, from, to, outPath, compression)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return segment.From, segment.To, nil
+}
+
+// CreateIncrementalBackup fetches the blocks following the last segment of the manifest via gRPC,
+// saves them as a new segment next to the manifest and adds the segment to the manifest
+func CreateIncrementalBackup(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	to *uint64,
+	manifestPath string,
+	compression Compression,
+) (*Segment, error) {
+	ctx, cancelFn := backupContext(logger)
+	defer cancelFn()
+
+	return createIncrementalBackup(ctx, proto.NewSystemClient(conn), logger, to, manifestPath, compression)
+}
+
+func createIncrementalBackup(
+	ctx context.Context,
+	clt proto.SystemClient,
+	logger hclog.Logger,
+	to *uint64,
+	manifestPath string,
+	compression Compression,
+) (*Segment, error) {
+	manifest, err := ReadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	from := uint64(0)
+
+	if last := manifest.Last(); last != nil {
+		// the new segment has to continue the chain of the previous one
+		hash, err := fetchBlockHash(ctx, clt, last.To)
+		if err != nil {
+			return nil, err
+		}
+
+		if hash != last.Hash {
+			return nil, fmt.Errorf("%w: block %d (%s)", ErrBackupNotLinked, last.To, last.Hash)
+		}
+
+		from = last.To + 1
+	}
+
+	outPath := filepath.Join(filepath.Dir(manifestPath), fmt.Sprintf("segment_%010d.bak", from))
+
+	segment, metadata, err := createBackup(ctx, clt, logger, from, to, outPath, compression)
+	if err != nil {
+		return nil, err
+	}
+
+	// a segment has to be complete, the next one starts after its last block
+	if segment.To != metadata.Latest {
+		removeBackupFile(logger, outPath)
+
+		return nil, ErrBackupInterrupted
+	}
+
+	if segment.Checksum, err = fileChecksum(outPath); err != nil {
+		return nil, err
+	}
+
+	segment.File = filepath.Base(outPath)
+	manifest.Segments = append(manifest.Segments, segment)
+
+	if err := WriteManifest(manifestPath, manifest); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// backupContext returns the context which is canceled on the termination signal
+func backupContext(logger hclog.Logger) (context.Context, context.CancelFunc) {
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-signalCh:
+			logger.Info("Caught termination signal, shutting down...")
+			cancelFn()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancelFn
+}
+
+func createBackup(
+	ctx context.Context,
+	clt proto.SystemClient,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outPath string,
+	compression Compression,
+) (*Segment, *Metadata, error) {
+	if compression == "" {
+		compression = CompressionNone
+	}
+
+	if !compression.IsValid() {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, compression)
+	}
+
 	// always create new file, throw error if the file exists
 	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
-		return 0, 0, err
+		return nil, nil, err
 	}
 
 	closeFile := func() error {
@@ -41,36 +167,35 @@ func CreateBackup(
 
 		return nil
 	}
-	removeFile := func() {
-		if err := os.Remove(outPath); err != nil {
-			logger.Error("an error occurred while removing file", "err", err)
-		}
-	}
 	// clean up function for the file when error occurs in the middle of function
 	closeAndRemoveFile := func() {
 		if err := closeFile(); err == nil {
-			removeFile()
+			removeBackupFile(logger, outPath)
 		}
 	}
 
-	signalCh := common.GetTerminationSignalCh()
-	ctx, cancelFn := context.WithCancel(context.Background())
+	reqTo, reqToHash, err := determineTo(ctx, clt, to)
+	if err != nil {
+		closeAndRemoveFile()
 
-	defer cancelFn()
+		return nil, nil, err
+	}
 
-	go func() {
-		<-signalCh
-		logger.Info("Caught termination signal, shutting down...")
-		cancelFn()
-	}()
+	if from > reqTo {
+		closeAndRemoveFile()
 
-	clt := proto.NewSystemClient(conn)
+		return nil, nil, ErrNothingToBackup
+	}
 
-	reqTo, reqToHash, err := determineTo(ctx, clt, to)
-	if err != nil {
-		closeAndRemoveFile()
+	// the hash of the parent links the backup to the preceding blocks
+	var parentHash types.Hash
 
-		return 0, 0, err
+	if from > 0 {
+		if parentHash, err = fetchBlockHash(ctx, clt, from-1); err != nil {
+			closeAndRemoveFile()
+
+			return nil, nil, err
+		}
 	}
 
 	stream, err := clt.Export(ctx, &proto.ExportRequest{
@@ -80,29 +205,89 @@ func CreateBackup(
 	if err != nil {
 		closeAndRemoveFile()
 
-		return 0, 0, err
+		return nil, nil, err
+	}
+
+	metadata := &Metadata{
+		Latest:      reqTo,
+		LatestHash:  reqToHash,
+		From:        from,
+		ParentHash:  parentHash,
+		Compression: compression,
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
+	if err := writeMetadata(fs, logger, metadata); err != nil {
 		closeAndRemoveFile()
 
-		return 0, 0, err
+		return nil, nil, err
+	}
+
+	// the metadata stays uncompressed, so the backup can be inspected without decompressing it
+	var (
+		writer  io.Writer = fs
+		encoder *zstd.Encoder
+	)
+
+	if compression == CompressionZstd {
+		if encoder, err = zstd.NewWriter(fs); err != nil {
+			closeAndRemoveFile()
+
+			return nil, nil, err
+		}
+
+		writer = encoder
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
+	resFrom, resTo, err := processExportStream(stream, logger, writer, from, reqTo)
 	if err != nil {
 		closeAndRemoveFile()
 
-		return 0, 0, err
+		return nil, nil, err
+	}
+
+	if encoder != nil {
+		if err := encoder.Close(); err != nil {
+			closeAndRemoveFile()
+
+			return nil, nil, err
+		}
 	}
 
 	if err := closeFile(); err != nil {
-		removeFile()
+		removeBackupFile(logger, outPath)
 
-		return 0, 0, err
+		return nil, nil, err
+	}
+
+	return &Segment{
+		File:        outPath,
+		From:        *resFrom,
+		To:          *resTo,
+		ParentHash:  parentHash,
+		Hash:        reqToHash,
+		Compression: compression,
+	}, metadata, nil
+}
+
+func removeBackupFile(logger hclog.Logger, path string) {
+	if err := os.Remove(path); err != nil {
+		logger.Error("an error occurred while removing file", "err", err)
+	}
+}
+
+// fetchBlockHash returns the hash of the block with the given number from the node
+func fetchBlockHash(ctx context.Context, clt proto.SystemClient, number uint64) (types.Hash, error) {
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: number})
+	if err != nil {
+		return types.Hash{}, err
 	}
 
-	return *resFrom, *resTo, nil
+	block := types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return types.Hash{}, err
+	}
+
+	return block.Hash(), nil
 }
 
 func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint64, types.Hash, error) {
@@ -127,19 +312,14 @@ func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint6
 	return uint64(status.Current.Number), types.StringToHash(status.Current.Hash), nil
 }
 
-// writeMetadata writes the latest block height and the block hash to the writer
-func writeMetadata(writer io.Writer, logger hclog.Logger, to uint64, toHash types.Hash) error {
-	metadata := Metadata{
-		Latest:     to,
-		LatestHash: toHash,
-	}
-
+// writeMetadata writes the metadata of the backup to the writer
+func writeMetadata(writer io.Writer, logger hclog.Logger, metadata *Metadata) error {
 	_, err := writer.Write(metadata.MarshalRLP())
 	if err != nil {
 		return err
 	}
 
-	logger.Info("Wrote metadata to backup", "latest", to, "hash", toHash)
+	logger.Info("Wrote metadata to backup", "latest", metadata.Latest, "hash", metadata.LatestHash)
 
 	return err
 }
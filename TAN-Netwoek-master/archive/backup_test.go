@@ -66,8 +66,13 @@ var (
 func init() {
 	genesis.Header.ComputeHash()
 
+	parent := genesis
+
 	for _, b := range blocks {
+		b.Header.ParentHash = parent.Hash()
 		b.Header.ComputeHash()
+
+		parent = b
 	}
 }
 
@@ -0,0 +1,422 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/server/proto"
+	"github.com/tarality/tan-network/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	blocksTable       = "blocks"
+	transactionsTable = "transactions"
+	logsTable         = "logs"
+
+	// DefaultExportPartitionSize is the default number of blocks of a single partition file
+	DefaultExportPartitionSize uint64 = 100000
+)
+
+var (
+	ErrInvalidExportFormat        = errors.New("invalid export format")
+	ErrInvalidExportPartitionSize = errors.New("partition size must be greater than 0")
+	ErrNothingToExport            = errors.New("all the blocks in the range are exported already")
+)
+
+// exportTables are the exported tables, in the order their partition files are finalized.
+// The blocks table is finalized last, so its files mark the completely exported ranges
+var exportTables = []struct {
+	name    string
+	rowType interface{}
+}{
+	{transactionsTable, TransactionRow{}},
+	{logsTable, LogRow{}},
+	{blocksTable, BlockRow{}},
+}
+
+// ExportConfig is the configuration of the table export
+type ExportConfig struct {
+	OutDir        string       // directory of the exported tables
+	Format        ExportFormat // file format of the tables
+	From          uint64       // first block to export
+	To            *uint64      // last block to export, the latest block if not set
+	PartitionSize uint64       // number of blocks of a single partition file
+	Resume        bool         // continue after the last block exported to OutDir
+}
+
+// ExportTables fetches the blocks and the receipts with the specific range via gRPC
+// and writes them as the flat blocks, transactions and logs tables,
+// partitioned by the block range, to the output directory
+func ExportTables(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	config *ExportConfig,
+) (uint64, uint64, error) {
+	ctx, cancelFn := backupContext(logger)
+	defer cancelFn()
+
+	return exportTablesFrom(ctx, proto.NewSystemClient(conn), logger, config)
+}
+
+func exportTablesFrom(
+	ctx context.Context,
+	clt proto.SystemClient,
+	logger hclog.Logger,
+	config *ExportConfig,
+) (uint64, uint64, error) {
+	if !config.Format.IsValid() {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidExportFormat, config.Format)
+	}
+
+	if config.PartitionSize == 0 {
+		return 0, 0, ErrInvalidExportPartitionSize
+	}
+
+	for _, table := range exportTables {
+		if err := os.MkdirAll(filepath.Join(config.OutDir, table.name), 0755); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	from := config.From
+
+	if config.Resume {
+		last, ok, err := lastExportedBlock(config.OutDir, config.Format)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if ok && last+1 > from {
+			logger.Info("Resuming the export", "last", last)
+
+			from = last + 1
+		}
+	}
+
+	serverStatus, err := clt.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, _, err := determineTo(ctx, clt, config.To)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from > to {
+		return 0, 0, ErrNothingToExport
+	}
+
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+
+	stream, err := clt.Export(streamCtx, &proto.ExportRequest{
+		From:     from,
+		To:       to,
+		Receipts: true,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	chainID := uint64(serverStatus.Network)
+	exporter := &tableExporter{
+		config: config,
+		signer: crypto.NewLondonSigner(chainID, true, crypto.NewEIP155Signer(chainID, true)),
+		logger: logger,
+	}
+
+	resFrom, resTo, err := exporter.processStream(stream, to)
+	if err != nil {
+		exporter.abort()
+
+		return 0, 0, err
+	}
+
+	if err := exporter.finalize(); err != nil {
+		exporter.abort()
+
+		return 0, 0, err
+	}
+
+	return *resFrom, *resTo, nil
+}
+
+// tableExporter writes the decoded blocks into the partition files of the tables
+type tableExporter struct {
+	config *ExportConfig
+	signer crypto.TxSigner
+	logger hclog.Logger
+
+	partition *tablePartition // partition being written
+}
+
+// tablePartition is a set of temporary table files of a block range,
+// which are renamed to their final names once the range is written
+type tablePartition struct {
+	from    uint64
+	to      uint64        // last written block
+	end     uint64        // last block of the partition
+	paths   []string      // temporary files, in the order of exportTables
+	writers []tableWriter // writers of the temporary files
+}
+
+// processStream decodes and writes the blocks of the export stream up to the given block
+func (e *tableExporter) processStream(stream proto.System_ExportClient, to uint64) (*uint64, *uint64, error) {
+	var from, last *uint64
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		done, err := e.processEvent(event, to, &from, &last)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if last != nil {
+			e.logger.Info("Exported blocks", "from", *from, "to", *last, "target", to)
+		}
+
+		if done {
+			break
+		}
+	}
+
+	if from == nil || last == nil {
+		return nil, nil, errors.New("couldn't get any blocks")
+	}
+
+	return from, last, nil
+}
+
+// processEvent writes the blocks of the event, it returns true once the given block is written
+func (e *tableExporter) processEvent(event *proto.ExportEvent, to uint64, from, last **uint64) (bool, error) {
+	blockStream := newBlockStream(bytes.NewReader(event.Data))
+
+	for {
+		block, err := blockStream.nextBlock()
+		if err != nil {
+			return false, err
+		}
+
+		if block == nil {
+			return false, nil
+		}
+
+		receipts, err := blockStream.nextReceipts()
+		if err != nil {
+			return false, err
+		}
+
+		if receipts == nil {
+			return false, fmt.Errorf("receipts of block %d are missing", block.Number())
+		}
+
+		number := block.Number()
+		if number > to {
+			return true, nil
+		}
+
+		if err := e.writeBlock(block, receipts); err != nil {
+			return false, fmt.Errorf("failed to export block %d: %w", number, err)
+		}
+
+		if *from == nil {
+			*from = &number
+		}
+
+		*last = &number
+
+		if number == to {
+			return true, nil
+		}
+	}
+}
+
+// writeBlock writes the rows of the block to the partition of the block
+func (e *tableExporter) writeBlock(block *types.Block, receipts types.Receipts) error {
+	rows, err := toExportRows(block, receipts, e.signer)
+	if err != nil {
+		return err
+	}
+
+	number := block.Number()
+
+	if e.partition != nil && number > e.partition.end {
+		if err := e.finalize(); err != nil {
+			return err
+		}
+	}
+
+	if e.partition == nil {
+		if e.partition, err = e.openPartition(number); err != nil {
+			return err
+		}
+	}
+
+	partition := e.partition
+
+	for _, tx := range rows.transactions {
+		if err := partition.writers[0].Write(tx); err != nil {
+			return err
+		}
+	}
+
+	for _, log := range rows.logs {
+		if err := partition.writers[1].Write(log); err != nil {
+			return err
+		}
+	}
+
+	if err := partition.writers[2].Write(rows.block); err != nil {
+		return err
+	}
+
+	partition.to = number
+
+	return nil
+}
+
+// openPartition creates the temporary table files of the partition starting at the given block
+func (e *tableExporter) openPartition(from uint64) (*tablePartition, error) {
+	size := e.config.PartitionSize
+	partition := &tablePartition{
+		from: from,
+		to:   from,
+		end:  (from/size+1)*size - 1,
+	}
+
+	for _, table := range exportTables {
+		path := filepath.Join(e.config.OutDir, table.name, fmt.Sprintf(".%s_%010d.%s.tmp", table.name, from, e.config.Format))
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			e.partition = partition
+			e.abort()
+
+			return nil, err
+		}
+
+		writer, err := newTableWriter(e.config.Format, file, table.rowType)
+		if err != nil {
+			file.Close()
+
+			e.partition = partition
+			e.abort()
+
+			return nil, err
+		}
+
+		partition.paths = append(partition.paths, path)
+		partition.writers = append(partition.writers, writer)
+	}
+
+	return partition, nil
+}
+
+// finalize closes the table files of the current partition and moves them to their final names
+func (e *tableExporter) finalize() error {
+	partition := e.partition
+	if partition == nil {
+		return nil
+	}
+
+	for i, writer := range partition.writers {
+		if err := writer.Close(); err != nil {
+			return err
+		}
+
+		partition.writers[i] = nil
+	}
+
+	for i, table := range exportTables {
+		if err := os.Rename(partition.paths[i], partitionPath(e.config, table.name, partition.from, partition.to)); err != nil {
+			return err
+		}
+	}
+
+	e.partition = nil
+
+	return nil
+}
+
+// abort closes and removes the temporary table files of the current partition
+func (e *tableExporter) abort() {
+	partition := e.partition
+	if partition == nil {
+		return
+	}
+
+	for i, writer := range partition.writers {
+		if writer != nil {
+			writer.Close()
+		}
+
+		if err := os.Remove(partition.paths[i]); err != nil && !os.IsNotExist(err) {
+			e.logger.Error("an error occurred while removing file", "err", err)
+		}
+	}
+
+	e.partition = nil
+}
+
+// partitionPath returns the path of the table file of the block range
+func partitionPath(config *ExportConfig, table string, from, to uint64) string {
+	return filepath.Join(config.OutDir, table, fmt.Sprintf("%s_%010d_%010d.%s", table, from, to, config.Format))
+}
+
+// lastExportedBlock returns the last block of the blocks table files in the output directory
+func lastExportedBlock(outDir string, format ExportFormat) (uint64, bool, error) {
+	entries, err := os.ReadDir(filepath.Join(outDir, blocksTable))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	var (
+		last  uint64
+		found bool
+	)
+
+	suffix := "." + string(format)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, blocksTable+"_") || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+
+		var from, to uint64
+		if _, err := fmt.Sscanf(strings.TrimSuffix(name, suffix), blocksTable+"_%d_%d", &from, &to); err != nil {
+			continue
+		}
+
+		if !found || to > last {
+			last, found = to, true
+		}
+	}
+
+	return last, found, nil
+}
@@ -0,0 +1,197 @@
+package archive
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/helper/hex"
+	"github.com/tarality/tan-network/types"
+)
+
+// Hashes and addresses of the export tables are lowercase hex strings, and the big numbers
+// (values and gas prices) are decimal strings, so they can be used by any analytics tool
+
+// BlockRow is a row of the exported blocks table
+type BlockRow struct {
+	Number           uint64 `json:"number" parquet:"number"`
+	Hash             string `json:"hash" parquet:"hash"`
+	ParentHash       string `json:"parentHash" parquet:"parent_hash"`
+	Timestamp        uint64 `json:"timestamp" parquet:"timestamp"`
+	Miner            string `json:"miner" parquet:"miner"`
+	StateRoot        string `json:"stateRoot" parquet:"state_root"`
+	TransactionsRoot string `json:"transactionsRoot" parquet:"transactions_root"`
+	ReceiptsRoot     string `json:"receiptsRoot" parquet:"receipts_root"`
+	GasLimit         uint64 `json:"gasLimit" parquet:"gas_limit"`
+	GasUsed          uint64 `json:"gasUsed" parquet:"gas_used"`
+	BaseFee          uint64 `json:"baseFee" parquet:"base_fee"`
+	Difficulty       uint64 `json:"difficulty" parquet:"difficulty"`
+	ExtraData        string `json:"extraData" parquet:"extra_data"`
+	TransactionCount uint64 `json:"transactionCount" parquet:"transaction_count"`
+}
+
+// TransactionRow is a row of the exported transactions table
+type TransactionRow struct {
+	BlockNumber       uint64  `json:"blockNumber" parquet:"block_number"`
+	BlockHash         string  `json:"blockHash" parquet:"block_hash"`
+	TransactionIndex  uint64  `json:"transactionIndex" parquet:"transaction_index"`
+	Hash              string  `json:"hash" parquet:"hash"`
+	Type              uint64  `json:"type" parquet:"type"`
+	From              string  `json:"from" parquet:"from"`
+	To                *string `json:"to" parquet:"to,optional"`
+	Nonce             uint64  `json:"nonce" parquet:"nonce"`
+	Value             string  `json:"value" parquet:"value"`
+	Gas               uint64  `json:"gas" parquet:"gas"`
+	GasPrice          string  `json:"gasPrice" parquet:"gas_price"`
+	GasTipCap         string  `json:"maxPriorityFeePerGas" parquet:"max_priority_fee_per_gas"`
+	GasFeeCap         string  `json:"maxFeePerGas" parquet:"max_fee_per_gas"`
+	EffectiveGasPrice string  `json:"effectiveGasPrice" parquet:"effective_gas_price"`
+	Input             string  `json:"input" parquet:"input"`
+	Status            *uint64 `json:"status" parquet:"status,optional"`
+	GasUsed           uint64  `json:"gasUsed" parquet:"gas_used"`
+	CumulativeGasUsed uint64  `json:"cumulativeGasUsed" parquet:"cumulative_gas_used"`
+	ContractAddress   *string `json:"contractAddress" parquet:"contract_address,optional"`
+}
+
+// LogRow is a row of the exported logs table
+type LogRow struct {
+	BlockNumber      uint64  `json:"blockNumber" parquet:"block_number"`
+	BlockHash        string  `json:"blockHash" parquet:"block_hash"`
+	TransactionIndex uint64  `json:"transactionIndex" parquet:"transaction_index"`
+	TransactionHash  string  `json:"transactionHash" parquet:"transaction_hash"`
+	LogIndex         uint64  `json:"logIndex" parquet:"log_index"`
+	Address          string  `json:"address" parquet:"address"`
+	Topic0           *string `json:"topic0" parquet:"topic0,optional"`
+	Topic1           *string `json:"topic1" parquet:"topic1,optional"`
+	Topic2           *string `json:"topic2" parquet:"topic2,optional"`
+	Topic3           *string `json:"topic3" parquet:"topic3,optional"`
+	Data             string  `json:"data" parquet:"data"`
+}
+
+// exportRows are the rows of the export tables of a single block
+type exportRows struct {
+	block        *BlockRow
+	transactions []*TransactionRow
+	logs         []*LogRow
+}
+
+// toExportRows flattens the block and its receipts into the rows of the export tables
+func toExportRows(block *types.Block, receipts types.Receipts, signer crypto.TxSigner) (*exportRows, error) {
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("block %d has %d transactions but %d receipts",
+			block.Number(), len(block.Transactions), len(receipts))
+	}
+
+	header := block.Header
+	blockHash := header.Hash.String()
+
+	rows := &exportRows{
+		block: &BlockRow{
+			Number:           header.Number,
+			Hash:             blockHash,
+			ParentHash:       header.ParentHash.String(),
+			Timestamp:        header.Timestamp,
+			Miner:            hex.EncodeToHex(header.Miner),
+			StateRoot:        header.StateRoot.String(),
+			TransactionsRoot: header.TxRoot.String(),
+			ReceiptsRoot:     header.ReceiptsRoot.String(),
+			GasLimit:         header.GasLimit,
+			GasUsed:          header.GasUsed,
+			BaseFee:          header.BaseFee,
+			Difficulty:       header.Difficulty,
+			ExtraData:        hex.EncodeToHex(header.ExtraData),
+			TransactionCount: uint64(len(block.Transactions)),
+		},
+		transactions: make([]*TransactionRow, 0, len(block.Transactions)),
+		logs:         make([]*LogRow, 0),
+	}
+
+	logIndex := uint64(0)
+
+	for i, tx := range block.Transactions {
+		from := tx.From
+		if from == types.ZeroAddress {
+			sender, err := signer.Sender(tx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover the sender of transaction %s: %w", tx.Hash, err)
+			}
+
+			from = sender
+		}
+
+		receipt := receipts[i]
+		txHash := tx.Hash.String()
+
+		txRow := &TransactionRow{
+			BlockNumber:       header.Number,
+			BlockHash:         blockHash,
+			TransactionIndex:  uint64(i),
+			Hash:              txHash,
+			Type:              uint64(tx.Type),
+			From:              addressString(from),
+			Nonce:             tx.Nonce,
+			Value:             bigString(tx.Value),
+			Gas:               tx.Gas,
+			GasPrice:          bigString(tx.GasPrice),
+			GasTipCap:         bigString(tx.GasTipCap),
+			GasFeeCap:         bigString(tx.GasFeeCap),
+			EffectiveGasPrice: bigString(tx.GetGasPrice(header.BaseFee)),
+			Input:             hex.EncodeToHex(tx.Input),
+			GasUsed:           receipt.GasUsed,
+			CumulativeGasUsed: receipt.CumulativeGasUsed,
+		}
+
+		if tx.To != nil {
+			to := addressString(*tx.To)
+			txRow.To = &to
+		}
+
+		if receipt.Status != nil {
+			status := uint64(*receipt.Status)
+			txRow.Status = &status
+		}
+
+		if receipt.ContractAddress != nil {
+			contractAddress := addressString(*receipt.ContractAddress)
+			txRow.ContractAddress = &contractAddress
+		}
+
+		rows.transactions = append(rows.transactions, txRow)
+
+		for _, log := range receipt.Logs {
+			logRow := &LogRow{
+				BlockNumber:      header.Number,
+				BlockHash:        blockHash,
+				TransactionIndex: uint64(i),
+				TransactionHash:  txHash,
+				LogIndex:         logIndex,
+				Address:          addressString(log.Address),
+				Data:             hex.EncodeToHex(log.Data),
+			}
+
+			for j, topic := range []**string{&logRow.Topic0, &logRow.Topic1, &logRow.Topic2, &logRow.Topic3} {
+				if j < len(log.Topics) {
+					value := log.Topics[j].String()
+					*topic = &value
+				}
+			}
+
+			rows.logs = append(rows.logs, logRow)
+			logIndex++
+		}
+	}
+
+	return rows, nil
+}
+
+func addressString(addr types.Address) string {
+	return hex.EncodeToHex(addr.Bytes())
+}
+
+func bigString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
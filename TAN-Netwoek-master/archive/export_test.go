@@ -0,0 +1,282 @@
+package archive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/server/proto"
+	"github.com/tarality/tan-network/types"
+	"github.com/tarality/tan-network/types/buildroot"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const exportChainID = 100
+
+type exportClientMock struct {
+	proto.SystemClient
+	blocks   []*types.Block
+	receipts []types.Receipts
+	requests []*proto.ExportRequest
+}
+
+func (m *exportClientMock) GetStatus(context.Context, *emptypb.Empty, ...grpc.CallOption) (*proto.ServerStatus, error) {
+	latest := m.blocks[len(m.blocks)-1]
+
+	return &proto.ServerStatus{
+		Network: exportChainID,
+		Current: &proto.ServerStatus_Block{
+			Number: int64(latest.Number()),
+			Hash:   latest.Hash().String(),
+		},
+	}, nil
+}
+
+func (m *exportClientMock) BlockByNumber(
+	_ context.Context,
+	req *proto.BlockByNumberRequest,
+	_ ...grpc.CallOption,
+) (*proto.BlockResponse, error) {
+	return &proto.BlockResponse{Data: m.blocks[req.Number].MarshalRLP()}, nil
+}
+
+func (m *exportClientMock) Export(
+	_ context.Context,
+	req *proto.ExportRequest,
+	_ ...grpc.CallOption,
+) (proto.System_ExportClient, error) {
+	m.requests = append(m.requests, req)
+
+	to := req.To
+	if to == 0 {
+		to = uint64(len(m.blocks) - 1)
+	}
+
+	// a single event per block, the receipts follow the block if requested
+	recvs := make([]recvData, 0)
+
+	for i := req.From; i <= to; i++ {
+		data := m.blocks[i].MarshalRLP()
+		if req.Receipts {
+			data = m.receipts[i].MarshalStoreRLPTo(data)
+		}
+
+		recvs = append(recvs, recvData{
+			event: &proto.ExportEvent{From: i, To: i, Data: data},
+		})
+	}
+
+	return &mockSystemExportClient{recvs: recvs}, nil
+}
+
+// newExportClientMock creates a chain of the given length, every block has a transfer with a log
+func newExportClientMock(t *testing.T, length uint64) (*exportClientMock, types.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	signer := crypto.NewLondonSigner(exportChainID, true, crypto.NewEIP155Signer(exportChainID, true))
+	to := types.StringToAddress("2")
+	success := types.ReceiptSuccess
+
+	m := &exportClientMock{}
+
+	for i := uint64(0); i < length; i++ {
+		header := &types.Header{Number: i, BaseFee: 10}
+		if i > 0 {
+			header.ParentHash = m.blocks[i-1].Hash()
+		}
+
+		block := &types.Block{Header: header}
+		receipts := types.Receipts{}
+
+		// genesis has no transactions
+		if i > 0 {
+			tx, err := signer.SignTx(&types.Transaction{
+				Type:      types.DynamicFeeTx,
+				Nonce:     i - 1,
+				To:        &to,
+				Value:     big.NewInt(1),
+				Gas:       21000,
+				GasTipCap: big.NewInt(2),
+				GasFeeCap: big.NewInt(100),
+				ChainID:   big.NewInt(exportChainID),
+			}, key)
+			require.NoError(t, err)
+
+			tx.ComputeHash(1)
+
+			block.Transactions = []*types.Transaction{tx}
+			receipts = append(receipts, &types.Receipt{
+				Status:            &success,
+				GasUsed:           21000,
+				CumulativeGasUsed: 21000,
+				TxHash:            tx.Hash,
+				Logs: []*types.Log{{
+					Address: to,
+					Topics:  []types.Hash{types.StringToHash("1"), types.StringToHash("2")},
+					Data:    []byte{0x1},
+				}},
+			})
+		}
+
+		header.TxRoot = buildroot.CalculateTransactionsRoot(block.Transactions, i)
+		header.ComputeHash()
+
+		m.blocks = append(m.blocks, block)
+		m.receipts = append(m.receipts, receipts)
+	}
+
+	return m, crypto.PubKeyToAddress(&key.PublicKey)
+}
+
+func readJSONLRows(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	rows := make([]map[string]interface{}, 0)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		row := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+
+		rows = append(rows, row)
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return rows
+}
+
+func TestExportTables_JSONL(t *testing.T) {
+	t.Parallel()
+
+	clt, sender := newExportClientMock(t, 6)
+	outDir := t.TempDir()
+	to := uint64(4)
+
+	config := &ExportConfig{
+		OutDir:        outDir,
+		Format:        ExportFormatJSONL,
+		To:            &to,
+		PartitionSize: 2,
+	}
+
+	from, last, err := exportTablesFrom(context.Background(), clt, hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), from)
+	assert.Equal(t, uint64(4), last)
+
+	// the last partition is not full, its file ends at the last exported block
+	for _, table := range []string{blocksTable, transactionsTable, logsTable} {
+		entries, err := os.ReadDir(filepath.Join(outDir, table))
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+
+		assert.Equal(t, []string{
+			table + "_0000000000_0000000001.jsonl",
+			table + "_0000000002_0000000003.jsonl",
+			table + "_0000000004_0000000004.jsonl",
+		}, names)
+	}
+
+	blockRows := readJSONLRows(t, partitionPath(config, blocksTable, 2, 3))
+	require.Len(t, blockRows, 2)
+	assert.Equal(t, clt.blocks[3].Hash().String(), blockRows[1]["hash"])
+
+	txRows := readJSONLRows(t, partitionPath(config, transactionsTable, 0, 1))
+	require.Len(t, txRows, 1)
+	assert.Equal(t, addressString(sender), txRows[0]["from"])
+	assert.Equal(t, "12", txRows[0]["effectiveGasPrice"])
+	assert.Equal(t, float64(1), txRows[0]["status"])
+	assert.Nil(t, txRows[0]["contractAddress"])
+
+	logRows := readJSONLRows(t, partitionPath(config, logsTable, 4, 4))
+	require.Len(t, logRows, 1)
+	assert.Equal(t, clt.blocks[4].Transactions[0].Hash.String(), logRows[0]["transactionHash"])
+	assert.Equal(t, types.StringToHash("2").String(), logRows[0]["topic1"])
+	assert.Nil(t, logRows[0]["topic2"])
+
+	// resuming continues after the last exported block
+	config.To = nil
+	config.Resume = true
+
+	from, last, err = exportTablesFrom(context.Background(), clt, hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), from)
+	assert.Equal(t, uint64(5), last)
+	assert.FileExists(t, partitionPath(config, blocksTable, 5, 5))
+
+	_, _, err = exportTablesFrom(context.Background(), clt, hclog.NewNullLogger(), config)
+	require.ErrorIs(t, err, ErrNothingToExport)
+}
+
+func TestExportTables_Parquet(t *testing.T) {
+	t.Parallel()
+
+	clt, sender := newExportClientMock(t, 3)
+	config := &ExportConfig{
+		OutDir:        t.TempDir(),
+		Format:        ExportFormatParquet,
+		PartitionSize: DefaultExportPartitionSize,
+	}
+
+	_, _, err := exportTablesFrom(context.Background(), clt, hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+
+	file, err := os.Open(partitionPath(config, transactionsTable, 0, 2))
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+
+	require.Equal(t, int64(2), reader.NumRows())
+
+	for i := 1; i <= 2; i++ {
+		row := TransactionRow{}
+		require.NoError(t, reader.Read(&row))
+
+		assert.Equal(t, uint64(i), row.BlockNumber)
+		assert.Equal(t, addressString(sender), row.From)
+		assert.Equal(t, "12", row.EffectiveGasPrice)
+		require.NotNil(t, row.To)
+		assert.Equal(t, addressString(types.StringToAddress("2")), *row.To)
+	}
+}
+
+func TestExportTables_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := exportTablesFrom(context.Background(), nil, hclog.NewNullLogger(), &ExportConfig{
+		Format:        "csv",
+		PartitionSize: 1,
+	})
+	require.ErrorIs(t, err, ErrInvalidExportFormat)
+
+	_, _, err = exportTablesFrom(context.Background(), nil, hclog.NewNullLogger(), &ExportConfig{
+		Format: ExportFormatJSONL,
+	})
+	require.ErrorIs(t, err, ErrInvalidExportPartitionSize)
+}
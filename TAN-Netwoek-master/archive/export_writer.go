@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/segmentio/parquet-go"
+)
+
+// ExportFormat is the file format of the exported tables
+type ExportFormat string
+
+const (
+	ExportFormatJSONL   ExportFormat = "jsonl"
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+// IsValid checks if the export format is supported
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatJSONL || f == ExportFormatParquet
+}
+
+// tableWriter writes the rows of a single table file
+type tableWriter interface {
+	Write(row interface{}) error
+	Close() error
+}
+
+// newTableWriter creates the table writer of the format on top of the file,
+// rowType is used to derive the schema of the table
+func newTableWriter(format ExportFormat, file *os.File, rowType interface{}) (tableWriter, error) {
+	switch format {
+	case ExportFormatJSONL:
+		return newJSONLWriter(file), nil
+	case ExportFormatParquet:
+		return newParquetWriter(file, rowType), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// jsonlWriter writes a JSON object per line
+type jsonlWriter struct {
+	file    *os.File
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLWriter(file *os.File) *jsonlWriter {
+	buf := bufio.NewWriter(file)
+
+	return &jsonlWriter{
+		file:    file,
+		buf:     buf,
+		encoder: json.NewEncoder(buf),
+	}
+}
+
+func (w *jsonlWriter) Write(row interface{}) error {
+	return w.encoder.Encode(row)
+}
+
+func (w *jsonlWriter) Close() error {
+	if err := w.buf.Flush(); err != nil {
+		w.file.Close()
+
+		return err
+	}
+
+	return w.file.Close()
+}
+
+// parquetWriter writes a zstd compressed parquet file
+type parquetWriter struct {
+	file   *os.File
+	writer *parquet.Writer
+}
+
+func newParquetWriter(file *os.File, rowType interface{}) *parquetWriter {
+	return &parquetWriter{
+		file: file,
+		writer: parquet.NewWriter(
+			file,
+			parquet.SchemaOf(rowType),
+			parquet.Compression(&parquet.Zstd),
+		),
+	}
+}
+
+func (w *parquetWriter) Write(row interface{}) error {
+	return w.writer.Write(row)
+}
+
+func (w *parquetWriter) Close() error {
+	if err := w.writer.Close(); err != nil {
+		w.file.Close()
+
+		return err
+	}
+
+	return w.file.Close()
+}
@@ -0,0 +1,115 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/tarality/tan-network/types"
+)
+
+var ErrChecksumMismatch = errors.New("backup checksum mismatch")
+
+// Manifest describes a set of incremental backups,
+// every segment continues the chain from the last block of the previous one
+type Manifest struct {
+	Segments []*Segment `json:"segments"`
+}
+
+// Segment is a single backup file of the manifest
+type Segment struct {
+	File        string      `json:"file"` // path relative to the manifest
+	From        uint64      `json:"from"`
+	To          uint64      `json:"to"`
+	ParentHash  types.Hash  `json:"parentHash"` // hash of the parent of the first block
+	Hash        types.Hash  `json:"hash"`       // hash of the last block
+	Compression Compression `json:"compression"`
+	Checksum    string      `json:"checksum"` // sha256 of the file
+}
+
+// ReadManifest reads the manifest from the file, a missing file is an empty manifest
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Manifest{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse backup manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// WriteManifest replaces the manifest file with the given manifest
+func WriteManifest(path string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write the new manifest aside, so an interrupted write doesn't lose the existing one
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// Last returns the last segment of the manifest, nil if the manifest is empty
+func (m *Manifest) Last() *Segment {
+	if len(m.Segments) == 0 {
+		return nil
+	}
+
+	return m.Segments[len(m.Segments)-1]
+}
+
+// segmentPath returns the path of the segment file of the manifest
+func segmentPath(manifestPath string, segment *Segment) string {
+	if filepath.IsAbs(segment.File) {
+		return segment.File
+	}
+
+	return filepath.Join(filepath.Dir(manifestPath), segment.File)
+}
+
+// verifyChecksum checks the file of the segment is the one recorded in the manifest
+func verifyChecksum(path string, segment *Segment) error {
+	checksum, err := fileChecksum(path)
+	if err != nil {
+		return err
+	}
+
+	if checksum != segment.Checksum {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, segment.File)
+	}
+
+	return nil
+}
+
+// fileChecksum returns the hex encoded sha256 of the file
+func fileChecksum(path string) (string, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer fp.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, fp); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"os"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/tarality/tan-network/blockchain"
 	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/helper/progress"
@@ -26,16 +27,91 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+var ErrSegmentNotLinked = errors.New("backup does not link to the local chain")
+
+// RestoreChain reads blocks from the archive and write to the chain.
+// The archive is either a backup file or a manifest of incremental backups
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
-	fp, err := os.Open(filePath)
+	isManifest, err := isManifestFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if isManifest {
+		return restoreManifest(chain, filePath, progression)
+	}
+
+	_, err = restoreFile(chain, filePath, progression)
+
+	return err
+}
+
+// restoreManifest restores the segments of the manifest in order
+func restoreManifest(chain blockchainInterface, manifestPath string, progression *progress.ProgressionWrapper) error {
+	manifest, err := ReadManifest(manifestPath)
 	if err != nil {
 		return err
 	}
 
+	for _, segment := range manifest.Segments {
+		path := segmentPath(manifestPath, segment)
+
+		if err := verifyChecksum(path, segment); err != nil {
+			return err
+		}
+
+		metadata, err := restoreFile(chain, path, progression)
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w", segment.File, err)
+		}
+
+		// the restore stops on the termination signal, don't continue with the next segment
+		if chain.GetHashByNumber(metadata.Latest) != metadata.LatestHash {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// restoreFile restores the blocks of the backup file
+func restoreFile(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) (*Metadata, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fp.Close()
+
 	blockStream := newBlockStream(fp)
+	defer blockStream.close()
+
+	if err := importBlocks(chain, blockStream, progression); err != nil {
+		return nil, err
+	}
 
-	return importBlocks(chain, blockStream, progression)
+	return blockStream.metadata, nil
+}
+
+// isManifestFile checks if the file is a manifest, which is JSON unlike the RLP encoded backups
+func isManifestFile(filePath string) (bool, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	defer fp.Close()
+
+	buf := make([]byte, 1)
+	if _, err := fp.Read(buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return buf[0] == '{', nil
 }
 
 // import blocks scans all blocks from stream and write them to chain
@@ -57,6 +133,11 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 		return nil
 	}
 
+	// an incremental backup has to continue the local chain
+	if metadata.From > 0 && chain.GetHashByNumber(metadata.From-1) != metadata.ParentHash {
+		return fmt.Errorf("%w: parent of block %d is %s", ErrSegmentNotLinked, metadata.From, metadata.ParentHash)
+	}
+
 	// skip existing blocks
 	firstBlock, err := consumeCommonBlocks(chain, blockStream, shutdownCh)
 	if err != nil {
@@ -67,6 +148,10 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 		return nil
 	}
 
+	if number := firstBlock.Number(); chain.GetHashByNumber(number-1) != firstBlock.ParentHash() {
+		return fmt.Errorf("%w: parent of block %d is %s", ErrSegmentNotLinked, number, firstBlock.ParentHash())
+	}
+
 	// Create a blockchain subscription for the sync progression and start tracking
 	progression.StartProgression(firstBlock.Number(), chain.SubscribeEvents())
 	// Stop monitoring the sync progression upon exit
@@ -150,8 +235,10 @@ func consumeCommonBlocks(
 
 // blockStream parse RLP-encoded block from stream and consumed the used bytes
 type blockStream struct {
-	input  io.Reader
-	buffer []byte
+	input    io.Reader
+	buffer   []byte
+	metadata *Metadata     // metadata read from the stream
+	decoder  *zstd.Decoder // decoder of the compressed blocks
 }
 
 func newBlockStream(input io.Reader) *blockStream {
@@ -172,7 +259,34 @@ func (b *blockStream) getMetadata() (*Metadata, error) {
 		return nil, nil
 	}
 
-	return b.parseMetadata(size)
+	metadata, err := b.parseMetadata(size)
+	if err != nil {
+		return nil, err
+	}
+
+	// the blocks following the metadata may be compressed
+	switch metadata.Compression {
+	case "", CompressionNone:
+	case CompressionZstd:
+		if b.decoder, err = zstd.NewReader(b.input, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+
+		b.input = b.decoder
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, metadata.Compression)
+	}
+
+	b.metadata = metadata
+
+	return metadata, nil
+}
+
+// close releases the decoder of the compressed blocks
+func (b *blockStream) close() {
+	if b.decoder != nil {
+		b.decoder.Close()
+	}
 }
 
 // nextBlock consumes some bytes from input and returns parsed block
@@ -194,6 +308,25 @@ func (b *blockStream) nextBlock() (*types.Block, error) {
 	return block, nil
 }
 
+// nextReceipts consumes some bytes from input and returns parsed receipts in the store format
+func (b *blockStream) nextReceipts() (types.Receipts, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	receipts := types.Receipts{}
+	if err := receipts.UnmarshalStoreRLP(b.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
 // loadRLPArray loads RLP encoded array from input to buffer
 func (b *blockStream) loadRLPArray() (uint64, error) {
 	prefix, err := b.loadRLPPrefix()
@@ -241,15 +374,14 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 
 		b.reserveCap(offset + payloadSizeSize)
 		payloadSizeBytes := b.buffer[offset : offset+payloadSizeSize]
-		n, err := b.input.Read(payloadSizeBytes)
 
-		if err != nil {
-			return 0, 0, err
-		}
+		if _, err := io.ReadFull(b.input, payloadSizeBytes); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				// couldn't load required amount of bytes
+				return 0, 0, io.EOF
+			}
 
-		if uint64(n) < payloadSizeSize {
-			// couldn't load required amount of bytes
-			return 0, 0, io.EOF
+			return 0, 0, err
 		}
 
 		payloadSize := new(big.Int).SetBytes(payloadSizeBytes).Int64()
@@ -265,7 +397,13 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	// an empty array has no payload, reading nothing would report the end of the input
+	if size == 0 {
+		return nil
+	}
+
+	// a decompressing input may return less than requested by a single read
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
@@ -19,6 +19,13 @@ var (
 		Latest:     10,
 		LatestHash: types.StringToHash("10"),
 	}
+	segmentMetadata = Metadata{
+		Latest:      10,
+		LatestHash:  types.StringToHash("10"),
+		From:        5,
+		ParentHash:  types.StringToHash("4"),
+		Compression: CompressionNone,
+	}
 )
 
 type mockChain struct {
@@ -41,6 +48,10 @@ func (m *mockChain) GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 }
 
 func (m *mockChain) GetHashByNumber(num uint64) types.Hash {
+	if num == 0 {
+		return m.genesis.Hash()
+	}
+
 	b, ok := m.GetBlockByNumber(num, false)
 	if !ok {
 		return types.Hash{}
@@ -239,6 +250,12 @@ func Test_parseMetadata(t *testing.T) {
 			metadata:    &metadata,
 			err:         nil,
 		},
+		{
+			name:        "should return Metadata of incremental backup",
+			blockstream: newBlockStream(bytes.NewBuffer(segmentMetadata.MarshalRLP())),
+			metadata:    &segmentMetadata,
+			err:         nil,
+		},
 	}
 
 	for _, tt := range tests {
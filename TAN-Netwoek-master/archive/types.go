@@ -1,16 +1,44 @@
 package archive
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/tarality/fastrlp"
 	"github.com/tarality/tan-network/types"
 )
 
+// Compression is the compression of the blocks following the metadata in backup
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionZstd Compression = "zstd"
+)
+
+var ErrUnsupportedCompression = errors.New("unsupported backup compression")
+
+// IsValid checks if the compression is supported
+func (c Compression) IsValid() bool {
+	return c == CompressionNone || c == CompressionZstd
+}
+
 // Metadata is the data stored in the beginning of backup
 type Metadata struct {
 	Latest     uint64
 	LatestHash types.Hash
+
+	// the fields below are omitted from the uncompressed backups starting at genesis,
+	// which keeps them readable by the older versions
+	From        uint64      // number of the first block
+	ParentHash  types.Hash  // hash of the parent of the first block, the block the backup links to
+	Compression Compression // compression of the blocks, none if empty
+}
+
+// isLegacy returns true if the metadata can be encoded in the format without the segment fields
+func (m *Metadata) isLegacy() bool {
+	return m.From == 0 && m.ParentHash == types.ZeroHash &&
+		(m.Compression == "" || m.Compression == CompressionNone)
 }
 
 // MarshalRLP returns RLP encoded bytes
@@ -30,6 +58,12 @@ func (m *Metadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewUint(m.Latest))
 	vv.Set(arena.NewBytes(m.LatestHash.Bytes()))
 
+	if !m.isLegacy() {
+		vv.Set(arena.NewUint(m.From))
+		vv.Set(arena.NewBytes(m.ParentHash.Bytes()))
+		vv.Set(arena.NewBytes([]byte(m.Compression)))
+	}
+
 	return vv
 }
 
@@ -57,5 +91,24 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
+	if len(elems) < 5 {
+		return nil
+	}
+
+	if m.From, err = elems[2].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[3].GetHash(m.ParentHash[:]); err != nil {
+		return err
+	}
+
+	compression, err := elems[4].GetBytes(nil)
+	if err != nil {
+		return err
+	}
+
+	m.Compression = Compression(compression)
+
 	return nil
 }
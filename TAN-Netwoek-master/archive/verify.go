@@ -0,0 +1,163 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tarality/tan-network/types"
+	"github.com/tarality/tan-network/types/buildroot"
+)
+
+var ErrInvalidBackup = errors.New("invalid backup")
+
+// VerifyBackupFile checks the blocks of the backup file are continuous, match the metadata
+// and have the bodies of their headers. It returns the range of the backup as a segment.
+// The block hashes are calculated by types.HeaderHash, which must be set up for the consensus of the chain
+func VerifyBackupFile(filePath string) (*Segment, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fp.Close()
+
+	blockStream := newBlockStream(fp)
+	defer blockStream.close()
+
+	metadata, err := blockStream.getMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		return nil, fmt.Errorf("%w: expected metadata in archive but doesn't exist", ErrInvalidBackup)
+	}
+
+	segment := &Segment{
+		File:        filePath,
+		ParentHash:  metadata.ParentHash,
+		Compression: metadata.Compression,
+	}
+
+	if segment.Compression == "" {
+		segment.Compression = CompressionNone
+	}
+
+	var prev *types.Block
+
+	for {
+		block, err := blockStream.nextBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		if block == nil {
+			break
+		}
+
+		if err := verifyNextBlock(metadata, prev, block); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+
+		if prev == nil {
+			segment.From = block.Number()
+		}
+
+		prev = block
+	}
+
+	if prev == nil {
+		return nil, fmt.Errorf("%w: no blocks", ErrInvalidBackup)
+	}
+
+	if prev.Number() != metadata.Latest {
+		return nil, fmt.Errorf("%w: last block is %d, metadata expects %d", ErrInvalidBackup, prev.Number(), metadata.Latest)
+	}
+
+	if prev.Hash() != metadata.LatestHash {
+		return nil, fmt.Errorf("%w: hash of the last block is %s, metadata expects %s",
+			ErrInvalidBackup, prev.Hash(), metadata.LatestHash)
+	}
+
+	segment.To = prev.Number()
+	segment.Hash = prev.Hash()
+
+	if segment.Checksum, err = fileChecksum(filePath); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// verifyNextBlock checks the block follows the previous one of the backup
+func verifyNextBlock(metadata *Metadata, prev, block *types.Block) error {
+	number := block.Number()
+
+	switch {
+	case prev != nil:
+		if number != prev.Number()+1 {
+			return fmt.Errorf("block %d follows block %d", number, prev.Number())
+		}
+
+		if block.ParentHash() != prev.Hash() {
+			return fmt.Errorf("parent of block %d is %s, previous block is %s", number, block.ParentHash(), prev.Hash())
+		}
+	case !metadata.isLegacy():
+		// the legacy metadata doesn't have the first block
+		if number != metadata.From {
+			return fmt.Errorf("first block is %d, metadata expects %d", number, metadata.From)
+		}
+
+		if number > 0 && block.ParentHash() != metadata.ParentHash {
+			return fmt.Errorf("parent of block %d is %s, metadata expects %s", number, block.ParentHash(), metadata.ParentHash)
+		}
+	}
+
+	if root := buildroot.CalculateTransactionsRoot(block.Transactions, number); root != block.Header.TxRoot {
+		return fmt.Errorf("transactions root of block %d is %s, header has %s", number, root, block.Header.TxRoot)
+	}
+
+	return nil
+}
+
+// VerifyManifest checks the segments of the manifest match their checksums and records,
+// and every segment continues the chain of the previous one
+func VerifyManifest(manifestPath string) (*Manifest, error) {
+	manifest, err := ReadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(manifest.Segments) == 0 {
+		return nil, fmt.Errorf("%w: manifest has no segments", ErrInvalidBackup)
+	}
+
+	var prev *Segment
+
+	for _, segment := range manifest.Segments {
+		path := segmentPath(manifestPath, segment)
+
+		if err := verifyChecksum(path, segment); err != nil {
+			return nil, err
+		}
+
+		verified, err := VerifyBackupFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", segment.File, err)
+		}
+
+		if verified.From != segment.From || verified.To != segment.To ||
+			verified.ParentHash != segment.ParentHash || verified.Hash != segment.Hash {
+			return nil, fmt.Errorf("%w: %s doesn't match the manifest", ErrInvalidBackup, segment.File)
+		}
+
+		if prev != nil && (segment.From != prev.To+1 || segment.ParentHash != prev.Hash) {
+			return nil, fmt.Errorf("%w: %s doesn't continue %s", ErrInvalidBackup, segment.File, prev.File)
+		}
+
+		prev = segment
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,155 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/helper/progress"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestIncrementalBackup(t *testing.T) {
+	t.Parallel()
+
+	clt, _ := newExportClientMock(t, 6)
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	to := uint64(2)
+
+	first, err := createIncrementalBackup(context.Background(), clt, hclog.NewNullLogger(), &to, manifestPath, CompressionNone)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), first.From)
+	assert.Equal(t, uint64(2), first.To)
+	assert.Equal(t, clt.blocks[2].Hash(), first.Hash)
+
+	// the next segment continues after the first one
+	second, err := createIncrementalBackup(context.Background(), clt, hclog.NewNullLogger(), nil, manifestPath, CompressionZstd)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), second.From)
+	assert.Equal(t, uint64(5), second.To)
+	assert.Equal(t, first.Hash, second.ParentHash)
+	assert.Equal(t, CompressionZstd, second.Compression)
+
+	_, err = createIncrementalBackup(context.Background(), clt, hclog.NewNullLogger(), nil, manifestPath, CompressionNone)
+	require.ErrorIs(t, err, ErrNothingToBackup)
+
+	manifest, err := VerifyManifest(manifestPath)
+	require.NoError(t, err)
+	require.Len(t, manifest.Segments, 2)
+	assert.Equal(t, "segment_0000000003.bak", manifest.Segments[1].File)
+
+	// the segments are restored in order
+	chain := &mockChain{genesis: clt.blocks[0], blocks: []*types.Block{}}
+	require.NoError(t, RestoreChain(chain, manifestPath, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, chain.blocks, 5)
+	assert.Equal(t, clt.blocks[5].Hash(), getLatestBlockFromMockChain(chain).Hash())
+
+	// a node which doesn't have the last backed up block can't continue the backups
+	header := clt.blocks[5].Header.Copy()
+	header.ExtraData = []byte{0x1}
+	header.ComputeHash()
+	clt.blocks[5] = &types.Block{Header: header}
+
+	_, err = createIncrementalBackup(context.Background(), clt, hclog.NewNullLogger(), nil, manifestPath, CompressionNone)
+	require.ErrorIs(t, err, ErrBackupNotLinked)
+}
+
+func TestRestore_NotLinked(t *testing.T) {
+	t.Parallel()
+
+	clt, _ := newExportClientMock(t, 4)
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	to := uint64(1)
+
+	_, err := createIncrementalBackup(context.Background(), clt, hclog.NewNullLogger(), &to, manifestPath, CompressionNone)
+	require.NoError(t, err)
+
+	segment, err := createIncrementalBackup(context.Background(), clt, hclog.NewNullLogger(), nil, manifestPath, CompressionZstd)
+	require.NoError(t, err)
+
+	// the second segment alone doesn't link to the genesis
+	chain := &mockChain{genesis: clt.blocks[0], blocks: []*types.Block{}}
+	err = RestoreChain(
+		chain,
+		filepath.Join(filepath.Dir(manifestPath), segment.File),
+		progress.NewProgressionWrapper(progress.ChainSyncRestore),
+	)
+	require.ErrorIs(t, err, ErrSegmentNotLinked)
+	assert.Empty(t, chain.blocks)
+}
+
+func TestVerifyManifest_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	clt, _ := newExportClientMock(t, 4)
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+
+	segment, err := createIncrementalBackup(context.Background(), clt, hclog.NewNullLogger(), nil, manifestPath, CompressionNone)
+	require.NoError(t, err)
+
+	path := filepath.Join(filepath.Dir(manifestPath), segment.File)
+
+	verified, err := VerifyBackupFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, segment.Checksum, verified.Checksum)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// drop the last block
+	require.NoError(t, os.WriteFile(path, data[:len(data)-len(clt.blocks[3].MarshalRLP())], 0600))
+
+	_, err = VerifyManifest(manifestPath)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	_, err = VerifyBackupFile(path)
+	require.ErrorIs(t, err, ErrInvalidBackup)
+}
+
+func TestVerifyBackupFile_BrokenHashChain(t *testing.T) {
+	t.Parallel()
+
+	writeBackup := func(t *testing.T, metadata *Metadata, blocks []*types.Block) string {
+		t.Helper()
+
+		data := metadata.MarshalRLP()
+		for _, block := range blocks {
+			data = append(data, block.MarshalRLP()...)
+		}
+
+		path := filepath.Join(t.TempDir(), "backup.bak")
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		return path
+	}
+
+	clt, _ := newExportClientMock(t, 4)
+
+	// the untouched chain passes
+	segment, err := VerifyBackupFile(writeBackup(t, &Metadata{Latest: 3, LatestHash: clt.blocks[3].Hash()}, clt.blocks))
+	require.NoError(t, err)
+	assert.Equal(t, clt.blocks[3].Hash(), segment.Hash)
+
+	// the last block doesn't match the hash of the metadata
+	_, err = VerifyBackupFile(writeBackup(t, &Metadata{Latest: 3, LatestHash: clt.blocks[2].Hash()}, clt.blocks))
+	require.ErrorIs(t, err, ErrInvalidBackup)
+
+	// a block in the middle is replaced by another one of the same number
+	header := clt.blocks[2].Header.Copy()
+	header.ExtraData = []byte{0x1}
+	header.ComputeHash()
+
+	spliced := []*types.Block{
+		clt.blocks[0],
+		clt.blocks[1],
+		{Header: header, Transactions: clt.blocks[2].Transactions},
+		clt.blocks[3],
+	}
+
+	_, err = VerifyBackupFile(writeBackup(t, &Metadata{Latest: 3, LatestHash: clt.blocks[3].Hash()}, spliced))
+	require.ErrorIs(t, err, ErrInvalidBackup)
+}
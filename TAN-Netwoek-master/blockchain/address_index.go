@@ -0,0 +1,305 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/state"
+	"github.com/tarality/tan-network/state/runtime/tracer/transfertracer"
+	"github.com/tarality/tan-network/types"
+)
+
+var (
+	ErrAddressIndexDisabled    = errors.New("address index is not enabled")
+	errTracingExecutorRequired = errors.New("indexing internal transfers requires a tracing capable executor")
+)
+
+// tracingExecutor is an executor which can re-execute the block transactions with a tracer attached
+type tracingExecutor interface {
+	BeginTxn(parentRoot types.Hash, header *types.Header, coinbaseReceiver types.Address) (*state.Transition, error)
+}
+
+const (
+	// transfersIndexInterval is the interval of checking for new blocks to trace for the internal transfers
+	transfersIndexInterval = 2 * time.Second
+)
+
+// addressIndexConfig is the configuration of the address to transaction index
+type addressIndexConfig struct {
+	transfers *transfersIndexer // indexes the internal value transfers, nil if disabled
+}
+
+// transfersIndexer adds the internal value transfers of the written blocks to the address index in the background,
+// since tracing every block while writing it would slow down the sync
+type transfersIndexer struct {
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// EnableAddressIndex turns on the address to transaction index for the blocks written from now on.
+// If internalTransfers is set, the written blocks are traced in the background
+// to index the internal value transfers as well
+func (b *Blockchain) EnableAddressIndex(internalTransfers bool) error {
+	if internalTransfers {
+		if _, ok := b.executor.(tracingExecutor); !ok {
+			return errTracingExecutorRequired
+		}
+	}
+
+	b.addressIndex = &addressIndexConfig{}
+
+	if internalTransfers {
+		indexer := &transfersIndexer{
+			closeCh: make(chan struct{}),
+		}
+
+		b.addressIndex.transfers = indexer
+
+		indexer.wg.Add(1)
+
+		go func() {
+			defer indexer.wg.Done()
+
+			b.runTransfersIndexer()
+		}()
+	}
+
+	return nil
+}
+
+// AddressIndexEnabled returns true if the address to transaction index is maintained
+func (b *Blockchain) AddressIndexEnabled() bool {
+	return b.addressIndex != nil
+}
+
+// GetAddressTxs calls the handler for the canonical transactions of the address within
+// [from, to] block range, until the handler returns false
+func (b *Blockchain) GetAddressTxs(
+	addr types.Address, from, to uint64, reverse bool, handler func(*storage.AddressTx) bool) error {
+	if b.addressIndex == nil {
+		return ErrAddressIndexDisabled
+	}
+
+	return b.db.ReadAddressTxs(addr, from, to, reverse, func(tx *storage.AddressTx) bool {
+		// the index keeps the entries of the forked blocks as well, skip them
+		if canonicalHash, ok := b.db.ReadCanonicalHash(tx.BlockNumber); !ok || canonicalHash != tx.BlockHash {
+			return true
+		}
+
+		return handler(tx)
+	})
+}
+
+// closeTransfersIndexer stops the internal transfers indexer
+func (b *Blockchain) closeTransfersIndexer() {
+	if b.addressIndex == nil || b.addressIndex.transfers == nil {
+		return
+	}
+
+	close(b.addressIndex.transfers.closeCh)
+	b.addressIndex.transfers.wg.Wait()
+}
+
+func (b *Blockchain) runTransfersIndexer() {
+	ticker := time.NewTicker(transfersIndexInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.indexInternalTransfers(); err != nil {
+			b.logger.Error("failed to index internal transfers", "err", err)
+		}
+
+		select {
+		case <-b.addressIndex.transfers.closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// indexInternalTransfers traces the canonical blocks written since the last traced block
+// and adds their internal value transfers to the address index
+func (b *Blockchain) indexInternalTransfers() error {
+	// the indexer can be enabled before the genesis is written
+	head := b.Header()
+	if head == nil {
+		return nil
+	}
+
+	number, hash, ok := b.db.ReadAddressTxTraced()
+	if !ok {
+		// the internal transfers are indexed for the blocks written after the option is enabled
+		batchWriter := storage.NewBatchWriter(b.db)
+		batchWriter.PutAddressTxTraced(head.Number, head.Hash)
+
+		return batchWriter.WriteBatch()
+	}
+
+	// go back to the canonical ancestor of the last traced block, in case of a reorg
+	for number > 0 {
+		if canonical, ok := b.db.ReadCanonicalHash(number); ok && canonical == hash {
+			break
+		}
+
+		header, err := b.db.ReadHeader(hash)
+		if err != nil {
+			return fmt.Errorf("header of traced block %d: %w", number, err)
+		}
+
+		number, hash = number-1, header.ParentHash
+	}
+
+	for number < head.Number {
+		select {
+		case <-b.addressIndex.transfers.closeCh:
+			return nil
+		default:
+		}
+
+		block, err := b.readCanonicalBlock(number + 1)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", number+1, err)
+		}
+
+		transfers, err := b.traceInternalTransfers(block)
+		if err != nil {
+			return fmt.Errorf("failed to trace block %d: %w", block.Number(), err)
+		}
+
+		batchWriter := storage.NewBatchWriter(b.db)
+
+		// the entries of the direct parties are written again, along with the kinds of the internal transfers
+		WriteAddressIndex(batchWriter, block, transfers)
+		batchWriter.PutAddressTxTraced(block.Number(), block.Hash())
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			return err
+		}
+
+		number = block.Number()
+	}
+
+	return nil
+}
+
+// readCanonicalBlock reads the canonical block with the given number,
+// the header is read from the db directly to not pollute the headers cache
+func (b *Blockchain) readCanonicalBlock(number uint64) (*types.Block, error) {
+	hash, ok := b.db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := b.db.ReadHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	header.Hash = hash
+
+	body, ok := b.readBody(hash)
+	if !ok {
+		return nil, fmt.Errorf("body of block %d not found", number)
+	}
+
+	return &types.Block{
+		Header:       header,
+		Transactions: body.Transactions,
+	}, nil
+}
+
+// traceInternalTransfers re-executes the block and collects the internal value transfers of every transaction
+func (b *Blockchain) traceInternalTransfers(block *types.Block) ([][]transfertracer.Transfer, error) {
+	if block.Number() == 0 || len(block.Transactions) == 0 {
+		return nil, nil
+	}
+
+	parent, ok := b.readHeader(block.ParentHash())
+	if !ok {
+		return nil, ErrParentNotFound
+	}
+
+	blockCreator, err := b.consensus.GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	executor, _ := b.executor.(tracingExecutor)
+
+	transition, err := executor.BeginTxn(parent.StateRoot, block.Header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := transfertracer.NewTransferTracer()
+	transition.SetTracer(tracer)
+
+	transfers := make([][]transfertracer.Transfer, len(block.Transactions))
+
+	for i, tx := range block.Transactions {
+		tracer.Clear()
+
+		if tx.Gas > block.Header.GasLimit {
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			return nil, err
+		}
+
+		transfers[i] = tracer.Transfers()
+	}
+
+	return transfers, nil
+}
+
+// WriteAddressIndex writes the address to transaction index entries of the block:
+// senders, recipients, created contracts and, if provided, the internal transfers parties of every transaction
+func WriteAddressIndex(
+	batchWriter *storage.BatchWriter, block *types.Block, transfers [][]transfertracer.Transfer) {
+	for i, tx := range block.Transactions {
+		entries := map[types.Address]*storage.AddressTx{}
+
+		add := func(addr types.Address, kind storage.AddressTxKind) {
+			if addr == types.ZeroAddress {
+				return
+			}
+
+			entry, ok := entries[addr]
+			if !ok {
+				entry = &storage.AddressTx{
+					BlockNumber: block.Number(),
+					TxIndex:     uint32(i),
+					TxHash:      tx.Hash,
+					BlockHash:   block.Hash(),
+				}
+				entries[addr] = entry
+			}
+
+			entry.Kind |= kind
+		}
+
+		add(tx.From, storage.AddressTxSender)
+
+		if tx.To != nil {
+			add(*tx.To, storage.AddressTxRecipient)
+		} else if tx.From != types.ZeroAddress {
+			add(crypto.CreateAddress(tx.From, tx.Nonce), storage.AddressTxCreation)
+		}
+
+		if i < len(transfers) {
+			for _, transfer := range transfers[i] {
+				add(transfer.From, storage.AddressTxInternalSender)
+				add(transfer.To, storage.AddressTxInternalRecipient)
+			}
+		}
+
+		for addr, entry := range entries {
+			batchWriter.PutAddressTx(addr, entry)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/state/runtime/tracer/transfertracer"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestBlockchain_AddressIndex(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender    = types.StringToAddress("1")
+		recipient = types.StringToAddress("2")
+		contract  = types.StringToAddress("3")
+		other     = types.StringToAddress("4")
+	)
+
+	b := TestBlockchain(t, &chain.Genesis{InitialReward: big.NewInt(1)})
+
+	require.ErrorIs(t, b.GetAddressTxs(sender, 0, 10, false, nil), ErrAddressIndexDisabled)
+	require.NoError(t, b.EnableAddressIndex(false))
+	require.True(t, b.AddressIndexEnabled())
+
+	txs := []*types.Transaction{
+		{Nonce: 0, From: sender, To: &recipient},
+		{Nonce: 1, From: sender},
+		{Nonce: 2, From: sender, To: &contract},
+	}
+	for _, tx := range txs {
+		tx.ComputeHash(1)
+	}
+
+	block := &types.Block{
+		Header:       &types.Header{Number: 1, ExtraData: []byte{1}},
+		Transactions: txs,
+	}
+	block.Header.ComputeHash()
+
+	// same transactions included by a forked block
+	forked := &types.Block{
+		Header:       &types.Header{Number: 1, ExtraData: []byte{2}},
+		Transactions: txs[2:],
+	}
+	forked.Header.ComputeHash()
+
+	batchWriter := storage.NewBatchWriter(b.db)
+	batchWriter.PutCanonicalHash(1, block.Hash())
+
+	WriteAddressIndex(batchWriter, block, [][]transfertracer.Transfer{
+		nil,
+		nil,
+		{{From: contract, To: other}},
+	})
+	WriteAddressIndex(batchWriter, forked, [][]transfertracer.Transfer{
+		{{From: contract, To: sender}},
+	})
+	require.NoError(t, batchWriter.WriteBatch())
+
+	read := func(addr types.Address) []*storage.AddressTx {
+		result := make([]*storage.AddressTx, 0)
+
+		require.NoError(t, b.GetAddressTxs(addr, 0, 10, false, func(tx *storage.AddressTx) bool {
+			result = append(result, tx)
+
+			return true
+		}))
+
+		return result
+	}
+
+	// the forked entry is not canonical
+	senderTxs := read(sender)
+	require.Len(t, senderTxs, 3)
+
+	for i, tx := range senderTxs {
+		assert.Equal(t, uint32(i), tx.TxIndex)
+		assert.Equal(t, txs[i].Hash, tx.TxHash)
+		assert.Equal(t, block.Hash(), tx.BlockHash)
+		assert.Equal(t, storage.AddressTxSender, tx.Kind)
+	}
+
+	assert.Equal(t, []*storage.AddressTx{
+		{BlockNumber: 1, TxIndex: 0, TxHash: txs[0].Hash, BlockHash: block.Hash(), Kind: storage.AddressTxRecipient},
+	}, read(recipient))
+
+	assert.Equal(t, []*storage.AddressTx{
+		{BlockNumber: 1, TxIndex: 1, TxHash: txs[1].Hash, BlockHash: block.Hash(), Kind: storage.AddressTxCreation},
+	}, read(crypto.CreateAddress(sender, 1)))
+
+	assert.Equal(t, []*storage.AddressTx{
+		{
+			BlockNumber: 1, TxIndex: 2, TxHash: txs[2].Hash, BlockHash: block.Hash(),
+			Kind: storage.AddressTxRecipient | storage.AddressTxInternalSender,
+		},
+	}, read(contract))
+
+	assert.Equal(t, []*storage.AddressTx{
+		{BlockNumber: 1, TxIndex: 2, TxHash: txs[2].Hash, BlockHash: block.Hash(), Kind: storage.AddressTxInternalRecipient},
+	}, read(other))
+}
+
+func TestBlockchain_TransfersIndexer(t *testing.T) {
+	t.Parallel()
+
+	b := TestBlockchain(t, &chain.Genesis{InitialReward: big.NewInt(1)})
+
+	// write the canonical blocks directly, without transactions the blocks don't need to be traced
+	writeBlocks := func(from, to uint64, seed byte) *types.Header {
+		var header *types.Header
+
+		parentHash, ok := b.db.ReadCanonicalHash(from - 1)
+		require.True(t, ok)
+
+		batchWriter := storage.NewBatchWriter(b.db)
+
+		for i := from; i <= to; i++ {
+			header = &types.Header{Number: i, ParentHash: parentHash, ExtraData: []byte{seed}}
+			header.ComputeHash()
+
+			parentHash = header.Hash
+
+			batchWriter.PutHeader(header)
+			batchWriter.PutBody(header.Hash, &types.Body{})
+			batchWriter.PutCanonicalHash(i, header.Hash)
+		}
+
+		require.NoError(t, batchWriter.WriteBatch())
+		b.currentHeader.Store(header)
+
+		return header
+	}
+
+	traced := func() (uint64, types.Hash) {
+		number, hash, ok := b.db.ReadAddressTxTraced()
+		require.True(t, ok)
+
+		return number, hash
+	}
+
+	writeBlocks(1, 5, 0)
+
+	b.addressIndex = &addressIndexConfig{transfers: &transfersIndexer{closeCh: make(chan struct{})}}
+
+	// the blocks written before the option is enabled are not traced
+	require.NoError(t, b.indexInternalTransfers())
+
+	number, _ := traced()
+	assert.Equal(t, uint64(5), number)
+
+	head := writeBlocks(6, 8, 0)
+	require.NoError(t, b.indexInternalTransfers())
+
+	number, hash := traced()
+	assert.Equal(t, uint64(8), number)
+	assert.Equal(t, head.Hash, hash)
+
+	// the traced blocks are replaced by a reorg
+	head = writeBlocks(7, 9, 1)
+	require.NoError(t, b.indexInternalTransfers())
+
+	number, hash = traced()
+	assert.Equal(t, uint64(9), number)
+	assert.Equal(t, head.Hash, hash)
+}
@@ -0,0 +1,98 @@
+package blockchain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/types"
+)
+
+// maxBadBlocks is the number of the most recent bad blocks kept in the storage
+const maxBadBlocks = 16
+
+// badBlockError is returned when the local execution result of the block
+// doesn't match the block header. It carries the locally computed result
+type badBlockError struct {
+	err    error
+	result *BlockResult
+}
+
+func (e *badBlockError) Error() string {
+	return e.err.Error()
+}
+
+func (e *badBlockError) Unwrap() error {
+	return e.err
+}
+
+// isBadBlockError checks whether the verification error makes the block bad,
+// as opposed to the block not being verifiable (yet) by the local node
+func isBadBlockError(err error) bool {
+	return !errors.Is(err, ErrNoBlock) && !errors.Is(err, ErrParentNotFound)
+}
+
+// writeBadBlock persists the rejected block along with the reason,
+// and the locally computed execution result if the block was executed.
+// Only the most recent bad blocks are kept
+func (b *Blockchain) writeBadBlock(block *types.Block, reason error) {
+	badBlock := &storage.BadBlock{
+		Block:  block,
+		Reason: reason.Error(),
+		Time:   uint64(time.Now().Unix()),
+	}
+
+	var resultErr *badBlockError
+	if errors.As(reason, &resultErr) {
+		badBlock.StateRoot = resultErr.result.Root
+		badBlock.GasUsed = resultErr.result.TotalGas
+		badBlock.Receipts = resultErr.result.Receipts
+	}
+
+	b.logger.Warn("bad block", "number", block.Number(), "hash", block.Hash(), "reason", badBlock.Reason)
+
+	b.badBlocksLock.Lock()
+	defer b.badBlocksLock.Unlock()
+
+	badBlocks, err := b.db.ReadBadBlocks()
+	if err != nil {
+		b.logger.Error("failed to read bad blocks", "err", err)
+	}
+
+	batchWriter := storage.NewBatchWriter(b.db)
+
+	// drop the oldest bad blocks to make room for the new one
+	sortBadBlocks(badBlocks)
+
+	for i := maxBadBlocks - 1; i < len(badBlocks); i++ {
+		if badBlocks[i].Block.Hash() != block.Hash() {
+			batchWriter.DeleteBadBlock(badBlocks[i].Block.Hash())
+		}
+	}
+
+	batchWriter.PutBadBlock(badBlock)
+
+	if err := batchWriter.WriteBatch(); err != nil {
+		b.logger.Error("failed to write bad block", "number", block.Number(), "err", err)
+	}
+}
+
+// BadBlocks returns the bad blocks rejected by the local node, the most recent first
+func (b *Blockchain) BadBlocks() ([]*storage.BadBlock, error) {
+	badBlocks, err := b.db.ReadBadBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	sortBadBlocks(badBlocks)
+
+	return badBlocks, nil
+}
+
+// sortBadBlocks sorts the bad blocks by the time they were rejected at, the most recent first
+func sortBadBlocks(badBlocks []*storage.BadBlock) {
+	sort.SliceStable(badBlocks, func(i, j int) bool {
+		return badBlocks[i].Time > badBlocks[j].Time
+	})
+}
@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/state"
+	itrie "github.com/tarality/tan-network/state/immutable-trie"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestBlockchain_BadBlocks(t *testing.T) {
+	t.Parallel()
+
+	config := &chain.Chain{
+		Genesis: &chain.Genesis{InitialReward: big.NewInt(1)},
+		Params: &chain.Params{
+			Forks: &chain.Forks{
+				chain.EIP155:    chain.NewFork(0),
+				chain.Homestead: chain.NewFork(0),
+			},
+			BlockGasTarget: defaultBlockGasTarget,
+		},
+	}
+
+	executor := state.NewExecutor(config.Params, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	b, err := newBlockChain(config, executor)
+	require.NoError(t, err)
+
+	executor.GetHash = b.GetHashHelper
+
+	newBlock := func(stateRoot types.Hash) *types.Block {
+		block := &types.Block{
+			Header: &types.Header{
+				Number:       1,
+				ParentHash:   b.Header().Hash,
+				GasLimit:     b.Header().GasLimit,
+				Sha3Uncles:   types.EmptyUncleHash,
+				TxRoot:       types.EmptyRootHash,
+				ReceiptsRoot: types.EmptyRootHash,
+				StateRoot:    stateRoot,
+				ExtraData:    []byte{},
+			},
+		}
+		block.Header.ComputeHash()
+
+		return block
+	}
+
+	t.Run("invalid state root is recorded", func(t *testing.T) {
+		block := newBlock(types.StringToHash("1"))
+
+		_, verifyErr := b.VerifyFinalizedBlock(block)
+		require.ErrorIs(t, verifyErr, ErrInvalidStateRoot)
+
+		badBlocks, err := b.BadBlocks()
+		require.NoError(t, err)
+		require.Len(t, badBlocks, 1)
+
+		assert.Equal(t, block.Hash(), badBlocks[0].Block.Hash())
+		assert.Equal(t, verifyErr.Error(), badBlocks[0].Reason)
+		assert.NotEqual(t, types.ZeroHash, badBlocks[0].StateRoot)
+		assert.NotEqual(t, block.Header.StateRoot, badBlocks[0].StateRoot)
+		assert.Len(t, badBlocks[0].Receipts, 0)
+	})
+
+	t.Run("missing parent is not recorded", func(t *testing.T) {
+		block := newBlock(types.StringToHash("2"))
+		block.Header.ParentHash = types.StringToHash("3")
+		block.Header.ComputeHash()
+
+		_, err := b.VerifyFinalizedBlock(block)
+		require.ErrorIs(t, err, ErrParentNotFound)
+
+		badBlocks, err := b.BadBlocks()
+		require.NoError(t, err)
+		require.Len(t, badBlocks, 1)
+	})
+
+	t.Run("only the most recent are kept", func(t *testing.T) {
+		for i := 0; i < maxBadBlocks+4; i++ {
+			block := newBlock(types.StringToHash("4"))
+			block.Header.ExtraData = []byte{byte(i)}
+			block.Header.ComputeHash()
+
+			b.writeBadBlock(block, errors.New("bad block"))
+		}
+
+		badBlocks, err := b.BadBlocks()
+		require.NoError(t, err)
+		require.Len(t, badBlocks, maxBadBlocks)
+	})
+}
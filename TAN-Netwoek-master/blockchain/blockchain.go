@@ -76,7 +76,12 @@ type Blockchain struct {
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
-	writeLock sync.Mutex
+	addressIndex *addressIndexConfig // Address to transaction index configuration, nil if disabled
+
+	bloomIndexer *bloomIndexer // Bloom bits log indexer, nil if disabled
+
+	writeLock     sync.Mutex
+	badBlocksLock sync.Mutex // Lock for pruning the bad blocks store
 }
 
 // gasPriceAverage keeps track of the average gas price (rolling average)
@@ -601,6 +606,13 @@ func (b *Blockchain) VerifyPotentialBlock(block *types.Block) error {
 	// Do just the initial block verification
 	_, err := b.verifyBlock(block)
 
+	// The consensus layer rejects invalid proposals on its own,
+	// so only the blocks with a mismatching execution result are kept
+	var resultErr *badBlockError
+	if errors.As(err, &resultErr) {
+		b.writeBadBlock(block, err)
+	}
+
 	return err
 }
 
@@ -609,12 +621,19 @@ func (b *Blockchain) VerifyPotentialBlock(block *types.Block) error {
 func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
 	// Make sure the consensus layer verifies this block header
 	if err := b.consensus.VerifyHeader(block.Header); err != nil {
-		return nil, fmt.Errorf("failed to verify the header: %w", err)
+		err = fmt.Errorf("failed to verify the header: %w", err)
+		b.writeBadBlock(block, err)
+
+		return nil, err
 	}
 
 	// Do the initial block verification
 	receipts, err := b.verifyBlock(block)
 	if err != nil {
+		if isBadBlockError(err) {
+			b.writeBadBlock(block, err)
+		}
+
 		return nil, err
 	}
 
@@ -724,7 +743,10 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 
 	// Verify the local execution result with the proposed block data
 	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+		return nil, &badBlockError{
+			err:    fmt.Errorf("unable to verify block execution result, %w", err),
+			result: blockResult,
+		}
 	}
 
 	return blockResult.Receipts, nil
@@ -994,7 +1016,8 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 }
 
 // writeBody writes the block body to the DB.
-// Additionally, it also updates the txn lookup, for txnHash -> block lookups
+// Additionally, it also updates the txn lookup, for txnHash -> block lookups,
+// and the address index, for address -> txns lookups, if enabled
 func (b *Blockchain) writeBody(batchWriter *storage.BatchWriter, block *types.Block) error {
 	// Recover 'from' field in tx before saving
 	// Because the block passed from the consensus layer doesn't have from field in tx,
@@ -1011,6 +1034,11 @@ func (b *Blockchain) writeBody(batchWriter *storage.BatchWriter, block *types.Bl
 		batchWriter.PutTxLookup(txn.Hash, block.Hash())
 	}
 
+	// Write address index (address -> txs), the internal transfers are indexed in the background
+	if b.addressIndex != nil {
+		WriteAddressIndex(batchWriter, block, nil)
+	}
+
 	return nil
 }
 
@@ -1354,6 +1382,9 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 
 // Close closes the DB connection
 func (b *Blockchain) Close() error {
+	b.closeBloomIndexer()
+	b.closeTransfersIndexer()
+
 	return b.db.Close()
 }
 
@@ -0,0 +1,224 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tarality/tan-network/blockchain/bloombits"
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	// BloomBitsBlocks is the number of blocks of a single bloom bits section
+	BloomBitsBlocks uint64 = 4096
+
+	// bloomConfirms is the number of blocks a section has to be behind the head before it is indexed,
+	// which keeps the index away from the blocks that can still be reorganized
+	bloomConfirms uint64 = 256
+
+	// bloomIndexInterval is the interval of checking for new sections to index
+	bloomIndexInterval = 10 * time.Second
+)
+
+var (
+	ErrBloomBitsNotIndexed = errors.New("bloom bits section is not indexed")
+	ErrBloomBitsStale      = errors.New("bloom bits section is not canonical")
+)
+
+// bloomIndexer builds the bloom bits log index in the background
+type bloomIndexer struct {
+	sections atomic.Uint64 // number of indexed sections
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// EnableBloomIndexer starts building the bloom bits log index from the header blooms in the background
+func (b *Blockchain) EnableBloomIndexer() {
+	if b.bloomIndexer != nil {
+		return
+	}
+
+	indexer := &bloomIndexer{
+		closeCh: make(chan struct{}),
+	}
+
+	if sections, ok := b.db.ReadBloomSections(); ok {
+		indexer.sections.Store(sections)
+	}
+
+	b.bloomIndexer = indexer
+
+	indexer.wg.Add(1)
+
+	go func() {
+		defer indexer.wg.Done()
+
+		b.runBloomIndexer()
+	}()
+}
+
+// BloomBitsSections returns the number of sections indexed by the bloom bits log index
+func (b *Blockchain) BloomBitsSections() uint64 {
+	if b.bloomIndexer == nil {
+		return 0
+	}
+
+	return b.bloomIndexer.sections.Load()
+}
+
+// GetBloomBits returns the bit vector of the bloom bit in the section.
+// The bit at position i is set if the bloom of the block section*BloomBitsBlocks+i has the bit set
+func (b *Blockchain) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	if section >= b.BloomBitsSections() {
+		return nil, ErrBloomBitsNotIndexed
+	}
+
+	head, bits, err := b.db.ReadBloomBits(bit, section)
+	if err != nil {
+		return nil, err
+	}
+
+	if canonical, ok := b.db.ReadCanonicalHash(bloomSectionEnd(section)); !ok || canonical != head {
+		return nil, ErrBloomBitsStale
+	}
+
+	return bits, nil
+}
+
+// closeBloomIndexer stops the bloom bits indexer
+func (b *Blockchain) closeBloomIndexer() {
+	if b.bloomIndexer == nil {
+		return
+	}
+
+	close(b.bloomIndexer.closeCh)
+	b.bloomIndexer.wg.Wait()
+}
+
+func (b *Blockchain) runBloomIndexer() {
+	ticker := time.NewTicker(bloomIndexInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.indexBloomSections(); err != nil {
+			b.logger.Error("failed to index bloom bits", "err", err)
+		}
+
+		select {
+		case <-b.bloomIndexer.closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// indexBloomSections indexes all the sections which have enough confirmations
+func (b *Blockchain) indexBloomSections() error {
+	sections := b.bloomIndexer.sections.Load()
+
+	// drop the sections which are not canonical anymore, in case of a deep reorg
+	for sections > 0 {
+		head, _, err := b.db.ReadBloomBits(0, sections-1)
+		if err == nil {
+			if canonical, ok := b.db.ReadCanonicalHash(bloomSectionEnd(sections - 1)); ok && canonical == head {
+				break
+			}
+		}
+
+		b.logger.Warn("bloom bits section is not canonical, reindexing", "section", sections-1)
+
+		sections--
+	}
+
+	if sections != b.bloomIndexer.sections.Load() {
+		batchWriter := storage.NewBatchWriter(b.db)
+		batchWriter.PutBloomSections(sections)
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			return err
+		}
+
+		b.bloomIndexer.sections.Store(sections)
+	}
+
+	// the indexer can be enabled before the genesis is written
+	head := b.Header()
+	if head == nil {
+		return nil
+	}
+
+	for bloomSectionEnd(sections)+bloomConfirms <= head.Number {
+		select {
+		case <-b.bloomIndexer.closeCh:
+			return nil
+		default:
+		}
+
+		if err := b.indexBloomSection(sections); err != nil {
+			return fmt.Errorf("section %d: %w", sections, err)
+		}
+
+		sections++
+		b.bloomIndexer.sections.Store(sections)
+
+		b.logger.Debug("indexed bloom bits section", "section", sections-1)
+	}
+
+	return nil
+}
+
+// indexBloomSection generates and writes the bloom bits of the section
+func (b *Blockchain) indexBloomSection(section uint64) error {
+	generator, err := bloombits.NewGenerator(BloomBitsBlocks)
+	if err != nil {
+		return err
+	}
+
+	var head types.Hash
+
+	for i := uint64(0); i < BloomBitsBlocks; i++ {
+		number := section*BloomBitsBlocks + i
+
+		hash, ok := b.db.ReadCanonicalHash(number)
+		if !ok {
+			return fmt.Errorf("canonical hash of block %d not found", number)
+		}
+
+		// read from the db directly, to not pollute the headers cache
+		header, err := b.db.ReadHeader(hash)
+		if err != nil {
+			return fmt.Errorf("header of block %d: %w", number, err)
+		}
+
+		if err := generator.AddBloom(i, header.LogsBloom); err != nil {
+			return err
+		}
+
+		head = hash
+	}
+
+	batchWriter := storage.NewBatchWriter(b.db)
+
+	for bit := uint(0); bit < bloombits.BloomBitLength; bit++ {
+		bits, err := generator.Bitset(bit)
+		if err != nil {
+			return err
+		}
+
+		batchWriter.PutBloomBits(bit, section, head, bits)
+	}
+
+	batchWriter.PutBloomSections(section + 1)
+
+	return batchWriter.WriteBatch()
+}
+
+// bloomSectionEnd returns the number of the last block of the section
+func bloomSectionEnd(section uint64) uint64 {
+	return (section+1)*BloomBitsBlocks - 1
+}
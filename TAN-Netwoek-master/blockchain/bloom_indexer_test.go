@@ -0,0 +1,118 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/blockchain/bloombits"
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/blockchain/storage/memory"
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestBlockchain_BloomIndexer(t *testing.T) {
+	t.Parallel()
+
+	address := types.StringToAddress("1")
+	bloom := types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: address}}}})
+
+	b := TestBlockchain(t, &chain.Genesis{InitialReward: big.NewInt(1)})
+
+	// write the canonical headers directly, the bloom of every 100th block matches the address
+	writeHeaders := func(from, to uint64, seed byte) *types.Header {
+		var header *types.Header
+
+		batchWriter := storage.NewBatchWriter(b.db)
+
+		for i := from; i <= to; i++ {
+			header = &types.Header{Number: i, ExtraData: []byte{seed}}
+			if i%100 == 0 {
+				header.LogsBloom = bloom
+			}
+
+			header.ComputeHash()
+
+			batchWriter.PutHeader(header)
+			batchWriter.PutCanonicalHash(i, header.Hash)
+		}
+
+		require.NoError(t, batchWriter.WriteBatch())
+		b.currentHeader.Store(header)
+
+		return header
+	}
+
+	writeHeaders(1, 2*BloomBitsBlocks+bloomConfirms-2, 0)
+
+	b.bloomIndexer = &bloomIndexer{closeCh: make(chan struct{})}
+
+	require.NoError(t, b.indexBloomSections())
+
+	// the second section does not have enough confirmations yet
+	assert.Equal(t, uint64(1), b.BloomBitsSections())
+
+	_, err := b.GetBloomBits(0, 1)
+	require.ErrorIs(t, err, ErrBloomBitsNotIndexed)
+
+	candidates := func(section uint64) []uint64 {
+		bits, err := bloombits.NewMatcher(BloomBitsBlocks, [][][]byte{{address.Bytes()}}).Match(section, b.GetBloomBits)
+		require.NoError(t, err)
+
+		numbers := make([]uint64, 0)
+
+		for i := uint64(0); i < BloomBitsBlocks; i++ {
+			if bloombits.IsSet(bits, i) {
+				numbers = append(numbers, section*BloomBitsBlocks+i)
+			}
+		}
+
+		return numbers
+	}
+
+	numbers := candidates(0)
+	require.Len(t, numbers, int(BloomBitsBlocks/100))
+	assert.Equal(t, uint64(100), numbers[0])
+	assert.Equal(t, uint64(4000), numbers[len(numbers)-1])
+
+	// confirm the second section
+	writeHeaders(2*BloomBitsBlocks+bloomConfirms-1, 2*BloomBitsBlocks+bloomConfirms-1, 0)
+	require.NoError(t, b.indexBloomSections())
+	assert.Equal(t, uint64(2), b.BloomBitsSections())
+	assert.Equal(t, uint64(4100), candidates(1)[0])
+
+	sections, ok := b.db.ReadBloomSections()
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), sections)
+
+	// reorg of the second section
+	writeHeaders(BloomBitsBlocks+10, 2*BloomBitsBlocks+bloomConfirms+10, 1)
+
+	_, err = b.GetBloomBits(0, 1)
+	require.ErrorIs(t, err, ErrBloomBitsStale)
+
+	require.NoError(t, b.indexBloomSections())
+	assert.Equal(t, uint64(2), b.BloomBitsSections())
+
+	_, err = b.GetBloomBits(0, 1)
+	require.NoError(t, err)
+}
+
+func TestBlockchain_BloomIndexerBeforeGenesis(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	// the server enables the indexer before the genesis is computed
+	b, err := NewBlockchain(hclog.NewNullLogger(), db, &chain.Chain{Genesis: &chain.Genesis{}}, nil, nil, nil)
+	require.NoError(t, err)
+
+	b.bloomIndexer = &bloomIndexer{closeCh: make(chan struct{})}
+
+	require.NoError(t, b.indexBloomSections())
+	assert.Equal(t, uint64(0), b.BloomBitsSections())
+}
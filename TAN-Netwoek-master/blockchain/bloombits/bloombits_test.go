@@ -0,0 +1,114 @@
+package bloombits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/types"
+)
+
+func bloomOf(addr []byte, topics ...[]byte) types.Bloom {
+	log := &types.Log{Address: types.BytesToAddress(addr)}
+	for _, topic := range topics {
+		log.Topics = append(log.Topics, types.BytesToHash(topic))
+	}
+
+	return types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{log}}})
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewGenerator(10)
+	require.ErrorIs(t, err, ErrInvalidSectionSize)
+
+	g, err := NewGenerator(16)
+	require.NoError(t, err)
+
+	addr := types.StringToAddress("1")
+	bloom := bloomOf(addr.Bytes())
+
+	require.ErrorIs(t, g.AddBloom(1, bloom), ErrBloomOutOfOrder)
+
+	for i := uint64(0); i < 16; i++ {
+		if i == 3 || i == 12 {
+			require.NoError(t, g.AddBloom(i, bloom))
+		} else {
+			require.NoError(t, g.AddBloom(i, types.Bloom{}))
+		}
+	}
+
+	for _, bit := range types.BloomBitIndexes(addr.Bytes()) {
+		bits, err := g.Bitset(bit)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x10, 0x08}, bits)
+	}
+
+	_, err = g.Bitset(BloomBitLength)
+	require.ErrorIs(t, err, ErrInvalidBloomBit)
+}
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1  = types.StringToAddress("1").Bytes()
+		addr2  = types.StringToAddress("2").Bytes()
+		topic1 = types.StringToHash("3").Bytes()
+		topic2 = types.StringToHash("4").Bytes()
+	)
+
+	blooms := []types.Bloom{
+		bloomOf(addr1),
+		bloomOf(addr1, topic1),
+		bloomOf(addr2, topic1),
+		bloomOf(addr2, topic2),
+		{}, {}, {}, {},
+	}
+
+	g, err := NewGenerator(8)
+	require.NoError(t, err)
+
+	for i, bloom := range blooms {
+		require.NoError(t, g.AddBloom(uint64(i), bloom))
+	}
+
+	retrieved := 0
+	retrieve := func(bit uint, section uint64) ([]byte, error) {
+		retrieved++
+
+		return g.Bitset(bit)
+	}
+
+	candidates := func(filters [][][]byte) []uint64 {
+		bits, err := NewMatcher(8, filters).Match(0, retrieve)
+		require.NoError(t, err)
+
+		result := make([]uint64, 0)
+
+		for i := uint64(0); i < 8; i++ {
+			if IsSet(bits, i) {
+				result = append(result, i)
+			}
+		}
+
+		return result
+	}
+
+	assert.True(t, NewMatcher(8, [][][]byte{{}, {}}).Empty())
+
+	assert.Equal(t, []uint64{0, 1}, candidates([][][]byte{{addr1}}))
+	assert.Equal(t, []uint64{0, 1, 2, 3}, candidates([][][]byte{{addr1, addr2}}))
+	assert.Equal(t, []uint64{1, 2}, candidates([][][]byte{{}, {topic1}}))
+	assert.Equal(t, []uint64{2}, candidates([][][]byte{{addr2}, {topic1}}))
+	assert.Equal(t, []uint64{}, candidates([][][]byte{{addr1}, {topic2}}))
+
+	// the bits are retrieved once per match
+	retrieved = 0
+	candidates([][][]byte{{addr1}, {addr1}})
+	assert.Equal(t, 3, retrieved)
+
+	_, err = NewMatcher(16, [][][]byte{{addr1}}).Match(0, retrieve)
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package bloombits
+
+import (
+	"errors"
+
+	"github.com/tarality/tan-network/types"
+)
+
+// BloomBitLength is the number of bits of the header bloom filter
+const BloomBitLength = types.BloomByteLength * 8
+
+var (
+	ErrInvalidSectionSize = errors.New("section size must be a multiple of 8")
+	ErrBloomOutOfOrder    = errors.New("bloom added out of order")
+	ErrSectionIncomplete  = errors.New("section is not fully generated")
+	ErrInvalidBloomBit    = errors.New("bloom bit out of range")
+)
+
+// Generator rotates the bloom filters of a section of blocks, so that for every
+// bloom bit there is a bit vector marking the blocks of the section having the bit set
+type Generator struct {
+	sectionSize uint64
+	blooms      uint64 // number of blooms added so far
+	bits        [BloomBitLength][]byte
+}
+
+// NewGenerator creates a bloom bits generator for a section of the given size
+func NewGenerator(sectionSize uint64) (*Generator, error) {
+	if sectionSize == 0 || sectionSize%8 != 0 {
+		return nil, ErrInvalidSectionSize
+	}
+
+	g := &Generator{
+		sectionSize: sectionSize,
+	}
+
+	for i := range g.bits {
+		g.bits[i] = make([]byte, sectionSize/8)
+	}
+
+	return g, nil
+}
+
+// AddBloom adds the bloom filter of the block with the given index inside of the section.
+// Blooms have to be added in order
+func (g *Generator) AddBloom(index uint64, bloom types.Bloom) error {
+	if index != g.blooms || index >= g.sectionSize {
+		return ErrBloomOutOfOrder
+	}
+
+	bytePos, bitMask := index/8, byte(1)<<(7-index%8)
+
+	for bit := uint(0); bit < BloomBitLength; bit++ {
+		if bloom.IsBitSet(bit) {
+			g.bits[bit][bytePos] |= bitMask
+		}
+	}
+
+	g.blooms++
+
+	return nil
+}
+
+// Bitset returns the bit vector of the bloom bit, once all the blooms of the section are added
+func (g *Generator) Bitset(bit uint) ([]byte, error) {
+	if g.blooms != g.sectionSize {
+		return nil, ErrSectionIncomplete
+	}
+
+	if bit >= BloomBitLength {
+		return nil, ErrInvalidBloomBit
+	}
+
+	return g.bits[bit], nil
+}
@@ -0,0 +1,135 @@
+package bloombits
+
+import (
+	"fmt"
+
+	"github.com/tarality/tan-network/types"
+)
+
+// BitsRetriever returns the bit vector of the bloom bit in the section
+type BitsRetriever func(bit uint, section uint64) ([]byte, error)
+
+// Matcher finds the candidate blocks of a section matching a filter, using the bloom bit vectors.
+//
+// The filter is a list of groups, where a block matches if, for every group,
+// the bloom filter of the block contains at least one of the values of the group.
+// Empty groups match everything
+type Matcher struct {
+	sectionSize uint64
+	groups      [][][3]uint
+}
+
+// NewMatcher creates a matcher for the filter groups
+func NewMatcher(sectionSize uint64, filters [][][]byte) *Matcher {
+	m := &Matcher{
+		sectionSize: sectionSize,
+		groups:      make([][][3]uint, 0, len(filters)),
+	}
+
+	for _, filter := range filters {
+		if len(filter) == 0 {
+			// wildcard
+			continue
+		}
+
+		group := make([][3]uint, len(filter))
+		for i, value := range filter {
+			group[i] = types.BloomBitIndexes(value)
+		}
+
+		m.groups = append(m.groups, group)
+	}
+
+	return m
+}
+
+// Empty returns true if the filter matches every block
+func (m *Matcher) Empty() bool {
+	return len(m.groups) == 0
+}
+
+// Match returns the bit vector of the candidate blocks of the section.
+// The bit at position i (most significant bit first) is set if block section*sectionSize+i may match the filter
+func (m *Matcher) Match(section uint64, retrieve BitsRetriever) ([]byte, error) {
+	size := m.sectionSize / 8
+	bitsCache := make(map[uint][]byte)
+
+	getBits := func(bit uint) ([]byte, error) {
+		if bits, ok := bitsCache[bit]; ok {
+			return bits, nil
+		}
+
+		bits, err := retrieve(bit, section)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(len(bits)) != size {
+			return nil, fmt.Errorf("invalid bit vector size of bloom bit %d in section %d: %d", bit, section, len(bits))
+		}
+
+		bitsCache[bit] = bits
+
+		return bits, nil
+	}
+
+	result := make([]byte, size)
+	for i := range result {
+		result[i] = 0xff
+	}
+
+	for _, group := range m.groups {
+		groupResult := make([]byte, size)
+
+		for _, indexes := range group {
+			valueResult := make([]byte, size)
+			copy(valueResult, result)
+
+			for _, bit := range indexes {
+				bits, err := getBits(bit)
+				if err != nil {
+					return nil, err
+				}
+
+				andBits(valueResult, bits)
+			}
+
+			orBits(groupResult, valueResult)
+		}
+
+		copy(result, groupResult)
+
+		if isZero(result) {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// IsSet checks if the bit at the index of the bit vector is set
+func IsSet(bits []byte, index uint64) bool {
+	return bits[index/8]&(1<<(7-index%8)) != 0
+}
+
+func andBits(dst, src []byte) {
+	for i := range dst {
+		dst[i] &= src[i]
+	}
+}
+
+func orBits(dst, src []byte) {
+	for i := range dst {
+		dst[i] |= src[i]
+	}
+}
+
+func isZero(bits []byte) bool {
+	for _, b := range bits {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/types"
+)
+
+// AddressTxKind describes how the indexed address relates to the transaction.
+// A single entry can combine several kinds (e.g. a transaction sent to self)
+type AddressTxKind byte
+
+const (
+	// AddressTxSender marks the address as the sender of the transaction
+	AddressTxSender AddressTxKind = 1 << iota
+	// AddressTxRecipient marks the address as the recipient of the transaction
+	AddressTxRecipient
+	// AddressTxCreation marks the address as the contract created by the transaction
+	AddressTxCreation
+	// AddressTxInternalSender marks the address as the sender of an internal value transfer
+	AddressTxInternalSender
+	// AddressTxInternalRecipient marks the address as the recipient of an internal value transfer
+	AddressTxInternalRecipient
+)
+
+const (
+	// addressTxKeySize is the size of the address tx index key without the prefix:
+	// address (20) + block number (8) + tx index (4) + tx hash (32)
+	addressTxKeySize = types.AddressLength + 8 + 4 + types.HashLength
+)
+
+// AddressTx is a single entry of the address to transaction index
+type AddressTx struct {
+	BlockNumber uint64
+	TxIndex     uint32
+	TxHash      types.Hash
+	BlockHash   types.Hash
+	Kind        AddressTxKind
+}
+
+// Has checks whether the entry has the given kind set
+func (a *AddressTx) Has(kind AddressTxKind) bool {
+	return a.Kind&kind != 0
+}
+
+// addressTxKey builds the address tx index key.
+// Entries of the same address are ordered by block number and tx index
+func addressTxKey(addr types.Address, tx *AddressTx) []byte {
+	key := make([]byte, 0, len(ADDRESS_TX)+addressTxKeySize)
+	key = append(key, ADDRESS_TX...)
+	key = append(key, addr.Bytes()...)
+	key = append(key, common.EncodeUint64ToBytes(tx.BlockNumber)...)
+	key = binary.BigEndian.AppendUint32(key, tx.TxIndex)
+
+	return append(key, tx.TxHash.Bytes()...)
+}
+
+// decodeAddressTx decodes the address tx index entry
+func decodeAddressTx(key, value []byte) (*AddressTx, bool) {
+	if len(key) != len(ADDRESS_TX)+addressTxKeySize || len(value) != 1+types.HashLength {
+		return nil, false
+	}
+
+	key = key[len(ADDRESS_TX)+types.AddressLength:]
+
+	return &AddressTx{
+		BlockNumber: common.EncodeBytesToUint64(key[:8]),
+		TxIndex:     binary.BigEndian.Uint32(key[8:12]),
+		TxHash:      types.BytesToHash(key[12:]),
+		BlockHash:   types.BytesToHash(value[1:]),
+		Kind:        AddressTxKind(value[0]),
+	}, true
+}
+
+// PutAddressTx writes the address to transaction index entry.
+// The value holds the kind and the hash of the block including the transaction
+func (b *BatchWriter) PutAddressTx(addr types.Address, tx *AddressTx) {
+	b.batch.Put(addressTxKey(addr, tx), append([]byte{byte(tx.Kind)}, tx.BlockHash.Bytes()...))
+}
+
+// ReadAddressTxs calls the handler for every indexed transaction of the address
+// within [from, to] block range, until the handler returns false.
+// The entries are visited in ascending order, or descending if reverse is set
+func (s *KeyValueStorage) ReadAddressTxs(
+	addr types.Address, from, to uint64, reverse bool, handler func(*AddressTx) bool) error {
+	if from > to {
+		return nil
+	}
+
+	start := addressTxKey(addr, &AddressTx{BlockNumber: from})[:len(ADDRESS_TX)+types.AddressLength+8]
+	end := append(addressTxKey(addr, &AddressTx{BlockNumber: to})[:len(ADDRESS_TX)+types.AddressLength+8],
+		bytes.Repeat([]byte{0xff}, addressTxKeySize)...)
+
+	return s.db.Iterate(start, end, reverse, func(key, value []byte) bool {
+		tx, ok := decodeAddressTx(key, value)
+		if !ok {
+			return true
+		}
+
+		return handler(tx)
+	})
+}
+
+// PutAddressTxTraced writes the last block whose internal transfers are written to the address index
+func (b *BatchWriter) PutAddressTxTraced(number uint64, hash types.Hash) {
+	b.putWithPrefix(ADDRESS_TX_TRACED, NUMBER, append(common.EncodeUint64ToBytes(number), hash.Bytes()...))
+}
+
+// ReadAddressTxTraced reads the last block whose internal transfers are written to the address index
+func (s *KeyValueStorage) ReadAddressTxTraced() (uint64, types.Hash, bool) {
+	data, ok := s.get(ADDRESS_TX_TRACED, NUMBER)
+	if !ok || len(data) != 8+types.HashLength {
+		return 0, types.Hash{}, false
+	}
+
+	return common.EncodeBytesToUint64(data[:8]), types.BytesToHash(data[8:]), true
+}
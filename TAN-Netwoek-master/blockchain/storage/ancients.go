@@ -0,0 +1,302 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/types"
+)
+
+// AncientKind is the kind of the data kept in the ancient store
+type AncientKind string
+
+const (
+	AncientHashes   AncientKind = "hashes"
+	AncientHeaders  AncientKind = "headers"
+	AncientBodies   AncientKind = "bodies"
+	AncientReceipts AncientKind = "receipts"
+)
+
+// AncientKinds are all the kinds of data kept in the ancient store, in append order
+var AncientKinds = []AncientKind{AncientHashes, AncientHeaders, AncientBodies, AncientReceipts}
+
+// freezeRecheckInterval is the frequency of checking whether new blocks can be frozen
+var freezeRecheckInterval = time.Minute
+
+// freezeBatchLimit is the maximum number of blocks moved to the ancient store in one go
+const freezeBatchLimit = 30000
+
+// AncientStore is an append-only store for the canonical chain data
+// which is old enough to never be reorganized
+type AncientStore interface {
+	// Ancients returns the number of blocks kept in the store
+	Ancients() uint64
+	// ReadAncient returns the raw data of the given kind for the given block number
+	ReadAncient(kind AncientKind, number uint64) ([]byte, error)
+	// AppendAncient appends the raw data of the next block to the store
+	AppendAncient(number uint64, data map[AncientKind][]byte) error
+	// Sync flushes the store to the disk
+	Sync() error
+	Close() error
+}
+
+// ancientsFreezer moves the canonical chain data older than the threshold
+// from the key-value store into the ancient store
+type ancientsFreezer struct {
+	store     AncientStore
+	threshold uint64
+
+	lock sync.Mutex // serializes the freezing
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewKeyValueStorageWithAncients creates the key-value storage which keeps the canonical
+// chain data older than threshold blocks in the given ancient store.
+// If the threshold is 0, the already frozen data is readable but nothing new gets frozen
+func NewKeyValueStorageWithAncients(
+	logger hclog.Logger, db KV, ancients AncientStore, threshold uint64) (Storage, error) {
+	s := &KeyValueStorage{
+		logger: logger,
+		db:     db,
+		ancients: &ancientsFreezer{
+			store:     ancients,
+			threshold: threshold,
+			closeCh:   make(chan struct{}),
+		},
+	}
+
+	if err := s.repairAncients(); err != nil {
+		return nil, fmt.Errorf("failed to repair the ancient store: %w", err)
+	}
+
+	// threshold of 0 opens the ancient store for reading only
+	if threshold > 0 {
+		s.ancients.wg.Add(1)
+
+		go s.freezeLoop()
+	}
+
+	return s, nil
+}
+
+// repairAncients removes the data of the frozen blocks which is still kept in the key-value store.
+// The data is left there if the node stops after the ancient store is synced,
+// but before the frozen data is deleted from the key-value store.
+// Only the last freezing batch can be affected, as the next one doesn't start before the cleanup
+func (s *KeyValueStorage) repairAncients() error {
+	var (
+		store    = s.ancients.store
+		ancients = store.Ancients()
+		first    = uint64(0)
+		repaired = 0
+		batch    = s.db.NewBatch()
+	)
+
+	if ancients > freezeBatchLimit {
+		first = ancients - freezeBatchLimit
+	}
+
+	for number := ancients; number > first; number-- {
+		hash, err := store.ReadAncient(AncientHashes, number-1)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := s.get(HEADER, hash); !ok {
+			// the blocks frozen earlier have been cleaned up already
+			break
+		}
+
+		deleteFrozen(batch, number-1, types.BytesToHash(hash))
+
+		repaired++
+	}
+
+	if repaired == 0 {
+		return nil
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	s.logger.Info("removed the frozen blocks left in the key-value store", "blocks", repaired)
+
+	return nil
+}
+
+// deleteFrozen deletes the data of the frozen block from the key-value store,
+// and keeps the mapping of its hash to the number, which the frozen data are looked up by
+func deleteFrozen(batch Batch, number uint64, hash types.Hash) {
+	batch.Put(append(append([]byte{}, HEADER_NUMBER...), hash.Bytes()...), common.EncodeUint64ToBytes(number))
+	batch.Delete(append(append([]byte{}, HEADER...), hash.Bytes()...))
+	batch.Delete(append(append([]byte{}, BODY...), hash.Bytes()...))
+	batch.Delete(append(append([]byte{}, RECEIPTS...), hash.Bytes()...))
+}
+
+// freezeLoop periodically moves the finalized canonical chain data into the ancient store
+func (s *KeyValueStorage) freezeLoop() {
+	defer s.ancients.wg.Done()
+
+	ticker := time.NewTicker(freezeRecheckInterval)
+	defer ticker.Stop()
+
+	for {
+		frozen, err := s.Freeze()
+		if err != nil {
+			s.logger.Error("failed to move blocks into the ancient store", "err", err)
+		} else if frozen > 0 {
+			s.logger.Debug("moved blocks into the ancient store",
+				"blocks", frozen, "ancients", s.ancients.store.Ancients())
+		}
+
+		// keep going without waiting while there is a backlog to freeze
+		if frozen == freezeBatchLimit {
+			select {
+			case <-s.ancients.closeCh:
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-s.ancients.closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Freeze moves the canonical blocks older than the threshold into the ancient store.
+// It returns the number of the blocks moved
+func (s *KeyValueStorage) Freeze() (uint64, error) {
+	if s.ancients == nil || s.ancients.threshold == 0 {
+		return 0, nil
+	}
+
+	s.ancients.lock.Lock()
+	defer s.ancients.lock.Unlock()
+
+	head, ok := s.ReadHeadNumber()
+	if !ok || head < s.ancients.threshold {
+		return 0, nil
+	}
+
+	var (
+		store = s.ancients.store
+		first = store.Ancients()
+		limit = head - s.ancients.threshold // exclusive
+	)
+
+	if limit > first+freezeBatchLimit {
+		limit = first + freezeBatchLimit
+	}
+
+	if first >= limit {
+		return 0, nil
+	}
+
+	hashes := make([]types.Hash, 0, limit-first)
+
+	for number := first; number < limit; number++ {
+		hash, ok := s.ReadCanonicalHash(number)
+		if !ok {
+			return 0, errors.New("canonical hash not found")
+		}
+
+		data := map[AncientKind][]byte{
+			AncientHashes: hash.Bytes(),
+		}
+
+		header, ok := s.get(HEADER, hash.Bytes())
+		if !ok {
+			return 0, ErrNotFound
+		}
+
+		data[AncientHeaders] = header
+
+		// genesis has neither a body nor receipts
+		data[AncientBodies], _ = s.get(BODY, hash.Bytes())
+		data[AncientReceipts], _ = s.get(RECEIPTS, hash.Bytes())
+
+		if err := store.AppendAncient(number, data); err != nil {
+			return 0, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	// the data must be persisted in the ancient store before it gets deleted from the kv store
+	if err := store.Sync(); err != nil {
+		return 0, err
+	}
+
+	batch := s.db.NewBatch()
+
+	for i, hash := range hashes {
+		deleteFrozen(batch, first+uint64(i), hash)
+	}
+
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	return limit - first, nil
+}
+
+// readAncient reads the data of the given kind from the ancient store,
+// if the block with the given hash has been frozen
+func (s *KeyValueStorage) readAncient(kind AncientKind, hash types.Hash) ([]byte, bool) {
+	if s.ancients == nil {
+		return nil, false
+	}
+
+	data, ok := s.get(HEADER_NUMBER, hash.Bytes())
+	if !ok || len(data) != 8 {
+		return nil, false
+	}
+
+	number := common.EncodeBytesToUint64(data)
+
+	// make sure the frozen block is the requested one
+	frozenHash, err := s.ancients.store.ReadAncient(AncientHashes, number)
+	if err != nil || types.BytesToHash(frozenHash) != hash {
+		return nil, false
+	}
+
+	blob, err := s.ancients.store.ReadAncient(kind, number)
+	if err != nil || len(blob) == 0 {
+		return nil, false
+	}
+
+	return blob, true
+}
+
+// readAncientRLP decodes the data of the given kind from the ancient store
+func (s *KeyValueStorage) readAncientRLP(kind AncientKind, hash types.Hash, raw types.RLPUnmarshaler) error {
+	data, ok := s.readAncient(kind, hash)
+	if !ok {
+		return ErrNotFound
+	}
+
+	return decodeRLP(data, raw)
+}
+
+// closeAncients stops the freezing and closes the ancient store
+func (s *KeyValueStorage) closeAncients() error {
+	if s.ancients == nil {
+		return nil
+	}
+
+	close(s.ancients.closeCh)
+	s.ancients.wg.Wait()
+
+	return s.ancients.store.Close()
+}
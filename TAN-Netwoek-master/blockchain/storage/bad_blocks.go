@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/fastrlp"
+	"github.com/tarality/tan-network/types"
+)
+
+// BadBlock is a block rejected by the local node, along with the reason
+// and the execution result computed locally (if the block was executed)
+type BadBlock struct {
+	Block     *types.Block
+	Reason    string
+	StateRoot types.Hash
+	GasUsed   uint64
+	Receipts  []*types.Receipt
+	// Time is the unix time (in seconds) the block was rejected at
+	Time uint64
+}
+
+// MarshalRLPTo is a wrapper function for calling the type marshal implementation
+func (b *BadBlock) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(b.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith is the actual RLP marshal implementation for the type.
+// The block and the receipts are nested as raw bytes in their own (store) encodings
+func (b *BadBlock) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+
+	vv.Set(ar.NewCopyBytes(b.Block.MarshalRLP()))
+	vv.Set(ar.NewString(b.Reason))
+	vv.Set(ar.NewCopyBytes(b.StateRoot.Bytes()))
+	vv.Set(ar.NewUint(b.GasUsed))
+	vv.Set(ar.NewCopyBytes(types.Receipts(b.Receipts).MarshalStoreRLPTo(nil)))
+	vv.Set(ar.NewUint(b.Time))
+
+	return vv
+}
+
+// UnmarshalRLP is a wrapper function for calling the type unmarshal implementation
+func (b *BadBlock) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(b.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom is the actual RLP unmarshal implementation for the type
+func (b *BadBlock) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 6 {
+		return fmt.Errorf("incorrect number of elements to decode bad block, expected 6 but found %d", len(elems))
+	}
+
+	raw, err := elems[0].GetBytes(nil)
+	if err != nil {
+		return err
+	}
+
+	b.Block = &types.Block{}
+	if err := b.Block.UnmarshalRLP(raw); err != nil {
+		return err
+	}
+
+	if b.Reason, err = elems[1].GetString(); err != nil {
+		return err
+	}
+
+	if err := elems[2].GetHash(b.StateRoot[:]); err != nil {
+		return err
+	}
+
+	if b.GasUsed, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	if raw, err = elems[4].GetBytes(nil); err != nil {
+		return err
+	}
+
+	receipts := types.Receipts{}
+	if err := receipts.UnmarshalStoreRLP(raw); err != nil {
+		return err
+	}
+
+	b.Receipts = receipts
+
+	if b.Time, err = elems[5].GetUint64(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PutBadBlock writes the bad block, keyed by the block hash
+func (b *BatchWriter) PutBadBlock(badBlock *BadBlock) {
+	b.putRlp(BAD_BLOCK, badBlock.Block.Hash().Bytes(), badBlock)
+}
+
+// DeleteBadBlock removes the bad block with the given hash
+func (b *BatchWriter) DeleteBadBlock(hash types.Hash) {
+	b.batch.Delete(append(append(make([]byte, 0, len(BAD_BLOCK)+types.HashLength), BAD_BLOCK...), hash.Bytes()...))
+}
+
+// ReadBadBlocks reads all the stored bad blocks, ordered by the block hash
+func (s *KeyValueStorage) ReadBadBlocks() ([]*BadBlock, error) {
+	var (
+		badBlocks []*BadBlock
+		err       error
+	)
+
+	start := append(append([]byte{}, BAD_BLOCK...), bytes.Repeat([]byte{0x00}, types.HashLength)...)
+	end := append(append([]byte{}, BAD_BLOCK...), bytes.Repeat([]byte{0xff}, types.HashLength+1)...)
+
+	iterErr := s.db.Iterate(start, end, false, func(key, value []byte) bool {
+		badBlock := &BadBlock{}
+		if err = badBlock.UnmarshalRLP(value); err != nil {
+			return false
+		}
+
+		badBlocks = append(badBlocks, badBlock)
+
+		return true
+	})
+	if iterErr != nil {
+		return nil, iterErr
+	}
+
+	return badBlocks, err
+}
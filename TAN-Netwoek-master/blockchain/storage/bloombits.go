@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/golang/snappy"
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/types"
+)
+
+var errInvalidBloomBits = errors.New("invalid bloom bits entry")
+
+// bloomBitsKey builds the bloom bits key: bit (2) + section (8)
+func bloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 0, 2+8)
+	key = binary.BigEndian.AppendUint16(key, uint16(bit))
+
+	return append(key, common.EncodeUint64ToBytes(section)...)
+}
+
+// PutBloomBits writes the bit vector of the bloom bit in the section,
+// along with the hash of the last block of the section the vector was generated from
+func (b *BatchWriter) PutBloomBits(bit uint, section uint64, head types.Hash, bits []byte) {
+	// bit vectors are sparse, so they compress well
+	value := append(head.Bytes(), snappy.Encode(nil, bits)...)
+
+	b.putWithPrefix(BLOOM_BITS, bloomBitsKey(bit, section), value)
+}
+
+// PutBloomSections writes the number of the sections indexed by the bloom bits log index
+func (b *BatchWriter) PutBloomSections(sections uint64) {
+	b.putWithPrefix(BLOOM_BITS, SECTIONS, common.EncodeUint64ToBytes(sections))
+}
+
+// ReadBloomBits reads the bit vector of the bloom bit in the section,
+// along with the hash of the last block of the section the vector was generated from
+func (s *KeyValueStorage) ReadBloomBits(bit uint, section uint64) (types.Hash, []byte, error) {
+	data, ok := s.get(BLOOM_BITS, bloomBitsKey(bit, section))
+	if !ok {
+		return types.Hash{}, nil, ErrNotFound
+	}
+
+	if len(data) < types.HashLength {
+		return types.Hash{}, nil, errInvalidBloomBits
+	}
+
+	bits, err := snappy.Decode(nil, data[types.HashLength:])
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+
+	return types.BytesToHash(data[:types.HashLength]), bits, nil
+}
+
+// ReadBloomSections reads the number of the sections indexed by the bloom bits log index
+func (s *KeyValueStorage) ReadBloomSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_BITS, SECTIONS)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
@@ -0,0 +1,148 @@
+package freezer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/tarality/tan-network/blockchain/storage"
+)
+
+var _ storage.AncientStore = (*Freezer)(nil)
+
+// Freezer is an append-only flat file store for the immutable part of the canonical chain.
+// Every kind of ancient data (hashes, headers, bodies, receipts) is kept in its own table,
+// where the item position is the block number.
+type Freezer struct {
+	lock sync.RWMutex
+
+	tables map[storage.AncientKind]*table
+	frozen uint64 // number of blocks stored in the freezer
+}
+
+// NewFreezer opens (or creates) the freezer in the given directory
+func NewFreezer(dir string) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	f := &Freezer{
+		tables: make(map[storage.AncientKind]*table, len(storage.AncientKinds)),
+	}
+
+	for _, kind := range storage.AncientKinds {
+		t, err := newTable(dir, string(kind))
+		if err != nil {
+			_ = f.Close()
+
+			return nil, fmt.Errorf("failed to open %s freezer table: %w", kind, err)
+		}
+
+		f.tables[kind] = t
+	}
+
+	if err := f.repair(); err != nil {
+		_ = f.Close()
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// repair truncates all the tables to the length of the shortest one,
+// so an interrupted append never leaves a block partially frozen
+func (f *Freezer) repair() error {
+	minItems := uint64(0)
+
+	for i, kind := range storage.AncientKinds {
+		if items := f.tables[kind].items; i == 0 || items < minItems {
+			minItems = items
+		}
+	}
+
+	for _, t := range f.tables {
+		if err := t.truncate(minItems); err != nil {
+			return err
+		}
+	}
+
+	f.frozen = minItems
+
+	return nil
+}
+
+// Ancients returns the number of blocks stored in the freezer
+func (f *Freezer) Ancients() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.frozen
+}
+
+// ReadAncient returns the raw data of the given kind for the given block number
+func (f *Freezer) ReadAncient(kind storage.AncientKind, number uint64) ([]byte, error) {
+	t, ok := f.tables[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown ancient kind %s", kind)
+	}
+
+	if number >= f.Ancients() {
+		return nil, storage.ErrNotFound
+	}
+
+	return t.retrieve(number)
+}
+
+// AppendAncient appends the block data to the freezer.
+// The block number has to be the next one after the last frozen block
+func (f *Freezer) AppendAncient(number uint64, data map[storage.AncientKind][]byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if number != f.frozen {
+		return fmt.Errorf("appending unexpected block, want %d, have %d", f.frozen, number)
+	}
+
+	for _, kind := range storage.AncientKinds {
+		if err := f.tables[kind].append(number, data[kind]); err != nil {
+			// roll back the tables that already got the item
+			for _, t := range f.tables {
+				_ = t.truncate(number)
+			}
+
+			return err
+		}
+	}
+
+	f.frozen++
+
+	return nil
+}
+
+// Sync flushes all the freezer tables to the disk
+func (f *Freezer) Sync() error {
+	var errs []error
+
+	for _, t := range f.tables {
+		if err := t.sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes all the freezer tables
+func (f *Freezer) Close() error {
+	var errs []error
+
+	for _, t := range f.tables {
+		if err := t.close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
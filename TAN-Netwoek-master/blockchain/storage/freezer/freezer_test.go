@@ -0,0 +1,101 @@
+package freezer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/blockchain/storage"
+)
+
+func blockData(number byte) map[storage.AncientKind][]byte {
+	return map[storage.AncientKind][]byte{
+		storage.AncientHashes:   {number, 0x1},
+		storage.AncientHeaders:  {number, 0x2, 0x2},
+		storage.AncientBodies:   {},
+		storage.AncientReceipts: {number, 0x4, 0x4, 0x4, 0x4},
+	}
+}
+
+func TestFreezer_AppendAndRead(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := NewFreezer(dir)
+	require.NoError(t, err)
+
+	for i := byte(0); i < 10; i++ {
+		require.NoError(t, f.AppendAncient(uint64(i), blockData(i)))
+	}
+
+	require.Equal(t, uint64(10), f.Ancients())
+	require.Error(t, f.AppendAncient(5, blockData(5)))
+
+	for i := byte(0); i < 10; i++ {
+		for kind, expected := range blockData(i) {
+			data, err := f.ReadAncient(kind, uint64(i))
+			require.NoError(t, err)
+			require.Equal(t, expected, data)
+		}
+	}
+
+	_, err = f.ReadAncient(storage.AncientHeaders, 10)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.NoError(t, f.Sync())
+	require.NoError(t, f.Close())
+
+	// reopen
+	f, err = NewFreezer(dir)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	require.Equal(t, uint64(10), f.Ancients())
+
+	data, err := f.ReadAncient(storage.AncientReceipts, 9)
+	require.NoError(t, err)
+	require.Equal(t, blockData(9)[storage.AncientReceipts], data)
+}
+
+func TestFreezer_RepairPartialAppend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := NewFreezer(dir)
+	require.NoError(t, err)
+
+	for i := byte(0); i < 3; i++ {
+		require.NoError(t, f.AppendAncient(uint64(i), blockData(i)))
+	}
+
+	require.NoError(t, f.Close())
+
+	// simulate a crash in the middle of appending the fourth block
+	t3, err := newTable(dir, string(storage.AncientHashes))
+	require.NoError(t, err)
+	require.NoError(t, t3.append(3, []byte{0x3, 0x1}))
+	require.NoError(t, t3.close())
+
+	// and a half written index entry
+	idx, err := os.OpenFile(filepath.Join(dir, string(storage.AncientHeaders)+".idx"), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = idx.Write([]byte{0x0, 0x0, 0x1})
+	require.NoError(t, err)
+	require.NoError(t, idx.Close())
+
+	f, err = NewFreezer(dir)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	require.Equal(t, uint64(3), f.Ancients())
+	require.NoError(t, f.AppendAncient(3, blockData(3)))
+
+	data, err := f.ReadAncient(storage.AncientHashes, 3)
+	require.NoError(t, err)
+	require.Equal(t, blockData(3)[storage.AncientHashes], data)
+}
@@ -0,0 +1,272 @@
+package freezer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// indexEntrySize is the size of a single index entry (end offset of the item in the data file)
+const indexEntrySize = 8
+
+var (
+	errOutOfBounds = errors.New("out of bounds")
+	errClosed      = errors.New("table closed")
+)
+
+// table is an append-only flat file storing a single kind of item.
+// Items are addressed by their position, which is the block number.
+//
+// Each table is made of two files:
+//   - <name>.dat holds the concatenated items
+//   - <name>.idx holds the end offset of every item in the data file as an uint64
+type table struct {
+	lock sync.RWMutex
+
+	name  string
+	data  *os.File
+	index *os.File
+
+	items uint64 // number of items stored in the table
+	size  uint64 // size of the data file
+}
+
+// newTable opens (or creates) the table files in the given directory,
+// repairing any half-written tail left by an unclean shutdown
+func newTable(dir, name string) (*table, error) {
+	data, err := os.OpenFile(filepath.Join(dir, name+".dat"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := os.OpenFile(filepath.Join(dir, name+".idx"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		_ = data.Close()
+
+		return nil, err
+	}
+
+	t := &table{
+		name:  name,
+		data:  data,
+		index: index,
+	}
+
+	if err := t.repair(); err != nil {
+		_ = t.close()
+
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// repair aligns the index and data files, dropping partially written entries
+func (t *table) repair() error {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	indexSize := uint64(indexStat.Size())
+	if rem := indexSize % indexEntrySize; rem != 0 {
+		indexSize -= rem
+	}
+
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	dataSize := uint64(dataStat.Size())
+
+	// drop index entries pointing past the end of the data file
+	for indexSize > 0 {
+		end, err := t.readOffset(indexSize/indexEntrySize - 1)
+		if err != nil {
+			return err
+		}
+
+		if end <= dataSize {
+			dataSize = end
+
+			break
+		}
+
+		indexSize -= indexEntrySize
+	}
+
+	if indexSize == 0 {
+		dataSize = 0
+	}
+
+	if err := t.index.Truncate(int64(indexSize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(dataSize)); err != nil {
+		return err
+	}
+
+	t.items = indexSize / indexEntrySize
+	t.size = dataSize
+
+	return nil
+}
+
+// readOffset returns the end offset of the item at the given position
+func (t *table) readOffset(item uint64) (uint64, error) {
+	buf := make([]byte, indexEntrySize)
+	if _, err := t.index.ReadAt(buf, int64(item*indexEntrySize)); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+// append writes the item at the end of the table
+func (t *table) append(item uint64, blob []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.data == nil {
+		return errClosed
+	}
+
+	if item != t.items {
+		return fmt.Errorf("%s: appending unexpected item, want %d, have %d", t.name, t.items, item)
+	}
+
+	if _, err := t.data.WriteAt(blob, int64(t.size)); err != nil {
+		return err
+	}
+
+	end := t.size + uint64(len(blob))
+
+	buf := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint64(buf, end)
+
+	if _, err := t.index.WriteAt(buf, int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+
+	t.items++
+	t.size = end
+
+	return nil
+}
+
+// retrieve reads the item at the given position
+func (t *table) retrieve(item uint64) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.data == nil {
+		return nil, errClosed
+	}
+
+	if item >= t.items {
+		return nil, errOutOfBounds
+	}
+
+	var start uint64
+
+	if item > 0 {
+		offset, err := t.readOffset(item - 1)
+		if err != nil {
+			return nil, err
+		}
+
+		start = offset
+	}
+
+	end, err := t.readOffset(item)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := make([]byte, end-start)
+	if _, err := t.data.ReadAt(blob, int64(start)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+// truncate drops all the items at and after the given position
+func (t *table) truncate(items uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if items >= t.items {
+		return nil
+	}
+
+	var size uint64
+
+	if items > 0 {
+		offset, err := t.readOffset(items - 1)
+		if err != nil {
+			return err
+		}
+
+		size = offset
+	}
+
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+
+	t.items = items
+	t.size = size
+
+	return nil
+}
+
+// sync flushes the table files to the disk
+func (t *table) sync() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.data == nil {
+		return errClosed
+	}
+
+	if err := t.index.Sync(); err != nil {
+		return err
+	}
+
+	return t.data.Sync()
+}
+
+// close closes the table files
+func (t *table) close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.data == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if err := t.index.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := t.data.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	t.index, t.data = nil, nil
+
+	return errors.Join(errs...)
+}
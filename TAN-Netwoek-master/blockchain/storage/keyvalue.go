@@ -2,6 +2,7 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -39,6 +40,21 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// HEADER_NUMBER is the prefix for the numbers of the blocks moved to the ancient store
+	HEADER_NUMBER = []byte("n")
+
+	// ADDRESS_TX is the prefix for the address to transaction index
+	ADDRESS_TX = []byte("a")
+
+	// ADDRESS_TX_TRACED is the prefix for the last block whose internal transfers are in the address index
+	ADDRESS_TX_TRACED = []byte("i")
+
+	// BLOOM_BITS is the prefix for the bloom bits log index
+	BLOOM_BITS = []byte("B")
+
+	// BAD_BLOCK is the prefix for the blocks rejected by the local node
+	BAD_BLOCK = []byte("x")
 )
 
 // Sub-prefixes
@@ -46,6 +62,8 @@ var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
 	EMPTY  = []byte("empty")
+
+	SECTIONS = []byte("sections")
 )
 
 // KV is a key value storage interface.
@@ -55,13 +73,17 @@ type KV interface {
 	Close() error
 	Get(p []byte) ([]byte, bool, error)
 	NewBatch() Batch
+	// Iterate calls the handler for every key in [start, end) range in ascending order
+	// (descending if reverse is set), until the handler returns false
+	Iterate(start, end []byte, reverse bool, handler func(key, value []byte) bool) error
 }
 
 // KeyValueStorage is a generic storage for kv databases
 type KeyValueStorage struct {
-	logger hclog.Logger
-	db     KV
-	Db     KV
+	logger   hclog.Logger
+	db       KV
+	Db       KV
+	ancients *ancientsFreezer
 }
 
 func NewKeyValueStorage(logger hclog.Logger, db KV) Storage {
@@ -133,7 +155,11 @@ func (s *KeyValueStorage) ReadTotalDifficulty(hash types.Hash) (*big.Int, bool)
 // ReadHeader reads the header
 func (s *KeyValueStorage) ReadHeader(hash types.Hash) (*types.Header, error) {
 	header := &types.Header{}
+
 	err := s.readRLP(HEADER, hash.Bytes(), header)
+	if errors.Is(err, ErrNotFound) {
+		err = s.readAncientRLP(AncientHeaders, hash, header)
+	}
 
 	return header, err
 }
@@ -143,13 +169,19 @@ func (s *KeyValueStorage) ReadHeader(hash types.Hash) (*types.Header, error) {
 // ReadBody reads the body
 func (s *KeyValueStorage) ReadBody(hash types.Hash) (*types.Body, error) {
 	body := &types.Body{}
-	if err := s.readRLP(BODY, hash.Bytes(), body); err != nil {
+
+	err := s.readRLP(BODY, hash.Bytes(), body)
+	if errors.Is(err, ErrNotFound) {
+		err = s.readAncientRLP(AncientBodies, hash, body)
+	}
+
+	if err != nil {
 		return nil, err
 	}
 
 	// must read header because block number is needed in order to calculate each tx hash
-	header := &types.Header{}
-	if err := s.readRLP(HEADER, hash.Bytes(), header); err != nil {
+	header, err := s.ReadHeader(hash)
+	if err != nil {
 		return nil, err
 	}
 
@@ -165,7 +197,11 @@ func (s *KeyValueStorage) ReadBody(hash types.Hash) (*types.Body, error) {
 // ReadReceipts reads the receipts
 func (s *KeyValueStorage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error) {
 	receipts := &types.Receipts{}
+
 	err := s.readRLP(RECEIPTS, hash.Bytes(), receipts)
+	if errors.Is(err, ErrNotFound) {
+		err = s.readAncientRLP(AncientReceipts, hash, receipts)
+	}
 
 	return *receipts, err
 }
@@ -205,6 +241,11 @@ func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
 		return ErrNotFound
 	}
 
+	return decodeRLP(data, raw)
+}
+
+// decodeRLP decodes the stored data into the given object
+func decodeRLP(data []byte, raw types.RLPUnmarshaler) error {
 	if obj, ok := raw.(types.RLPStoreUnmarshaler); ok {
 		// decode in the store format
 		if err := obj.UnmarshalStoreRLP(data); err != nil {
@@ -247,6 +288,10 @@ func (s *KeyValueStorage) get(p []byte, k []byte) ([]byte, bool) {
 
 // Close closes the connection with the db
 func (s *KeyValueStorage) Close() error {
+	if err := s.closeAncients(); err != nil {
+		return err
+	}
+
 	return s.db.Close()
 }
 
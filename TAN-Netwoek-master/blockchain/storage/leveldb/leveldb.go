@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/blockchain/storage/freezer"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 const (
@@ -53,6 +55,41 @@ func NewLevelDBStorageWithOpt(path string, logger hclog.Logger, opts *opt.Option
 	return storage.NewKeyValueStorage(logger.Named("leveldb"), kv), nil
 }
 
+// NewLevelDBStorageWithAncients creates the new storage reference with leveldb default options,
+// which moves the canonical chain data older than threshold blocks into the freezer at ancientsPath
+func NewLevelDBStorageWithAncients(
+	path, ancientsPath string, threshold uint64, logger hclog.Logger) (storage.Storage, error) {
+	options := &opt.Options{
+		OpenFilesCacheCapacity: DefaultHandles,
+		BlockCacheCapacity:     DefaultCache / 2 * opt.MiB,
+		WriteBuffer:            DefaultCache / 4 * opt.MiB, // Two of these are used internally
+	}
+
+	db, err := leveldb.OpenFile(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ancients, err := freezer.NewFreezer(ancientsPath)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	kv := &levelDBKV{db}
+
+	s, err := storage.NewKeyValueStorageWithAncients(logger.Named("leveldb"), kv, ancients, threshold)
+	if err != nil {
+		_ = ancients.Close()
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return s, nil
+}
+
 // levelDBKV is the leveldb implementation of the kv storage
 type levelDBKV struct {
 	db *leveldb.DB
@@ -82,6 +119,25 @@ func (l *levelDBKV) Close() error {
 	return l.db.Close()
 }
 
+// Iterate iterates over the key-value pairs in the given range of the leveldb storage
+func (l *levelDBKV) Iterate(start, end []byte, reverse bool, handler func(key, value []byte) bool) error {
+	iter := l.db.NewIterator(&util.Range{Start: start, Limit: end}, nil)
+	defer iter.Release()
+
+	first, next := iter.First, iter.Next
+	if reverse {
+		first, next = iter.Last, iter.Prev
+	}
+
+	for ok := first(); ok; ok = next() {
+		if !handler(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
 func (l *levelDBKV) NewBatch() storage.Batch {
 	return NewBatchLevelDB(l.db)
 }
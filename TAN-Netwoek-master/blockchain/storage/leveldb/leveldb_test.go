@@ -16,6 +16,7 @@ import (
 	"github.com/tarality/tan-network/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
 )
 
 func newStorage(t *testing.T) (storage.Storage, func()) {
@@ -254,3 +255,157 @@ insertloop:
 		}
 	}
 }
+
+func TestStorage_Ancients(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	s, err := NewLevelDBStorageWithAncients(
+		filepath.Join(path, "blockchain"), filepath.Join(path, "ancient"), 4, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	addr1 := types.StringToAddress("1")
+	addr2 := types.StringToAddress("2")
+	blocks := make([]*types.Block, 10)
+
+	for i := range blocks {
+		header := &types.Header{Number: uint64(i), ExtraData: []byte{byte(i)}}
+		if i > 0 {
+			header.ParentHash = blocks[i-1].Hash()
+		}
+
+		header.ComputeHash()
+
+		blocks[i] = &types.Block{
+			Header:       header,
+			Transactions: generateTxs(t, i, 2, addr1, &addr2),
+		}
+
+		batchWriter := storage.NewBatchWriter(s)
+		batchWriter.PutCanonicalHeader(header, big.NewInt(int64(i)))
+
+		if i > 0 {
+			batchWriter.PutBody(header.Hash, blocks[i].Body())
+			batchWriter.PutReceipts(header.Hash, []*types.Receipt{{GasUsed: uint64(i)}})
+		}
+
+		require.NoError(t, batchWriter.WriteBatch())
+	}
+
+	kv, ok := s.(*storage.KeyValueStorage)
+	require.True(t, ok)
+
+	frozen, err := kv.Freeze()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), frozen)
+
+	// nothing new to freeze
+	frozen, err = kv.Freeze()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), frozen)
+
+	for i, block := range blocks {
+		header, err := s.ReadHeader(block.Hash())
+		require.NoError(t, err)
+		require.Equal(t, block.Number(), header.Number)
+
+		if i == 0 {
+			_, err = s.ReadBody(block.Hash())
+			require.ErrorIs(t, err, storage.ErrNotFound)
+
+			continue
+		}
+
+		body, err := s.ReadBody(block.Hash())
+		require.NoError(t, err)
+		require.Len(t, body.Transactions, 2)
+		require.Equal(t, block.Transactions[1].Hash, body.Transactions[1].Hash)
+
+		receipts, err := s.ReadReceipts(block.Hash())
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Equal(t, uint64(i), receipts[0].GasUsed)
+	}
+
+	_, err = s.ReadHeader(types.StringToHash("unknown"))
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.NoError(t, s.Close())
+
+	// data is still there after reopening
+	s, err = NewLevelDBStorageWithAncients(
+		filepath.Join(path, "blockchain"), filepath.Join(path, "ancient"), 4, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	header, err := s.ReadHeader(blocks[2].Hash())
+	require.NoError(t, err)
+	require.Equal(t, blocks[2].Header.ExtraData, header.ExtraData)
+}
+
+func TestStorage_Ancients_Repair(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path          = t.TempDir()
+		blockchainDir = filepath.Join(path, "blockchain")
+		ancientsDir   = filepath.Join(path, "ancient")
+	)
+
+	s, err := NewLevelDBStorageWithAncients(blockchainDir, ancientsDir, 4, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	headers := make([]*types.Header, 10)
+
+	for i := range headers {
+		headers[i] = &types.Header{Number: uint64(i), ExtraData: []byte{byte(i)}}
+		if i > 0 {
+			headers[i].ParentHash = headers[i-1].Hash
+		}
+
+		headers[i].ComputeHash()
+
+		batchWriter := storage.NewBatchWriter(s)
+		batchWriter.PutCanonicalHeader(headers[i], big.NewInt(int64(i)))
+		require.NoError(t, batchWriter.WriteBatch())
+	}
+
+	frozen, err := s.(*storage.KeyValueStorage).Freeze() //nolint:forcetypeassert
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), frozen)
+
+	// node stopped before the frozen headers were deleted from the key-value store
+	batchWriter := storage.NewBatchWriter(s)
+	for _, header := range headers[:5] {
+		batchWriter.PutHeader(header)
+	}
+
+	require.NoError(t, batchWriter.WriteBatch())
+	require.NoError(t, s.Close())
+
+	// the ancient store is repaired on opening, even if it is opened for reading only
+	s, err = NewLevelDBStorageWithAncients(blockchainDir, ancientsDir, 0, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	header, err := s.ReadHeader(headers[2].Hash)
+	require.NoError(t, err)
+	require.Equal(t, headers[2].ExtraData, header.ExtraData)
+
+	require.NoError(t, s.Close())
+
+	db, err := leveldb.OpenFile(blockchainDir, nil)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	for i, header := range headers {
+		_, err := db.Get(append(append([]byte{}, storage.HEADER...), header.Hash.Bytes()...), nil)
+		if i < 5 {
+			require.ErrorIs(t, err, leveldb.ErrNotFound)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package memory
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/tarality/tan-network/blockchain/storage"
 	"github.com/tarality/tan-network/helper/hex"
 	"github.com/hashicorp/go-hclog"
@@ -33,6 +36,37 @@ func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryKV) Iterate(start, end []byte, reverse bool, handler func(key, value []byte) bool) error {
+	keys := make([][]byte, 0)
+
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Compare(key, start) >= 0 && bytes.Compare(key, end) < 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if reverse {
+			return bytes.Compare(keys[i], keys[j]) > 0
+		}
+
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	for _, key := range keys {
+		if !handler(key, m.db[hex.EncodeToHex(key)]) {
+			break
+		}
+	}
+
+	return nil
+}
+
 func (m *memoryKV) Close() error {
 	return nil
 }
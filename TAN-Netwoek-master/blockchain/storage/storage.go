@@ -26,6 +26,14 @@ type Storage interface {
 
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	ReadAddressTxs(addr types.Address, from, to uint64, reverse bool, handler func(*AddressTx) bool) error
+	ReadAddressTxTraced() (uint64, types.Hash, bool)
+
+	ReadBloomBits(bit uint, section uint64) (types.Hash, []byte, error)
+	ReadBloomSections() (uint64, bool)
+
+	ReadBadBlocks() ([]*BadBlock, error)
+
 	NewBatch() Batch
 
 	Close() error
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/helper/hex"
 	"github.com/tarality/tan-network/types"
 	"github.com/stretchr/testify/assert"
@@ -49,6 +50,15 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testAddressTxs", func(t *testing.T) {
+		testAddressTxs(t, m)
+	})
+	t.Run("testBloomBits", func(t *testing.T) {
+		testBloomBits(t, m)
+	})
+	t.Run("testBadBlocks", func(t *testing.T) {
+		testBadBlocks(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -394,6 +404,161 @@ func testReceipts(t *testing.T, m PlaceholderStorage) {
 	assert.True(t, reflect.DeepEqual(receipts, found))
 }
 
+func testAddressTxs(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	batch := NewBatchWriter(s)
+
+	entries := []*AddressTx{
+		{BlockNumber: 1, TxIndex: 0, TxHash: types.StringToHash("10"), Kind: AddressTxSender},
+		{BlockNumber: 1, TxIndex: 3, TxHash: types.StringToHash("13"), Kind: AddressTxRecipient},
+		{BlockNumber: 2, TxIndex: 1, TxHash: types.StringToHash("21"), Kind: AddressTxSender | AddressTxRecipient},
+		{BlockNumber: 256, TxIndex: 0, TxHash: types.StringToHash("2560"), Kind: AddressTxCreation},
+	}
+
+	for _, entry := range entries {
+		entry.BlockHash = types.BytesToHash(common.EncodeUint64ToBytes(entry.BlockNumber))
+		batch.PutAddressTx(addr1, entry)
+	}
+
+	batch.PutAddressTx(addr2, &AddressTx{BlockNumber: 2, TxHash: types.StringToHash("20"), Kind: AddressTxSender})
+
+	require.NoError(t, batch.WriteBatch())
+
+	read := func(addr types.Address, from, to uint64, reverse bool, limit int) []*AddressTx {
+		t.Helper()
+
+		found := []*AddressTx{}
+
+		require.NoError(t, s.ReadAddressTxs(addr, from, to, reverse, func(tx *AddressTx) bool {
+			found = append(found, tx)
+
+			return len(found) < limit
+		}))
+
+		return found
+	}
+
+	assert.Equal(t, entries, read(addr1, 0, 1000, false, 10))
+	assert.Equal(t, entries[:3], read(addr1, 1, 255, false, 10))
+	assert.Equal(t, []*AddressTx{entries[3], entries[2]}, read(addr1, 0, 1000, true, 2))
+	assert.Equal(t, entries[:2], read(addr1, 0, 1, false, 10))
+	assert.Len(t, read(addr1, 3, 255, false, 10), 0)
+	assert.Len(t, read(addr2, 0, 1000, false, 10), 1)
+	assert.Len(t, read(types.StringToAddress("3"), 0, 1000, false, 10), 0)
+
+	// the progress of the internal transfers tracing
+	_, _, ok := s.ReadAddressTxTraced()
+	assert.False(t, ok)
+
+	batch = NewBatchWriter(s)
+	batch.PutAddressTxTraced(256, entries[3].BlockHash)
+	require.NoError(t, batch.WriteBatch())
+
+	number, hash, ok := s.ReadAddressTxTraced()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(256), number)
+	assert.Equal(t, entries[3].BlockHash, hash)
+}
+
+func testBloomBits(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadBloomSections()
+	assert.False(t, ok)
+
+	_, _, err := s.ReadBloomBits(0, 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	bits := make([]byte, 512)
+	bits[0], bits[511] = 0x80, 0x01
+
+	batch := NewBatchWriter(s)
+	batch.PutBloomBits(2047, 1, hash1, bits)
+	batch.PutBloomSections(2)
+	require.NoError(t, batch.WriteBatch())
+
+	sections, ok := s.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), sections)
+
+	head, found, err := s.ReadBloomBits(2047, 1)
+	require.NoError(t, err)
+	assert.Equal(t, hash1, head)
+	assert.Equal(t, bits, found)
+
+	_, _, err = s.ReadBloomBits(2047, 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func testBadBlocks(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	badBlocks, err := s.ReadBadBlocks()
+	require.NoError(t, err)
+	assert.Len(t, badBlocks, 0)
+
+	newBadBlock := func(number uint64) *BadBlock {
+		header := &types.Header{Number: number, ExtraData: []byte{}}
+		header.ComputeHash()
+
+		return &BadBlock{
+			Block:     &types.Block{Header: header},
+			Reason:    "invalid state root",
+			StateRoot: hash1,
+			GasUsed:   21000,
+			Receipts: []*types.Receipt{
+				{CumulativeGasUsed: 21000, TxHash: hash2, Logs: []*types.Log{}},
+			},
+			Time: 100 + number,
+		}
+	}
+
+	first, second := newBadBlock(1), newBadBlock(2)
+
+	batch := NewBatchWriter(s)
+	batch.PutBadBlock(first)
+	batch.PutBadBlock(second)
+	require.NoError(t, batch.WriteBatch())
+
+	badBlocks, err = s.ReadBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 2)
+
+	for _, badBlock := range badBlocks {
+		expected := first
+		if badBlock.Block.Hash() == second.Block.Hash() {
+			expected = second
+		}
+
+		assert.Equal(t, expected.Block.Number(), badBlock.Block.Number())
+		assert.Equal(t, expected.Reason, badBlock.Reason)
+		assert.Equal(t, expected.StateRoot, badBlock.StateRoot)
+		assert.Equal(t, expected.GasUsed, badBlock.GasUsed)
+		assert.Equal(t, expected.Time, badBlock.Time)
+		require.Len(t, badBlock.Receipts, 1)
+		assert.Equal(t, hash2, badBlock.Receipts[0].TxHash)
+	}
+
+	batch = NewBatchWriter(s)
+	batch.DeleteBadBlock(first.Block.Hash())
+	require.NoError(t, batch.WriteBatch())
+
+	badBlocks, err = s.ReadBadBlocks()
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 1)
+	assert.Equal(t, second.Block.Hash(), badBlocks[0].Block.Hash())
+}
+
 func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -460,6 +625,11 @@ type readBodyDelegate func(types.Hash) (*types.Body, error)
 type readSnapshotDelegate func(types.Hash) ([]byte, bool)
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type readAddressTxsDelegate func(types.Address, uint64, uint64, bool, func(*AddressTx) bool) error
+type readAddressTxTracedDelegate func() (uint64, types.Hash, bool)
+type readBloomBitsDelegate func(uint, uint64) (types.Hash, []byte, error)
+type readBloomSectionsDelegate func() (uint64, bool)
+type readBadBlocksDelegate func() ([]*BadBlock, error)
 type closeDelegate func() error
 type newBatchDelegate func() Batch
 
@@ -473,6 +643,11 @@ type MockStorage struct {
 	readBodyFn            readBodyDelegate
 	readReceiptsFn        readReceiptsDelegate
 	readTxLookupFn        readTxLookupDelegate
+	readAddressTxsFn      readAddressTxsDelegate
+	readAddressTxTracedFn readAddressTxTracedDelegate
+	readBloomBitsFn       readBloomBitsDelegate
+	readBloomSectionsFn   readBloomSectionsDelegate
+	readBadBlocksFn       readBadBlocksDelegate
 	closeFn               closeDelegate
 	newBatchFn            newBatchDelegate
 }
@@ -589,6 +764,67 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) ReadAddressTxs(
+	addr types.Address, from, to uint64, reverse bool, handler func(*AddressTx) bool) error {
+	if m.readAddressTxsFn != nil {
+		return m.readAddressTxsFn(addr, from, to, reverse, handler)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookReadAddressTxs(fn readAddressTxsDelegate) {
+	m.readAddressTxsFn = fn
+}
+
+func (m *MockStorage) ReadAddressTxTraced() (uint64, types.Hash, bool) {
+	if m.readAddressTxTracedFn != nil {
+		return m.readAddressTxTracedFn()
+	}
+
+	return 0, types.Hash{}, false
+}
+
+func (m *MockStorage) HookReadAddressTxTraced(fn readAddressTxTracedDelegate) {
+	m.readAddressTxTracedFn = fn
+}
+
+func (m *MockStorage) ReadBloomBits(bit uint, section uint64) (types.Hash, []byte, error) {
+	if m.readBloomBitsFn != nil {
+		return m.readBloomBitsFn(bit, section)
+	}
+
+	return types.Hash{}, nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadBloomBits(fn readBloomBitsDelegate) {
+	m.readBloomBitsFn = fn
+}
+
+func (m *MockStorage) ReadBloomSections() (uint64, bool) {
+	if m.readBloomSectionsFn != nil {
+		return m.readBloomSectionsFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadBloomSections(fn readBloomSectionsDelegate) {
+	m.readBloomSectionsFn = fn
+}
+
+func (m *MockStorage) ReadBadBlocks() ([]*BadBlock, error) {
+	if m.readBadBlocksFn != nil {
+		return m.readBadBlocksFn()
+	}
+
+	return nil, nil
+}
+
+func (m *MockStorage) HookReadBadBlocks(fn readBadBlocksDelegate) {
+	m.readBadBlocksFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
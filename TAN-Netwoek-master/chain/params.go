@@ -2,9 +2,12 @@ package chain
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 	"sort"
 
 	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/helper/hex"
 	"github.com/tarality/tan-network/types"
 )
 
@@ -30,6 +33,16 @@ type Params struct {
 	BridgeAllowList           *AddressListConfig `json:"bridgeAllowList,omitempty"`
 	BridgeBlockList           *AddressListConfig `json:"bridgeBlockList,omitempty"`
 
+	// Gas fee allow list, its enabled addresses pay the discounted gas price
+	GasFeeAllowList *GasFeeAllowListConfig `json:"gasFeeAllowList,omitempty"`
+
+	// Call permission list, restricting the callers of the contract functions
+	CallPermissionList *CallPermissionListConfig `json:"callPermissionList,omitempty"`
+
+	// Base fee distribution between the burn contract, the treasury and the block proposer,
+	// the base fee is burnt if it's not set
+	BaseFeeDistribution *BaseFeeDistributionConfig `json:"baseFeeDistribution,omitempty"`
+
 	// Governance contract where the token will be sent to and burn in london fork
 	BurnContract map[uint64]types.Address `json:"burnContract"`
 	// Destination address to initialize default burn contract with
@@ -44,6 +57,103 @@ type AddressListConfig struct {
 	EnabledAddresses []types.Address `json:"enabledAddresses,omitempty"`
 }
 
+// GasFeeAllowListConfig is the configuration of the gas fee allow list
+type GasFeeAllowListConfig struct {
+	AddressListConfig
+
+	// DiscountPercent is the percentage of the gas price the enabled addresses don't pay,
+	// 100 makes their transactions gas free
+	DiscountPercent uint64 `json:"discountPercent"`
+}
+
+// Discount returns the part of the gas fee amount the enabled addresses pay
+func (c *GasFeeAllowListConfig) Discount(amount *big.Int) *big.Int {
+	if c.DiscountPercent >= 100 {
+		return big.NewInt(0)
+	}
+
+	discounted := new(big.Int).Mul(amount, new(big.Int).SetUint64(100-c.DiscountPercent))
+
+	return discounted.Div(discounted, big.NewInt(100))
+}
+
+// BaseFeeDistributionConfig is the configuration of the base fee distribution.
+// The forks can change the percentages through their params
+type BaseFeeDistributionConfig struct {
+	forkmanager.BaseFeeDistribution
+
+	// Treasury is the address of the community treasury
+	Treasury types.Address `json:"treasury"`
+}
+
+// CallPermissionListConfig is the configuration of the call permission list.
+// Its admins manage the roles and the calls, its enabled addresses manage the calls only
+type CallPermissionListConfig struct {
+	AddressListConfig
+
+	// Restrictions are the initially restricted calls, only the permitted callers can make them
+	Restrictions []*CallRestriction `json:"restrictions,omitempty"`
+
+	// Permissions are the initial permissions of the callers
+	Permissions []*CallPermission `json:"permissions,omitempty"`
+}
+
+// CallRestriction restricts calling the function of the target contract,
+// the zero selector restricts calling any function of it.
+// Only the accounts with code are restricted, the plain transfers to the other accounts are never checked
+type CallRestriction struct {
+	Target   types.Address `json:"target"`
+	Selector Selector      `json:"selector"`
+}
+
+// CallPermission permits the caller to call the function of the target contract,
+// the zero selector permits calling any function of it
+type CallPermission struct {
+	Caller   types.Address `json:"caller"`
+	Target   types.Address `json:"target"`
+	Selector Selector      `json:"selector"`
+}
+
+// Selector is the function selector of a contract call
+type Selector [types.SignatureSize]byte
+
+// MarshalText implements encoding.TextMarshaler
+func (s Selector) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToHex(s[:])), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (s *Selector) UnmarshalText(input []byte) error {
+	buf, err := hex.DecodeHex(string(input))
+	if err != nil {
+		return err
+	}
+
+	if len(buf) != types.SignatureSize {
+		return fmt.Errorf("invalid selector length %d, expected %d", len(buf), types.SignatureSize)
+	}
+
+	copy(s[:], buf)
+
+	return nil
+}
+
+// GetBaseFeeDistribution returns the base fee distribution for the given block number,
+// or nil if the base fee is burnt
+func (p *Params) GetBaseFeeDistribution(block uint64) *BaseFeeDistributionConfig {
+	if p.BaseFeeDistribution == nil {
+		return nil
+	}
+
+	distribution := *p.BaseFeeDistribution
+
+	if params := forkmanager.GetInstance().GetParams(block); params != nil && params.BaseFeeDistribution != nil {
+		distribution.BaseFeeDistribution = *params.BaseFeeDistribution
+	}
+
+	return &distribution
+}
+
 // CalculateBurnContract calculates burn contract address for the given block number
 func (p *Params) CalculateBurnContract(block uint64) (types.Address, error) {
 	blocks := make([]uint64, 0, len(p.BurnContract))
@@ -86,11 +196,16 @@ const (
 	Petersburg          = "petersburg"
 	Istanbul            = "istanbul"
 	London              = "london"
+	Berlin              = "berlin"  // EIP-2929 cold and warm state access gas costs
+	London2             = "london2" // EIP-3529 reduction in refunds
 	EIP150              = "EIP150"
 	EIP158              = "EIP158"
 	EIP155              = "EIP155"
 	QuorumCalcAlignment = "quorumcalcalignment"
 	TxHashWithType      = "txHashWithType"
+	FeeDelegation       = "feeDelegation"
+	DoubleSignSlashing  = "doubleSignSlashing"
+	KeyRotation         = "keyRotation"
 )
 
 // Forks is map which contains all forks and their starting blocks from genesis
@@ -121,11 +236,16 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Petersburg:          f.IsActive(Petersburg, block),
 		Istanbul:            f.IsActive(Istanbul, block),
 		London:              f.IsActive(London, block),
+		Berlin:              f.IsActive(Berlin, block),
+		London2:             f.IsActive(London2, block),
 		EIP150:              f.IsActive(EIP150, block),
 		EIP158:              f.IsActive(EIP158, block),
 		EIP155:              f.IsActive(EIP155, block),
 		QuorumCalcAlignment: f.IsActive(QuorumCalcAlignment, block),
 		TxHashWithType:      f.IsActive(TxHashWithType, block),
+		FeeDelegation:       f.IsActive(FeeDelegation, block),
+		DoubleSignSlashing:  f.IsActive(DoubleSignSlashing, block),
+		KeyRotation:         f.IsActive(KeyRotation, block),
 	}
 }
 
@@ -150,11 +270,16 @@ type ForksInTime struct {
 	Petersburg,
 	Istanbul,
 	London,
+	Berlin,
+	London2,
 	EIP150,
 	EIP158,
 	EIP155,
 	QuorumCalcAlignment,
-	TxHashWithType bool
+	TxHashWithType,
+	FeeDelegation,
+	DoubleSignSlashing,
+	KeyRotation bool
 }
 
 // AllForksEnabled should contain all supported forks by current node version
@@ -168,6 +293,11 @@ var AllForksEnabled = &Forks{
 	Petersburg:          NewFork(0),
 	Istanbul:            NewFork(0),
 	London:              NewFork(0),
+	Berlin:              NewFork(0),
+	London2:             NewFork(0),
 	QuorumCalcAlignment: NewFork(0),
 	TxHashWithType:      NewFork(0),
+	FeeDelegation:       NewFork(0),
+	DoubleSignSlashing:  NewFork(0),
+	KeyRotation:         NewFork(0),
 }
@@ -2,11 +2,13 @@ package chain
 
 import (
 	"encoding/json"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/tarality/tan-network/forkmanager"
 	"github.com/tarality/tan-network/types"
 )
 
@@ -128,3 +130,83 @@ func TestParams_CalculateBurnContract(t *testing.T) {
 		})
 	}
 }
+
+func TestGasFeeAllowListConfig_Discount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		discountPercent uint64
+		amount          int64
+		want            int64
+	}{
+		{0, 1000, 1000},
+		{25, 1000, 750},
+		{50, 15, 7},
+		{100, 1000, 0},
+		{150, 1000, 0},
+	}
+
+	for _, tt := range tests {
+		c := &GasFeeAllowListConfig{DiscountPercent: tt.discountPercent}
+
+		require.Equal(t, tt.want, c.Discount(big.NewInt(tt.amount)).Int64(), "discount %d%%", tt.discountPercent)
+	}
+}
+
+func TestParams_GetBaseFeeDistribution(t *testing.T) {
+	fm := forkmanager.GetInstance()
+	defer fm.Clear()
+
+	treasury := types.StringToAddress("1")
+	p := &Params{}
+
+	require.Nil(t, p.GetBaseFeeDistribution(0))
+
+	p.BaseFeeDistribution = &BaseFeeDistributionConfig{
+		BaseFeeDistribution: forkmanager.BaseFeeDistribution{BurnPercent: 100},
+		Treasury:            treasury,
+	}
+
+	fm.Clear()
+	fm.RegisterFork("governance", &forkmanager.ForkParams{
+		BaseFeeDistribution: &forkmanager.BaseFeeDistribution{BurnPercent: 20, TreasuryPercent: 80},
+	})
+	require.NoError(t, fm.ActivateFork("governance", 10))
+
+	// the configured percentages are used until the fork changes them
+	distribution := p.GetBaseFeeDistribution(9)
+	require.Equal(t, uint64(100), distribution.BurnPercent)
+	require.Equal(t, treasury, distribution.Treasury)
+
+	distribution = p.GetBaseFeeDistribution(10)
+	require.Equal(t, uint64(20), distribution.BurnPercent)
+	require.Equal(t, uint64(80), distribution.TreasuryPercent)
+	require.Equal(t, treasury, distribution.Treasury)
+
+	// the configuration isn't changed
+	require.Equal(t, uint64(100), p.BaseFeeDistribution.BurnPercent)
+}
+
+func TestCallPermissionListConfig_JSON(t *testing.T) {
+	t.Parallel()
+
+	raw := `{
+		"adminAddresses": ["0x0000000000000000000000000000000000000001"],
+		"restrictions": [{"target": "0x0000000000000000000000000000000000000002", "selector": "0xa9059cbb"}],
+		"permissions": [{"caller": "0x0000000000000000000000000000000000000003",
+			"target": "0x0000000000000000000000000000000000000002", "selector": "0xa9059cbb"}]
+	}`
+
+	config := &CallPermissionListConfig{}
+	require.NoError(t, json.Unmarshal([]byte(raw), config))
+
+	require.Equal(t, []types.Address{types.StringToAddress("1")}, config.AdminAddresses)
+	require.Equal(t, Selector{0xa9, 0x05, 0x9c, 0xbb}, config.Restrictions[0].Selector)
+	require.Equal(t, types.StringToAddress("3"), config.Permissions[0].Caller)
+
+	data, err := json.Marshal(config.Permissions[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"selector":"0xa9059cbb"`)
+
+	require.Error(t, json.Unmarshal([]byte(`{"target": "0x02", "selector": "0xa9059c"}`), &CallRestriction{}))
+}
@@ -0,0 +1,24 @@
+package addressindex
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command/addressindex/backfill"
+)
+
+func GetCommand() *cobra.Command {
+	addressIndexCmd := &cobra.Command{
+		Use:   "address-index",
+		Short: "Top level command for managing the address to transaction index. Only accepts subcommands.",
+	}
+
+	registerSubcommands(addressIndexCmd)
+
+	return addressIndexCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// address-index backfill
+		backfill.GetCommand(),
+	)
+}
@@ -0,0 +1,69 @@
+package backfill
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+)
+
+func GetCommand() *cobra.Command {
+	backfillCmd := &cobra.Command{
+		Use: "backfill",
+		Short: "Builds the address to transaction index for the blocks already stored in the data directory. " +
+			"The node must be stopped while the command runs. Internal value transfers are not backfilled",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(backfillCmd)
+
+	return backfillCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.freezerDir,
+		freezerDirFlag,
+		"",
+		"the directory of the ancient store, if it differs from the default one in the data directory",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"1",
+		"the first block to index",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block to index (defaults to the head block)",
+	)
+
+	_ = cmd.MarkFlagRequired(dataDirFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.backfill(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
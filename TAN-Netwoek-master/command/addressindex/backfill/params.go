@@ -0,0 +1,172 @@
+package backfill
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/blockchain"
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/blockchain/storage/leveldb"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	dataDirFlag    = "data-dir"
+	freezerDirFlag = "freezer-dir"
+	fromFlag       = "from"
+	toFlag         = "to"
+)
+
+// blocksPerBatch is the number of blocks indexed in a single write batch
+const blocksPerBatch = 1000
+
+var (
+	params = &backfillParams{}
+)
+
+var (
+	errDecodeRange  = errors.New("unable to decode range value")
+	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errHeadNotFound = errors.New("head block not found, is the data directory initialized?")
+)
+
+type backfillParams struct {
+	dataDir    string
+	freezerDir string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	resFrom uint64
+	resTo   uint64
+	resTxs  uint64
+}
+
+func (p *backfillParams) validateFlags() error {
+	var parseErr error
+
+	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = types.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+// openStorage opens the blockchain storage of the data directory, including the ancient store if there is one
+func (p *backfillParams) openStorage() (storage.Storage, error) {
+	logger := hclog.NewNullLogger()
+	blockchainDir := filepath.Join(p.dataDir, "blockchain")
+
+	freezerDir := p.freezerDir
+	if freezerDir == "" {
+		freezerDir = filepath.Join(blockchainDir, "ancient")
+	}
+
+	if _, err := os.Stat(freezerDir); err == nil {
+		// threshold of 0 keeps the ancient store read only
+		return leveldb.NewLevelDBStorageWithAncients(blockchainDir, freezerDir, 0, logger)
+	}
+
+	return leveldb.NewLevelDBStorage(blockchainDir, logger)
+}
+
+func (p *backfillParams) backfill() error {
+	db, err := p.openStorage()
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return errHeadNotFound
+	}
+
+	to := head
+	if p.to != nil && *p.to < head {
+		to = *p.to
+	}
+
+	// genesis has no transactions
+	from := p.from
+	if from == 0 {
+		from = 1
+	}
+
+	p.resFrom, p.resTo = from, to
+
+	batchWriter := storage.NewBatchWriter(db)
+
+	for number := from; number <= to; number++ {
+		block, err := readBlock(db, number)
+		if err != nil {
+			return fmt.Errorf("failed to read block %d: %w", number, err)
+		}
+
+		blockchain.WriteAddressIndex(batchWriter, block, nil)
+		p.resTxs += uint64(len(block.Transactions))
+
+		if (number-from+1)%blocksPerBatch == 0 || number == to {
+			if err := batchWriter.WriteBatch(); err != nil {
+				return err
+			}
+
+			batchWriter = storage.NewBatchWriter(db)
+		}
+	}
+
+	return nil
+}
+
+// readBlock reads the canonical block with the given number
+func readBlock(db storage.Storage, number uint64) (*types.Block, error) {
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := db.ReadBody(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Block{
+		Header:       header,
+		Transactions: body.Transactions,
+	}, nil
+}
+
+func (p *backfillParams) getResult() command.CommandResult {
+	return &BackfillResult{
+		From:         p.resFrom,
+		To:           p.resTo,
+		Transactions: p.resTxs,
+	}
+}
@@ -0,0 +1,29 @@
+package backfill
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+)
+
+type BackfillResult struct {
+	From         uint64 `json:"from"`
+	To           uint64 `json:"to"`
+	Transactions uint64 `json:"transactions"`
+}
+
+func (r *BackfillResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ADDRESS INDEX BACKFILL]\n")
+	buffer.WriteString("Indexed the stored blocks successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
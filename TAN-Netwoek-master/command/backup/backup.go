@@ -1,9 +1,14 @@
 package backup
 
 import (
+	"fmt"
+
+	"github.com/tarality/tan-network/archive"
 	"github.com/tarality/tan-network/command"
 	"github.com/spf13/cobra"
 
+	"github.com/tarality/tan-network/command/backup/export"
+	"github.com/tarality/tan-network/command/backup/verify"
 	"github.com/tarality/tan-network/command/helper"
 )
 
@@ -18,7 +23,13 @@ func GetCommand() *cobra.Command {
 	helper.RegisterGRPCAddressFlag(backupCmd)
 
 	setFlags(backupCmd)
-	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
+
+	backupCmd.AddCommand(
+		// backup export
+		export.GetCommand(),
+		// backup verify
+		verify.GetCommand(),
+	)
 
 	return backupCmd
 }
@@ -44,6 +55,28 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().StringVar(
+		&params.manifest,
+		manifestFlag,
+		"",
+		"the manifest of the incremental backups, the backup continues after its last segment "+
+			"and is written next to it",
+	)
+
+	cmd.Flags().StringVar(
+		&params.compressionRaw,
+		compressionFlag,
+		string(archive.CompressionNone),
+		fmt.Sprintf(
+			"the compression of the backed up blocks [%s, %s]",
+			archive.CompressionNone,
+			archive.CompressionZstd,
+		),
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(outFlag, manifestFlag)
+	cmd.MarkFlagsMutuallyExclusive(fromFlag, manifestFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/archive"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Export blocks, transactions and logs as flat tables for analytics, " +
+			"by fetching blockchain data from the running node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(exportCmd)
+	helper.SetRequiredFlags(exportCmd, params.getRequiredFlags())
+
+	return exportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the output directory of the exported tables",
+	)
+
+	cmd.Flags().StringVar(
+		&params.formatRaw,
+		formatFlag,
+		string(archive.ExportFormatJSONL),
+		fmt.Sprintf(
+			"the file format of the exported tables [%s, %s]",
+			archive.ExportFormatJSONL,
+			archive.ExportFormatParquet,
+		),
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the first block to export",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block to export, the latest block if not set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.partitionSize,
+		partitionSizeFlag,
+		archive.DefaultExportPartitionSize,
+		"the number of blocks of a single table file",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.resume,
+		resumeFlag,
+		false,
+		"continue after the last block exported to the output directory",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.exportTables(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/archive"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	outFlag           = "out"
+	formatFlag        = "format"
+	fromFlag          = "from"
+	toFlag            = "to"
+	partitionSizeFlag = "partition-size"
+	resumeFlag        = "resume"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeRange  = errors.New("unable to decode range value")
+	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+)
+
+type exportParams struct {
+	out           string
+	formatRaw     string
+	partitionSize uint64
+	resume        bool
+
+	fromRaw string
+	toRaw   string
+
+	format archive.ExportFormat
+	from   uint64
+	to     *uint64
+
+	resFrom uint64
+	resTo   uint64
+}
+
+func (p *exportParams) validateFlags() error {
+	p.format = archive.ExportFormat(p.formatRaw)
+	if !p.format.IsValid() {
+		return archive.ErrInvalidExportFormat
+	}
+
+	if p.partitionSize == 0 {
+		return archive.ErrInvalidExportPartitionSize
+	}
+
+	var parseErr error
+
+	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = types.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
+
+func (p *exportParams) exportTables(grpcAddress string) error {
+	connection, err := helper.GetGRPCConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	// resFrom and resTo represents the range of blocks exported by this run
+	resFrom, resTo, err := archive.ExportTables(
+		connection,
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "export",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+		&archive.ExportConfig{
+			OutDir:        p.out,
+			Format:        p.format,
+			From:          p.from,
+			To:            p.to,
+			PartitionSize: p.partitionSize,
+			Resume:        p.resume,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.resFrom = resFrom
+	p.resTo = resTo
+
+	return nil
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &ExportResult{
+		From:   p.resFrom,
+		To:     p.resTo,
+		Out:    p.out,
+		Format: string(p.format),
+	}
+}
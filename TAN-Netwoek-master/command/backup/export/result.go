@@ -0,0 +1,30 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+)
+
+type ExportResult struct {
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+	Out    string `json:"out"`
+	Format string `json:"format"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BACKUP EXPORT]\n")
+	buffer.WriteString("Exported tables successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Directory|%s", r.Out),
+		fmt.Sprintf("Format|%s", r.Format),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+	}))
+
+	return buffer.String()
+}
@@ -2,6 +2,7 @@ package backup
 
 import (
 	"errors"
+	"path/filepath"
 
 	"github.com/tarality/tan-network/archive"
 	"github.com/tarality/tan-network/command"
@@ -11,9 +12,11 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag         = "out"
+	fromFlag        = "from"
+	toFlag          = "to"
+	manifestFlag    = "manifest"
+	compressionFlag = "compression"
 )
 
 var (
@@ -23,10 +26,15 @@ var (
 var (
 	errDecodeRange  = errors.New("unable to decode range value")
 	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errMissingOut   = errors.New(`either "out" or "manifest" is required`)
 )
 
 type backupParams struct {
-	out string
+	out      string
+	manifest string
+
+	compressionRaw string
+	compression    archive.Compression
 
 	fromRaw string
 	toRaw   string
@@ -39,6 +47,15 @@ type backupParams struct {
 }
 
 func (p *backupParams) validateFlags() error {
+	if p.out == "" && p.manifest == "" {
+		return errMissingOut
+	}
+
+	p.compression = archive.Compression(p.compressionRaw)
+	if !p.compression.IsValid() {
+		return archive.ErrUnsupportedCompression
+	}
+
 	var parseErr error
 
 	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
@@ -62,12 +79,6 @@ func (p *backupParams) validateFlags() error {
 	return nil
 }
 
-func (p *backupParams) getRequiredFlags() []string {
-	return []string{
-		outFlag,
-	}
-}
-
 func (p *backupParams) createBackup(grpcAddress string) error {
 	connection, err := helper.GetGRPCConnection(
 		grpcAddress,
@@ -76,16 +87,32 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		return err
 	}
 
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "backup",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	if p.manifest != "" {
+		segment, err := archive.CreateIncrementalBackup(connection, logger, p.to, p.manifest, p.compression)
+		if err != nil {
+			return err
+		}
+
+		p.out = filepath.Join(filepath.Dir(p.manifest), segment.File)
+		p.resFrom = segment.From
+		p.resTo = segment.To
+
+		return nil
+	}
+
 	// resFrom and resTo represents the range of blocks that can be included in the file
 	resFrom, resTo, err := archive.CreateBackup(
 		connection,
-		hclog.New(&hclog.LoggerOptions{
-			Name:  "backup",
-			Level: hclog.LevelFromString("INFO"),
-		}),
+		logger,
 		p.from,
 		p.to,
 		p.out,
+		p.compression,
 	)
 	if err != nil {
 		return err
@@ -99,8 +126,9 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 
 func (p *backupParams) getResult() command.CommandResult {
 	return &BackupResult{
-		From: p.resFrom,
-		To:   p.resTo,
-		Out:  p.out,
+		From:     p.resFrom,
+		To:       p.resTo,
+		Out:      p.out,
+		Manifest: p.manifest,
 	}
 }
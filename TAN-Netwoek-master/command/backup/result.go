@@ -8,9 +8,10 @@ import (
 )
 
 type BackupResult struct {
-	From uint64 `json:"from"`
-	To   uint64 `json:"to"`
-	Out  string `json:"out"`
+	From     uint64 `json:"from"`
+	To       uint64 `json:"to"`
+	Out      string `json:"out"`
+	Manifest string `json:"manifest,omitempty"`
 }
 
 func (r *BackupResult) GetOutput() string {
@@ -18,11 +19,17 @@ func (r *BackupResult) GetOutput() string {
 
 	buffer.WriteString("\n[BACKUP]\n")
 	buffer.WriteString("Exported backup file successfully:\n")
-	buffer.WriteString(helper.FormatKV([]string{
+	vals := []string{
 		fmt.Sprintf("File|%s", r.Out),
 		fmt.Sprintf("From|%d", r.From),
 		fmt.Sprintf("To|%d", r.To),
-	}))
+	}
+
+	if r.Manifest != "" {
+		vals = append(vals, fmt.Sprintf("Manifest|%s", r.Manifest))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
 
 	return buffer.String()
 }
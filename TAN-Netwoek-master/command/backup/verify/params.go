@@ -0,0 +1,116 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tarality/tan-network/archive"
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/consensus/ibft/fork"
+	"github.com/tarality/tan-network/consensus/polybft"
+	"github.com/tarality/tan-network/server"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	manifestFlag = "manifest"
+	fileFlag     = "file"
+	chainFlag    = "chain"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+var (
+	errMissingBackup = errors.New(`either "manifest" or "file" is required`)
+)
+
+type verifyParams struct {
+	manifest    string
+	file        string
+	genesisPath string
+
+	segments []*archive.Segment
+}
+
+func (p *verifyParams) validateFlags() error {
+	if p.manifest == "" && p.file == "" {
+		return errMissingBackup
+	}
+
+	return nil
+}
+
+func (p *verifyParams) verify() error {
+	if err := p.setupHeaderHash(); err != nil {
+		return err
+	}
+
+	if p.file != "" {
+		segment, err := archive.VerifyBackupFile(p.file)
+		if err != nil {
+			return err
+		}
+
+		p.segments = []*archive.Segment{segment}
+
+		return nil
+	}
+
+	manifest, err := archive.VerifyManifest(p.manifest)
+	if err != nil {
+		return err
+	}
+
+	p.segments = manifest.Segments
+
+	return nil
+}
+
+// setupHeaderHash sets up the header hash of the consensus of the chain,
+// which the block hashes of the backups are verified with
+func (p *verifyParams) setupHeaderHash() error {
+	config, err := chain.ImportFromFile(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the genesis file: %w", err)
+	}
+
+	switch server.ConsensusType(config.Params.GetEngine()) {
+	case server.PolyBFTConsensus:
+		polybft.SetupHeaderHashFunc()
+	case server.IBFTConsensus:
+		ibftConfig, ok := config.Params.Engine[string(server.IBFTConsensus)].(map[string]interface{})
+		if !ok {
+			return fork.ErrUndefinedIBFTConfig
+		}
+
+		forks, err := fork.GetIBFTForks(ibftConfig)
+		if err != nil {
+			return err
+		}
+
+		types.HeaderHash = forks.HeaderHashFunc()
+	}
+
+	return nil
+}
+
+func (p *verifyParams) getResult() command.CommandResult {
+	result := &VerifyResult{
+		Segments: make([]SegmentResult, 0, len(p.segments)),
+	}
+
+	for _, segment := range p.segments {
+		result.Segments = append(result.Segments, SegmentResult{
+			File:     segment.File,
+			From:     segment.From,
+			To:       segment.To,
+			Hash:     segment.Hash.String(),
+			Checksum: segment.Checksum,
+		})
+	}
+
+	return result
+}
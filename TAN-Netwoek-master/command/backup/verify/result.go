@@ -0,0 +1,41 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+)
+
+type SegmentResult struct {
+	File     string `json:"file"`
+	From     uint64 `json:"from"`
+	To       uint64 `json:"to"`
+	Hash     string `json:"hash"`
+	Checksum string `json:"checksum"`
+}
+
+type VerifyResult struct {
+	Segments []SegmentResult `json:"segments"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BACKUP VERIFY]\n")
+	buffer.WriteString(fmt.Sprintf("Verified %d backup file(s) successfully:\n", len(r.Segments)))
+
+	for _, segment := range r.Segments {
+		buffer.WriteString("\n")
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("File|%s", segment.File),
+			fmt.Sprintf("From|%d", segment.From),
+			fmt.Sprintf("To|%d", segment.To),
+			fmt.Sprintf("Last Hash|%s", segment.Hash),
+			fmt.Sprintf("Checksum|%s", segment.Checksum),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
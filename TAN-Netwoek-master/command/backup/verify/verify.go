@@ -0,0 +1,63 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+)
+
+func GetCommand() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use:     "verify",
+		Short:   "Verify the continuity and the checksums of the backups, without a running node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(verifyCmd)
+
+	return verifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.manifest,
+		manifestFlag,
+		"",
+		"the manifest of the incremental backups to verify",
+	)
+
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the backup file to verify",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file of the chain, which defines how the block hashes are calculated",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(manifestFlag, fileFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.verify(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
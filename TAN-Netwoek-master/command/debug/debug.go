@@ -0,0 +1,24 @@
+package debug
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command/debug/replayblock"
+)
+
+func GetCommand() *cobra.Command {
+	debugCmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Top level command for debugging the chain data of a stopped node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(debugCmd)
+
+	return debugCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// debug replay-block
+		replayblock.GetCommand(),
+	)
+}
@@ -0,0 +1,452 @@
+package replayblock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/blockchain/storage/leveldb"
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/helper/hex"
+	"github.com/tarality/tan-network/server"
+	"github.com/tarality/tan-network/server/forks"
+	"github.com/tarality/tan-network/state"
+	itrie "github.com/tarality/tan-network/state/immutable-trie"
+	"github.com/tarality/tan-network/state/runtime/tracer/structtracer"
+	"github.com/tarality/tan-network/types"
+	"github.com/tarality/tan-network/types/buildroot"
+)
+
+const (
+	dataDirFlag    = "data-dir"
+	freezerDirFlag = "freezer-dir"
+	chainFlag      = "chain"
+	blockFlag      = "block"
+)
+
+var (
+	params = &replayBlockParams{}
+)
+
+var (
+	errBlockNotFound         = errors.New("block not found")
+	errParentNotFound        = errors.New("parent header not found")
+	errGenesisNotReplayable  = errors.New("genesis block can't be replayed")
+	errConsensusNotSupported = errors.New("replaying blocks is not supported for the IBFT consensus")
+)
+
+type replayBlockParams struct {
+	dataDir     string
+	freezerDir  string
+	genesisPath string
+	blockRaw    string
+
+	// number is set if the block is read from the data directory, otherwise the block is read from the file
+	number *uint64
+}
+
+func (p *replayBlockParams) validateFlags() error {
+	if number, err := types.ParseUint64orHex(&p.blockRaw); err == nil {
+		p.number = &number
+
+		return nil
+	}
+
+	if _, err := os.Stat(p.blockRaw); err != nil {
+		return fmt.Errorf("invalid block %q, must be a block number or a file: %w", p.blockRaw, err)
+	}
+
+	return nil
+}
+
+// openStorage opens the blockchain storage of the data directory, including the ancient store if there is one
+func (p *replayBlockParams) openStorage(logger hclog.Logger) (storage.Storage, error) {
+	blockchainDir := filepath.Join(p.dataDir, "blockchain")
+
+	freezerDir := p.freezerDir
+	if freezerDir == "" {
+		freezerDir = filepath.Join(blockchainDir, "ancient")
+	}
+
+	if _, err := os.Stat(freezerDir); err == nil {
+		// threshold of 0 keeps the ancient store read only
+		return leveldb.NewLevelDBStorageWithAncients(blockchainDir, freezerDir, 0, logger)
+	}
+
+	return leveldb.NewLevelDBStorage(blockchainDir, logger)
+}
+
+// readBlock reads the block to replay, either from the storage or from the file
+func (p *replayBlockParams) readBlock(db storage.Storage) (*types.Block, error) {
+	if p.number == nil {
+		return readBlockFile(p.blockRaw)
+	}
+
+	hash, ok := db.ReadCanonicalHash(*p.number)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errBlockNotFound, *p.number)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := db.ReadBody(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Block{
+		Header:       header,
+		Transactions: body.Transactions,
+		Uncles:       body.Uncles,
+	}, nil
+}
+
+// readBlockFile reads the RLP encoded block from the file, the encoding can be either raw or hex
+func readBlockFile(path string) (*types.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if trimmed := strings.Trim(string(bytes.TrimSpace(data)), `"`); strings.HasPrefix(trimmed, "0x") {
+		if data, err = hex.DecodeHex(trimmed); err != nil {
+			return nil, fmt.Errorf("unable to decode block, %w", err)
+		}
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(data); err != nil {
+		return nil, fmt.Errorf("unable to decode block, %w", err)
+	}
+
+	return block, nil
+}
+
+func (p *replayBlockParams) replay() (*ReplayBlockResult, error) {
+	logger := hclog.NewNullLogger()
+
+	config, err := chain.ImportFromFile(p.genesisPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the genesis file: %w", err)
+	}
+
+	// the block creator is recovered from the seal by IBFT, which requires the validator snapshots
+	engineName := config.Params.GetEngine()
+	if server.ConsensusType(engineName) == server.IBFTConsensus {
+		return nil, errConsensusNotSupported
+	}
+
+	if err := forks.Init(engineName, config); err != nil {
+		return nil, err
+	}
+
+	db, err := p.openStorage(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	block, err := p.readBlock(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Number() == 0 {
+		return nil, errGenesisNotReplayable
+	}
+
+	parent, err := db.ReadHeader(block.ParentHash())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errParentNotFound, block.ParentHash())
+	}
+
+	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stateStorage.Close()
+
+	stateDB := itrie.NewState(stateStorage)
+
+	executor := state.NewExecutor(config.Params, stateDB, logger)
+	executor.GetHash = getHashHelper(db)
+
+	transition, err := executor.BeginTxn(parent.StateRoot, block.Header, types.BytesToAddress(block.Header.Miner))
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := structtracer.NewStructTracer(structtracer.Config{
+		EnableStack:      true,
+		EnableStorage:    true,
+		EnableReturnData: true,
+	})
+	transition.SetTracer(tracer)
+
+	result := &ReplayBlockResult{
+		Number:             block.Number(),
+		Hash:               block.Hash(),
+		HeaderStateRoot:    block.Header.StateRoot,
+		HeaderGasUsed:      block.Header.GasUsed,
+		HeaderReceiptsRoot: block.Header.ReceiptsRoot,
+		Traces:             make([]*structtracer.StructTraceResult, 0, len(block.Transactions)),
+	}
+
+	// the transactions are executed the same way the executor processes the block
+	for _, tx := range block.Transactions {
+		if tx.Gas > block.Header.GasLimit {
+			continue
+		}
+
+		tracer.Clear()
+
+		if err := transition.Write(tx); err != nil {
+			return nil, fmt.Errorf("failed to execute transaction %s: %w", tx.Hash, err)
+		}
+
+		trace, err := tracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+
+		traceResult, _ := trace.(*structtracer.StructTraceResult)
+		result.Traces = append(result.Traces, traceResult)
+	}
+
+	_, root, _, _, err := transition.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit the state changes: %w", err)
+	}
+
+	// the empty objects are already deleted by the commit of the transition
+	objs, err := transition.Txn().Commit(false)
+	if err != nil {
+		return nil, err
+	}
+
+	parentState, err := stateDB.NewSnapshotAt(parent.StateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// the state of the header root exists only if the node accepted the block
+	headerState, err := stateDB.NewSnapshotAt(block.Header.StateRoot)
+	if err != nil {
+		headerState = nil
+	}
+
+	if result.StateDiff, err = diffState(objs, parentState, headerState); err != nil {
+		return nil, err
+	}
+
+	receipts := transition.Receipts()
+
+	result.StateRoot = root
+	result.GasUsed = transition.TotalGas()
+	result.ReceiptsRoot = buildroot.CalculateReceiptsRoot(receipts)
+	result.Receipts = receipts
+	result.Mismatches = diffBlockResult(block, result)
+
+	for _, diff := range result.StateDiff {
+		if diff.mismatch() {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("account %s %s: local %s, header state %s",
+				diff.Address, diff.Field, diff.Local, diff.Header))
+		}
+	}
+
+	// the receipts stored by the node are available if the block was accepted
+	if stored, err := db.ReadReceipts(block.Hash()); err == nil && len(stored) > 0 {
+		result.Mismatches = append(result.Mismatches, diffReceipts(stored, receipts)...)
+	}
+
+	return result, nil
+}
+
+// getHashHelper returns the hash of the ancestor of the header with the given number, walking the parent headers
+func getHashHelper(db storage.Storage) state.GetHashByNumberHelper {
+	return func(header *types.Header) state.GetHashByNumber {
+		return func(i uint64) types.Hash {
+			num, hash := header.Number-1, header.ParentHash
+
+			for num > i {
+				h, err := db.ReadHeader(hash)
+				if err != nil {
+					return types.ZeroHash
+				}
+
+				num, hash = num-1, h.ParentHash
+			}
+
+			if num != i {
+				return types.ZeroHash
+			}
+
+			return hash
+		}
+	}
+}
+
+// diffBlockResult compares the local execution result with the block header
+func diffBlockResult(block *types.Block, result *ReplayBlockResult) []string {
+	var mismatches []string
+
+	if len(result.Receipts) != len(block.Transactions) {
+		mismatches = append(mismatches, fmt.Sprintf("receipts count: local %d, transactions %d",
+			len(result.Receipts), len(block.Transactions)))
+	}
+
+	if result.StateRoot != result.HeaderStateRoot {
+		mismatches = append(mismatches, fmt.Sprintf("state root: local %s, header %s",
+			result.StateRoot, result.HeaderStateRoot))
+	}
+
+	if result.GasUsed != result.HeaderGasUsed {
+		mismatches = append(mismatches, fmt.Sprintf("gas used: local %d, header %d",
+			result.GasUsed, result.HeaderGasUsed))
+	}
+
+	if result.ReceiptsRoot != result.HeaderReceiptsRoot {
+		mismatches = append(mismatches, fmt.Sprintf("receipts root: local %s, header %s",
+			result.ReceiptsRoot, result.HeaderReceiptsRoot))
+	}
+
+	return mismatches
+}
+
+// diffState lists the account fields and the storage slots changed by the local execution with their values
+// in the parent state, and in the state of the header root if the node has it
+func diffState(objs []*state.Object, parentState, headerState state.Snapshot) ([]*StateDiff, error) {
+	diffs := make([]*StateDiff, 0)
+
+	for _, obj := range objs {
+		parentAccount, err := readAccount(parentState, obj.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		var headerAccount *state.Account
+
+		if headerState != nil {
+			if headerAccount, err = readAccount(headerState, obj.Address); err != nil {
+				return nil, err
+			}
+		}
+
+		localAccount := &state.Account{Nonce: obj.Nonce, Balance: obj.Balance, CodeHash: obj.CodeHash.Bytes()}
+		if obj.Deleted {
+			localAccount, _ = readAccount(nil, obj.Address)
+		}
+
+		add := func(field string, value func(account *state.Account) string) {
+			diff := &StateDiff{Address: obj.Address, Field: field, Parent: value(parentAccount), Local: value(localAccount)}
+			if headerAccount != nil {
+				diff.Header = value(headerAccount)
+			}
+
+			if diff.changed() {
+				diffs = append(diffs, diff)
+			}
+		}
+
+		add("nonce", func(a *state.Account) string { return strconv.FormatUint(a.Nonce, 10) })
+		add("balance", func(a *state.Account) string { return a.Balance.String() })
+		add("code hash", func(a *state.Account) string { return types.BytesToHash(a.CodeHash).String() })
+
+		for _, slot := range obj.Storage {
+			key := types.BytesToHash(slot.Key)
+
+			local := types.ZeroHash
+			if !slot.Deleted {
+				local = types.BytesToHash(slot.Val)
+			}
+
+			diff := &StateDiff{
+				Address: obj.Address,
+				Field:   fmt.Sprintf("storage %s", key),
+				Parent:  parentState.GetStorage(obj.Address, parentAccount.Root, key).String(),
+				Local:   local.String(),
+			}
+
+			if headerAccount != nil {
+				diff.Header = headerState.GetStorage(obj.Address, headerAccount.Root, key).String()
+			}
+
+			if diff.changed() {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+// readAccount reads the account from the state, a missing account is returned as an empty one
+func readAccount(snap state.Snapshot, addr types.Address) (*state.Account, error) {
+	if snap != nil {
+		account, err := snap.GetAccount(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if account != nil {
+			return account, nil
+		}
+	}
+
+	return &state.Account{
+		Balance:  big.NewInt(0),
+		Root:     types.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	}, nil
+}
+
+// diffReceipts compares the locally computed receipts with the receipts stored by the node
+func diffReceipts(stored, local []*types.Receipt) []string {
+	var mismatches []string
+
+	if len(stored) != len(local) {
+		return []string{fmt.Sprintf("receipts count: local %d, stored %d", len(local), len(stored))}
+	}
+
+	for i := range local {
+		prefix := fmt.Sprintf("receipt %d (%s)", i, local[i].TxHash)
+
+		if status, storedStatus := receiptStatus(local[i]), receiptStatus(stored[i]); status != storedStatus {
+			mismatches = append(mismatches, fmt.Sprintf("%s status: local %d, stored %d", prefix, status, storedStatus))
+		}
+
+		if local[i].CumulativeGasUsed != stored[i].CumulativeGasUsed {
+			mismatches = append(mismatches, fmt.Sprintf("%s cumulative gas used: local %d, stored %d",
+				prefix, local[i].CumulativeGasUsed, stored[i].CumulativeGasUsed))
+		}
+
+		if len(local[i].Logs) != len(stored[i].Logs) {
+			mismatches = append(mismatches, fmt.Sprintf("%s logs: local %d, stored %d",
+				prefix, len(local[i].Logs), len(stored[i].Logs)))
+		} else if local[i].LogsBloom != stored[i].LogsBloom {
+			mismatches = append(mismatches, fmt.Sprintf("%s logs bloom differs", prefix))
+		}
+	}
+
+	return mismatches
+}
+
+func receiptStatus(receipt *types.Receipt) types.ReceiptStatus {
+	if receipt.Status == nil {
+		return types.ReceiptFailed
+	}
+
+	return *receipt.Status
+}
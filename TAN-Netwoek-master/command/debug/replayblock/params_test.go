@@ -0,0 +1,76 @@
+package replayblock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/state"
+	itrie "github.com/tarality/tan-network/state/immutable-trie"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestDiffState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("1")
+		contract = types.StringToAddress("2")
+		slot     = types.StringToHash("1")
+	)
+
+	stateDB := itrie.NewState(itrie.NewMemoryStorage())
+
+	parentState, _ := stateDB.NewSnapshot().Commit([]*state.Object{
+		{Address: sender, Balance: big.NewInt(100), Nonce: 1, CodeHash: types.EmptyCodeHash},
+	})
+
+	// the local execution sends 10 from the sender and writes the slot of the new contract
+	localObjs := []*state.Object{
+		{Address: sender, Balance: big.NewInt(90), Nonce: 2, CodeHash: types.EmptyCodeHash},
+		{
+			Address:  contract,
+			Balance:  big.NewInt(10),
+			CodeHash: types.EmptyCodeHash,
+			Storage:  []*state.StorageObject{{Key: slot.Bytes(), Val: types.StringToHash("5").Bytes()}},
+		},
+	}
+
+	diffs, err := diffState(localObjs, parentState, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*StateDiff{
+		{Address: sender, Field: "nonce", Parent: "1", Local: "2"},
+		{Address: sender, Field: "balance", Parent: "100", Local: "90"},
+		{Address: contract, Field: "balance", Parent: "0", Local: "10"},
+		{Address: contract, Field: "storage " + slot.String(), Parent: types.ZeroHash.String(), Local: types.StringToHash("5").String()},
+	}, diffs)
+
+	// the node accepted the block, whose state has a different balance of the contract
+	headerState, _ := parentState.Commit([]*state.Object{
+		{Address: sender, Balance: big.NewInt(90), Nonce: 2, CodeHash: types.EmptyCodeHash},
+		{
+			Address:  contract,
+			Balance:  big.NewInt(11),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage:  []*state.StorageObject{{Key: slot.Bytes(), Val: types.StringToHash("5").Bytes()}},
+		},
+	})
+
+	diffs, err = diffState(localObjs, parentState, headerState)
+	require.NoError(t, err)
+	require.Len(t, diffs, 4)
+
+	mismatches := make([]*StateDiff, 0)
+
+	for _, diff := range diffs {
+		if diff.mismatch() {
+			mismatches = append(mismatches, diff)
+		}
+	}
+
+	assert.Equal(t, []*StateDiff{
+		{Address: contract, Field: "balance", Parent: "0", Local: "10", Header: "11"},
+	}, mismatches)
+}
@@ -0,0 +1,74 @@
+package replayblock
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+)
+
+func GetCommand() *cobra.Command {
+	replayBlockCmd := &cobra.Command{
+		Use: "replay-block",
+		Short: "Re-executes a block on top of the parent state stored in the data directory with the struct tracer, " +
+			"and diffs the receipts and the state against the block header. The node must be stopped while the command runs",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(replayBlockCmd)
+
+	return replayBlockCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.freezerDir,
+		freezerDirFlag,
+		"",
+		"the directory of the ancient store, if it differs from the default one in the data directory",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		"the number of the stored block to replay, or the path of a file with the RLP encoded block "+
+			"(raw or hex, e.g. the rlp field returned by debug_getBadBlocks)",
+	)
+
+	_ = cmd.MarkFlagRequired(dataDirFlag)
+	_ = cmd.MarkFlagRequired(blockFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.replay()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
@@ -0,0 +1,107 @@
+package replayblock
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+	"github.com/tarality/tan-network/state/runtime/tracer/structtracer"
+	"github.com/tarality/tan-network/types"
+)
+
+type ReplayBlockResult struct {
+	Number uint64     `json:"number"`
+	Hash   types.Hash `json:"hash"`
+
+	HeaderStateRoot    types.Hash `json:"headerStateRoot"`
+	HeaderGasUsed      uint64     `json:"headerGasUsed"`
+	HeaderReceiptsRoot types.Hash `json:"headerReceiptsRoot"`
+
+	StateRoot    types.Hash       `json:"stateRoot"`
+	GasUsed      uint64           `json:"gasUsed"`
+	ReceiptsRoot types.Hash       `json:"receiptsRoot"`
+	Receipts     []*types.Receipt `json:"receipts"`
+
+	StateDiff  []*StateDiff                      `json:"stateDiff"`
+	Mismatches []string                          `json:"mismatches"`
+	Traces     []*structtracer.StructTraceResult `json:"traces"`
+}
+
+// StateDiff is an account field or a storage slot changed by the local execution of the block
+type StateDiff struct {
+	Address types.Address `json:"address"`
+	Field   string        `json:"field"`
+	Parent  string        `json:"parent"`
+	Local   string        `json:"local"`
+	// Header is the value in the state of the header root, which the node has only if it accepted the block
+	Header string `json:"header,omitempty"`
+}
+
+// changed returns true if the local execution changed the value, or the value differs from the header state
+func (d *StateDiff) changed() bool {
+	return d.Parent != d.Local || d.mismatch()
+}
+
+// mismatch returns true if the local value differs from the value in the state of the header root
+func (d *StateDiff) mismatch() bool {
+	return d.Header != "" && d.Header != d.Local
+}
+
+func (r *ReplayBlockResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REPLAY BLOCK]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Number|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State root|local %s, header %s", r.StateRoot, r.HeaderStateRoot),
+		fmt.Sprintf("Gas used|local %d, header %d", r.GasUsed, r.HeaderGasUsed),
+		fmt.Sprintf("Receipts root|local %s, header %s", r.ReceiptsRoot, r.HeaderReceiptsRoot),
+	}))
+	buffer.WriteString("\n")
+
+	if len(r.Traces) > 0 {
+		buffer.WriteString("\n[TRANSACTIONS]\n")
+
+		rows := make([]string, 0, len(r.Traces))
+		for i, trace := range r.Traces {
+			if trace == nil || i >= len(r.Receipts) {
+				continue
+			}
+
+			rows = append(rows, fmt.Sprintf("%s|gas %d, failed %t, %d opcodes",
+				r.Receipts[i].TxHash, trace.Gas, trace.Failed, len(trace.StructLogs)))
+		}
+
+		buffer.WriteString(helper.FormatKV(rows))
+		buffer.WriteString("\n")
+	}
+
+	if len(r.StateDiff) > 0 {
+		buffer.WriteString("\n[STATE DIFF]\n")
+
+		rows := make([]string, 0, len(r.StateDiff))
+		for _, diff := range r.StateDiff {
+			row := fmt.Sprintf("%s %s|parent %s, local %s", diff.Address, diff.Field, diff.Parent, diff.Local)
+			if diff.Header != "" {
+				row += fmt.Sprintf(", header %s", diff.Header)
+			}
+
+			rows = append(rows, row)
+		}
+
+		buffer.WriteString(helper.FormatKV(rows))
+		buffer.WriteString("\n")
+	}
+
+	buffer.WriteString("\n[MISMATCHES]\n")
+
+	if len(r.Mismatches) == 0 {
+		buffer.WriteString("Local execution matches the block\n")
+	} else {
+		buffer.WriteString(helper.FormatList(r.Mismatches))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
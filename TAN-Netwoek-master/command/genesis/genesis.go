@@ -192,6 +192,14 @@ func setFlags(cmd *cobra.Command) {
 			"the predefined period which determines block creation frequency",
 		)
 
+		cmd.Flags().DurationVar(
+			&params.maxEmptyBlockInterval,
+			maxEmptyBlockIntervalFlag,
+			0,
+			"the maximum period the proposer waits for transactions before it creates an empty block "+
+				"(empty blocks are created every block time if not set)",
+		)
+
 		cmd.Flags().Uint64Var(
 			&params.epochReward,
 			epochRewardFlag,
@@ -322,6 +330,42 @@ func setFlags(cmd *cobra.Command) {
 			[]string{},
 			"list of addresses to enable by default in the bridge block list",
 		)
+
+		cmd.Flags().StringArrayVar(
+			&params.gasFeeAllowListAdmin,
+			gasFeeAllowListAdminFlag,
+			[]string{},
+			"list of addresses to use as admin accounts in the gas fee allow list",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.gasFeeAllowListEnabled,
+			gasFeeAllowListEnabledFlag,
+			[]string{},
+			"list of addresses to enable by default in the gas fee allow list",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.gasFeeDiscount,
+			gasFeeDiscountFlag,
+			defaultGasFeeDiscount,
+			"percentage of the gas price the addresses enabled in the gas fee allow list don't pay, "+
+				"100 makes their transactions gas free",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.callPermissionListAdmin,
+			callPermissionListAdminFlag,
+			[]string{},
+			"list of addresses to use as admin accounts in the call permission list",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.callPermissionListEnabled,
+			callPermissionListEnabledFlag,
+			[]string{},
+			"list of addresses to enable by default in the call permission list, which can manage the permitted calls",
+		)
 	}
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/command"
 	"github.com/tarality/tan-network/command/helper"
+	"github.com/tarality/tan-network/consensus"
 	"github.com/tarality/tan-network/consensus/ibft"
 	"github.com/tarality/tan-network/consensus/ibft/fork"
 	"github.com/tarality/tan-network/consensus/ibft/signer"
@@ -63,6 +64,8 @@ var (
 		"(<name:symbol:decimals count:mintable flag:[mintable token owner address]>)")
 	errRewardWalletAmountZero   = errors.New("reward wallet amount can not be zero or negative")
 	errReserveAccMustBePremined = errors.New("it is mandatory to premine reserve account (0x0 address)")
+	errInvalidGasFeeDiscount    = errors.New("gas fee discount must be a percentage between 0 and 100")
+	errInvalidMaxEmptyBlockTime = errors.New("max empty block interval must not be lower than the block time")
 )
 
 type genesisParams struct {
@@ -106,6 +109,8 @@ type genesisParams struct {
 	epochReward          uint64
 	blockTimeDrift       uint64
 
+	maxEmptyBlockInterval time.Duration
+
 	initialStateRoot string
 
 	// access lists
@@ -121,6 +126,11 @@ type genesisParams struct {
 	bridgeAllowListEnabled           []string
 	bridgeBlockListAdmin             []string
 	bridgeBlockListEnabled           []string
+	gasFeeAllowListAdmin             []string
+	gasFeeAllowListEnabled           []string
+	gasFeeDiscount                   uint64
+	callPermissionListAdmin          []string
+	callPermissionListEnabled        []string
 
 	nativeTokenConfigRaw string
 	nativeTokenConfig    *polybft.TokenConfig
@@ -165,6 +175,10 @@ func (p *genesisParams) validateFlags() error {
 		if err := p.validatePremineInfo(); err != nil {
 			return err
 		}
+
+		if p.gasFeeDiscount > 100 {
+			return errInvalidGasFeeDiscount
+		}
 	}
 
 	// Check if the genesis file already exists
@@ -172,6 +186,10 @@ func (p *genesisParams) validateFlags() error {
 		return errors.New(generateError.GetMessage())
 	}
 
+	if p.maxEmptyBlockInterval != 0 && p.maxEmptyBlockInterval < p.blockTime {
+		return errInvalidMaxEmptyBlockTime
+	}
+
 	// Check that the epoch size is correct
 	if p.epochSize < 2 && (p.isIBFTConsensus() || p.isPolyBFTConsensus()) {
 		// Epoch size must be greater than 1, so new transactions have a chance to be added to a block.
@@ -355,13 +373,19 @@ func (p *genesisParams) initConsensusEngineConfig() {
 }
 
 func (p *genesisParams) initIBFTEngineMap(ibftType fork.IBFTType) {
+	ibftConfig := map[string]interface{}{
+		fork.KeyType:          ibftType,
+		fork.KeyValidatorType: p.ibftValidatorType,
+		fork.KeyBlockTime:     p.blockTime,
+		ibft.KeyEpochSize:     p.epochSize,
+	}
+
+	if p.maxEmptyBlockInterval != 0 {
+		ibftConfig[consensus.KeyMaxEmptyBlockInterval] = p.maxEmptyBlockInterval
+	}
+
 	p.consensusEngineConfig = map[string]interface{}{
-		string(server.IBFTConsensus): map[string]interface{}{
-			fork.KeyType:          ibftType,
-			fork.KeyValidatorType: p.ibftValidatorType,
-			fork.KeyBlockTime:     p.blockTime,
-			ibft.KeyEpochSize:     p.epochSize,
-		},
+		string(server.IBFTConsensus): ibftConfig,
 	}
 }
 
@@ -37,6 +37,8 @@ const (
 
 	blockTimeDriftFlag = "block-time-drift"
 
+	maxEmptyBlockIntervalFlag = "max-empty-block-interval"
+
 	defaultEpochSize        = uint64(10)
 	defaultSprintSize       = uint64(5)
 	defaultValidatorSetSize = 100
@@ -56,6 +58,13 @@ const (
 	bridgeAllowListEnabledFlag           = "bridge-allow-list-enabled"
 	bridgeBlockListAdminFlag             = "bridge-block-list-admin"
 	bridgeBlockListEnabledFlag           = "bridge-block-list-enabled"
+	gasFeeAllowListAdminFlag             = "gas-fee-allow-list-admin"
+	gasFeeAllowListEnabledFlag           = "gas-fee-allow-list-enabled"
+	gasFeeDiscountFlag                   = "gas-fee-discount"
+	callPermissionListAdminFlag          = "call-permission-list-admin"
+	callPermissionListEnabledFlag        = "call-permission-list-enabled"
+
+	defaultGasFeeDiscount = uint64(100)
 
 	bootnodePortStart = 30301
 
@@ -148,7 +157,8 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 			WalletAddress: walletPremineInfo.address,
 			WalletAmount:  walletPremineInfo.amount,
 		},
-		BlockTimeDrift: p.blockTimeDrift,
+		BlockTimeDrift:        p.blockTimeDrift,
+		MaxEmptyBlockInterval: common.Duration{Duration: p.maxEmptyBlockInterval},
 	}
 
 	// Disable london hardfork if burn contract address is not provided
@@ -294,6 +304,29 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 		}
 	}
 
+	if len(p.gasFeeAllowListAdmin) != 0 {
+		// only enable allow list if there is at least one address as **admin**, otherwise
+		// the allow list could never be updated
+		chainConfig.Params.GasFeeAllowList = &chain.GasFeeAllowListConfig{
+			AddressListConfig: chain.AddressListConfig{
+				AdminAddresses:   stringSliceToAddressSlice(p.gasFeeAllowListAdmin),
+				EnabledAddresses: stringSliceToAddressSlice(p.gasFeeAllowListEnabled),
+			},
+			DiscountPercent: p.gasFeeDiscount,
+		}
+	}
+
+	if len(p.callPermissionListAdmin) != 0 {
+		// only enable call permission list if there is at least one address as **admin**, otherwise
+		// the list could never be updated
+		chainConfig.Params.CallPermissionList = &chain.CallPermissionListConfig{
+			AddressListConfig: chain.AddressListConfig{
+				AdminAddresses:   stringSliceToAddressSlice(p.callPermissionListAdmin),
+				EnabledAddresses: stringSliceToAddressSlice(p.callPermissionListEnabled),
+			},
+		}
+	}
+
 	if p.isBurnContractEnabled() {
 		// only populate base fee and base fee multiplier values if burn contract(s)
 		// is provided
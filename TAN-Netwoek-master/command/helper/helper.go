@@ -12,6 +12,7 @@ import (
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/command"
 	ibftOp "github.com/tarality/tan-network/consensus/ibft/proto"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
 	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/server"
 	"github.com/tarality/tan-network/server/proto"
@@ -137,6 +138,19 @@ func GetIBFTOperatorClientConnection(address string) (
 	return ibftOp.NewIbftOperatorClient(conn), nil
 }
 
+// GetPolybftOperatorClientConnection returns the polybft operator client connection
+func GetPolybftOperatorClientConnection(address string) (
+	polybftOp.PolybftOperatorClient,
+	error,
+) {
+	conn, err := GetGRPCConnection(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return polybftOp.NewPolybftOperatorClient(conn), nil
+}
+
 // GetGRPCConnection returns a grpc client connection
 func GetGRPCConnection(address string) (*grpc.ClientConn, error) {
 	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -0,0 +1,48 @@
+package epoch
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	epochCmd := &cobra.Command{
+		Use:   "epoch",
+		Short: "Returns the current epoch and sprint, together with the checkpoint and state sync progress",
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(epochCmd)
+
+	return epochCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	epochResponse, err := getPolybftEpoch(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newPolybftEpochResult(epochResponse))
+}
+
+func getPolybftEpoch(grpcAddress string) (*polybftOp.EpochResp, error) {
+	client, err := helper.GetPolybftOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Epoch(context.Background(), &empty.Empty{})
+}
@@ -0,0 +1,67 @@
+package epoch
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
+)
+
+type PolybftEpochResult struct {
+	Number                     uint64 `json:"number"`
+	FirstBlock                 uint64 `json:"first_block"`
+	LastBlock                  uint64 `json:"last_block"`
+	Sprint                     uint64 `json:"sprint"`
+	SprintSize                 uint64 `json:"sprint_size"`
+	Block                      uint64 `json:"block"`
+	LastCheckpointedBlock      uint64 `json:"last_checkpointed_block"`
+	LastStateSyncEventID       uint64 `json:"last_state_sync_event_id"`
+	LastCommitmentStartEventID uint64 `json:"last_commitment_start_event_id"`
+	LastCommitmentEndEventID   uint64 `json:"last_commitment_end_event_id"`
+}
+
+func newPolybftEpochResult(resp *polybftOp.EpochResp) *PolybftEpochResult {
+	return &PolybftEpochResult{
+		Number:                     resp.Number,
+		FirstBlock:                 resp.FirstBlock,
+		LastBlock:                  resp.LastBlock,
+		Sprint:                     resp.Sprint,
+		SprintSize:                 resp.SprintSize,
+		Block:                      resp.Block,
+		LastCheckpointedBlock:      resp.GetCheckpoint().GetLastProcessedBlock(),
+		LastStateSyncEventID:       resp.GetStateSync().GetLastEventId(),
+		LastCommitmentStartEventID: resp.GetStateSync().GetLastCommitmentStartId(),
+		LastCommitmentEndEventID:   resp.GetStateSync().GetLastCommitmentEndId(),
+	}
+}
+
+func (r *PolybftEpochResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[POLYBFT EPOCH]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Epoch|%d", r.Number),
+		fmt.Sprintf("First block|%d", r.FirstBlock),
+		fmt.Sprintf("Last block|%d", r.LastBlock),
+		fmt.Sprintf("Sprint|%d", r.Sprint),
+		fmt.Sprintf("Sprint size|%d", r.SprintSize),
+		fmt.Sprintf("Latest block|%d", r.Block),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[CHECKPOINT PROGRESS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Last processed block|%d", r.LastCheckpointedBlock),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[STATE SYNC PROGRESS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Last state sync event|%d", r.LastStateSyncEventID),
+		fmt.Sprintf("Last commitment|%d - %d", r.LastCommitmentStartEventID, r.LastCommitmentEndEventID),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -1,6 +1,10 @@
 package polybft
 
 import (
+	"github.com/tarality/tan-network/command/polybft/epoch"
+	"github.com/tarality/tan-network/command/polybft/proposers"
+	"github.com/tarality/tan-network/command/polybft/status"
+	polybftValidators "github.com/tarality/tan-network/command/polybft/validators"
 	"github.com/tarality/tan-network/command/rootchain/registration"
 	"github.com/tarality/tan-network/command/rootchain/staking"
 	"github.com/tarality/tan-network/command/rootchain/supernet"
@@ -9,6 +13,7 @@ import (
 	"github.com/tarality/tan-network/command/rootchain/whitelist"
 	"github.com/tarality/tan-network/command/rootchain/withdraw"
 	"github.com/tarality/tan-network/command/sidechain/rewards"
+	"github.com/tarality/tan-network/command/sidechain/rotatekey"
 	"github.com/tarality/tan-network/command/sidechain/unstaking"
 	sidechainWithdraw "github.com/tarality/tan-network/command/sidechain/withdraw"
 	"github.com/spf13/cobra"
@@ -27,6 +32,8 @@ func GetCommand() *cobra.Command {
 		sidechainWithdraw.GetCommand(),
 		// sidechain (reward pool) command to withdraw pending rewards
 		rewards.GetCommand(),
+		// sidechain (validator set) command to rotate validator bls key
+		rotatekey.GetCommand(),
 		// rootchain (stake manager) command to withdraw stake
 		withdraw.GetCommand(),
 		// rootchain (supernet manager) command that queries validator info
@@ -42,6 +49,14 @@ func GetCommand() *cobra.Command {
 		supernet.GetCommand(),
 		// rootchain command for deploying stake manager
 		stakemanager.GetCommand(),
+		// polybft operator command that queries client status
+		status.GetCommand(),
+		// polybft operator command that queries current validator set
+		polybftValidators.GetCommand(),
+		// polybft operator command that queries epoch and bridge progress
+		epoch.GetCommand(),
+		// polybft operator command that queries upcoming proposers
+		proposers.GetCommand(),
 	)
 
 	return polybftCmd
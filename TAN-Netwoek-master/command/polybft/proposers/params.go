@@ -0,0 +1,41 @@
+package proposers
+
+import (
+	"context"
+
+	"github.com/tarality/tan-network/command/helper"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
+)
+
+const (
+	countFlag = "count"
+)
+
+var (
+	params = &proposersParams{}
+)
+
+type proposersParams struct {
+	count uint64
+
+	proposers *polybftOp.ProposersResp
+}
+
+func (p *proposersParams) initProposers(grpcAddress string) error {
+	client, err := helper.GetPolybftOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	proposers, err := client.Proposers(
+		context.Background(),
+		&polybftOp.ProposersReq{Count: p.count},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.proposers = proposers
+
+	return nil
+}
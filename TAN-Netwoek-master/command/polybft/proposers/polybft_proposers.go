@@ -0,0 +1,42 @@
+package proposers
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	proposersCmd := &cobra.Command{
+		Use:   "proposers",
+		Short: "Returns the upcoming block proposers order, assuming every block is finalized in the first round",
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(proposersCmd)
+	setFlags(proposersCmd)
+
+	return proposersCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.count,
+		countFlag,
+		10,
+		"the number of upcoming proposers",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initProposers(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newPolybftProposersResult(params.proposers))
+}
@@ -0,0 +1,58 @@
+package proposers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
+)
+
+type PolybftProposer struct {
+	Height   uint64 `json:"height"`
+	Address  string `json:"address"`
+	Priority string `json:"priority"`
+}
+
+type PolybftProposersResult struct {
+	Height    uint64            `json:"height"`
+	Proposers []PolybftProposer `json:"proposers"`
+}
+
+func newPolybftProposersResult(resp *polybftOp.ProposersResp) *PolybftProposersResult {
+	res := &PolybftProposersResult{
+		Height:    resp.Height,
+		Proposers: make([]PolybftProposer, len(resp.Proposers)),
+	}
+
+	for i, p := range resp.Proposers {
+		res.Proposers[i] = PolybftProposer{
+			Height:   p.Height,
+			Address:  p.Address,
+			Priority: p.Priority,
+		}
+	}
+
+	return res
+}
+
+func (r *PolybftProposersResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[POLYBFT PROPOSERS]\n")
+
+	rows := make([]string, len(r.Proposers)+1)
+	rows[0] = "No proposers found"
+
+	if len(r.Proposers) > 0 {
+		rows[0] = "HEIGHT|ADDRESS|PRIORITY"
+		for i, p := range r.Proposers {
+			rows[i+1] = fmt.Sprintf("%d|%s|%s", p.Height, p.Address, p.Priority)
+		}
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,54 @@
+package status
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Returns the validator key, current epoch and sprint of the polybft client",
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(statusCmd)
+
+	return statusCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	statusResponse, err := getPolybftStatus(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&PolybftStatusResult{
+		ValidatorKey:    statusResponse.Key,
+		ActiveValidator: statusResponse.ActiveValidator,
+		Block:           statusResponse.Block,
+		Epoch:           statusResponse.Epoch,
+		Sprint:          statusResponse.Sprint,
+	})
+}
+
+func getPolybftStatus(grpcAddress string) (*polybftOp.PolybftStatusResp, error) {
+	client, err := helper.GetPolybftOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Status(context.Background(), &empty.Empty{})
+}
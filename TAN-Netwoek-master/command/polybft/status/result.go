@@ -0,0 +1,32 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+)
+
+type PolybftStatusResult struct {
+	ValidatorKey    string `json:"validator_key"`
+	ActiveValidator bool   `json:"active_validator"`
+	Block           uint64 `json:"block"`
+	Epoch           uint64 `json:"epoch"`
+	Sprint          uint64 `json:"sprint"`
+}
+
+func (r *PolybftStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[POLYBFT STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Validator key|%s", r.ValidatorKey),
+		fmt.Sprintf("Active validator|%t", r.ActiveValidator),
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("Epoch|%d", r.Epoch),
+		fmt.Sprintf("Sprint|%d", r.Sprint),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	validatorsCmd := &cobra.Command{
+		Use:   "validators",
+		Short: "Returns the validator set of the current epoch, together with the pending validator set changes",
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(validatorsCmd)
+
+	return validatorsCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	validatorsResponse, err := getPolybftValidators(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newPolybftValidatorsResult(validatorsResponse))
+}
+
+func getPolybftValidators(grpcAddress string) (*polybftOp.ValidatorsResp, error) {
+	client, err := helper.GetPolybftOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Validators(context.Background(), &empty.Empty{})
+}
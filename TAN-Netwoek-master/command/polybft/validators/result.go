@@ -0,0 +1,94 @@
+package validators
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+	polybftOp "github.com/tarality/tan-network/consensus/polybft/proto"
+)
+
+type PolybftValidator struct {
+	Address     string `json:"address"`
+	VotingPower string `json:"voting_power"`
+	IsActive    bool   `json:"is_active"`
+}
+
+type PolybftValidatorsResult struct {
+	Epoch            uint64             `json:"epoch"`
+	TotalVotingPower string             `json:"total_voting_power"`
+	Validators       []PolybftValidator `json:"validators"`
+	Added            []PolybftValidator `json:"added"`
+	Updated          []PolybftValidator `json:"updated"`
+	Removed          []string           `json:"removed"`
+}
+
+func newPolybftValidatorsResult(resp *polybftOp.ValidatorsResp) *PolybftValidatorsResult {
+	return &PolybftValidatorsResult{
+		Epoch:            resp.Epoch,
+		TotalVotingPower: resp.TotalVotingPower,
+		Validators:       toPolybftValidators(resp.Validators),
+		Added:            toPolybftValidators(resp.GetPendingDelta().GetAdded()),
+		Updated:          toPolybftValidators(resp.GetPendingDelta().GetUpdated()),
+		Removed:          resp.GetPendingDelta().GetRemoved(),
+	}
+}
+
+func toPolybftValidators(validators []*polybftOp.Validator) []PolybftValidator {
+	res := make([]PolybftValidator, len(validators))
+
+	for i, v := range validators {
+		res[i] = PolybftValidator{
+			Address:     v.Address,
+			VotingPower: v.VotingPower,
+			IsActive:    v.IsActive,
+		}
+	}
+
+	return res
+}
+
+func (r *PolybftValidatorsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[POLYBFT VALIDATORS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Epoch|%d", r.Epoch),
+		fmt.Sprintf("Total voting power|%s", r.TotalVotingPower),
+	}))
+	buffer.WriteString("\n")
+
+	writeValidators(&buffer, "VALIDATORS", r.Validators)
+	writeValidators(&buffer, "PENDING ADDED", r.Added)
+	writeValidators(&buffer, "PENDING UPDATED", r.Updated)
+
+	removed := make([]string, len(r.Removed)+1)
+	removed[0] = "No validators found"
+
+	if len(r.Removed) > 0 {
+		removed[0] = "ADDRESS"
+		copy(removed[1:], r.Removed)
+	}
+
+	buffer.WriteString("\n[PENDING REMOVED]\n")
+	buffer.WriteString(helper.FormatList(removed))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func writeValidators(buffer *bytes.Buffer, title string, validators []PolybftValidator) {
+	rows := make([]string, len(validators)+1)
+	rows[0] = "No validators found"
+
+	if len(validators) > 0 {
+		rows[0] = "ADDRESS|VOTING POWER|ACTIVE"
+		for i, v := range validators {
+			rows[i+1] = fmt.Sprintf("%s|%s|%t", v.Address, v.VotingPower, v.IsActive)
+		}
+	}
+
+	buffer.WriteString(fmt.Sprintf("\n[%s]\n", title))
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+}
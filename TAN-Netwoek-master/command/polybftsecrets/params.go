@@ -9,6 +9,7 @@ import (
 	"github.com/tarality/tan-network/consensus/polybft/wallet"
 	"github.com/tarality/tan-network/secrets"
 	"github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/secrets/local"
 	"github.com/tarality/tan-network/types"
 	"github.com/spf13/cobra"
 )
@@ -38,6 +39,9 @@ type initParams struct {
 
 	insecureLocalStore bool
 
+	encrypted        bool
+	passphraseSource helper.PassphraseSource
+
 	output bool
 }
 
@@ -116,11 +120,43 @@ func (ip *initParams) setFlags(cmd *cobra.Command) {
 		false,
 		"the flag indicating to output existing secrets",
 	)
+
+	cmd.Flags().BoolVar(
+		&ip.encrypted,
+		EncryptedFlag,
+		false,
+		EncryptedFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&ip.passphraseSource.File,
+		PassphraseFileFlag,
+		"",
+		PassphraseFileFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&ip.passphraseSource.Env,
+		PassphraseEnvFlag,
+		"",
+		PassphraseEnvFlagDesc,
+	)
+
+	// Encrypted local secrets are the alternative to the plaintext local secrets
+	// and they are not related to the remote secrets manager.
+	cmd.MarkFlagsMutuallyExclusive(EncryptedFlag, insecureLocalStoreFlag)
+	cmd.MarkFlagsMutuallyExclusive(EncryptedFlag, AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(PassphraseFileFlag, PassphraseEnvFlag)
 }
 
 func (ip *initParams) Execute() (Results, error) {
 	results := make(Results, ip.numberOfSecrets)
 
+	passphrase, err := ip.readPassphrase()
+	if err != nil {
+		return results, err
+	}
+
 	for i := 0; i < ip.numberOfSecrets; i++ {
 		configDir, dataDir := ip.accountConfig, ip.accountDir
 
@@ -132,7 +168,7 @@ func (ip *initParams) Execute() (Results, error) {
 			configDir = fmt.Sprintf("%s%d", ip.accountConfig, i+1)
 		}
 
-		secretManager, err := GetSecretsManager(dataDir, configDir, ip.insecureLocalStore)
+		secretManager, err := ip.getSecretsManager(dataDir, configDir, passphrase)
 		if err != nil {
 			return results, err
 		}
@@ -156,6 +192,29 @@ func (ip *initParams) Execute() (Results, error) {
 	return results, nil
 }
 
+// readPassphrase reads the passphrase of the encrypted local secrets, if they are used.
+// The passphrase is confirmed only when it's going to encrypt the new secrets
+func (ip *initParams) readPassphrase() (string, error) {
+	if !ip.encrypted {
+		return "", nil
+	}
+
+	dataDir := ip.accountDir
+	if ip.numberOfSecrets > 1 {
+		dataDir = fmt.Sprintf("%s%d", ip.accountDir, 1)
+	}
+
+	return helper.ReadPassphrase(ip.passphraseSource, !local.HasEncryptedSecrets(dataDir))
+}
+
+func (ip *initParams) getSecretsManager(dataDir, configDir, passphrase string) (secrets.SecretsManager, error) {
+	if ip.encrypted {
+		return helper.SetupEncryptedLocalSecretsManager(dataDir, passphrase)
+	}
+
+	return GetSecretsManager(dataDir, configDir, ip.insecureLocalStore)
+}
+
 func (ip *initParams) initKeys(secretsManager secrets.SecretsManager) ([]string, error) {
 	var generated []string
 
@@ -15,10 +15,20 @@ const (
 	PrivateKeyFlag    = "private-key"
 	ChainIDFlag       = "chain-id"
 
+	EncryptedFlag      = "encrypted"
+	PassphraseFileFlag = "passphrase-file"
+	PassphraseEnvFlag  = "passphrase-env"
+
 	AccountDirFlagDesc    = "the directory for the TAN Network data if the local FS is used"
 	AccountConfigFlagDesc = "the path to the SecretsManager config file, if omitted, the local FS secrets manager is used"
 	PrivateKeyFlagDesc    = "hex-encoded private key of the account which executes rootchain commands"
 	ChainIDFlagDesc       = "ID of child chain"
+
+	EncryptedFlagDesc = "the flag indicating whether the secrets are stored on the local storage " +
+		"encrypted in the Ethereum v3 keystore format"
+	PassphraseFileFlagDesc = "the path to the file containing the passphrase of the encrypted secrets, " +
+		"if omitted along with passphrase-env, the passphrase is prompted"
+	PassphraseEnvFlagDesc = "the name of the environment variable containing the passphrase of the encrypted secrets"
 )
 
 // common errors for all polybft commands
@@ -27,8 +37,9 @@ var (
 	ErrInvalidParams                  = errors.New("no config file or data directory passed in")
 	ErrUnsupportedType                = errors.New("unsupported secrets manager")
 	ErrSecureLocalStoreNotImplemented = errors.New(
-		"use a secrets backend, or supply an --insecure flag " +
-			"to store the private keys locally on the filesystem, " +
+		"use a secrets backend, supply an --encrypted flag " +
+			"to store the private keys locally in the encrypted keystore, " +
+			"or supply an --insecure flag to store them locally in plaintext, " +
 			"avoid doing so in production")
 )
 
@@ -0,0 +1,174 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/command/polybftsecrets"
+	"github.com/tarality/tan-network/consensus/polybft/wallet"
+	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/secrets/keysigner"
+	"github.com/tarality/tan-network/secrets/local"
+)
+
+const (
+	listenFlag             = "listen"
+	tokenFlag              = "token"
+	blsSchemeFlag          = "bls-scheme"
+	slashingProtectionFlag = "slashing-protection-db"
+	tlsCertFileFlag        = "tls-cert-file"
+	tlsKeyFileFlag         = "tls-key-file"
+	promotePendingBLSFlag  = "promote-pending-bls-key"
+
+	defaultListenAddr               = "127.0.0.1:9650"
+	defaultBLSScheme                = string(keysigner.BLSSchemePolyBFT)
+	defaultSlashingProtectionDBName = "slashing-protection.db"
+)
+
+var (
+	errInvalidBLSScheme          = errors.New("invalid bls scheme, must be either ibft or polybft")
+	errSlashingProtectionMissing = errors.New("slashing protection database path must be set when using the config file")
+	errTLSKeyPairIncomplete      = errors.New("both tls certificate and key files must be set")
+	errMissingToken              = errors.New("the token must be set, tls doesn't authorize the requests")
+)
+
+type remoteSignerParams struct {
+	dataDir    string
+	configPath string
+
+	listenAddr             string
+	token                  string
+	blsScheme              string
+	slashingProtectionPath string
+	tlsCertFile            string
+	tlsKeyFile             string
+	promotePendingBLS      bool
+
+	passphraseSource helper.PassphraseSource
+}
+
+func (p *remoteSignerParams) validateFlags() error {
+	if p.dataDir == "" && p.configPath == "" {
+		return polybftsecrets.ErrInvalidParams
+	}
+
+	if p.blsScheme != string(keysigner.BLSSchemeIBFT) && p.blsScheme != string(keysigner.BLSSchemePolyBFT) {
+		return errInvalidBLSScheme
+	}
+
+	if (p.tlsCertFile == "") != (p.tlsKeyFile == "") {
+		return errTLSKeyPairIncomplete
+	}
+
+	if p.token == "" {
+		return errMissingToken
+	}
+
+	if p.slashingProtectionPath == "" {
+		if p.dataDir == "" {
+			return errSlashingProtectionMissing
+		}
+
+		p.slashingProtectionPath = filepath.Join(p.dataDir, defaultSlashingProtectionDBName)
+	}
+
+	return nil
+}
+
+// getSecretsManager resolves the secrets manager holding the validator keys.
+// The passphrase is read only if the local secrets are encrypted
+func (p *remoteSignerParams) getSecretsManager() (secrets.SecretsManager, error) {
+	if p.configPath != "" || (!p.passphraseSource.IsSet() && !local.HasEncryptedSecrets(p.dataDir)) {
+		return polybftsecrets.GetSecretsManager(p.dataDir, p.configPath, true)
+	}
+
+	passphrase, err := helper.ReadPassphrase(p.passphraseSource, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.SetupEncryptedLocalSecretsManager(p.dataDir, passphrase)
+}
+
+// startSigner loads the validator keys, starts serving the remote signing protocol
+// and returns the function which stops the remote signer
+func (p *remoteSignerParams) startSigner() (func(), error) {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "remote-signer",
+		Level: hclog.Info,
+	})
+
+	secretsManager, err := p.getSecretsManager()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.promotePendingBLS {
+		if err := wallet.PromotePendingBls(secretsManager); err != nil {
+			return nil, err
+		}
+
+		logger.Info("promoted the pending bls key to the validator bls key")
+	}
+
+	protection, err := keysigner.NewSlashingProtection(p.slashingProtectionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := keysigner.NewLocalSignerFromSecrets(secretsManager, keysigner.BLSScheme(p.blsScheme), protection)
+	if err != nil {
+		_ = protection.Close()
+
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", p.listenAddr)
+	if err != nil {
+		_ = protection.Close()
+
+		return nil, fmt.Errorf("failed to listen on %s: %w", p.listenAddr, err)
+	}
+
+	srv := &http.Server{
+		Handler:           keysigner.NewServer(logger, signer, p.token).Handler(),
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	go func() {
+		var err error
+
+		if p.tlsCertFile != "" {
+			err = srv.ServeTLS(listener, p.tlsCertFile, p.tlsKeyFile)
+		} else {
+			err = srv.Serve(listener)
+		}
+
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("remote signer stopped serving", "err", err)
+		}
+	}()
+
+	if p.tlsCertFile == "" {
+		logger.Warn("remote signer serves without TLS, the token and the signatures are sent in plain text")
+	}
+
+	logger.Info("remote signer started", "address", signer.Address(), "listen", listener.Addr(),
+		"tls", p.tlsCertFile != "")
+
+	return func() {
+		if err := srv.Close(); err != nil {
+			logger.Error("failed to close remote signer", "err", err)
+		}
+
+		if err := protection.Close(); err != nil {
+			logger.Error("failed to close slashing protection db", "err", err)
+		}
+	}, nil
+}
@@ -0,0 +1,130 @@
+package remotesigner
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+	"github.com/tarality/tan-network/command/polybftsecrets"
+)
+
+var (
+	params = &remoteSignerParams{}
+)
+
+// GetCommand returns the remote signer command
+func GetCommand() *cobra.Command {
+	remoteSignerCmd := &cobra.Command{
+		Use: "remote-signer",
+		Short: "Starts the reference remote signer, which holds the validator keys " +
+			"and signs consensus data for the validator node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(remoteSignerCmd)
+
+	return remoteSignerCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.listenAddr,
+		listenFlag,
+		defaultListenAddr,
+		"the address on which the remote signer serves the requests",
+	)
+
+	cmd.Flags().StringVar(
+		&params.token,
+		tokenFlag,
+		"",
+		"the bearer token the requests must be authorized with (required, also when TLS is set)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tlsCertFile,
+		tlsCertFileFlag,
+		"",
+		"the path to the PEM encoded TLS certificate the remote signer serves the requests with",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tlsKeyFile,
+		tlsKeyFileFlag,
+		"",
+		"the path to the PEM encoded private key of the TLS certificate",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blsScheme,
+		blsSchemeFlag,
+		defaultBLSScheme,
+		"the BLS scheme of the validator BLS key (ibft or polybft)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.slashingProtectionPath,
+		slashingProtectionFlag,
+		"",
+		"the path to the slashing protection database "+
+			"(defaults to the slashing protection database in the data directory)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.promotePendingBLS,
+		promotePendingBLSFlag,
+		false,
+		"replace the validator BLS key with the pending key created by the BLS key rotation before starting, "+
+			"once the rotation takes effect",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseSource.File,
+		polybftsecrets.PassphraseFileFlag,
+		"",
+		polybftsecrets.PassphraseFileFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseSource.Env,
+		polybftsecrets.PassphraseEnvFlag,
+		"",
+		polybftsecrets.PassphraseEnvFlagDesc,
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	closeFn, err := params.startSigner()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := helper.HandleSignals(closeFn, outputter); err != nil {
+		outputter.SetError(err)
+	}
+}
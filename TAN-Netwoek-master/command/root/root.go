@@ -6,8 +6,10 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tarality/tan-network/command/addressindex"
 	"github.com/tarality/tan-network/command/backup"
 	"github.com/tarality/tan-network/command/bridge"
+	"github.com/tarality/tan-network/command/debug"
 	"github.com/tarality/tan-network/command/genesis"
 	"github.com/tarality/tan-network/command/helper"
 	"github.com/tarality/tan-network/command/ibft"
@@ -17,6 +19,7 @@ import (
 	"github.com/tarality/tan-network/command/polybft"
 	"github.com/tarality/tan-network/command/polybftsecrets"
 	"github.com/tarality/tan-network/command/regenesis"
+	"github.com/tarality/tan-network/command/remotesigner"
 	"github.com/tarality/tan-network/command/rootchain"
 	"github.com/tarality/tan-network/command/secrets"
 	"github.com/tarality/tan-network/command/server"
@@ -61,6 +64,9 @@ func (rc *RootCommand) registerSubCommands() {
 		polybft.GetCommand(),
 		bridge.GetCommand(),
 		regenesis.GetCommand(),
+		addressindex.GetCommand(),
+		debug.GetCommand(),
+		remotesigner.GetCommand(),
 	)
 }
 
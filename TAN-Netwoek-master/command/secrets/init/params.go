@@ -16,6 +16,9 @@ const (
 	networkFlag            = "network"
 	numFlag                = "num"
 	insecureLocalStoreFlag = "insecure"
+	encryptedFlag          = "encrypted"
+	passphraseFileFlag     = "passphrase-file"
+	passphraseEnvFlag      = "passphrase-env"
 )
 
 var (
@@ -23,8 +26,9 @@ var (
 	errInvalidParams                  = errors.New("no config file or data directory passed in")
 	errUnsupportedType                = errors.New("unsupported secrets manager")
 	errSecureLocalStoreNotImplemented = errors.New(
-		"use a secrets backend, or supply an --insecure flag " +
-			"to store the private keys locally on the filesystem, " +
+		"use a secrets backend, supply an --encrypted flag " +
+			"to store the private keys locally in the encrypted keystore, " +
+			"or supply an --insecure flag to store them locally in plaintext, " +
 			"avoid doing so in production")
 )
 
@@ -35,6 +39,9 @@ type initParams struct {
 	generatesBLS       bool
 	generatesNetwork   bool
 	insecureLocalStore bool
+	encrypted          bool
+	passphraseSource   helper.PassphraseSource
+	passphrase         string
 
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
@@ -95,6 +102,17 @@ func (ip *initParams) parseConfig() error {
 }
 
 func (ip *initParams) initLocalSecretsManager() error {
+	if ip.encrypted {
+		local, err := helper.SetupEncryptedLocalSecretsManager(ip.dataDir, ip.passphrase)
+		if err != nil {
+			return err
+		}
+
+		ip.secretsManager = local
+
+		return nil
+	}
+
 	if !ip.insecureLocalStore {
 		//Storing secrets on a local file system should only be allowed with --insecure flag,
 		//to raise awareness that it should be only used in development/testing environments.
@@ -113,6 +131,22 @@ func (ip *initParams) initLocalSecretsManager() error {
 	return nil
 }
 
+// readPassphrase reads the passphrase of the encrypted local secrets, if they are used
+func (ip *initParams) readPassphrase() error {
+	if !ip.encrypted {
+		return nil
+	}
+
+	passphrase, err := helper.ReadPassphrase(ip.passphraseSource, true)
+	if err != nil {
+		return err
+	}
+
+	ip.passphrase = passphrase
+
+	return nil
+}
+
 func (ip *initParams) initValidatorKey() error {
 	var err error
 
@@ -91,6 +91,35 @@ func setFlags(cmd *cobra.Command) {
 		false,
 		"the flag indicating should the secrets stored on the local storage be encrypted",
 	)
+
+	cmd.Flags().BoolVar(
+		&basicParams.encrypted,
+		encryptedFlag,
+		false,
+		"the flag indicating whether the secrets are stored on the local storage "+
+			"encrypted in the Ethereum v3 keystore format",
+	)
+
+	cmd.Flags().StringVar(
+		&basicParams.passphraseSource.File,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted secrets, "+
+			"if omitted along with passphrase-env, the passphrase is prompted",
+	)
+
+	cmd.Flags().StringVar(
+		&basicParams.passphraseSource.Env,
+		passphraseEnvFlag,
+		"",
+		"the name of the environment variable containing the passphrase of the encrypted secrets",
+	)
+
+	// Encrypted local secrets are the alternative to the plaintext local secrets
+	// and they are not related to the remote secrets manager.
+	cmd.MarkFlagsMutuallyExclusive(encryptedFlag, insecureLocalStoreFlag)
+	cmd.MarkFlagsMutuallyExclusive(encryptedFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(passphraseFileFlag, passphraseEnvFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -105,6 +134,13 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
+	// The passphrase is read once and shared by all the generated secrets
+	if err := basicParams.readPassphrase(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
 	paramsList := getParamsList()
 	results := make(command.Results, len(paramsList))
 
@@ -144,6 +180,8 @@ func getParamsList() []initParams {
 			generatesBLS:       basicParams.generatesBLS,
 			generatesNetwork:   basicParams.generatesNetwork,
 			insecureLocalStore: basicParams.insecureLocalStore,
+			encrypted:          basicParams.encrypted,
+			passphrase:         basicParams.passphrase,
 		}
 	}
 
@@ -0,0 +1,113 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/secrets/local"
+)
+
+const (
+	dataDirFlag        = "data-dir"
+	passphraseFileFlag = "passphrase-file"
+	passphraseEnvFlag  = "passphrase-env"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errInvalidParams = errors.New("no data directory passed in")
+)
+
+// localSecrets are the secrets stored by the local secrets manager
+var localSecrets = []string{
+	secrets.ValidatorKey,
+	secrets.ValidatorBLSKey,
+	secrets.ValidatorBLSKeyNext,
+	secrets.NetworkKey,
+}
+
+type migrateParams struct {
+	dataDir          string
+	passphraseSource helper.PassphraseSource
+
+	migrated         []string
+	alreadyEncrypted []string
+}
+
+func (mp *migrateParams) validateFlags() error {
+	if mp.dataDir == "" {
+		return errInvalidParams
+	}
+
+	if !common.DirectoryExists(mp.dataDir) {
+		dataDirAbs, _ := filepath.Abs(mp.dataDir)
+
+		return fmt.Errorf("the data directory provided does not exist: %s", dataDirAbs)
+	}
+
+	return nil
+}
+
+func (mp *migrateParams) migrateSecrets() error {
+	// New passphrase is confirmed, while the passphrase of already encrypted secrets is verified below
+	passphrase, err := helper.ReadPassphrase(mp.passphraseSource, !local.HasEncryptedSecrets(mp.dataDir))
+	if err != nil {
+		return err
+	}
+
+	manager, err := helper.SetupEncryptedLocalSecretsManager(mp.dataDir, passphrase)
+	if err != nil {
+		return err
+	}
+
+	localManager, ok := manager.(*local.LocalSecretsManager)
+	if !ok {
+		return errors.New("invalid type assertion")
+	}
+
+	// Make sure all the secrets are readable before any of them is migrated,
+	// so that the data directory doesn't end up with the secrets encrypted by different passphrases
+	for _, name := range localSecrets {
+		if !localManager.HasSecret(name) {
+			continue
+		}
+
+		if _, err := localManager.GetSecret(name); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range localSecrets {
+		if !localManager.HasSecret(name) {
+			continue
+		}
+
+		migrated, err := localManager.EncryptSecret(name)
+		if err != nil {
+			return fmt.Errorf("unable to migrate %s: %w", name, err)
+		}
+
+		if migrated {
+			mp.migrated = append(mp.migrated, name)
+		} else {
+			mp.alreadyEncrypted = append(mp.alreadyEncrypted, name)
+		}
+	}
+
+	return nil
+}
+
+func (mp *migrateParams) getResult() command.CommandResult {
+	return &SecretsMigrateResult{
+		Migrated:         mp.migrated,
+		AlreadyEncrypted: mp.alreadyEncrypted,
+	}
+}
@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/tarality/tan-network/command/helper"
+)
+
+type SecretsMigrateResult struct {
+	Migrated         []string `json:"migrated"`
+	AlreadyEncrypted []string `json:"already_encrypted"`
+}
+
+func (r *SecretsMigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Migrated|%s", formatSecrets(r.Migrated)),
+		fmt.Sprintf("Already encrypted|%s", formatSecrets(r.AlreadyEncrypted)),
+	}
+
+	buffer.WriteString("\n[SECRETS MIGRATE]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func formatSecrets(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,64 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tarality/tan-network/command"
+)
+
+func GetCommand() *cobra.Command {
+	secretsMigrateCmd := &cobra.Command{
+		Use: "migrate",
+		Short: "Encrypts the plaintext private keys stored in the local FS secrets manager in place, " +
+			"using the Ethereum v3 keystore format",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsMigrateCmd)
+
+	return secretsMigrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the TAN Network data containing the local FS secrets",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseSource.File,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted secrets, "+
+			"if omitted along with passphrase-env, the passphrase is prompted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseSource.Env,
+		passphraseEnvFlag,
+		"",
+		"the name of the environment variable containing the passphrase of the encrypted secrets",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(passphraseFileFlag, passphraseEnvFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.migrateSecrets(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/secrets"
 	"github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/secrets/local"
 	"github.com/tarality/tan-network/types"
 )
 
@@ -19,6 +20,9 @@ const (
 	validatorFlag = "validator"
 	blsFlag       = "bls"
 	nodeIDFlag    = "node-id"
+
+	passphraseFileFlag = "passphrase-file"
+	passphraseEnvFlag  = "passphrase-env"
 )
 
 var (
@@ -39,6 +43,8 @@ type outputParams struct {
 	outputValidator bool
 	outputBLS       bool
 
+	passphraseSource helper.PassphraseSource
+
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
 
@@ -134,12 +140,23 @@ func (op *outputParams) initLocalSecretsManager() error {
 		return fmt.Errorf(strings.Join(errs, "\n"))
 	}
 
-	local, err := helper.SetupLocalSecretsManager(op.dataDir)
+	// Passphrase is needed only if the secrets are encrypted
+	var passphrase string
+
+	if op.passphraseSource.IsSet() || local.HasEncryptedSecrets(op.dataDir) {
+		var err error
+
+		if passphrase, err = helper.ReadPassphrase(op.passphraseSource, false); err != nil {
+			return err
+		}
+	}
+
+	localManager, err := helper.SetupEncryptedLocalSecretsManager(op.dataDir, passphrase)
 	if err != nil {
 		return err
 	}
 
-	op.secretsManager = local
+	op.secretsManager = localManager
 
 	return nil
 }
@@ -58,7 +58,23 @@ func setFlags(cmd *cobra.Command) {
 			"from the provided secrets manager",
 	)
 
+	cmd.Flags().StringVar(
+		&params.passphraseSource.File,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted local FS secrets, "+
+			"if omitted along with passphrase-env, the passphrase is prompted when needed",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseSource.Env,
+		passphraseEnvFlag,
+		"",
+		"the name of the environment variable containing the passphrase of the encrypted local FS secrets",
+	)
+
 	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(passphraseFileFlag, passphraseEnvFlag)
 	cmd.MarkFlagsMutuallyExclusive(nodeIDFlag, validatorFlag, blsFlag)
 }
 
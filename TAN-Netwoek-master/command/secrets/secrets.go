@@ -4,6 +4,7 @@ import (
 	"github.com/tarality/tan-network/command/helper"
 	"github.com/tarality/tan-network/command/secrets/generate"
 	initCmd "github.com/tarality/tan-network/command/secrets/init"
+	"github.com/tarality/tan-network/command/secrets/migrate"
 	"github.com/tarality/tan-network/command/secrets/output"
 	"github.com/spf13/cobra"
 )
@@ -29,5 +30,7 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		generate.GetCommand(),
 		// secrets output public data
 		output.GetCommand(),
+		// secrets migrate
+		migrate.GetCommand(),
 	)
 }
@@ -0,0 +1,26 @@
+//go:build e2e
+// +build e2e
+
+package server
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	byzantineDoubleSignFlag = "byzantine-double-sign"
+)
+
+// setByzantineFlags registers the flags which make the validator behave as a byzantine one.
+// They are available only in the e2e builds
+func setByzantineFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&params.byzantineDoubleSign,
+		byzantineDoubleSignFlag,
+		false,
+		"should the validator sign conflicting consensus messages, used for testing double sign detection "+
+			"(default false)",
+	)
+
+	_ = cmd.Flags().MarkHidden(byzantineDoubleSignFlag)
+}
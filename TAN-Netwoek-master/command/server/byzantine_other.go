@@ -0,0 +1,11 @@
+//go:build !e2e
+// +build !e2e
+
+package server
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// setByzantineFlags does nothing, the byzantine flags are available only in the e2e builds
+func setByzantineFlags(_ *cobra.Command) {}
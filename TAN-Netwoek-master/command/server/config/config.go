@@ -34,6 +34,26 @@ type Config struct {
 
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+	UptimeAlertThreshold  uint64 `json:"uptime_alert_threshold" yaml:"uptime_alert_threshold"`
+
+	FreezerDir       string `json:"freezer_dir" yaml:"freezer_dir"`
+	FreezerThreshold uint64 `json:"freezer_threshold" yaml:"freezer_threshold"`
+
+	AddressIndex                  bool `json:"address_index" yaml:"address_index"`
+	AddressIndexInternalTransfers bool `json:"address_index_internal_transfers" yaml:"address_index_internal_transfers"`
+
+	BloomIndex bool `json:"bloom_index" yaml:"bloom_index"`
+
+	ParallelExecutionWorkers int `json:"parallel_execution_workers" yaml:"parallel_execution_workers"`
+
+	StoreRevertReasons bool `json:"store_revert_reasons" yaml:"store_revert_reasons"`
+
+	RemoteSignerURL    string `json:"remote_signer_url" yaml:"remote_signer_url"`
+	RemoteSignerToken  string `json:"remote_signer_token" yaml:"remote_signer_token"`
+	RemoteSignerCACert string `json:"remote_signer_ca_cert" yaml:"remote_signer_ca_cert"`
+
+	SecretsPassphraseFile string `json:"secrets_passphrase_file" yaml:"secrets_passphrase_file"`
+	SecretsPassphraseEnv  string `json:"secrets_passphrase_env" yaml:"secrets_passphrase_env"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -50,6 +70,9 @@ type Network struct {
 	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
 	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+
+	PrivatePeerIDs []string `json:"private_peer_ids,omitempty" yaml:"private_peer_ids,omitempty"`
+	Sentries       []string `json:"sentries,omitempty" yaml:"sentries,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
@@ -79,6 +102,9 @@ const (
 	// DefaultNumBlockConfirmations minimal number of child blocks required for the parent block to be considered final
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
+
+	// DefaultUptimeAlertThreshold number of consecutively missed blocks after which a validator is reported as silent
+	DefaultUptimeAlertThreshold uint64 = 50
 )
 
 // DefaultConfig returns the default server configuration
@@ -116,6 +142,25 @@ func DefaultConfig() *Config {
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
 		Relayer:                  false,
 		NumBlockConfirmations:    DefaultNumBlockConfirmations,
+		UptimeAlertThreshold:     DefaultUptimeAlertThreshold,
+		FreezerDir:               "",
+		FreezerThreshold:         0,
+
+		AddressIndex:                  false,
+		AddressIndexInternalTransfers: false,
+
+		BloomIndex: true,
+
+		ParallelExecutionWorkers: 0,
+
+		StoreRevertReasons: false,
+
+		RemoteSignerURL:    "",
+		RemoteSignerToken:  "",
+		RemoteSignerCACert: "",
+
+		SecretsPassphraseFile: "",
+		SecretsPassphraseEnv:  "",
 	}
 }
 
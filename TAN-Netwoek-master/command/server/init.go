@@ -14,12 +14,16 @@ import (
 	"github.com/tarality/tan-network/command/helper"
 	"github.com/tarality/tan-network/network"
 	"github.com/tarality/tan-network/secrets"
+	secretsHelper "github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/secrets/local"
 	"github.com/tarality/tan-network/server"
 	"github.com/tarality/tan-network/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var (
 	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errAddressIndexNotEnabled = errors.New("indexing the internal transfers requires the address index to be enabled")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -49,18 +53,38 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initSecretsPassphrase(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
 
+	if err := p.initAddressIndex(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
+	if err := p.initSentryConfig(); err != nil {
+		return err
+	}
+
 	p.relayer = p.rawConfig.Relayer
 
 	return p.initAddresses()
 }
 
+func (p *serverParams) initAddressIndex() error {
+	if p.rawConfig.AddressIndexInternalTransfers && !p.rawConfig.AddressIndex {
+		return errAddressIndexNotEnabled
+	}
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -103,6 +127,31 @@ func (p *serverParams) initSecretsConfig() error {
 	return nil
 }
 
+// initSecretsPassphrase reads the passphrase of the encrypted local secrets.
+// The passphrase is prompted only if the secrets stored in the data directory are encrypted
+func (p *serverParams) initSecretsPassphrase() error {
+	if p.isSecretsConfigPathSet() {
+		return nil
+	}
+
+	source := secretsHelper.PassphraseSource{
+		File: p.rawConfig.SecretsPassphraseFile,
+		Env:  p.rawConfig.SecretsPassphraseEnv,
+	}
+
+	if !source.IsSet() && !local.HasEncryptedSecrets(p.rawConfig.DataDir) {
+		return nil
+	}
+
+	var err error
+
+	if p.secretsPassphrase, err = secretsHelper.ReadPassphrase(source, false); err != nil {
+		return fmt.Errorf("unable to read secrets passphrase, %w", err)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initGenesisConfig() error {
 	var parseErr error
 
@@ -199,6 +248,32 @@ func (p *serverParams) initUsingMaxPeers() {
 	}
 }
 
+func (p *serverParams) initSentryConfig() error {
+	p.privatePeerIDs = make([]peer.ID, 0, len(p.rawConfig.Network.PrivatePeerIDs))
+
+	for _, rawID := range p.rawConfig.Network.PrivatePeerIDs {
+		peerID, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid private peer ID %s: %w", rawID, err)
+		}
+
+		p.privatePeerIDs = append(p.privatePeerIDs, peerID)
+	}
+
+	p.sentries = make([]*peer.AddrInfo, 0, len(p.rawConfig.Network.Sentries))
+
+	for _, rawAddr := range p.rawConfig.Network.Sentries {
+		sentry, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return fmt.Errorf("invalid sentry address %s: %w", rawAddr, err)
+		}
+
+		p.sentries = append(p.sentries, sentry)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initAddresses() error {
 	if err := p.initPrometheusAddress(); err != nil {
 		return err
@@ -8,8 +8,10 @@ import (
 	"github.com/tarality/tan-network/command/server/config"
 	"github.com/tarality/tan-network/network"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/server"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -25,6 +27,8 @@ const (
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
+	privatePeerIDsFlag           = "private-peer-ids"
+	sentriesFlag                 = "sentries"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
@@ -40,6 +44,26 @@ const (
 
 	relayerFlag               = "relayer"
 	numBlockConfirmationsFlag = "num-block-confirmations"
+	uptimeAlertThresholdFlag  = "uptime-alert-threshold"
+
+	freezerDirFlag       = "freezer-dir"
+	freezerThresholdFlag = "freezer-threshold"
+
+	addressIndexFlag                  = "address-index"
+	addressIndexInternalTransfersFlag = "address-index-internal-transfers"
+
+	bloomIndexFlag = "bloom-index"
+
+	parallelExecutionWorkersFlag = "parallel-execution-workers"
+
+	storeRevertReasonsFlag = "store-revert-reasons"
+
+	remoteSignerURLFlag   = "remote-signer"
+	remoteSignerTokenFlag = "remote-signer-token"
+	remoteSignerCAFlag    = "remote-signer-ca-cert"
+
+	secretsPassphraseFileFlag = "secrets-passphrase-file"
+	secretsPassphraseEnvFlag  = "secrets-passphrase-env"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -77,6 +101,9 @@ type serverParams struct {
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
 
+	privatePeerIDs []peer.ID
+	sentries       []*peer.AddrInfo
+
 	blockGasTarget uint64
 	devInterval    uint64
 	isDevMode      bool
@@ -86,9 +113,13 @@ type serverParams struct {
 	genesisConfig *chain.Chain
 	secretsConfig *secrets.SecretsManagerConfig
 
+	secretsPassphrase string
+
 	logFileLocation string
 
 	relayer bool
+
+	byzantineDoubleSign bool
 }
 
 func (p *serverParams) isMaxPeersSet() bool {
@@ -168,6 +199,8 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
 			Chain:            p.genesisConfig,
+			PrivatePeerIDs:   p.privatePeerIDs,
+			Sentries:         p.sentries,
 		},
 		DataDir:            p.rawConfig.DataDir,
 		Seal:               p.rawConfig.ShouldSeal,
@@ -175,6 +208,7 @@ func (p *serverParams) generateConfig() *server.Config {
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
 		SecretsManager:     p.secretsConfig,
+		SecretsPassphrase:  p.secretsPassphrase,
 		RestoreFile:        p.getRestoreFilePath(),
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
 		JSONLogFormat:      p.rawConfig.JSONLogFormat,
@@ -182,5 +216,33 @@ func (p *serverParams) generateConfig() *server.Config {
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+		UptimeAlertThreshold:  p.rawConfig.UptimeAlertThreshold,
+		ByzantineDoubleSign:   p.byzantineDoubleSign,
+
+		FreezerDir:       p.rawConfig.FreezerDir,
+		FreezerThreshold: p.rawConfig.FreezerThreshold,
+
+		AddressIndex:                  p.rawConfig.AddressIndex,
+		AddressIndexInternalTransfers: p.rawConfig.AddressIndexInternalTransfers,
+
+		BloomIndex: p.rawConfig.BloomIndex,
+
+		ParallelExecutionWorkers: p.rawConfig.ParallelExecutionWorkers,
+
+		StoreRevertReasons: p.rawConfig.StoreRevertReasons,
+
+		RemoteSigner: p.remoteSignerConfig(),
+	}
+}
+
+func (p *serverParams) remoteSignerConfig() *keysigner.RemoteConfig {
+	if p.rawConfig.RemoteSignerURL == "" {
+		return nil
+	}
+
+	return &keysigner.RemoteConfig{
+		URL:        p.rawConfig.RemoteSignerURL,
+		Token:      p.rawConfig.RemoteSignerToken,
+		CACertFile: p.rawConfig.RemoteSignerCACert,
 	}
 }
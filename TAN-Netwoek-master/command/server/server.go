@@ -115,7 +115,8 @@ func setFlags(cmd *cobra.Command) {
 		&params.rawConfig.RestoreFile,
 		restoreFlag,
 		"",
-		"the path to the archive blockchain data to restore on initialization",
+		"the path to the archive blockchain data to restore on initialization, "+
+			"either a backup file or a manifest of incremental backups",
 	)
 
 	cmd.Flags().BoolVar(
@@ -161,6 +162,22 @@ func setFlags(cmd *cobra.Command) {
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 	cmd.MarkFlagsMutuallyExclusive(maxPeersFlag, maxOutboundPeersFlag)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.PrivatePeerIDs,
+		privatePeerIDsFlag,
+		nil,
+		"the libp2p IDs of the peers that are never advertised to other peers, "+
+			"used by sentry nodes to hide their validators",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.Sentries,
+		sentriesFlag,
+		nil,
+		"the libp2p addresses of the sentry nodes. If set, the node connects only to these peers "+
+			"and does not take part in peer discovery",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -228,6 +245,108 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.UptimeAlertThreshold,
+		uptimeAlertThresholdFlag,
+		defaultConfig.UptimeAlertThreshold,
+		"number of consecutively missed blocks after which a validator is reported as silent, 0 disables alerting (PolyBFT only)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.FreezerDir,
+		freezerDirFlag,
+		defaultConfig.FreezerDir,
+		"the directory of the ancient store for old canonical blocks and receipts "+
+			"(defaults to the blockchain directory in the data directory)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.FreezerThreshold,
+		freezerThresholdFlag,
+		defaultConfig.FreezerThreshold,
+		"number of the most recent blocks kept in the key-value store, older canonical blocks "+
+			"are moved to the ancient store, value of 0 stops moving the new blocks "+
+			"(the existing ancient store stays readable)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.AddressIndex,
+		addressIndexFlag,
+		defaultConfig.AddressIndex,
+		"maintain the address to transaction index used by tan_getTransactionsByAddress",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.AddressIndexInternalTransfers,
+		addressIndexInternalTransfersFlag,
+		defaultConfig.AddressIndexInternalTransfers,
+		"trace the written blocks in the background to add the internal value transfers to the address index, "+
+			"requires the address index",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.BloomIndex,
+		bloomIndexFlag,
+		defaultConfig.BloomIndex,
+		"build the bloom bits log index in the background to speed up eth_getLogs over large block ranges",
+	)
+
+	cmd.Flags().IntVar(
+		&params.rawConfig.ParallelExecutionWorkers,
+		parallelExecutionWorkersFlag,
+		defaultConfig.ParallelExecutionWorkers,
+		"number of workers executing the block transactions speculatively in parallel "+
+			"when building and importing blocks, value of 0 or 1 executes them sequentially",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StoreRevertReasons,
+		storeRevertReasonsFlag,
+		defaultConfig.StoreRevertReasons,
+		"store the revert data of the reverted transactions with their receipts, "+
+			"exposed as the revertReason of the receipt",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSignerURL,
+		remoteSignerURLFlag,
+		defaultConfig.RemoteSignerURL,
+		"the URL of the remote signer holding the validator keys, "+
+			"validator keys are loaded from the secrets manager if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSignerToken,
+		remoteSignerTokenFlag,
+		defaultConfig.RemoteSignerToken,
+		"the bearer token used to authorize requests to the remote signer "+
+			"(required when the remote signer is set, also over https)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSignerCACert,
+		remoteSignerCAFlag,
+		defaultConfig.RemoteSignerCACert,
+		"the path to the PEM encoded CA certificate the TLS certificate of the remote signer is verified with",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.SecretsPassphraseFile,
+		secretsPassphraseFileFlag,
+		defaultConfig.SecretsPassphraseFile,
+		"the path to the file containing the passphrase of the encrypted local secrets, "+
+			"if omitted along with secrets-passphrase-env, the passphrase is prompted when the secrets are encrypted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.SecretsPassphraseEnv,
+		secretsPassphraseEnvFlag,
+		defaultConfig.SecretsPassphraseEnv,
+		"the name of the environment variable containing the passphrase of the encrypted local secrets",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(secretsPassphraseFileFlag, secretsPassphraseEnvFlag)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -265,6 +384,8 @@ func setDevFlags(cmd *cobra.Command) {
 	)
 
 	_ = cmd.Flags().MarkHidden(devIntervalFlag)
+
+	setByzantineFlags(cmd)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
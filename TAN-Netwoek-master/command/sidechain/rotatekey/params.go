@@ -0,0 +1,61 @@
+package rotatekey
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/command/helper"
+	"github.com/tarality/tan-network/command/polybftsecrets"
+	sidechainHelper "github.com/tarality/tan-network/command/sidechain"
+	"github.com/tarality/tan-network/secrets"
+	secretsHelper "github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/secrets/local"
+)
+
+type rotateKeyParams struct {
+	accountDir       string
+	accountConfig    string
+	jsonRPC          string
+	passphraseSource secretsHelper.PassphraseSource
+}
+
+func (rp *rotateKeyParams) validateFlags() error {
+	return sidechainHelper.ValidateSecretFlags(rp.accountDir, rp.accountConfig)
+}
+
+// getSecretsManager resolves the secrets manager of the validator.
+// Encrypted local secrets are decrypted with the passphrase, which is read from the given source
+func (rp *rotateKeyParams) getSecretsManager() (secrets.SecretsManager, error) {
+	if rp.accountConfig == "" && local.HasEncryptedSecrets(rp.accountDir) {
+		passphrase, err := secretsHelper.ReadPassphrase(rp.passphraseSource, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return secretsHelper.SetupEncryptedLocalSecretsManager(rp.accountDir, passphrase)
+	}
+
+	return polybftsecrets.GetSecretsManager(rp.accountDir, rp.accountConfig, true)
+}
+
+type rotateKeyResult struct {
+	ValidatorAddress string `json:"validatorAddress"`
+	BLSPublicKey     string `json:"blsPublicKey"`
+	BlockNumber      uint64 `json:"blockNumber"`
+}
+
+func (rr rotateKeyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ROTATE KEY]\n")
+
+	vals := make([]string, 0, 3)
+	vals = append(vals, fmt.Sprintf("Validator Address|%s", rr.ValidatorAddress))
+	vals = append(vals, fmt.Sprintf("New BLS Public Key|%s", rr.BLSPublicKey))
+	vals = append(vals, fmt.Sprintf("Included In Block|%d", rr.BlockNumber))
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\nNew BLS key is used by the validator set starting from the next epoch\n")
+
+	return buffer.String()
+}
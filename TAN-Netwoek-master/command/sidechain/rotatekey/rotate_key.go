@@ -0,0 +1,168 @@
+package rotatekey
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tarality/tan-network/command"
+	"github.com/tarality/tan-network/command/helper"
+	"github.com/tarality/tan-network/command/polybftsecrets"
+	"github.com/tarality/tan-network/consensus/polybft"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/consensus/polybft/wallet"
+	"github.com/tarality/tan-network/contracts"
+	"github.com/tarality/tan-network/helper/hex"
+	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/txrelayer"
+	"github.com/tarality/tan-network/types"
+	"github.com/umbracle/ethgo"
+)
+
+var params rotateKeyParams
+
+func GetCommand() *cobra.Command {
+	rotateKeyCmd := &cobra.Command{
+		Use: "rotate-key",
+		Short: "Rotates the validator BLS key without leaving the validator set. " +
+			"The new key is used starting from the next epoch",
+		Long: "Rotates the validator BLS key without leaving the validator set. " +
+			"The new key is generated as the pending key of the given secrets and used starting from the next epoch, " +
+			"when the node switches to it. If the validator keys are held by a remote signer, " +
+			"the rotation must be run with the secrets of the remote signer, which has to be restarted " +
+			"with --promote-pending-bls-key once the rotation takes effect (the node can't switch keys it doesn't hold). " +
+			"The supernet manager keeps the registered key, the rotated key is found in the validator set of the chain",
+		PreRunE: runPreRun,
+		RunE:    runCommand,
+	}
+
+	helper.RegisterJSONRPCFlag(rotateKeyCmd)
+	setFlags(rotateKeyCmd)
+
+	return rotateKeyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.accountDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.accountConfig,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseSource.File,
+		polybftsecrets.PassphraseFileFlag,
+		"",
+		polybftsecrets.PassphraseFileFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseSource.Env,
+		polybftsecrets.PassphraseEnvFlag,
+		"",
+		polybftsecrets.PassphraseEnvFlagDesc,
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.PassphraseFileFlag, polybftsecrets.PassphraseEnvFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	secretsManager, err := params.getSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	validatorAccount, err := wallet.NewAccountFromSecret(secretsManager)
+	if err != nil {
+		return err
+	}
+
+	newKey, err := getPendingKey(secretsManager)
+	if err != nil {
+		return err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithReceiptTimeout(150*time.Millisecond))
+	if err != nil {
+		return err
+	}
+
+	chainID, err := txRelayer.Client().Eth().ChainID()
+	if err != nil {
+		return err
+	}
+
+	validatorAddr := types.Address(validatorAccount.Ecdsa.Address())
+
+	rotation, err := polybft.NewValidatorKeyRotation(chainID.Uint64(), validatorAddr, validatorAccount.Bls, newKey)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := rotation.EncodeAbi()
+	if err != nil {
+		return err
+	}
+
+	txn := &ethgo.Transaction{
+		From:  validatorAccount.Ecdsa.Address(),
+		Input: encoded,
+		To:    (*ethgo.Address)(&contracts.ValidatorKeyRotationContract),
+	}
+
+	receipt, err := txRelayer.SendTransaction(txn, validatorAccount.Ecdsa)
+	if err != nil {
+		return err
+	}
+
+	if receipt.Status != uint64(types.ReceiptSuccess) {
+		return fmt.Errorf("rotate key transaction failed on block: %d", receipt.BlockNumber)
+	}
+
+	outputter.WriteCommandResult(&rotateKeyResult{
+		ValidatorAddress: validatorAddr.String(),
+		BLSPublicKey:     hex.EncodeToHex(newKey.PublicKey().Marshal()),
+		BlockNumber:      receipt.BlockNumber,
+	})
+
+	return nil
+}
+
+// getPendingKey returns the pending BLS key of the validator. The new key is generated and persisted
+// before the rotation is sent, so the validator is able to switch to it once the rotation takes effect.
+// If the pending key already exists (e.g. the previous rotation transaction failed), it is reused
+func getPendingKey(secretsManager secrets.SecretsManager) (*bls.PrivateKey, error) {
+	if secretsManager.HasSecret(secrets.ValidatorBLSKeyNext) {
+		return wallet.GetPendingBlsFromSecret(secretsManager)
+	}
+
+	newKey, err := bls.GenerateBlsKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.SavePendingBls(secretsManager, newKey); err != nil {
+		return nil, fmt.Errorf("failed to save pending bls key: %w", err)
+	}
+
+	return newKey, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/tarality/tan-network/helper/progress"
 	"github.com/tarality/tan-network/network"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/state"
 	"github.com/tarality/tan-network/txpool"
 	"github.com/tarality/tan-network/types"
@@ -37,6 +38,12 @@ type Consensus interface {
 	// GetBridgeProvider returns an instance of BridgeDataProvider
 	GetBridgeProvider() BridgeDataProvider
 
+	// GetUptimeProvider returns an instance of ValidatorUptimeProvider
+	GetUptimeProvider() ValidatorUptimeProvider
+
+	// GetFinalizedBlockNumber returns the number of the latest finalized block for the given head block number
+	GetFinalizedBlockNumber(head uint64) uint64
+
 	// FilterExtra filters extra data in header that is not a part of block hash
 	FilterExtra(extra []byte) ([]byte, error)
 
@@ -77,7 +84,14 @@ type Params struct {
 	SecretsManager secrets.SecretsManager
 	BlockTime      uint64
 
+	// KeySigner holds the validator keys, if they are not loaded from SecretsManager (e.g. remote signer)
+	KeySigner keysigner.KeySigner
+
 	NumBlockConfirmations uint64
+	UptimeAlertThreshold  uint64
+
+	// ByzantineDoubleSign makes the validator sign conflicting consensus messages (used only for testing)
+	ByzantineDoubleSign bool
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -90,4 +104,16 @@ type BridgeDataProvider interface {
 
 	// GetStateSyncProof retrieves the StateSync proof
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+
+	// GetHeaderProof returns the epoch-ending headers needed to follow the validator set from one epoch to another
+	GetHeaderProof(fromEpoch, toEpoch uint64) ([]*types.Header, error)
+}
+
+// ValidatorUptimeProvider is an interface providing validators uptime related functions
+type ValidatorUptimeProvider interface {
+	// GetValidatorsUptime returns signing statistics of validators for the given number of the latest blocks
+	GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error)
+
+	// GetEpochUptime returns signing statistics of validators for the given epoch
+	GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error)
 }
@@ -29,6 +29,8 @@ type Dev struct {
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	numBlockConfirmations uint64
 }
 
 // Factory implements the base factory method
@@ -44,6 +46,8 @@ func Factory(
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.TxPool,
+
+		numBlockConfirmations: params.NumBlockConfirmations,
 	}
 
 	rawInterval, ok := params.Config.Config["interval"]
@@ -263,6 +267,16 @@ func (d *Dev) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dev) GetUptimeProvider() consensus.ValidatorUptimeProvider {
+	return nil
+}
+
+// GetFinalizedBlockNumber returns the head block number minus the number of block confirmations,
+// since blocks are not instantly final
+func (d *Dev) GetFinalizedBlockNumber(head uint64) uint64 {
+	return consensus.FinalizedByConfirmations(head, d.numBlockConfirmations)
+}
+
 func (d *Dev) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
@@ -17,6 +17,8 @@ type Dummy struct {
 	txpool     *txpool.TxPool
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	numBlockConfirmations uint64
 }
 
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
@@ -29,6 +31,8 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.TxPool,
+
+		numBlockConfirmations: params.NumBlockConfirmations,
 	}
 
 	return d, nil
@@ -79,6 +83,16 @@ func (d *Dummy) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dummy) GetUptimeProvider() consensus.ValidatorUptimeProvider {
+	return nil
+}
+
+// GetFinalizedBlockNumber returns the head block number minus the number of block confirmations,
+// since blocks are not instantly final
+func (d *Dummy) GetFinalizedBlockNumber(head uint64) uint64 {
+	return consensus.FinalizedByConfirmations(head, d.numBlockConfirmations)
+}
+
 func (d *Dummy) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
@@ -0,0 +1,57 @@
+package consensus
+
+import (
+	"time"
+)
+
+// KeyMaxEmptyBlockInterval is the consensus engine configuration key of the maximum empty block interval
+const KeyMaxEmptyBlockInterval = "maxEmptyBlockInterval"
+
+// emptyBlockPollInterval is how often the txpool is checked while the proposer waits for transactions
+const emptyBlockPollInterval = 100 * time.Millisecond
+
+// PendingTxCounter returns the number of transactions in the txpool, which are ready to be included in a block
+type PendingTxCounter interface {
+	Length() uint64
+}
+
+// WaitForTransactions delays the proposal of an empty block. While the txpool is empty,
+// it waits until a transaction arrives, or until maxEmptyBlockInterval passes since the parent block.
+// Waiting is disabled if maxEmptyBlockInterval is zero. It stops waiting once stopCh is closed
+func WaitForTransactions(txPool PendingTxCounter, parentTimestamp uint64,
+	maxEmptyBlockInterval time.Duration, stopCh <-chan struct{}) {
+	if maxEmptyBlockInterval == 0 {
+		return
+	}
+
+	untilDeadline := time.Until(time.Unix(int64(parentTimestamp), 0).Add(maxEmptyBlockInterval))
+	if untilDeadline <= 0 {
+		return
+	}
+
+	deadline := time.NewTimer(untilDeadline)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(emptyBlockPollInterval)
+	defer ticker.Stop()
+
+	for txPool.Length() == 0 {
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			return
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// EmptyBlockRoundTimeout returns the round timeout extension of the next sequence. The proposer may wait
+// for transactions only while the txpool is empty, so the round timeout is extended only in that case
+func EmptyBlockRoundTimeout(txPool PendingTxCounter, maxEmptyBlockInterval time.Duration) time.Duration {
+	if txPool.Length() > 0 {
+		return 0
+	}
+
+	return maxEmptyBlockInterval
+}
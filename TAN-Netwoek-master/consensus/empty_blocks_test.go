@@ -0,0 +1,81 @@
+package consensus
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type txPoolLengthMock struct {
+	length uint64
+}
+
+func (m *txPoolLengthMock) Length() uint64 {
+	return atomic.LoadUint64(&m.length)
+}
+
+func TestWaitForTransactions(t *testing.T) {
+	t.Parallel()
+
+	now := uint64(time.Now().Unix())
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Now()
+		WaitForTransactions(&txPoolLengthMock{}, now, 0, nil)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("txpool not empty", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Now()
+		WaitForTransactions(&txPoolLengthMock{length: 1}, now, time.Hour, nil)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("interval passed", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Now()
+		WaitForTransactions(&txPoolLengthMock{}, now-10, 5*time.Second, nil)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("transaction arrives", func(t *testing.T) {
+		t.Parallel()
+
+		txPool := &txPoolLengthMock{}
+
+		time.AfterFunc(300*time.Millisecond, func() {
+			atomic.StoreUint64(&txPool.length, 1)
+		})
+
+		start := time.Now()
+		WaitForTransactions(txPool, now, time.Hour, nil)
+		require.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		t.Parallel()
+
+		stopCh := make(chan struct{})
+		time.AfterFunc(300*time.Millisecond, func() { close(stopCh) })
+
+		start := time.Now()
+		WaitForTransactions(&txPoolLengthMock{}, now, time.Hour, stopCh)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestEmptyBlockRoundTimeout(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, time.Minute, EmptyBlockRoundTimeout(&txPoolLengthMock{}, time.Minute))
+	require.Equal(t, time.Duration(0), EmptyBlockRoundTimeout(&txPoolLengthMock{length: 1}, time.Minute))
+	require.Equal(t, time.Duration(0), EmptyBlockRoundTimeout(&txPoolLengthMock{}, 0))
+}
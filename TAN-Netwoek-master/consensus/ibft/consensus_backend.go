@@ -31,6 +31,11 @@ func (i *backendIBFT) BuildProposal(view *proto.View) []byte {
 		return nil
 	}
 
+	// the empty block is delayed, unless the round is changed or the epoch ends
+	if view.Round == 0 && !i.IsLastOfEpoch(view.Height) {
+		consensus.WaitForTransactions(i.txpool, latestHeader.Timestamp, i.maxEmptyBlockInterval, i.closeCh)
+	}
+
 	block, err := i.buildBlock(latestHeader)
 
 	if err != nil {
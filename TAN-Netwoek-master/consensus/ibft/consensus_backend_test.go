@@ -111,3 +111,24 @@ func TestIBFTBackend_RoundUpTime(t *testing.T) {
 		})
 	}
 }
+
+// TestIBFTBackend_ExtractMaxEmptyBlockInterval verifies the max empty block interval is read from the engine config
+func TestIBFTBackend_ExtractMaxEmptyBlockInterval(t *testing.T) {
+	t.Parallel()
+
+	interval, err := extractMaxEmptyBlockInterval(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+
+	interval, err = extractMaxEmptyBlockInterval(map[string]interface{}{"maxEmptyBlockInterval": "1m"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, interval)
+
+	// durations are stored as nanoseconds by the genesis command
+	interval, err = extractMaxEmptyBlockInterval(map[string]interface{}{"maxEmptyBlockInterval": float64(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, interval)
+
+	_, err = extractMaxEmptyBlockInterval(map[string]interface{}{"maxEmptyBlockInterval": "invalid"})
+	assert.ErrorIs(t, err, errInvalidMaxEmptyBlockInterval)
+}
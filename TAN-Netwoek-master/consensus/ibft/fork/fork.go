@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/tarality/tan-network/consensus/ibft/signer"
 	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 )
 
@@ -159,6 +161,41 @@ func (fs *IBFTForks) getFork(height uint64) *IBFTFork {
 	return nil
 }
 
+// HeaderHashFunc returns the IBFT header hash calculation of the forks, which doesn't require the validator keys.
+// It's used by the tools reading the blocks without running the consensus
+func (fs *IBFTForks) HeaderHashFunc() func(*types.Header) types.Hash {
+	keyManager := func(height uint64) (signer.KeyManager, error) {
+		fork := fs.getFork(height)
+		if fork == nil {
+			return nil, ErrForkNotFound
+		}
+
+		return signer.NewKeylessKeyManager(fork.ValidatorType)
+	}
+
+	return func(h *types.Header) types.Hash {
+		current, err := keyManager(h.Number)
+		if err != nil {
+			return types.ZeroHash
+		}
+
+		var parent signer.KeyManager
+
+		if h.Number > 1 {
+			if parent, err = keyManager(h.Number - 1); err != nil {
+				return types.ZeroHash
+			}
+		}
+
+		hash, err := signer.NewSigner(current, parent).CalculateHeaderHash(h)
+		if err != nil {
+			return types.ZeroHash
+		}
+
+		return hash
+	}
+}
+
 // filterByType returns new list of IBFTFork whose type matches with the given type
 func (fs *IBFTForks) filterByType(ibftType IBFTType) IBFTForks {
 	filteredForks := make(IBFTForks, 0)
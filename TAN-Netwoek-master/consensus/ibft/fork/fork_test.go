@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/tarality/tan-network/consensus/ibft/signer"
+	"github.com/tarality/tan-network/crypto"
 	"github.com/tarality/tan-network/helper/common"
 	testHelper "github.com/tarality/tan-network/helper/tests"
 	"github.com/tarality/tan-network/types"
@@ -335,3 +337,35 @@ func TestIBFTForks_filterByType(t *testing.T) {
 		forks.filterByType(PoS),
 	)
 }
+
+func TestIBFTForks_HeaderHashFunc(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	keyManager := signer.NewECDSAKeyManagerFromKey(key)
+	ibftSigner := signer.NewSigner(keyManager, keyManager)
+
+	header := &types.Header{Number: 2}
+	ibftSigner.InitIBFTExtra(header, validators.NewECDSAValidatorSet(
+		validators.NewECDSAValidator(keyManager.Address()),
+	), nil)
+
+	sealed, err := ibftSigner.WriteProposerSeal(header)
+	assert.NoError(t, err)
+
+	expected, err := ibftSigner.CalculateHeaderHash(sealed)
+	assert.NoError(t, err)
+
+	forks := IBFTForks{
+		{Type: PoA, ValidatorType: validators.ECDSAValidatorType, From: common.JSONNumber{Value: 0}},
+	}
+
+	// the hash doesn't depend on the keys of the validator
+	assert.Equal(t, expected, forks.HeaderHashFunc()(sealed))
+
+	// the header isn't covered by any fork
+	forks[0].From = common.JSONNumber{Value: 3}
+	assert.Equal(t, types.ZeroHash, forks.HeaderHashFunc()(sealed))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/tarality/tan-network/consensus/ibft/hook"
 	"github.com/tarality/tan-network/consensus/ibft/signer"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/state"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
@@ -61,6 +62,7 @@ type ForkManager struct {
 	blockchain     store.HeaderGetter
 	executor       contract.Executor
 	secretsManager secrets.SecretsManager
+	keySigner      keysigner.KeySigner
 
 	// configuration
 	forks     IBFTForks
@@ -79,6 +81,7 @@ func NewForkManager(
 	blockchain store.HeaderGetter,
 	executor contract.Executor,
 	secretManager secrets.SecretsManager,
+	keySigner keysigner.KeySigner,
 	filePath string,
 	epochSize uint64,
 	ibftConfig map[string]interface{},
@@ -93,6 +96,7 @@ func NewForkManager(
 		blockchain:      blockchain,
 		executor:        executor,
 		secretsManager:  secretManager,
+		keySigner:       keySigner,
 		filePath:        filePath,
 		epochSize:       epochSize,
 		forks:           forks,
@@ -238,7 +242,18 @@ func (m *ForkManager) initializeKeyManager(valType validators.ValidatorType) err
 		return nil
 	}
 
-	keyManager, err := signer.NewKeyManagerFromType(m.secretsManager, valType)
+	var (
+		keyManager signer.KeyManager
+		err        error
+	)
+
+	// keys are held by the key signer (e.g. remote signer) if it's set, otherwise they are loaded from secrets manager
+	if m.keySigner != nil {
+		keyManager, err = signer.NewKeyManagerFromSigner(m.keySigner, valType)
+	} else {
+		keyManager, err = signer.NewKeyManagerFromType(m.secretsManager, valType)
+	}
+
 	if err != nil {
 		return err
 	}
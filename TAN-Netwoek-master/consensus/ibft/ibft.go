@@ -1,6 +1,7 @@
 package ibft
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/tarality/tan-network/consensus/ibft/fork"
 	"github.com/tarality/tan-network/consensus/ibft/proto"
 	"github.com/tarality/tan-network/consensus/ibft/signer"
+	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/helper/progress"
 	"github.com/tarality/tan-network/network"
 	"github.com/tarality/tan-network/secrets"
@@ -39,6 +41,8 @@ var (
 	ErrInvalidMixHash             = errors.New("invalid mixhash")
 	ErrInvalidSha3Uncles          = errors.New("invalid sha3 uncles")
 	ErrWrongDifficulty            = errors.New("wrong difficulty")
+
+	errInvalidMaxEmptyBlockInterval = errors.New("invalid max empty block interval provided")
 )
 
 type txPoolInterface interface {
@@ -92,10 +96,33 @@ type backendIBFT struct {
 	quorumSizeBlockNum uint64
 	blockTime          time.Duration // Minimum block generation time in seconds
 
+	// maxEmptyBlockInterval is the maximum time the proposer waits for transactions before proposing an empty block
+	maxEmptyBlockInterval time.Duration
+
 	// Channels
 	closeCh chan struct{} // Channel for closing
 }
 
+// extractMaxEmptyBlockInterval extracts the optional max empty block interval from the engine configuration
+func extractMaxEmptyBlockInterval(config map[string]interface{}) (time.Duration, error) {
+	rawInterval, ok := config[consensus.KeyMaxEmptyBlockInterval]
+	if !ok {
+		return 0, nil
+	}
+
+	intervalJSON, err := json.Marshal(rawInterval)
+	if err != nil {
+		return 0, errInvalidMaxEmptyBlockInterval
+	}
+
+	var interval common.Duration
+	if err := json.Unmarshal(intervalJSON, &interval); err != nil || interval.Duration < 0 {
+		return 0, errInvalidMaxEmptyBlockInterval
+	}
+
+	return interval.Duration, nil
+}
+
 // Factory implements the base consensus Factory method
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
 	// defaults for user set fields in genesis
@@ -124,6 +151,11 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		quorumSizeBlockNum = uint64(readBlockNum)
 	}
 
+	maxEmptyBlockInterval, err := extractMaxEmptyBlockInterval(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	logger := params.Logger.Named("ibft")
 
 	forkManager, err := fork.NewForkManager(
@@ -131,6 +163,7 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		params.Blockchain,
 		params.Executor,
 		params.SecretsManager,
+		params.KeySigner,
 		params.Config.Path,
 		epochSize,
 		params.Config.Config,
@@ -163,6 +196,8 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		quorumSizeBlockNum: quorumSizeBlockNum,
 		blockTime:          time.Duration(params.BlockTime) * time.Second,
 
+		maxEmptyBlockInterval: maxEmptyBlockInterval,
+
 		// Channels
 		closeCh: make(chan struct{}),
 	}
@@ -306,6 +341,7 @@ func (i *backendIBFT) startConsensus() {
 		i.txpool.SetSealing(isValidator)
 
 		if isValidator {
+			i.consensus.ExtendRoundTimeout(i.blockTime + i.emptyBlockRoundTimeout(pending))
 			sequenceCh = i.consensus.runSequence(pending)
 		}
 
@@ -508,6 +544,16 @@ func (i *backendIBFT) GetEpoch(number uint64) uint64 {
 	return number/i.epochSize + 1
 }
 
+// emptyBlockRoundTimeout returns the time the proposer of the given height may wait for transactions,
+// which the round timeout of the sequence is extended by
+func (i *backendIBFT) emptyBlockRoundTimeout(height uint64) time.Duration {
+	if i.IsLastOfEpoch(height) {
+		return 0
+	}
+
+	return consensus.EmptyBlockRoundTimeout(i.txpool, i.maxEmptyBlockInterval)
+}
+
 // IsLastOfEpoch checks if the block number is the last of the epoch
 func (i *backendIBFT) IsLastOfEpoch(number uint64) bool {
 	return number > 0 && number%i.epochSize == 0
@@ -554,6 +600,16 @@ func (i *backendIBFT) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+// GetUptimeProvider returns an instance of ValidatorUptimeProvider
+func (i *backendIBFT) GetUptimeProvider() consensus.ValidatorUptimeProvider {
+	return nil
+}
+
+// GetFinalizedBlockNumber returns the head block number, since IBFT provides instant finality
+func (i *backendIBFT) GetFinalizedBlockNumber(head uint64) uint64 {
+	return head
+}
+
 // FilterExtra is the implementation of Consensus interface
 func (i *backendIBFT) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
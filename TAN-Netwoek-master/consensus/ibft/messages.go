@@ -4,6 +4,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	protoIBFT "github.com/tarality/0xTaral/messages/proto"
+	"github.com/tarality/tan-network/secrets/keysigner"
 )
 
 func (i *backendIBFT) signMessage(msg *protoIBFT.Message) *protoIBFT.Message {
@@ -12,7 +13,7 @@ func (i *backendIBFT) signMessage(msg *protoIBFT.Message) *protoIBFT.Message {
 		return nil
 	}
 
-	if msg.Signature, err = i.currentSigner.SignIBFTMessage(raw); err != nil {
+	if msg.Signature, err = i.currentSigner.SignIBFTMessage(raw, keysigner.IBFTMessageMetadata(msg, raw)); err != nil {
 		return nil
 	}
 
@@ -67,7 +68,14 @@ func (i *backendIBFT) BuildPrepareMessage(proposalHash []byte, view *protoIBFT.V
 }
 
 func (i *backendIBFT) BuildCommitMessage(proposalHash []byte, view *protoIBFT.View) *protoIBFT.Message {
-	committedSeal, err := i.currentSigner.CreateCommittedSeal(proposalHash)
+	meta, err := keysigner.CommittedSealMetadata(view, proposalHash)
+	if err != nil {
+		i.logger.Error("Unable to build commit message, %v", err)
+
+		return nil
+	}
+
+	committedSeal, err := i.currentSigner.CreateCommittedSeal(proposalHash, meta)
 	if err != nil {
 		i.logger.Error("Unable to build commit message, %v", err)
 
@@ -3,7 +3,9 @@ package ibft
 import (
 	"testing"
 
+	"github.com/tarality/0xTaral/messages/proto"
 	"github.com/tarality/tan-network/consensus/ibft/signer"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -90,7 +92,10 @@ func TestSign_CommittedSeals(t *testing.T) {
 				),
 			)
 
-			seal, err := signer.CreateCommittedSeal(h.Hash.Bytes())
+			meta, err := keysigner.CommittedSealMetadata(&proto.View{Height: h.Number}, h.Hash.Bytes())
+			assert.NoError(t, err)
+
+			seal, err := signer.CreateCommittedSeal(h.Hash.Bytes(), meta)
 
 			assert.NoError(t, err)
 
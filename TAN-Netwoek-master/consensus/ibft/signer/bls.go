@@ -9,16 +9,16 @@ import (
 	"github.com/tarality/fastrlp"
 	"github.com/tarality/tan-network/crypto"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 )
 
-// BLSKeyManager is a module that holds ECDSA and BLS keys
-// and implements methods of signing by these keys
+// BLSKeyManager is a module that signs by ECDSA and BLS keys
+// which are held by the KeySigner
 type BLSKeyManager struct {
-	ecdsaKey *ecdsa.PrivateKey
-	blsKey   *bls_sig.SecretKey
-	address  types.Address
+	signer  keysigner.KeySigner
+	address types.Address
 }
 
 // NewBLSKeyManager initializes BLSKeyManager by the ECDSA key and BLS key which are loaded from SecretsManager
@@ -38,10 +38,18 @@ func NewBLSKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
 
 // NewBLSKeyManagerFromKeys initializes BLSKeyManager from the given ECDSA and BLS keys
 func NewBLSKeyManagerFromKeys(ecdsaKey *ecdsa.PrivateKey, blsKey *bls_sig.SecretKey) KeyManager {
+	return NewBLSKeyManagerFromSigner(keysigner.NewLocalSigner(
+		keysigner.NewECDSAKey(ecdsaKey),
+		keysigner.NewIBFTBLSKey(blsKey),
+		nil,
+	))
+}
+
+// NewBLSKeyManagerFromSigner initializes BLSKeyManager from the given KeySigner
+func NewBLSKeyManagerFromSigner(signer keysigner.KeySigner) KeyManager {
 	return &BLSKeyManager{
-		ecdsaKey: ecdsaKey,
-		blsKey:   blsKey,
-		address:  crypto.PubKeyToAddress(&ecdsaKey.PublicKey),
+		signer:  signer,
+		address: signer.Address(),
 	}
 }
 
@@ -65,12 +73,16 @@ func (s *BLSKeyManager) NewEmptyCommittedSeals() Seals {
 	return &AggregatedSeal{}
 }
 
-func (s *BLSKeyManager) SignProposerSeal(data []byte) ([]byte, error) {
-	return crypto.Sign(s.ecdsaKey, data)
+func (s *BLSKeyManager) SignProposerSeal(headerPreimage []byte) ([]byte, error) {
+	return s.signer.Sign(&keysigner.SignRequest{
+		Metadata: keysigner.Metadata{Kind: keysigner.KindProposerSeal},
+		Scheme:   keysigner.SchemeECDSA,
+		Preimage: headerPreimage,
+	})
 }
 
-func (s *BLSKeyManager) SignCommittedSeal(data []byte) ([]byte, error) {
-	return crypto.SignByBLS(s.blsKey, data)
+func (s *BLSKeyManager) SignCommittedSeal(data []byte, meta keysigner.Metadata) ([]byte, error) {
+	return s.sign(keysigner.SchemeBLS, data, meta)
 }
 
 func (s *BLSKeyManager) VerifyCommittedSeal(
@@ -150,14 +162,23 @@ func (s *BLSKeyManager) VerifyCommittedSeals(
 	return verifyBLSCommittedSealsImpl(committedSeal, message, vals)
 }
 
-func (s *BLSKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
-	return crypto.Sign(s.ecdsaKey, msg)
+func (s *BLSKeyManager) SignIBFTMessage(msg []byte, meta keysigner.Metadata) ([]byte, error) {
+	return s.sign(keysigner.SchemeECDSA, msg, meta)
 }
 
 func (s *BLSKeyManager) Ecrecover(sig, digest []byte) (types.Address, error) {
 	return ecrecover(sig, digest)
 }
 
+// sign signs the given digest by the key of the given scheme through the KeySigner
+func (s *BLSKeyManager) sign(scheme keysigner.Scheme, digest []byte, meta keysigner.Metadata) ([]byte, error) {
+	return s.signer.Sign(&keysigner.SignRequest{
+		Metadata: meta,
+		Scheme:   scheme,
+		Digest:   digest,
+	})
+}
+
 type AggregatedSeal struct {
 	Bitmap    *big.Int
 	Signature []byte
@@ -4,25 +4,35 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"math/big"
+	"sync"
 	"testing"
 
+	"github.com/tarality/0xTaral/messages/proto"
 	"github.com/tarality/tan-network/crypto"
 	"github.com/tarality/tan-network/helper/hex"
 	testHelper "github.com/tarality/tan-network/helper/tests"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
 	"github.com/stretchr/testify/assert"
 )
 
+// testBLSKeys holds BLS keys of the key managers created by newTestBLSKeyManager,
+// since key managers don't hold the keys themselves
+var testBLSKeys sync.Map
+
 func newTestBLSKeyManager(t *testing.T) (KeyManager, *ecdsa.PrivateKey, *bls_sig.SecretKey) {
 	t.Helper()
 
 	testECDSAKey, _ := newTestECDSAKey(t)
 	testBLSKey, _ := newTestBLSKey(t)
 
-	return NewBLSKeyManagerFromKeys(testECDSAKey, testBLSKey), testECDSAKey, testBLSKey
+	keyManager := NewBLSKeyManagerFromKeys(testECDSAKey, testBLSKey)
+	testBLSKeys.Store(keyManager.Address(), testBLSKey)
+
+	return keyManager, testECDSAKey, testBLSKey
 }
 
 func testAggregateBLSSignatureBytes(t *testing.T, sigs ...[]byte) []byte {
@@ -52,7 +62,10 @@ func testBLSKeyManagerToBLSValidator(t *testing.T, keyManager KeyManager) *valid
 	blsKeyManager, ok := keyManager.(*BLSKeyManager)
 	assert.True(t, ok)
 
-	pubkeyBytes, err := crypto.BLSSecretKeyToPubkeyBytes(blsKeyManager.blsKey)
+	blsKey, ok := testBLSKeys.Load(blsKeyManager.Address())
+	assert.True(t, ok)
+
+	pubkeyBytes, err := crypto.BLSSecretKeyToPubkeyBytes(blsKey.(*bls_sig.SecretKey))
 	assert.NoError(t, err)
 
 	return validators.NewBLSValidator(
@@ -61,13 +74,17 @@ func testBLSKeyManagerToBLSValidator(t *testing.T, keyManager KeyManager) *valid
 	)
 }
 
-func testCreateAggregatedSignature(t *testing.T, msg []byte, keyManagers ...KeyManager) []byte {
+// testCreateAggregatedSignature aggregates the committed seals of the given proposal hash
+func testCreateAggregatedSignature(t *testing.T, proposalHash []byte, keyManagers ...KeyManager) []byte {
 	t.Helper()
 
 	signatures := make([][]byte, len(keyManagers))
 
+	meta, err := keysigner.CommittedSealMetadata(&proto.View{Height: 9}, proposalHash)
+	assert.NoError(t, err)
+
 	for idx, km := range keyManagers {
-		sig, err := km.SignCommittedSeal(msg)
+		sig, err := km.SignCommittedSeal(crypto.Keccak256(wrapCommitHash(proposalHash)), meta)
 		assert.NoError(t, err)
 
 		signatures[idx] = sig
@@ -135,12 +152,8 @@ func TestNewBLSKeyManager(t *testing.T) {
 					return nil, nil
 				},
 			},
-			expectedResult: &BLSKeyManager{
-				ecdsaKey: testECDSAKey,
-				blsKey:   testBLSKey,
-				address:  crypto.PubKeyToAddress(&testECDSAKey.PublicKey),
-			},
-			expectedErr: nil,
+			expectedResult: NewBLSKeyManagerFromKeys(testECDSAKey, testBLSKey),
+			expectedErr:    nil,
 		},
 		{
 			name: "should return error if getOrCreateECDSAKey returns error",
@@ -217,9 +230,12 @@ func TestNewECDSAKeyManagerFromKeys(t *testing.T) {
 	assert.Equal(
 		t,
 		&BLSKeyManager{
-			ecdsaKey: testKey,
-			blsKey:   testBLSKey,
-			address:  crypto.PubKeyToAddress(&testKey.PublicKey),
+			signer: keysigner.NewLocalSigner(
+				keysigner.NewECDSAKey(testKey),
+				keysigner.NewIBFTBLSKey(testBLSKey),
+				nil,
+			),
+			address: crypto.PubKeyToAddress(&testKey.PublicKey),
 		},
 		NewBLSKeyManagerFromKeys(testKey, testBLSKey),
 	)
@@ -279,11 +295,9 @@ func TestBLSKeyManagerSignProposerSeal(t *testing.T) {
 	t.Parallel()
 
 	blsKeyManager, _, _ := newTestBLSKeyManager(t)
-	msg := crypto.Keccak256(
-		hex.MustDecodeHex(testHeaderHashHex),
-	)
+	msg := crypto.Keccak256(calculateHeaderHash(testHeader).Bytes())
 
-	proposerSeal, err := blsKeyManager.SignProposerSeal(msg)
+	proposerSeal, err := blsKeyManager.SignProposerSeal(calculateHeaderPreimage(testHeader))
 	assert.NoError(t, err)
 
 	recoveredAddress, err := ecrecover(proposerSeal, msg)
@@ -309,7 +323,7 @@ func TestBLSKeyManagerSignCommittedSeal(t *testing.T) {
 		),
 	)
 
-	proposerSealBytes, err := ecdsaKeyManager.SignCommittedSeal(msg)
+	proposerSealBytes, err := ecdsaKeyManager.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	proposerSeal, err := crypto.UnmarshalBLSSignature(proposerSealBytes)
@@ -337,10 +351,10 @@ func TestBLSKeyManagerVerifyCommittedSeal(t *testing.T) {
 		),
 	)
 
-	correctSignature, err := blsKeyManager1.SignCommittedSeal(msg)
+	correctSignature, err := blsKeyManager1.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
-	wrongSignature, err := blsKeyManager2.SignCommittedSeal(msg)
+	wrongSignature, err := blsKeyManager2.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	blsPublicKey1, err := blsSecretKey1.GetPublicKey()
@@ -434,12 +448,12 @@ func TestBLSKeyManagerGenerateCommittedSeals(t *testing.T) {
 		),
 	)
 
-	correctCommittedSeal, err := blsKeyManager1.SignCommittedSeal(msg)
+	correctCommittedSeal, err := blsKeyManager1.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	aggregatedBLSSigBytes := testCreateAggregatedSignature(
 		t,
-		msg,
+		hex.MustDecodeHex(testHeaderHashHex),
 		blsKeyManager1,
 	)
 
@@ -522,7 +536,7 @@ func TestBLSKeyManagerVerifyCommittedSeals(t *testing.T) {
 
 	aggregatedBLSSigBytes := testCreateAggregatedSignature(
 		t,
-		msg,
+		hex.MustDecodeHex(testHeaderHashHex),
 		blsKeyManager1,
 	)
 
@@ -591,9 +605,10 @@ func TestBLSKeyManagerSignIBFTMessageAndEcrecover(t *testing.T) {
 	t.Parallel()
 
 	blsKeyManager, _, _ := newTestBLSKeyManager(t)
-	msg := crypto.Keccak256([]byte("message"))
+	raw, meta := newTestIBFTMessage(t)
+	msg := crypto.Keccak256(raw)
 
-	proposerSeal, err := blsKeyManager.SignIBFTMessage(msg)
+	proposerSeal, err := blsKeyManager.SignIBFTMessage(msg, meta)
 	assert.NoError(t, err)
 
 	recoveredAddress, err := blsKeyManager.Ecrecover(proposerSeal, msg)
@@ -618,10 +633,10 @@ func Test_getBLSSignatures(t *testing.T) {
 		),
 	)
 
-	validatorCommittedSeal, err := validatorKeyManager.SignCommittedSeal(msg)
+	validatorCommittedSeal, err := validatorKeyManager.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
-	nonValidatorCommittedSeal, err := nonValidatorKeyManager.SignCommittedSeal(msg)
+	nonValidatorCommittedSeal, err := nonValidatorKeyManager.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	wrongCommittedSeal := []byte("fake committed seal")
@@ -749,7 +764,7 @@ func Test_getBLSSignatures(t *testing.T) {
 				continue
 			}
 
-			committedSeal, err := blsKeyManager.SignCommittedSeal(msg)
+			committedSeal, err := blsKeyManager.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 			assert.NoError(t, err)
 
 			// set committed seals to sealMap
@@ -894,14 +909,14 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 
 	correctAggregatedSig := testCreateAggregatedSignature(
 		t,
-		msg,
+		hex.MustDecodeHex(testHeaderHashHex),
 		validatorKeyManager1,
 		validatorKeyManager2,
 	)
 
 	wrongAggregatedSig := testCreateAggregatedSignature(
 		t,
-		[]byte("fake"),
+		crypto.Keccak256([]byte("fake")),
 		validatorKeyManager1,
 		validatorKeyManager2,
 	)
@@ -5,16 +5,16 @@ import (
 	"fmt"
 
 	"github.com/tarality/fastrlp"
-	"github.com/tarality/tan-network/crypto"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 )
 
-// ECDSAKeyManager is a module that holds ECDSA key
-// and implements methods of signing by this key
+// ECDSAKeyManager is a module that signs by ECDSA key
+// which is held by the KeySigner
 type ECDSAKeyManager struct {
-	key     *ecdsa.PrivateKey
+	signer  keysigner.KeySigner
 	address types.Address
 }
 
@@ -30,9 +30,14 @@ func NewECDSAKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
 
 // NewECDSAKeyManagerFromKey initializes ECDSAKeyManager from the given ECDSA key
 func NewECDSAKeyManagerFromKey(key *ecdsa.PrivateKey) KeyManager {
+	return NewECDSAKeyManagerFromSigner(keysigner.NewLocalSigner(keysigner.NewECDSAKey(key), nil, nil))
+}
+
+// NewECDSAKeyManagerFromSigner initializes ECDSAKeyManager from the given KeySigner
+func NewECDSAKeyManagerFromSigner(signer keysigner.KeySigner) KeyManager {
 	return &ECDSAKeyManager{
-		key:     key,
-		address: crypto.PubKeyToAddress(&key.PublicKey),
+		signer:  signer,
+		address: signer.Address(),
 	}
 }
 
@@ -56,14 +61,18 @@ func (s *ECDSAKeyManager) NewEmptyCommittedSeals() Seals {
 	return &SerializedSeal{}
 }
 
-// SignProposerSeal signs the given message by ECDSA key the ECDSAKeyManager holds for ProposerSeal
-func (s *ECDSAKeyManager) SignProposerSeal(message []byte) ([]byte, error) {
-	return crypto.Sign(s.key, message)
+// SignProposerSeal signs the hash of the given encoded header by ECDSA key the ECDSAKeyManager holds for ProposerSeal
+func (s *ECDSAKeyManager) SignProposerSeal(headerPreimage []byte) ([]byte, error) {
+	return s.signer.Sign(&keysigner.SignRequest{
+		Metadata: keysigner.Metadata{Kind: keysigner.KindProposerSeal},
+		Scheme:   keysigner.SchemeECDSA,
+		Preimage: headerPreimage,
+	})
 }
 
 // SignProposerSeal signs the given message by ECDSA key the ECDSAKeyManager holds for committed seal
-func (s *ECDSAKeyManager) SignCommittedSeal(message []byte) ([]byte, error) {
-	return crypto.Sign(s.key, message)
+func (s *ECDSAKeyManager) SignCommittedSeal(message []byte, meta keysigner.Metadata) ([]byte, error) {
+	return s.sign(message, meta)
 }
 
 // VerifyCommittedSeal verifies a committed seal
@@ -129,14 +138,23 @@ func (s *ECDSAKeyManager) VerifyCommittedSeals(
 	return s.verifyCommittedSealsImpl(committedSeal, digest, vals)
 }
 
-func (s *ECDSAKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
-	return crypto.Sign(s.key, msg)
+func (s *ECDSAKeyManager) SignIBFTMessage(msg []byte, meta keysigner.Metadata) ([]byte, error) {
+	return s.sign(msg, meta)
 }
 
 func (s *ECDSAKeyManager) Ecrecover(sig, digest []byte) (types.Address, error) {
 	return ecrecover(sig, digest)
 }
 
+// sign signs the given digest by ECDSA key through the KeySigner
+func (s *ECDSAKeyManager) sign(digest []byte, meta keysigner.Metadata) ([]byte, error) {
+	return s.signer.Sign(&keysigner.SignRequest{
+		Metadata: meta,
+		Scheme:   keysigner.SchemeECDSA,
+		Digest:   digest,
+	})
+}
+
 func (s *ECDSAKeyManager) verifyCommittedSealsImpl(
 	committedSeal *SerializedSeal,
 	msg []byte,
@@ -9,6 +9,7 @@ import (
 	"github.com/tarality/tan-network/helper/hex"
 	testHelper "github.com/tarality/tan-network/helper/tests"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 	"github.com/stretchr/testify/assert"
@@ -55,11 +56,8 @@ func TestNewECDSAKeyManager(t *testing.T) {
 					return testKeyEncoded, nil
 				},
 			},
-			expectedResult: &ECDSAKeyManager{
-				key:     testKey,
-				address: crypto.PubKeyToAddress(&testKey.PublicKey),
-			},
-			expectedErr: nil,
+			expectedResult: NewECDSAKeyManagerFromKey(testKey),
+			expectedErr:    nil,
 		},
 		{
 			name: "should return error if getOrCreateECDSAKey returns error",
@@ -101,7 +99,7 @@ func TestNewECDSAKeyManagerFromKey(t *testing.T) {
 	assert.Equal(
 		t,
 		&ECDSAKeyManager{
-			key:     testKey,
+			signer:  keysigner.NewLocalSigner(keysigner.NewECDSAKey(testKey), nil, nil),
 			address: crypto.PubKeyToAddress(&testKey.PublicKey),
 		},
 		NewECDSAKeyManagerFromKey(testKey),
@@ -161,11 +159,9 @@ func TestECDSAKeyManagerSignProposerSeal(t *testing.T) {
 	t.Parallel()
 
 	ecdsaKeyManager, _ := newTestECDSAKeyManager(t)
-	msg := crypto.Keccak256(
-		hex.MustDecodeHex(testHeaderHashHex),
-	)
+	msg := crypto.Keccak256(calculateHeaderHash(testHeader).Bytes())
 
-	proposerSeal, err := ecdsaKeyManager.SignProposerSeal(msg)
+	proposerSeal, err := ecdsaKeyManager.SignProposerSeal(calculateHeaderPreimage(testHeader))
 	assert.NoError(t, err)
 
 	recoveredAddress, err := ecrecover(proposerSeal, msg)
@@ -188,7 +184,7 @@ func TestECDSAKeyManagerSignCommittedSeal(t *testing.T) {
 		),
 	)
 
-	proposerSeal, err := ecdsaKeyManager.SignCommittedSeal(msg)
+	proposerSeal, err := ecdsaKeyManager.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	recoveredAddress, err := ecrecover(proposerSeal, msg)
@@ -213,10 +209,10 @@ func TestECDSAKeyManagerVerifyCommittedSeal(t *testing.T) {
 		),
 	)
 
-	correctSignature, err := ecdsaKeyManager1.SignCommittedSeal(msg)
+	correctSignature, err := ecdsaKeyManager1.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
-	wrongSignature, err := ecdsaKeyManager2.SignCommittedSeal(msg)
+	wrongSignature, err := ecdsaKeyManager2.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -304,7 +300,7 @@ func TestECDSAKeyManagerGenerateCommittedSeals(t *testing.T) {
 		),
 	)
 
-	correctCommittedSeal, err := ecdsaKeyManager1.SignCommittedSeal(msg)
+	correctCommittedSeal, err := ecdsaKeyManager1.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	wrongCommittedSeal := []byte("fake")
@@ -363,7 +359,7 @@ func TestECDSAKeyManagerVerifyCommittedSeals(t *testing.T) {
 		),
 	)
 
-	correctCommittedSeal, err := ecdsaKeyManager1.SignCommittedSeal(msg)
+	correctCommittedSeal, err := ecdsaKeyManager1.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -428,9 +424,10 @@ func TestECDSAKeyManagerSignIBFTMessageAndEcrecover(t *testing.T) {
 	t.Parallel()
 
 	ecdsaKeyManager, _ := newTestECDSAKeyManager(t)
-	msg := crypto.Keccak256([]byte("message"))
+	raw, meta := newTestIBFTMessage(t)
+	msg := crypto.Keccak256(raw)
 
-	proposerSeal, err := ecdsaKeyManager.SignIBFTMessage(msg)
+	proposerSeal, err := ecdsaKeyManager.SignIBFTMessage(msg, meta)
 	assert.NoError(t, err)
 
 	recoveredAddress, err := ecdsaKeyManager.Ecrecover(proposerSeal, msg)
@@ -455,10 +452,10 @@ func TestECDSAKeyManager_verifyCommittedSealsImpl(t *testing.T) {
 		),
 	)
 
-	correctCommittedSeal, err := ecdsaKeyManager1.SignCommittedSeal(msg)
+	correctCommittedSeal, err := ecdsaKeyManager1.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
-	nonValidatorsCommittedSeal, err := ecdsaKeyManager2.SignCommittedSeal(msg)
+	nonValidatorsCommittedSeal, err := ecdsaKeyManager2.SignCommittedSeal(msg, testCommittedSealMetadata(t))
 	assert.NoError(t, err)
 
 	wrongSignature := []byte("fake")
@@ -8,9 +8,9 @@ import (
 	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
 	"github.com/tarality/fastrlp"
 	"github.com/tarality/tan-network/crypto"
-	"github.com/tarality/tan-network/helper/keccak"
 	"github.com/tarality/tan-network/secrets"
 	"github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 )
@@ -61,6 +61,11 @@ func getOrCreateBLSKey(manager secrets.SecretsManager) (*bls_sig.SecretKey, erro
 
 // calculateHeaderHash is hash calculation of header for IBFT
 func calculateHeaderHash(h *types.Header) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(calculateHeaderPreimage(h)))
+}
+
+// calculateHeaderPreimage returns the RLP encoded header the IBFT header hash is calculated from
+func calculateHeaderPreimage(h *types.Header) []byte {
 	arena := fastrlp.DefaultArenaPool.Get()
 	defer fastrlp.DefaultArenaPool.Put(arena)
 
@@ -88,9 +93,7 @@ func calculateHeaderHash(h *types.Header) types.Hash {
 	// h.BlockReward.SetInt64(1000)
 
 	// fmt.Println("------------line no vvset-------", h.BlockReward)
-	buf := keccak.Keccak256Rlp(nil, vv)
-
-	return types.BytesToHash(buf)
+	return vv.MarshalTo(nil)
 }
 
 // ecrecover recovers signer address from the given digest and signature
@@ -118,6 +121,34 @@ func NewKeyManagerFromType(
 	}
 }
 
+// NewKeylessKeyManager creates KeyManager of the given type without keys,
+// which can only parse IBFT Extra and calculate header hashes
+func NewKeylessKeyManager(validatorType validators.ValidatorType) (KeyManager, error) {
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		return &ECDSAKeyManager{}, nil
+	case validators.BLSValidatorType:
+		return &BLSKeyManager{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+}
+
+// NewKeyManagerFromSigner creates KeyManager signing through the given KeySigner based on the given type
+func NewKeyManagerFromSigner(
+	keySigner keysigner.KeySigner,
+	validatorType validators.ValidatorType,
+) (KeyManager, error) {
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		return NewECDSAKeyManagerFromSigner(keySigner), nil
+	case validators.BLSValidatorType:
+		return NewBLSKeyManagerFromSigner(keySigner), nil
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+}
+
 // verifyIBFTExtraSize checks whether header.ExtraData has enough size for IBFT Extra
 func verifyIBFTExtraSize(header *types.Header) error {
 	if len(header.ExtraData) < IstanbulExtraVanity {
@@ -6,13 +6,16 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/tarality/0xTaral/messages/proto"
 	"github.com/tarality/tan-network/crypto"
 	"github.com/tarality/tan-network/helper/hex"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
 	"github.com/stretchr/testify/assert"
+	protobuf "google.golang.org/protobuf/proto"
 )
 
 var (
@@ -54,6 +57,35 @@ func newTestBLSKey(t *testing.T) (*bls_sig.SecretKey, []byte) {
 	return testKey, testKeyEncoded
 }
 
+// testCommittedSealMetadata returns metadata of the committed seal of testHeaderHashHex,
+// whose digest is crypto.Keccak256(wrapCommitHash(testHeaderHash))
+func testCommittedSealMetadata(t *testing.T) keysigner.Metadata {
+	t.Helper()
+
+	meta, err := keysigner.CommittedSealMetadata(&proto.View{Height: 9}, hex.MustDecodeHex(testHeaderHashHex))
+	assert.NoError(t, err)
+
+	return meta
+}
+
+// newTestIBFTMessage returns the encoded IBFT consensus message and its metadata
+func newTestIBFTMessage(t *testing.T) ([]byte, keysigner.Metadata) {
+	t.Helper()
+
+	msg := &proto.Message{
+		View: &proto.View{Height: 9},
+		Type: proto.MessageType_PREPARE,
+		Payload: &proto.Message_PrepareData{
+			PrepareData: &proto.PrepareMessage{ProposalHash: hex.MustDecodeHex(testHeaderHashHex)},
+		},
+	}
+
+	raw, err := protobuf.Marshal(msg)
+	assert.NoError(t, err)
+
+	return raw, keysigner.IBFTMessageMetadata(msg, raw)
+}
+
 // Make sure the target function always returns the same result
 func Test_wrapCommitHash(t *testing.T) {
 	t.Parallel()
@@ -73,7 +105,7 @@ func Test_wrapCommitHash(t *testing.T) {
 	assert.Equal(t, expectedOutput, output)
 }
 
-//nolint
+// nolint
 func Test_getOrCreateECDSAKey(t *testing.T) {
 	t.Parallel()
 
@@ -184,7 +216,7 @@ func Test_getOrCreateECDSAKey(t *testing.T) {
 	}
 }
 
-//nolint
+// nolint
 func Test_getOrCreateBLSKey(t *testing.T) {
 	t.Parallel()
 
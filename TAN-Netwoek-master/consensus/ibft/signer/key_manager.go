@@ -1,6 +1,7 @@
 package signer
 
 import (
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 )
@@ -15,10 +16,10 @@ type KeyManager interface {
 	NewEmptyValidators() validators.Validators
 	// NewEmptyCommittedSeals creates empty committed seals the Signer expects
 	NewEmptyCommittedSeals() Seals
-	// SignProposerSeal creates a signature for ProposerSeal
-	SignProposerSeal(hash []byte) ([]byte, error)
+	// SignProposerSeal creates a signature for ProposerSeal of the given RLP encoded header (without the seals)
+	SignProposerSeal(headerPreimage []byte) ([]byte, error)
 	// SignCommittedSeal creates a signature for committed seal
-	SignCommittedSeal(hash []byte) ([]byte, error)
+	SignCommittedSeal(hash []byte, meta keysigner.Metadata) ([]byte, error)
 	// VerifyCommittedSeal verifies a committed seal
 	VerifyCommittedSeal(vals validators.Validators, signer types.Address, sig, hash []byte) error
 	// GenerateCommittedSeals creates CommittedSeals from committed seals
@@ -26,7 +27,7 @@ type KeyManager interface {
 	// VerifyCommittedSeals verifies CommittedSeals
 	VerifyCommittedSeals(seals Seals, hash []byte, vals validators.Validators) (int, error)
 	// SignIBFTMessage signs for arbitrary bytes message
-	SignIBFTMessage(msg []byte) ([]byte, error)
+	SignIBFTMessage(msg []byte, meta keysigner.Metadata) ([]byte, error)
 	// Ecrecover recovers address from signature and message
 	Ecrecover(sig []byte, msg []byte) (types.Address, error)
 }
@@ -2,6 +2,7 @@ package signer
 
 import (
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 )
@@ -61,7 +62,7 @@ func (m *MockKeyManager) SignProposerSeal(hash []byte) ([]byte, error) {
 	return m.SignProposerSealFunc(hash)
 }
 
-func (m *MockKeyManager) SignCommittedSeal(hash []byte) ([]byte, error) {
+func (m *MockKeyManager) SignCommittedSeal(hash []byte, _ keysigner.Metadata) ([]byte, error) {
 	return m.SignCommittedSealFunc(hash)
 }
 
@@ -80,7 +81,7 @@ func (m *MockKeyManager) VerifyCommittedSeals(seals Seals, hash []byte, vals val
 	return m.VerifyCommittedSealsFunc(seals, hash, vals)
 }
 
-func (m *MockKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
+func (m *MockKeyManager) SignIBFTMessage(msg []byte, _ keysigner.Metadata) ([]byte, error) {
 	return m.SignIBFTMessageFunc(msg)
 }
 
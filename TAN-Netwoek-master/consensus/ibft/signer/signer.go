@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 )
@@ -38,7 +39,7 @@ type Signer interface {
 	EcrecoverFromHeader(*types.Header) (types.Address, error)
 
 	// CommittedSeal
-	CreateCommittedSeal([]byte) ([]byte, error)
+	CreateCommittedSeal([]byte, keysigner.Metadata) ([]byte, error)
 	VerifyCommittedSeal(validators.Validators, types.Address, []byte, []byte) error
 
 	// CommittedSeals
@@ -64,7 +65,7 @@ type Signer interface {
 	) error
 
 	// IBFTMessage
-	SignIBFTMessage([]byte) ([]byte, error)
+	SignIBFTMessage([]byte, keysigner.Metadata) ([]byte, error)
 	EcrecoverFromIBFTMessage([]byte, []byte) (types.Address, error)
 
 	// Hash of Header
@@ -139,14 +140,13 @@ func (s *SignerImpl) GetIBFTExtra(header *types.Header) (*IstanbulExtra, error)
 
 // WriteProposerSeal signs and set ProposerSeal into IBFT Extra of the header
 func (s *SignerImpl) WriteProposerSeal(header *types.Header) (*types.Header, error) {
-	hash, err := s.CalculateHeaderHash(header)
+	filteredHeader, err := s.FilterHeaderForHash(header)
 	if err != nil {
 		return nil, err
 	}
 
-	seal, err := s.keyManager.SignProposerSeal(
-		crypto.Keccak256(hash.Bytes()),
-	)
+	// the signer derives the signed digest (keccak256 of the header hash) from the encoded header
+	seal, err := s.keyManager.SignProposerSeal(calculateHeaderPreimage(filteredHeader))
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +170,7 @@ func (s *SignerImpl) EcrecoverFromHeader(header *types.Header) (types.Address, e
 }
 
 // CreateCommittedSeal returns CommittedSeal from given hash
-func (s *SignerImpl) CreateCommittedSeal(hash []byte) ([]byte, error) {
+func (s *SignerImpl) CreateCommittedSeal(hash []byte, meta keysigner.Metadata) ([]byte, error) {
 	return s.keyManager.SignCommittedSeal(
 		// Of course, this keccaking of an extended array is not according to the IBFT 2.0 spec,
 		// but almost nothing in this legacy signing package is. This is kept
@@ -179,6 +179,7 @@ func (s *SignerImpl) CreateCommittedSeal(hash []byte) ([]byte, error) {
 		crypto.Keccak256(
 			wrapCommitHash(hash[:]),
 		),
+		meta,
 	)
 }
 
@@ -300,8 +301,8 @@ func (s *SignerImpl) VerifyParentCommittedSeals(
 }
 
 // SignIBFTMessage signs arbitrary message
-func (s *SignerImpl) SignIBFTMessage(msg []byte) ([]byte, error) {
-	return s.keyManager.SignIBFTMessage(crypto.Keccak256(msg))
+func (s *SignerImpl) SignIBFTMessage(msg []byte, meta keysigner.Metadata) ([]byte, error) {
+	return s.keyManager.SignIBFTMessage(crypto.Keccak256(msg), meta)
 }
 
 // EcrecoverFromIBFTMessage recovers signer address from given signature and digest
@@ -8,6 +8,7 @@ import (
 
 	"github.com/tarality/tan-network/crypto"
 	testHelper "github.com/tarality/tan-network/helper/tests"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/tarality/tan-network/validators"
 	"github.com/stretchr/testify/assert"
@@ -697,7 +698,7 @@ func TestSignerCreateCommittedSeal(t *testing.T) {
 		},
 	)
 
-	res, err := signer.CreateCommittedSeal(hash)
+	res, err := signer.CreateCommittedSeal(hash, keysigner.Metadata{})
 
 	assert.Equal(t, sig, res)
 	assert.NoError(t, err)
@@ -1180,7 +1181,7 @@ func TestSignerSignIBFTMessage(t *testing.T) {
 		},
 	}
 
-	res, err := signer.SignIBFTMessage(msg)
+	res, err := signer.SignIBFTMessage(msg, keysigner.Metadata{})
 
 	assert.Equal(
 		t,
@@ -1230,7 +1231,7 @@ func TestEcrecoverFromIBFTMessage(t *testing.T) {
 func TestSignerSignIBFTMessageAndEcrecoverFromIBFTMessage(t *testing.T) {
 	t.Parallel()
 
-	msg := []byte("message")
+	msg, meta := newTestIBFTMessage(t)
 
 	ecdsaKeyManager, _ := newTestECDSAKeyManager(t)
 	blsKeyManager, _, _ := newTestBLSKeyManager(t)
@@ -1257,7 +1258,7 @@ func TestSignerSignIBFTMessageAndEcrecoverFromIBFTMessage(t *testing.T) {
 
 			signer := newTestSingleKeyManagerSigner(test.keyManager)
 
-			sig, err := signer.SignIBFTMessage(msg)
+			sig, err := signer.SignIBFTMessage(msg, meta)
 			assert.NoError(t, err)
 
 			recovered, err := signer.EcrecoverFromIBFTMessage(sig, msg)
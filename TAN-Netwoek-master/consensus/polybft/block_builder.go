@@ -162,6 +162,12 @@ func (b *BlockBuilder) Fill() {
 	blockTimer := time.NewTimer(b.params.BlockTime)
 
 	b.params.TxPool.Prepare(b.params.BaseFee)
+
+	if workers := b.params.Executor.ParallelWorkers; workers > 1 {
+		b.fillParallel(blockTimer, workers)
+
+		return
+	}
 write:
 	for {
 		select {
@@ -186,6 +192,68 @@ write:
 	<-blockTimer.C
 }
 
+// fillParallel fills the block with batches of transactions from the txpool,
+// the transactions of a batch are executed in parallel and written in order
+func (b *BlockBuilder) fillParallel(blockTimer *time.Timer, workers int) {
+write:
+	for {
+		select {
+		case <-blockTimer.C:
+			return
+		default:
+			// the txpool returns a single transaction per account until it's popped
+			txs := make([]*types.Transaction, 0, workers)
+
+			for len(txs) < workers {
+				tx := b.params.TxPool.Peek()
+				if tx == nil {
+					break
+				}
+
+				txs = append(txs, tx)
+			}
+
+			if len(txs) == 0 {
+				break write
+			}
+
+			batch := b.state.ExecuteParallel(txs, workers)
+
+			for i, tx := range txs {
+				finished, err := b.handleWriteResult(tx, b.writeParallelTx(batch, i, tx))
+				if err != nil {
+					b.params.Logger.Debug("Fill transaction error", "hash", tx.Hash, "err", err)
+				}
+
+				if finished {
+					break write
+				}
+			}
+		}
+	}
+
+	//	wait for the timer to expire
+	<-blockTimer.C
+}
+
+// writeParallelTx writes the transaction of the batch executed in parallel to the state
+func (b *BlockBuilder) writeParallelTx(batch *state.ParallelBatch, index int, tx *types.Transaction) error {
+	if tx.Gas > b.params.GasLimit {
+		b.params.Logger.Info("Transaction gas limit exceedes block gas limit", "hash", tx.Hash,
+			"tx gas limit", tx.Gas, "block gas limt", b.params.GasLimit)
+
+		return txpool.ErrBlockLimitExceeded
+	}
+
+	if err := b.state.WriteParallel(batch, index); err != nil {
+		return err
+	}
+
+	b.txns = append(b.txns, tx)
+
+	return nil
+}
+
 // Receipts returns the collection of transaction receipts for given block
 func (b *BlockBuilder) Receipts() []*types.Receipt {
 	return b.state.Receipts()
@@ -196,7 +264,13 @@ func (b *BlockBuilder) writeTxPoolTransaction(tx *types.Transaction) (bool, erro
 		return true, nil
 	}
 
-	if err := b.WriteTx(tx); err != nil {
+	return b.handleWriteResult(tx, b.WriteTx(tx))
+}
+
+// handleWriteResult updates the txpool with the result of writing the transaction,
+// it returns true if the block is full
+func (b *BlockBuilder) handleWriteResult(tx *types.Transaction, err error) (bool, error) {
+	if err != nil {
 		if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 			// stop processing
 			return true, err
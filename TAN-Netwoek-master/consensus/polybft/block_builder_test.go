@@ -158,3 +158,96 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 	assert.False(t, fb.Block.Header.LogsBloom.IsLogInBloom(
 		&types.Log{Address: types.StringToAddress("111177779999")}))
 }
+
+func TestBlockBuilder_FillParallel(t *testing.T) {
+	t.Parallel()
+
+	const chainID = 100
+
+	accounts := [4]*wallet.Account{}
+	for i := range accounts {
+		accounts[i] = generateTestAccount(t)
+	}
+
+	forks := &chain.Forks{}
+	signer := crypto.NewSigner(forks.At(0), chainID)
+	receiver := types.StringToAddress("1234")
+
+	txs := make([]*types.Transaction, len(accounts))
+	balanceMap := map[types.Address]*chain.GenesisAccount{}
+
+	for i, acc := range accounts {
+		balanceMap[types.Address(acc.Ecdsa.Address())] = &chain.GenesisAccount{
+			Balance: ethgo.Ether(1),
+		}
+
+		privateKey, err := acc.GetEcdsaPrivateKey()
+		require.NoError(t, err)
+
+		// the first two transactions conflict on the receiver
+		to := types.Address(acc.Ecdsa.Address())
+		if i < 2 {
+			to = receiver
+		}
+
+		txs[i], err = signer.SignTx(&types.Transaction{
+			Value:    big.NewInt(1_000),
+			GasPrice: big.NewInt(1_000),
+			Gas:      21000,
+			To:       &to,
+		}, privateKey)
+		require.NoError(t, err)
+	}
+
+	buildBlock := func(workers int) *types.FullBlock {
+		logger := hclog.NewNullLogger()
+		executor := state.NewExecutor(&chain.Params{ChainID: chainID, Forks: forks},
+			itrie.NewState(itrie.NewMemoryStorage()), logger)
+		executor.ParallelWorkers = workers
+		executor.GetHash = func(header *types.Header) func(i uint64) types.Hash {
+			return func(i uint64) (res types.Hash) {
+				return types.BytesToHash(common.EncodeUint64ToBytes(i))
+			}
+		}
+
+		hash, err := executor.WriteGenesis(balanceMap, types.ZeroHash)
+		require.NoError(t, err)
+
+		txPool := &txPoolMock{}
+		txPool.On("Prepare", uint64(0)).Once()
+
+		for _, tx := range txs {
+			txPool.On("Peek").Return(tx).Once()
+			txPool.On("Pop", tx).Once()
+		}
+
+		txPool.On("Peek").Return((*types.Transaction)(nil))
+
+		bb := NewBlockBuilder(&BlockBuilderParams{
+			BlockTime: time.Millisecond * 10,
+			Parent:    &types.Header{StateRoot: hash, GasLimit: 1_000_000_000_000_000},
+			Coinbase:  types.ZeroAddress,
+			Executor:  executor,
+			GasLimit:  21000 * 10,
+			TxPool:    txPool,
+			Logger:    logger,
+		})
+
+		require.NoError(t, bb.Reset())
+		bb.Fill()
+
+		fb, err := bb.Build(nil)
+		require.NoError(t, err)
+
+		txPool.AssertExpectations(t)
+
+		return fb
+	}
+
+	sequential := buildBlock(0)
+	parallel := buildBlock(3)
+
+	require.Len(t, parallel.Block.Transactions, len(txs))
+	require.Equal(t, sequential.Block.Header.StateRoot, parallel.Block.Header.StateRoot)
+	require.Equal(t, sequential.Receipts, parallel.Receipts)
+}
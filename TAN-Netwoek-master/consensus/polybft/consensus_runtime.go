@@ -8,9 +8,10 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/tarality/tan-network/consensus"
 	"github.com/tarality/tan-network/consensus/polybft/contractsapi"
-	bls "github.com/tarality/tan-network/consensus/polybft/signer"
 	"github.com/tarality/tan-network/consensus/polybft/validator"
 	"github.com/tarality/tan-network/consensus/polybft/wallet"
 	"github.com/tarality/tan-network/contracts"
@@ -80,6 +81,13 @@ type runtimeConfig struct {
 	txPool                txPoolInterface
 	bridgeTopic           topic
 	numBlockConfirmations uint64
+	uptimeAlertThreshold  uint64
+
+	// closeCh is closed once the consensus is stopped
+	closeCh <-chan struct{}
+
+	// keySwitcher switches to the rotated validator BLS key (nil if the keys are held by the key signer)
+	keySwitcher *validatorKeySwitcher
 }
 
 // consensusRuntime is a struct that provides consensus runtime features like epoch, state and event management
@@ -117,6 +125,12 @@ type consensusRuntime struct {
 	// manager for handling validator stake change and updating validator set
 	stakeManager StakeManager
 
+	// uptimeTracker tracks validators signing and proposing activity
+	uptimeTracker UptimeTracker
+
+	// evidenceCollector detects validators which sign conflicting consensus messages
+	evidenceCollector EvidenceCollector
+
 	// logger instance
 	logger hcf.Logger
 }
@@ -148,6 +162,20 @@ func newConsensusRuntime(log hcf.Logger, config *runtimeConfig) (*consensusRunti
 		return nil, err
 	}
 
+	runtime.uptimeTracker = newUptimeTracker(
+		log.Named("uptime_tracker"),
+		runtime.state,
+		config.blockchain,
+		config.polybftBackend,
+		config.uptimeAlertThreshold,
+	)
+
+	runtime.evidenceCollector = newEvidenceCollector(
+		log.Named("evidence_collector"),
+		runtime.state,
+		config.polybftBackend,
+	)
+
 	// we need to call restart epoch on runtime to initialize epoch state
 	runtime.epoch, err = runtime.restartEpoch(runtime.lastBuiltBlock)
 	if err != nil {
@@ -314,6 +342,16 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		c.logger.Error("failed to post block in stake manager", "err", err)
 	}
 
+	// track validators signing and proposing activity
+	if err := c.uptimeTracker.PostBlock(postBlock); err != nil {
+		c.logger.Error("failed to post block in uptime tracker", "err", err)
+	}
+
+	// remove double sign evidence included in block from pending evidence
+	if err := c.evidenceCollector.PostBlock(postBlock); err != nil {
+		c.logger.Error("failed to post block in evidence collector", "err", err)
+	}
+
 	if isEndOfEpoch {
 		if epoch, err = c.restartEpoch(fullBlock.Block.Header); err != nil {
 			c.logger.Error("failed to restart epoch after block inserted", "error", err)
@@ -379,6 +417,11 @@ func (c *consensusRuntime) FSM() error {
 		logger:            c.logger.Named("fsm"),
 	}
 
+	// pending double sign evidence gets included in the block, in case this node is the proposer
+	if isDoubleSignSlashingEnabled(pendingBlockNumber) {
+		ff.doubleSignEvidence = c.evidenceCollector.PendingEvidence(pendingBlockNumber, maxDoubleSignEvidencePerBlock)
+	}
+
 	if isEndOfSprint {
 		commitment, err := c.stateSyncManager.Commitment(pendingBlockNumber)
 		if err != nil {
@@ -442,6 +485,12 @@ func (c *consensusRuntime) restartEpoch(header *types.Header) (*epochMetadata, e
 		return nil, fmt.Errorf("restart epoch - cannot get validators: %w", err)
 	}
 
+	if c.config.keySwitcher != nil {
+		if err := c.config.keySwitcher.onNewEpoch(validatorSet); err != nil {
+			c.logger.Error("Could not switch to the rotated validator key", "epoch", epochNumber, "error", err)
+		}
+	}
+
 	updateEpochMetrics(epochMetadata{
 		Number:     epochNumber,
 		Validators: validatorSet,
@@ -601,6 +650,16 @@ func (c *consensusRuntime) GetStateSyncProof(stateSyncID uint64) (types.Proof, e
 	return c.stateSyncManager.GetStateSyncProof(stateSyncID)
 }
 
+// GetValidatorsUptime returns signing statistics of validators for the given number of the latest blocks
+func (c *consensusRuntime) GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error) {
+	return c.uptimeTracker.GetValidatorsUptime(blocks)
+}
+
+// GetEpochUptime returns signing statistics of validators for the given epoch
+func (c *consensusRuntime) GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	return c.uptimeTracker.GetEpochUptime(epoch)
+}
+
 // setIsActiveValidator updates the activeValidatorFlag field
 func (c *consensusRuntime) setIsActiveValidator(isActiveValidator bool) {
 	c.activeValidatorFlag.Store(isActiveValidator)
@@ -734,6 +793,11 @@ func (c *consensusRuntime) BuildProposal(view *proto.View) []byte {
 		return nil
 	}
 
+	if c.shouldWaitForTransactions(view.Round) {
+		consensus.WaitForTransactions(c.config.txPool, c.fsm.parent.Timestamp,
+			c.config.PolyBFTConfig.MaxEmptyBlockInterval.Duration, c.config.closeCh)
+	}
+
 	proposal, err := c.fsm.BuildProposal(view.Round)
 	if err != nil {
 		c.logger.Error("unable to build proposal", "blockNumber", view, "error", err)
@@ -744,6 +808,26 @@ func (c *consensusRuntime) BuildProposal(view *proto.View) []byte {
 	return proposal
 }
 
+// shouldWaitForTransactions returns true if the proposer should wait for transactions before proposing,
+// instead of proposing an empty block. It proposes at once after a round change, not to delay the consensus
+// any further, and at the epoch and sprint ending blocks, which carry the state transactions
+func (c *consensusRuntime) shouldWaitForTransactions(round uint64) bool {
+	return round == 0 && !c.fsm.isEndOfEpoch && !c.fsm.isEndOfSprint && len(c.fsm.doubleSignEvidence) == 0
+}
+
+// emptyBlockRoundTimeout returns the time the proposer of the current sequence may wait for transactions,
+// which the round timeout of the sequence is extended by
+func (c *consensusRuntime) emptyBlockRoundTimeout() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.fsm == nil || !c.shouldWaitForTransactions(0) {
+		return 0
+	}
+
+	return consensus.EmptyBlockRoundTimeout(c.config.txPool, c.config.PolyBFTConfig.MaxEmptyBlockInterval.Duration)
+}
+
 // InsertProposal inserts a proposal with the specified committed seals
 func (c *consensusRuntime) InsertProposal(proposal *proto.Proposal, committedSeals []*messages.CommittedSeal) {
 	fsm := c.fsm
@@ -864,7 +948,7 @@ func (c *consensusRuntime) BuildPrepareMessage(proposalHash []byte, view *proto.
 
 // BuildCommitMessage builds a COMMIT message based on the passed in proposal
 func (c *consensusRuntime) BuildCommitMessage(proposalHash []byte, view *proto.View) *proto.Message {
-	committedSeal, err := c.config.Key.SignWithDomain(proposalHash, bls.DomainCheckpointManager)
+	committedSeal, err := c.config.Key.SignCommittedSeal(proposalHash, view)
 	if err != nil {
 		c.logger.Error("Cannot create committed seal message.", "error", err)
 
@@ -16,7 +16,6 @@ import (
 	"github.com/tarality/tan-network/consensus"
 	"github.com/tarality/tan-network/consensus/polybft/bitmap"
 	"github.com/tarality/tan-network/consensus/polybft/contractsapi"
-	bls "github.com/tarality/tan-network/consensus/polybft/signer"
 	"github.com/tarality/tan-network/consensus/polybft/validator"
 	"github.com/tarality/tan-network/consensus/polybft/wallet"
 	"github.com/tarality/tan-network/contracts"
@@ -230,6 +229,8 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 		stateSyncManager:  &dummyStateSyncManager{},
 		checkpointManager: &dummyCheckpointManager{},
 		stakeManager:      &dummyStakeManager{},
+		uptimeTracker:     &dummyUptimeTracker{},
+		evidenceCollector: &dummyEvidenceCollector{},
 	}
 	runtime.OnBlockInserted(&types.FullBlock{Block: builtBlock})
 
@@ -354,6 +355,7 @@ func TestConsensusRuntime_FSM_NotEndOfEpoch_NotEndOfSprint(t *testing.T) {
 		state:             newTestState(t),
 		stateSyncManager:  &dummyStateSyncManager{},
 		checkpointManager: &dummyCheckpointManager{},
+		evidenceCollector: &dummyEvidenceCollector{},
 	}
 	runtime.setIsActiveValidator(true)
 
@@ -424,6 +426,7 @@ func TestConsensusRuntime_FSM_EndOfEpoch_BuildCommitEpoch(t *testing.T) {
 		stateSyncManager:   &dummyStateSyncManager{},
 		checkpointManager:  &dummyCheckpointManager{},
 		stakeManager:       &dummyStakeManager{},
+		evidenceCollector:  &dummyEvidenceCollector{},
 	}
 
 	err := runtime.FSM()
@@ -692,7 +695,7 @@ func TestConsensusRuntime_TamperMessageContent(t *testing.T) {
 	}
 	sender := validatorAccounts.GetValidator("A")
 	proposalHash := []byte{2, 4, 6, 8, 10}
-	proposalSignature, err := sender.Key().SignWithDomain(proposalHash, bls.DomainCheckpointManager)
+	proposalSignature, err := sender.Key().SignCommittedSeal(proposalHash, &proto.View{})
 	require.NoError(t, err)
 
 	msg := &proto.Message{
@@ -826,6 +829,55 @@ func TestConsensusRuntime_BuildProposal_InvalidParent(t *testing.T) {
 	require.Nil(t, runtime.BuildProposal(&proto.View{Round: 5}))
 }
 
+func TestConsensusRuntime_ShouldWaitForTransactions(t *testing.T) {
+	t.Parallel()
+
+	runtime := &consensusRuntime{fsm: &fsm{}}
+	require.True(t, runtime.shouldWaitForTransactions(0))
+
+	// round is changed, proposer doesn't wait anymore
+	require.False(t, runtime.shouldWaitForTransactions(1))
+
+	runtime.fsm = &fsm{isEndOfSprint: true}
+	require.False(t, runtime.shouldWaitForTransactions(0))
+
+	runtime.fsm = &fsm{isEndOfEpoch: true}
+	require.False(t, runtime.shouldWaitForTransactions(0))
+
+	runtime.fsm = &fsm{doubleSignEvidence: []*DoubleSignEvidence{{}}}
+	require.False(t, runtime.shouldWaitForTransactions(0))
+}
+
+func TestConsensusRuntime_EmptyBlockRoundTimeout(t *testing.T) {
+	t.Parallel()
+
+	emptyTxPool := new(txPoolMock)
+	emptyTxPool.On("Length").Return(uint64(0))
+
+	runtime := &consensusRuntime{
+		fsm: &fsm{},
+		config: &runtimeConfig{
+			txPool: emptyTxPool,
+			PolyBFTConfig: &PolyBFTConfig{
+				MaxEmptyBlockInterval: common.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	require.Equal(t, time.Minute, runtime.emptyBlockRoundTimeout())
+
+	// the round timeout isn't extended if the txpool isn't empty
+	pendingTxPool := new(txPoolMock)
+	pendingTxPool.On("Length").Return(uint64(1))
+
+	runtime.config.txPool = pendingTxPool
+	require.Equal(t, time.Duration(0), runtime.emptyBlockRoundTimeout())
+
+	// nor at the epoch ending block, which is proposed at once
+	runtime.config.txPool = emptyTxPool
+	runtime.fsm = &fsm{isEndOfEpoch: true}
+	require.Equal(t, time.Duration(0), runtime.emptyBlockRoundTimeout())
+}
+
 func TestConsensusRuntime_ID(t *testing.T) {
 	t.Parallel()
 
@@ -914,7 +966,7 @@ func TestConsensusRuntime_BuildCommitMessage(t *testing.T) {
 		},
 	}
 
-	committedSeal, err := key.SignWithDomain(proposalHash, bls.DomainCheckpointManager)
+	committedSeal, err := key.SignCommittedSeal(proposalHash, view)
 	require.NoError(t, err)
 
 	expected := proto.Message{
@@ -0,0 +1,490 @@
+package polybft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	ibftProto "github.com/tarality/0xTaral/messages/proto"
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/consensus/polybft/contractsapi"
+	"github.com/tarality/tan-network/consensus/polybft/wallet"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/types"
+	"github.com/umbracle/ethgo/abi"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxDoubleSignEvidencePerBlock is the maximum number of double sign evidence state transactions in a block
+	maxDoubleSignEvidencePerBlock = 5
+
+	// evidenceMessagesRetention is the number of heights for which the first seen consensus messages are kept
+	evidenceMessagesRetention = 10
+
+	// maxDoubleSignEvidenceAge is the number of blocks after which double sign evidence can't be included anymore
+	maxDoubleSignEvidenceAge = 1000
+
+	// doubleSignJailPeriod is the number of blocks a double signer is jailed for.
+	// It is longer than the evidence age, so the offender can't be jailed again for the same offense once released
+	doubleSignJailPeriod = 100 * maxDoubleSignEvidenceAge
+)
+
+var (
+	// submitDoubleSignEvidenceMethod is the ABI method of double sign evidence state transaction.
+	// Both arguments are protobuf encoded, signed IBFT messages.
+	submitDoubleSignEvidenceMethod = abi.MustNewMethod("function submitDoubleSignEvidence(bytes first, bytes second)")
+
+	errInvalidEvidenceMessageType = errors.New("only prepare and commit messages are accepted as evidence")
+	errEvidenceViewMismatch       = errors.New("evidence messages are not signed for the same view")
+	errEvidenceSenderMismatch     = errors.New("evidence messages are not signed by the same sender")
+	errEvidenceNotConflicting     = errors.New("evidence messages are not conflicting")
+	errDoubleSignSlashingDisabled = errors.New("double sign slashing fork is not enabled")
+)
+
+var _ contractsapi.StateTransactionInput = &DoubleSignEvidence{}
+
+// DoubleSignEvidence is a proof that a validator signed two conflicting consensus messages
+// (for the same height, round and message type, but for different proposals)
+type DoubleSignEvidence struct {
+	First  *ibftProto.Message
+	Second *ibftProto.Message
+}
+
+// newDoubleSignEvidence creates double sign evidence from two conflicting messages.
+// Messages are ordered by the proposal hash, so the evidence is the same regardless of the order they were seen in.
+func newDoubleSignEvidence(first, second *ibftProto.Message) *DoubleSignEvidence {
+	if bytes.Compare(proposalHashOf(first), proposalHashOf(second)) > 0 {
+		first, second = second, first
+	}
+
+	return &DoubleSignEvidence{First: first, Second: second}
+}
+
+// Offender returns the address of the validator which signed conflicting messages
+func (e *DoubleSignEvidence) Offender() types.Address {
+	return types.BytesToAddress(e.First.From)
+}
+
+// Height returns the height for which conflicting messages were signed
+func (e *DoubleSignEvidence) Height() uint64 {
+	return e.First.GetView().GetHeight()
+}
+
+// ID returns the unique identifier of the evidence, which is the same for every evidence
+// of the offender, signed for the same height, round and message type
+func (e *DoubleSignEvidence) ID() types.Hash {
+	view := e.First.GetView()
+
+	return types.BytesToHash(crypto.Keccak256(
+		e.First.From,
+		common.EncodeUint64ToBytes(view.GetHeight()),
+		common.EncodeUint64ToBytes(view.GetRound()),
+		common.EncodeUint64ToBytes(uint64(e.First.Type)),
+	))
+}
+
+// Verify checks that both messages are signed by the same sender,
+// for the same view and message type, and that they are conflicting
+func (e *DoubleSignEvidence) Verify() error {
+	if e.First == nil || e.Second == nil || e.First.View == nil || e.Second.View == nil {
+		return errors.New("evidence messages are missing")
+	}
+
+	if !isEvidenceMessageType(e.First.Type) || e.First.Type != e.Second.Type {
+		return errInvalidEvidenceMessageType
+	}
+
+	if e.First.View.Height != e.Second.View.Height || e.First.View.Round != e.Second.View.Round {
+		return errEvidenceViewMismatch
+	}
+
+	if !bytes.Equal(e.First.From, e.Second.From) {
+		return errEvidenceSenderMismatch
+	}
+
+	firstHash, secondHash := proposalHashOf(e.First), proposalHashOf(e.Second)
+	if len(firstHash) == 0 || len(secondHash) == 0 || bytes.Equal(firstHash, secondHash) {
+		return errEvidenceNotConflicting
+	}
+
+	for _, msg := range []*ibftProto.Message{e.First, e.Second} {
+		if err := verifyMessageSender(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncodeAbi contains logic for encoding arbitrary data into ABI format
+func (e *DoubleSignEvidence) EncodeAbi() ([]byte, error) {
+	first, err := protobuf.Marshal(e.First)
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := protobuf.Marshal(e.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return submitDoubleSignEvidenceMethod.Encode([]interface{}{first, second})
+}
+
+// DecodeAbi contains logic for decoding given ABI data
+func (e *DoubleSignEvidence) DecodeAbi(b []byte) error {
+	if len(b) < abiMethodIDLength || !bytes.Equal(b[:abiMethodIDLength], submitDoubleSignEvidenceMethod.ID()) {
+		return errors.New("invalid double sign evidence signature")
+	}
+
+	raw, err := abi.Decode(submitDoubleSignEvidenceMethod.Inputs, b[abiMethodIDLength:])
+	if err != nil {
+		return err
+	}
+
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return errors.New("could not decode double sign evidence")
+	}
+
+	first, ok := values["first"].([]byte)
+	if !ok {
+		return errors.New("could not decode first evidence message")
+	}
+
+	second, ok := values["second"].([]byte)
+	if !ok {
+		return errors.New("could not decode second evidence message")
+	}
+
+	e.First, e.Second = &ibftProto.Message{}, &ibftProto.Message{}
+
+	if err := protobuf.Unmarshal(first, e.First); err != nil {
+		return fmt.Errorf("could not unmarshal first evidence message: %w", err)
+	}
+
+	if err := protobuf.Unmarshal(second, e.Second); err != nil {
+		return fmt.Errorf("could not unmarshal second evidence message: %w", err)
+	}
+
+	return nil
+}
+
+// isDoubleSignSlashingEnabled returns true if the double sign evidence can be included in the block of given height
+func isDoubleSignSlashingEnabled(height uint64) bool {
+	return forkmanager.GetInstance().IsForkEnabled(chain.DoubleSignSlashing, height)
+}
+
+// verifyDoubleSignEvidence verifies the evidence and checks that the offender was a validator
+// at the evidence height, which must not be greater than the given (pending block) height
+// and not older than maxDoubleSignEvidenceAge blocks
+func verifyDoubleSignEvidence(evidence *DoubleSignEvidence, height uint64, backend polybftBackend) error {
+	if !isDoubleSignSlashingEnabled(height) {
+		return errDoubleSignSlashingDisabled
+	}
+
+	if err := evidence.Verify(); err != nil {
+		return err
+	}
+
+	if evidence.Height() == 0 || evidence.Height() > height || height-evidence.Height() > maxDoubleSignEvidenceAge {
+		return fmt.Errorf("invalid double sign evidence height %d (current height %d)", evidence.Height(), height)
+	}
+
+	validators, err := backend.GetValidators(evidence.Height()-1, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get validators for double sign evidence height %d: %w", evidence.Height(), err)
+	}
+
+	if !validators.ContainsAddress(evidence.Offender()) {
+		return fmt.Errorf("double sign offender %s is not a validator at height %d",
+			evidence.Offender(), evidence.Height())
+	}
+
+	return nil
+}
+
+// verifyMessageSender checks that the message signature is created by its sender
+func verifyMessageSender(msg *ibftProto.Message) error {
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return err
+	}
+
+	signerAddress, err := wallet.RecoverAddressFromSignature(msg.Signature, msgNoSig)
+	if err != nil {
+		return fmt.Errorf("failed to recover address from signature: %w", err)
+	}
+
+	if !bytes.Equal(msg.From, signerAddress.Bytes()) {
+		return fmt.Errorf("signer address %s doesn't match From field", signerAddress.String())
+	}
+
+	return nil
+}
+
+// isEvidenceMessageType returns true if the message of given type can be used as double sign evidence.
+// Preprepare messages are not accepted, since they carry the whole proposal
+// (conflicting proposals are always followed by conflicting prepare messages anyway).
+func isEvidenceMessageType(msgType ibftProto.MessageType) bool {
+	return msgType == ibftProto.MessageType_PREPARE || msgType == ibftProto.MessageType_COMMIT
+}
+
+// proposalHashOf returns the proposal hash signed by the prepare or commit message
+func proposalHashOf(msg *ibftProto.Message) []byte {
+	switch msg.Type {
+	case ibftProto.MessageType_PREPARE:
+		return msg.GetPrepareData().GetProposalHash()
+	case ibftProto.MessageType_COMMIT:
+		return msg.GetCommitData().GetProposalHash()
+	default:
+		return nil
+	}
+}
+
+// EvidenceCollector detects validators which sign conflicting consensus messages
+// and provides the collected evidence to block proposers
+type EvidenceCollector interface {
+	AddMessage(msg *ibftProto.Message)
+	PendingEvidence(height uint64, limit int) []*DoubleSignEvidence
+	PostBlock(req *PostBlockRequest) error
+}
+
+var _ EvidenceCollector = (*dummyEvidenceCollector)(nil)
+
+// dummyEvidenceCollector is a dummy implementation of EvidenceCollector interface
+// used only for unit testing
+type dummyEvidenceCollector struct{}
+
+func (d *dummyEvidenceCollector) AddMessage(msg *ibftProto.Message) {}
+func (d *dummyEvidenceCollector) PendingEvidence(height uint64, limit int) []*DoubleSignEvidence {
+	return nil
+}
+func (d *dummyEvidenceCollector) PostBlock(req *PostBlockRequest) error { return nil }
+
+var _ EvidenceCollector = (*evidenceCollector)(nil)
+
+// seenMessageKey identifies a consensus message which a validator is allowed to sign only once
+type seenMessageKey struct {
+	from    types.Address
+	height  uint64
+	round   uint64
+	msgType ibftProto.MessageType
+}
+
+// evidenceCollector keeps the first seen prepare and commit message of each validator per view
+// and persists double sign evidence once a conflicting message is seen
+type evidenceCollector struct {
+	// state is reference to the struct which encapsulates evidence persistence logic
+	state *State
+	// polybftBackend is abstraction for polybft backend
+	polybftBackend polybftBackend
+	// seen holds the first seen message of each validator per view and message type
+	seen map[seenMessageKey]*ibftProto.Message
+	// lock protects seen messages
+	lock sync.Mutex
+	// logger instance
+	logger hclog.Logger
+}
+
+// newEvidenceCollector returns a new instance of evidence collector
+func newEvidenceCollector(logger hclog.Logger, state *State, polybftBackend polybftBackend) *evidenceCollector {
+	return &evidenceCollector{
+		state:          state,
+		polybftBackend: polybftBackend,
+		seen:           map[seenMessageKey]*ibftProto.Message{},
+		logger:         logger,
+	}
+}
+
+// AddMessage is called for every consensus message, either received through gossip or sent by the IBFT transport.
+// If the message conflicts with the first seen message of the same sender, double sign evidence is persisted.
+func (c *evidenceCollector) AddMessage(msg *ibftProto.Message) {
+	if msg == nil || msg.View == nil || !isEvidenceMessageType(msg.Type) {
+		return
+	}
+
+	key := seenMessageKey{
+		from:    types.BytesToAddress(msg.From),
+		height:  msg.View.Height,
+		round:   msg.View.Round,
+		msgType: msg.Type,
+	}
+
+	c.lock.Lock()
+	first, exists := c.seen[key]
+
+	if !exists {
+		// only messages with a valid signature are remembered,
+		// otherwise anyone could prevent detection by sending a forged message first
+		if err := verifyMessageSender(msg); err == nil {
+			c.seen[key] = msg
+		}
+
+		c.lock.Unlock()
+
+		return
+	}
+	c.lock.Unlock()
+
+	if bytes.Equal(proposalHashOf(first), proposalHashOf(msg)) {
+		return
+	}
+
+	evidence := newDoubleSignEvidence(first, msg)
+	if err := evidence.Verify(); err != nil {
+		c.logger.Debug("invalid double sign evidence", "from", key.from, "height", key.height, "error", err)
+
+		return
+	}
+
+	inserted, err := c.state.EvidenceStore.insertDoubleSignEvidence(evidence)
+	if err != nil {
+		c.logger.Error("failed to save double sign evidence", "offender", key.from, "error", err)
+
+		return
+	}
+
+	if inserted {
+		metrics.IncrCounter([]string{consensusMetricsPrefix, "double_sign_evidence"}, 1)
+
+		c.logger.Warn("validator signed conflicting consensus messages", "offender", key.from,
+			"height", key.height, "round", key.round, "type", key.msgType.String())
+	}
+}
+
+// PendingEvidence returns valid evidence which is not yet included in a block.
+// Evidence which can not be included in the block of given height is discarded.
+func (c *evidenceCollector) PendingEvidence(height uint64, limit int) []*DoubleSignEvidence {
+	pending, err := c.state.EvidenceStore.getDoubleSignEvidence()
+	if err != nil {
+		c.logger.Error("failed to get pending double sign evidence", "error", err)
+
+		return nil
+	}
+
+	jailed, err := c.getJailedValidators()
+	if err != nil {
+		c.logger.Error("failed to get jailed validators", "error", err)
+
+		return nil
+	}
+
+	result := make([]*DoubleSignEvidence, 0, limit)
+	invalid := []types.Hash{}
+
+	for _, evidence := range pending {
+		if len(result) == limit {
+			break
+		}
+
+		if evidence.Height() > height {
+			// evidence for a future height (the node is lagging behind), keep it for later
+			continue
+		}
+
+		if _, isJailed := jailed[evidence.Offender()]; isJailed {
+			invalid = append(invalid, evidence.ID())
+
+			continue
+		}
+
+		if err := verifyDoubleSignEvidence(evidence, height, c.polybftBackend); err != nil {
+			c.logger.Warn("discarding invalid double sign evidence", "offender", evidence.Offender(), "error", err)
+
+			invalid = append(invalid, evidence.ID())
+
+			continue
+		}
+
+		result = append(result, evidence)
+	}
+
+	if len(invalid) > 0 {
+		if err := c.state.EvidenceStore.removeDoubleSignEvidence(invalid...); err != nil {
+			c.logger.Error("failed to remove invalid double sign evidence", "error", err)
+		}
+	}
+
+	return result
+}
+
+// PostBlock is called on every insert of finalized block (either from consensus or syncer).
+// It removes evidence included in the block from pending evidence and prunes old seen messages.
+func (c *evidenceCollector) PostBlock(req *PostBlockRequest) error {
+	block := req.FullBlock.Block
+
+	included, err := getDoubleSignEvidenceFromBlock(block)
+	if err != nil {
+		return err
+	}
+
+	if len(included) > 0 {
+		ids := make([]types.Hash, len(included))
+		for i, evidence := range included {
+			ids[i] = evidence.ID()
+		}
+
+		if err := c.state.EvidenceStore.removeDoubleSignEvidence(ids...); err != nil {
+			return err
+		}
+	}
+
+	if block.Number() > evidenceMessagesRetention {
+		c.lock.Lock()
+		for key := range c.seen {
+			if key.height <= block.Number()-evidenceMessagesRetention {
+				delete(c.seen, key)
+			}
+		}
+		c.lock.Unlock()
+	}
+
+	return nil
+}
+
+// getJailedValidators returns validators which have already been jailed
+func (c *evidenceCollector) getJailedValidators() (map[types.Address]uint64, error) {
+	fullValidatorSet, err := c.state.StakeStore.getFullValidatorSet()
+	if err != nil {
+		if errors.Is(err, errNoFullValidatorSet) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return fullValidatorSet.Jailed, nil
+}
+
+// getDoubleSignEvidenceFromBlock returns double sign evidence included in the given block
+func getDoubleSignEvidenceFromBlock(block *types.Block) ([]*DoubleSignEvidence, error) {
+	var result []*DoubleSignEvidence
+
+	for _, tx := range block.Transactions {
+		if !isDoubleSignEvidenceTx(tx) {
+			continue
+		}
+
+		evidence := &DoubleSignEvidence{}
+		if err := evidence.DecodeAbi(tx.Input); err != nil {
+			return nil, fmt.Errorf("failed to decode double sign evidence transaction %s: %w", tx.Hash, err)
+		}
+
+		result = append(result, evidence)
+	}
+
+	return result, nil
+}
+
+// isDoubleSignEvidenceTx returns true if the transaction is a double sign evidence state transaction
+func isDoubleSignEvidenceTx(tx *types.Transaction) bool {
+	return tx.Type == types.StateTx && len(tx.Input) >= abiMethodIDLength &&
+		bytes.Equal(tx.Input[:abiMethodIDLength], submitDoubleSignEvidenceMethod.ID())
+}
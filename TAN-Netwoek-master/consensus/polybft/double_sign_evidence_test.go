@@ -0,0 +1,323 @@
+package polybft
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	ibftProto "github.com/tarality/0xTaral/messages/proto"
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+	"github.com/tarality/tan-network/consensus/polybft/wallet"
+	"github.com/tarality/tan-network/contracts"
+	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/types"
+)
+
+// doubleSignSlashingForkBlock is the block the double sign slashing fork is enabled from in the tests
+const doubleSignSlashingForkBlock = 3
+
+var doubleSignSlashingForkOnce sync.Once
+
+// enableDoubleSignSlashing registers the double sign slashing fork in the fork manager
+func enableDoubleSignSlashing(t *testing.T) {
+	t.Helper()
+
+	doubleSignSlashingForkOnce.Do(func() {
+		fm := forkmanager.GetInstance()
+		fm.RegisterFork(chain.DoubleSignSlashing, nil)
+		require.NoError(t, fm.ActivateFork(chain.DoubleSignSlashing, doubleSignSlashingForkBlock))
+	})
+}
+
+func TestDoubleSignEvidence_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	byzantine := validator.NewTestValidator(t, "A", 1)
+	first, second := createConflictingMessages(t, byzantine.Key(), ibftProto.MessageType_COMMIT, 5, 1)
+	evidence := newDoubleSignEvidence(first, second)
+
+	input, err := evidence.EncodeAbi()
+	require.NoError(t, err)
+
+	decoded, err := decodeStateTransaction(input)
+	require.NoError(t, err)
+
+	decodedEvidence, ok := decoded.(*DoubleSignEvidence)
+	require.True(t, ok)
+	require.NoError(t, decodedEvidence.Verify())
+	require.Equal(t, byzantine.Address(), decodedEvidence.Offender())
+	require.Equal(t, uint64(5), decodedEvidence.Height())
+	require.Equal(t, evidence.ID(), decodedEvidence.ID())
+
+	// evidence is the same regardless of the order in which messages are seen
+	require.Equal(t, evidence, newDoubleSignEvidence(second, first))
+}
+
+func TestDoubleSignEvidence_Verify(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	keyA, keyB := validators.GetValidator("A").Key(), validators.GetValidator("B").Key()
+
+	first, second := createConflictingMessages(t, keyA, ibftProto.MessageType_PREPARE, 5, 0)
+	require.NoError(t, (&DoubleSignEvidence{First: first, Second: second}).Verify())
+
+	cases := []struct {
+		name     string
+		evidence func() *DoubleSignEvidence
+		err      string
+	}{
+		{
+			name: "same proposal",
+			evidence: func() *DoubleSignEvidence {
+				return &DoubleSignEvidence{First: first, Second: first}
+			},
+			err: errEvidenceNotConflicting.Error(),
+		},
+		{
+			name: "different rounds",
+			evidence: func() *DoubleSignEvidence {
+				other := createTestIBFTMessage(t, keyA, ibftProto.MessageType_PREPARE, 5, 1, []byte{3})
+
+				return &DoubleSignEvidence{First: first, Second: other}
+			},
+			err: errEvidenceViewMismatch.Error(),
+		},
+		{
+			name: "different senders",
+			evidence: func() *DoubleSignEvidence {
+				other := createTestIBFTMessage(t, keyB, ibftProto.MessageType_PREPARE, 5, 0, []byte{3})
+
+				return &DoubleSignEvidence{First: first, Second: other}
+			},
+			err: errEvidenceSenderMismatch.Error(),
+		},
+		{
+			name: "different types",
+			evidence: func() *DoubleSignEvidence {
+				other := createTestIBFTMessage(t, keyA, ibftProto.MessageType_COMMIT, 5, 0, []byte{3})
+
+				return &DoubleSignEvidence{First: first, Second: other}
+			},
+			err: errInvalidEvidenceMessageType.Error(),
+		},
+		{
+			name: "forged message",
+			evidence: func() *DoubleSignEvidence {
+				forged := createTestIBFTMessage(t, keyB, ibftProto.MessageType_PREPARE, 5, 0, []byte{3})
+				forged.From = keyA.Address().Bytes()
+
+				return &DoubleSignEvidence{First: first, Second: forged}
+			},
+			err: "doesn't match From field",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.ErrorContains(t, c.evidence().Verify(), c.err)
+		})
+	}
+}
+
+func TestEvidenceCollector_ByzantineValidator(t *testing.T) {
+	t.Parallel()
+
+	enableDoubleSignSlashing(t)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"})
+	byzantine := validators.GetValidator("A")
+
+	backend := new(polybftBackendMock)
+	backend.On("GetValidators", mock.Anything, mock.Anything).Return(validators.GetPublicIdentities())
+
+	state := newTestState(t)
+	collector := newEvidenceCollector(hclog.NewNullLogger(), state, backend)
+
+	// honest validator sends the same message twice
+	honest := createTestIBFTMessage(t, validators.GetValidator("B").Key(),
+		ibftProto.MessageType_PREPARE, 5, 0, []byte{1})
+	collector.AddMessage(honest)
+	collector.AddMessage(honest)
+
+	require.Empty(t, collector.PendingEvidence(6, maxDoubleSignEvidencePerBlock))
+
+	// byzantine validator signs two different proposals
+	first, second := createConflictingMessages(t, byzantine.Key(), ibftProto.MessageType_PREPARE, 5, 0)
+	collector.AddMessage(first)
+	collector.AddMessage(second)
+	collector.AddMessage(second)
+
+	// evidence for a future height is kept, but not included
+	require.Empty(t, collector.PendingEvidence(4, maxDoubleSignEvidencePerBlock))
+
+	pending := collector.PendingEvidence(6, maxDoubleSignEvidencePerBlock)
+	require.Len(t, pending, 1)
+	require.Equal(t, byzantine.Address(), pending[0].Offender())
+
+	// proposer includes the evidence into a block
+	input, err := pending[0].EncodeAbi()
+	require.NoError(t, err)
+
+	block := &types.Block{
+		Header: &types.Header{Number: 6},
+		Transactions: []*types.Transaction{
+			createStateTransactionWithData(6, contracts.DoubleSignEvidenceContract, input),
+		},
+	}
+
+	f := &fsm{
+		parent:         &types.Header{Number: 5},
+		polybftBackend: backend,
+	}
+	require.NoError(t, f.VerifyStateTransactions(block.Transactions))
+
+	require.NoError(t, collector.PostBlock(&PostBlockRequest{FullBlock: &types.FullBlock{Block: block}}))
+	require.Empty(t, collector.PendingEvidence(7, maxDoubleSignEvidencePerBlock))
+
+	// stake manager jails the offender, so it is removed from the validator set at the end of the epoch
+	require.NoError(t, state.StakeStore.insertFullValidatorSet(validatorSetState{
+		Validators: newValidatorStakeMap(validators.GetPublicIdentities()),
+	}))
+
+	stakeManager := &stakeManager{
+		logger:              hclog.NewNullLogger(),
+		state:               state,
+		maxValidatorSetSize: 10,
+	}
+
+	fullValidatorSet, err := state.StakeStore.getFullValidatorSet()
+	require.NoError(t, err)
+	require.NoError(t, stakeManager.jailDoubleSigners(&fullValidatorSet, block))
+	require.NoError(t, state.StakeStore.insertFullValidatorSet(fullValidatorSet))
+
+	delta, err := stakeManager.UpdateValidatorSet(1, validators.GetPublicIdentities())
+	require.NoError(t, err)
+	require.Empty(t, delta.Added)
+	require.Empty(t, delta.Updated)
+	require.True(t, delta.Removed.IsSet(uint64(validators.GetPublicIdentities().Index(byzantine.Address()))))
+
+	// evidence against jailed validator is not included anymore
+	first, second = createConflictingMessages(t, byzantine.Key(), ibftProto.MessageType_COMMIT, 7, 0)
+	collector.AddMessage(first)
+	collector.AddMessage(second)
+
+	require.Empty(t, collector.PendingEvidence(8, maxDoubleSignEvidencePerBlock))
+}
+
+func TestEvidenceCollector_ForgedFirstMessage(t *testing.T) {
+	t.Parallel()
+
+	enableDoubleSignSlashing(t)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	keyA, keyB := validators.GetValidator("A").Key(), validators.GetValidator("B").Key()
+
+	backend := new(polybftBackendMock)
+	backend.On("GetValidators", mock.Anything, mock.Anything).Return(validators.GetPublicIdentities())
+
+	collector := newEvidenceCollector(hclog.NewNullLogger(), newTestState(t), backend)
+
+	// message which is not signed by its sender is ignored
+	forged := createTestIBFTMessage(t, keyB, ibftProto.MessageType_PREPARE, 5, 0, []byte{1})
+	forged.From = keyA.Address().Bytes()
+	collector.AddMessage(forged)
+
+	first, second := createConflictingMessages(t, keyA, ibftProto.MessageType_PREPARE, 5, 0)
+	collector.AddMessage(first)
+	collector.AddMessage(second)
+
+	require.Len(t, collector.PendingEvidence(6, maxDoubleSignEvidencePerBlock), 1)
+}
+
+func TestVerifyDoubleSignEvidence(t *testing.T) {
+	t.Parallel()
+
+	enableDoubleSignSlashing(t)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	key := validators.GetValidator("A").Key()
+
+	backend := new(polybftBackendMock)
+	backend.On("GetValidators", mock.Anything, mock.Anything).Return(validators.GetPublicIdentities())
+
+	// evidence can't be included before the fork
+	first, second := createConflictingMessages(t, key, ibftProto.MessageType_PREPARE, 1, 0)
+	require.ErrorIs(t, verifyDoubleSignEvidence(newDoubleSignEvidence(first, second),
+		doubleSignSlashingForkBlock-1, backend), errDoubleSignSlashingDisabled)
+
+	// evidence can be included until it gets too old
+	first, second = createConflictingMessages(t, key, ibftProto.MessageType_PREPARE, 5, 0)
+	evidence := newDoubleSignEvidence(first, second)
+
+	require.NoError(t, verifyDoubleSignEvidence(evidence, 5+maxDoubleSignEvidenceAge, backend))
+	require.ErrorContains(t, verifyDoubleSignEvidence(evidence, 5+maxDoubleSignEvidenceAge+1, backend),
+		"invalid double sign evidence height")
+}
+
+func TestStakeManager_ReleaseJailedValidators(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C"})
+	addrA, addrB := validators.GetValidator("A").Address(), validators.GetValidator("B").Address()
+
+	stakeManager := &stakeManager{logger: hclog.NewNullLogger()}
+	fullValidatorSet := &validatorSetState{
+		Validators: newValidatorStakeMap(validators.GetPublicIdentities()),
+		Jailed:     map[types.Address]uint64{addrA: 10, addrB: 20},
+	}
+
+	stakeManager.releaseJailedValidators(fullValidatorSet, 10+doubleSignJailPeriod-1)
+	require.Len(t, fullValidatorSet.Jailed, 2)
+
+	// A served the jail period, so it gets back into the validator set
+	stakeManager.releaseJailedValidators(fullValidatorSet, 10+doubleSignJailPeriod)
+	require.Equal(t, map[types.Address]uint64{addrB: 20}, fullValidatorSet.Jailed)
+
+	next := fullValidatorSet.nextValidatorSet(10)
+	require.True(t, next.ContainsAddress(addrA))
+	require.False(t, next.ContainsAddress(addrB))
+}
+
+// createConflictingMessages creates two messages of the same view and type, signed for different proposals,
+// as a byzantine validator would do
+func createConflictingMessages(t *testing.T, key *wallet.Key, msgType ibftProto.MessageType,
+	height, round uint64) (*ibftProto.Message, *ibftProto.Message) {
+	t.Helper()
+
+	return createTestIBFTMessage(t, key, msgType, height, round, []byte{1}),
+		createTestIBFTMessage(t, key, msgType, height, round, []byte{2})
+}
+
+// createTestIBFTMessage creates signed prepare or commit message for the given proposal hash
+func createTestIBFTMessage(t *testing.T, key *wallet.Key, msgType ibftProto.MessageType,
+	height, round uint64, proposalHash []byte) *ibftProto.Message {
+	t.Helper()
+
+	msg := &ibftProto.Message{
+		View: &ibftProto.View{Height: height, Round: round},
+		From: key.Address().Bytes(),
+		Type: msgType,
+	}
+
+	if msgType == ibftProto.MessageType_COMMIT {
+		msg.Payload = &ibftProto.Message_CommitData{
+			CommitData: &ibftProto.CommitMessage{ProposalHash: proposalHash},
+		}
+	} else {
+		msg.Payload = &ibftProto.Message_PrepareData{
+			PrepareData: &ibftProto.PrepareMessage{ProposalHash: proposalHash},
+		}
+	}
+
+	msg, err := key.SignIBFTMessage(msg)
+	require.NoError(t, err)
+
+	return msg
+}
@@ -18,19 +18,18 @@ import (
 	bls "github.com/tarality/tan-network/consensus/polybft/signer"
 	"github.com/tarality/tan-network/consensus/polybft/validator"
 	"github.com/tarality/tan-network/consensus/polybft/wallet"
-	"github.com/tarality/tan-network/crypto"
 	"github.com/tarality/tan-network/types"
 )
 
 func TestExtra_Encoding(t *testing.T) {
 	t.Parallel()
 
-	digest := crypto.Keccak256([]byte("Dummy content to sign"))
+	content := []byte("Dummy content to sign")
 	keys := createRandomTestKeys(t, 2)
-	parentSig, err := keys[0].Sign(digest)
+	parentSig, err := keys[0].Sign(content)
 	require.NoError(t, err)
 
-	committedSig, err := keys[1].Sign(digest)
+	committedSig, err := keys[1].Sign(content)
 	require.NoError(t, err)
 
 	bmp := bitmap.Bitmap{}
@@ -1,7 +1,6 @@
 package polybft
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
@@ -15,7 +14,6 @@ import (
 	"github.com/tarality/tan-network/consensus/polybft/contractsapi"
 	bls "github.com/tarality/tan-network/consensus/polybft/signer"
 	"github.com/tarality/tan-network/consensus/polybft/validator"
-	"github.com/tarality/tan-network/consensus/polybft/wallet"
 	"github.com/tarality/tan-network/contracts"
 	"github.com/tarality/tan-network/state"
 	"github.com/tarality/tan-network/types"
@@ -91,6 +89,9 @@ type fsm struct {
 	// proposerCommitmentToRegister is a commitment that is registered via state transaction by proposer
 	proposerCommitmentToRegister *CommitmentMessageSigned
 
+	// doubleSignEvidence is a double sign evidence that is included via state transactions by proposer
+	doubleSignEvidence []*DoubleSignEvidence
+
 	// logger instance
 	logger hcf.Logger
 
@@ -151,6 +152,10 @@ func (f *fsm) BuildProposal(currentRound uint64) ([]byte, error) {
 		}
 	}
 
+	if err := f.applyDoubleSignEvidenceTxs(); err != nil {
+		return nil, err
+	}
+
 	// fill the block with transactions
 	f.blockBuilder.Fill()
 
@@ -235,6 +240,24 @@ func (f *fsm) createBridgeCommitmentTx() (*types.Transaction, error) {
 	return createStateTransactionWithData(f.Height(), contracts.StateReceiverContract, inputData), nil
 }
 
+// applyDoubleSignEvidenceTxs builds state transactions which contain double sign evidence
+func (f *fsm) applyDoubleSignEvidenceTxs() error {
+	for _, evidence := range f.doubleSignEvidence {
+		inputData, err := evidence.EncodeAbi()
+		if err != nil {
+			return fmt.Errorf("failed to encode input data for double sign evidence: %w", err)
+		}
+
+		tx := createStateTransactionWithData(f.Height(), contracts.DoubleSignEvidenceContract, inputData)
+
+		if err := f.blockBuilder.WriteTx(tx); err != nil {
+			return fmt.Errorf("failed to apply double sign evidence state transaction. Error: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // getValidatorsTransition applies delta to the current validators,
 func (f *fsm) getValidatorsTransition(delta *validator.ValidatorSetDelta) (validator.AccountSet, error) {
 	nextValidators, err := f.validators.Accounts().ApplyDelta(delta)
@@ -298,7 +321,7 @@ func (f *fsm) Validate(proposal []byte) error {
 	}
 
 	// validate header fields
-	if err := validateHeaderFields(f.parent, block.Header, f.config.BlockTimeDrift); err != nil {
+	if err := validateHeaderFields(f.parent, block.Header, f.config.BlockTimeDrift, f.config.MinBlockTime()); err != nil {
 		return fmt.Errorf(
 			"failed to validate header (parent header# %d, current header#%d): %w",
 			f.parent.Number,
@@ -391,20 +414,12 @@ func (f *fsm) Validate(proposal []byte) error {
 
 // ValidateSender validates sender address and signature
 func (f *fsm) ValidateSender(msg *proto.Message) error {
-	msgNoSig, err := msg.PayloadNoSig()
-	if err != nil {
+	// verify the signature came from the sender
+	if err := verifyMessageSender(msg); err != nil {
 		return err
 	}
 
-	signerAddress, err := wallet.RecoverAddressFromSignature(msg.Signature, msgNoSig)
-	if err != nil {
-		return fmt.Errorf("failed to recover address from signature: %w", err)
-	}
-
-	// verify the signature came from the sender
-	if !bytes.Equal(msg.From, signerAddress.Bytes()) {
-		return fmt.Errorf("signer address %s doesn't match From field", signerAddress.String())
-	}
+	signerAddress := types.BytesToAddress(msg.From)
 
 	// verify the sender is in the active validator set
 	if !f.validators.Includes(signerAddress) {
@@ -419,6 +434,8 @@ func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
 		commitmentTxExists        bool
 		commitEpochTxExists       bool
 		distributeRewardsTxExists bool
+		doubleSignEvidenceTxs     int
+		doubleSignEvidenceIDs     = map[types.Hash]struct{}{}
 	)
 
 	for _, tx := range transactions {
@@ -472,6 +489,22 @@ func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
 			if err := f.verifyDistributeRewardsTx(tx); err != nil {
 				return fmt.Errorf("error while verifying distribute rewards transaction. error: %w", err)
 			}
+		case *DoubleSignEvidence:
+			doubleSignEvidenceTxs++
+			if doubleSignEvidenceTxs > maxDoubleSignEvidencePerBlock {
+				return fmt.Errorf("only %d double sign evidence txs are allowed per block (tx hash=%s)",
+					maxDoubleSignEvidencePerBlock, tx.Hash)
+			}
+
+			if _, exists := doubleSignEvidenceIDs[stateTxData.ID()]; exists {
+				return fmt.Errorf("duplicate double sign evidence tx (tx hash=%s)", tx.Hash)
+			}
+
+			doubleSignEvidenceIDs[stateTxData.ID()] = struct{}{}
+
+			if err := verifyDoubleSignEvidence(stateTxData, f.Height(), f.polybftBackend); err != nil {
+				return fmt.Errorf("error while verifying double sign evidence transaction. error: %w", err)
+			}
 		default:
 			return fmt.Errorf("invalid state transaction data type: %v", stateTxData)
 		}
@@ -653,7 +686,10 @@ func verifyBridgeCommitmentTx(blockNumber uint64, txHash types.Hash,
 	return nil
 }
 
-func validateHeaderFields(parent *types.Header, header *types.Header, blockTimeDrift uint64) error {
+// validateHeaderFields validates the header against its parent. The header timestamp has to be
+// at least minBlockTime after the parent timestamp and at most blockTimeDrift seconds in the future
+func validateHeaderFields(parent *types.Header, header *types.Header,
+	blockTimeDrift uint64, minBlockTime time.Duration) error {
 	// header extra data must be higher or equal to ExtraVanity = 32 in order to be compliant with Ethereum blocks
 	if len(header.ExtraData) < ExtraVanity {
 		return fmt.Errorf("extra-data shorter than %d bytes (%d)", ExtraVanity, len(header.ExtraData))
@@ -683,6 +719,11 @@ func validateHeaderFields(parent *types.Header, header *types.Header, blockTimeD
 	if header.Timestamp <= parent.Timestamp {
 		return fmt.Errorf("timestamp older than parent")
 	}
+	// verify the minimum block time has passed, so the proposer can't shorten the block time
+	if minBlockTime > 0 && header.Timestamp < parent.Timestamp+uint64(minBlockTime/time.Second) {
+		return fmt.Errorf("block time shorter than %s. block timestamp: %d, parent timestamp: %d",
+			minBlockTime, header.Timestamp, parent.Timestamp)
+	}
 	// verify mix digest
 	if header.MixHash != PolyBFTMixDigest {
 		return fmt.Errorf("mix digest is not correct")
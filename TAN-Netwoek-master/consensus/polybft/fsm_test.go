@@ -33,52 +33,91 @@ func TestFSM_ValidateHeader(t *testing.T) {
 	header := &types.Header{Number: 0}
 
 	// extra data
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "extra-data shorter than")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "extra-data shorter than")
 	header.ExtraData = extra
 
 	// parent hash
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "incorrect header parent hash")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "incorrect header parent hash")
 	header.ParentHash = parent.Hash
 
 	// sequence number
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "invalid number")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "invalid number")
 	header.Number = 1
 
 	// failed timestamp
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "timestamp older than parent")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "timestamp older than parent")
 	header.Timestamp = 10
 
 	// failed nonce
 	header.SetNonce(1)
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "invalid nonce")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "invalid nonce")
 
 	header.SetNonce(0)
 
 	// failed gas
 	header.GasLimit = 10
 	header.GasUsed = 11
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "invalid gas limit")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "invalid gas limit")
 	header.GasLimit = 10
 	header.GasUsed = 10
 
 	// mix digest
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "mix digest is not correct")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "mix digest is not correct")
 	header.MixHash = PolyBFTMixDigest
 
 	// difficulty
 	header.Difficulty = 0
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "difficulty should be greater than zero")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "difficulty should be greater than zero")
 
 	header.Difficulty = 1
 	header.Hash = types.BytesToHash([]byte{11, 22, 33})
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "invalid header hash")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "invalid header hash")
 	header.Timestamp = uint64(time.Now().UTC().Unix() + 150)
-	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift), "block from the future")
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 0), "block from the future")
 
 	header.Timestamp = uint64(time.Now().UTC().Unix())
 
 	header.ComputeHash()
-	require.NoError(t, validateHeaderFields(parent, header, blockTimeDrift))
+	require.NoError(t, validateHeaderFields(parent, header, blockTimeDrift, 0))
+}
+
+func TestFSM_ValidateHeader_MinBlockTime(t *testing.T) {
+	t.Parallel()
+
+	const blockTimeDrift = uint64(1)
+
+	now := uint64(time.Now().UTC().Unix())
+	parent := &types.Header{Number: 0, Hash: types.BytesToHash([]byte{1, 2, 3}), Timestamp: now - 10}
+	header := &types.Header{
+		Number:        1,
+		ParentHash:    parent.Hash,
+		ExtraData:     createTestExtra(validator.AccountSet{}, validator.AccountSet{}, 0, 0, 0),
+		MixHash:       PolyBFTMixDigest,
+		Difficulty:    1,
+		InitialReward: big.NewInt(1),
+		Timestamp:     parent.Timestamp + 1,
+	}
+	header.ComputeHash()
+
+	// minimum block time isn't enforced if the empty blocks aren't delayed
+	require.NoError(t, validateHeaderFields(parent, header, blockTimeDrift, 0))
+
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 2*time.Second),
+		"block time shorter than")
+
+	header.Timestamp = parent.Timestamp + 2
+	header.ComputeHash()
+	require.NoError(t, validateHeaderFields(parent, header, blockTimeDrift, 2*time.Second))
+
+	// block time may be stretched up to the current time and the drift
+	header.Timestamp = now
+	header.ComputeHash()
+	require.NoError(t, validateHeaderFields(parent, header, blockTimeDrift, 2*time.Second))
+
+	header.Timestamp = now + 10
+	header.ComputeHash()
+	require.ErrorContains(t, validateHeaderFields(parent, header, blockTimeDrift, 2*time.Second),
+		"block from the future")
 }
 
 func TestFSM_verifyCommitEpochTx(t *testing.T) {
@@ -1366,6 +1405,81 @@ func TestFSM_VerifyStateTransaction_ValidBothTypesOfStateTransactions(t *testing
 	assert.ErrorContains(t, executeForValidators("A", "B", "C"), "quorum size not reached for state tx")
 }
 
+func TestFSM_VerifyStateTransactions_DoubleSignEvidence(t *testing.T) {
+	t.Parallel()
+
+	enableDoubleSignSlashing(t)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D", "E", "F", "G"})
+	nonValidator := validator.NewTestValidator(t, "X", 1)
+
+	backend := new(polybftBackendMock)
+	backend.On("GetValidators", mock.Anything, mock.Anything).Return(validators.GetPublicIdentities())
+
+	f := &fsm{
+		parent:         &types.Header{Number: 9},
+		polybftBackend: backend,
+	}
+
+	createEvidenceTx := func(key *wallet.Key, height uint64) *types.Transaction {
+		first, second := createConflictingMessages(t, key, proto.MessageType_PREPARE, height, 0)
+
+		input, err := newDoubleSignEvidence(first, second).EncodeAbi()
+		require.NoError(t, err)
+
+		return createStateTransactionWithData(f.Height(), contracts.DoubleSignEvidenceContract, input)
+	}
+
+	t.Run("valid evidence", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{
+			createEvidenceTx(validators.GetValidator("A").Key(), 9),
+			createEvidenceTx(validators.GetValidator("B").Key(), 10),
+		}
+
+		require.NoError(t, f.VerifyStateTransactions(txs))
+	})
+
+	t.Run("duplicate evidence", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{
+			createEvidenceTx(validators.GetValidator("A").Key(), 9),
+			createEvidenceTx(validators.GetValidator("A").Key(), 9),
+		}
+
+		require.ErrorContains(t, f.VerifyStateTransactions(txs), "duplicate double sign evidence")
+	})
+
+	t.Run("too many evidence txs", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{}
+		for _, v := range validators.GetValidators() {
+			txs = append(txs, createEvidenceTx(v.Key(), 9))
+		}
+
+		require.ErrorContains(t, f.VerifyStateTransactions(txs), "double sign evidence txs are allowed per block")
+	})
+
+	t.Run("offender is not a validator", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{createEvidenceTx(nonValidator.Key(), 9)}
+
+		require.ErrorContains(t, f.VerifyStateTransactions(txs), "is not a validator")
+	})
+
+	t.Run("future height", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{createEvidenceTx(validators.GetValidator("A").Key(), 11)}
+
+		require.ErrorContains(t, f.VerifyStateTransactions(txs), "invalid double sign evidence height")
+	})
+}
+
 func TestFSM_VerifyStateTransaction_InvalidTypeOfStateTransactions(t *testing.T) {
 	t.Parallel()
 
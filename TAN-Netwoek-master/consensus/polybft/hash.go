@@ -30,3 +30,8 @@ func setupHeaderHashFunc() {
 		}
 	})
 }
+
+// SetupHeaderHashFunc sets up the PolyBFT header hash for the tools reading the blocks without running the consensus
+func SetupHeaderHashFunc() {
+	setupHeaderHashFunc()
+}
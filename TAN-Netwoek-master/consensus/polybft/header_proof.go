@@ -0,0 +1,99 @@
+package polybft
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tarality/tan-network/types"
+)
+
+// maxHeaderProofEpochs is the maximum number of epochs a single header proof can span
+const maxHeaderProofEpochs = 1000
+
+var (
+	errInvalidHeaderProofRange = errors.New("invalid epoch range, from epoch must be positive and not after to epoch")
+	errHeaderProofTooLong      = fmt.Errorf("header proof can't span more than %d epochs", maxHeaderProofEpochs)
+)
+
+// GetHeaderProof returns the epoch-ending headers of the epochs [fromEpoch, toEpoch).
+// Each of them is signed by the validator set of its epoch and contains the validator set delta,
+// so the verifier that trusts the validator set of fromEpoch can follow it up to the validator set of toEpoch
+func (c *consensusRuntime) GetHeaderProof(fromEpoch, toEpoch uint64) ([]*types.Header, error) {
+	if fromEpoch == 0 || fromEpoch > toEpoch {
+		return nil, errInvalidHeaderProofRange
+	}
+
+	if toEpoch-fromEpoch > maxHeaderProofEpochs {
+		return nil, errHeaderProofTooLong
+	}
+
+	head := c.config.blockchain.CurrentHeader()
+
+	headExtra, err := GetIbftExtra(head.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+
+	if toEpoch > headExtra.Checkpoint.EpochNumber {
+		return nil, fmt.Errorf("epoch %d is not reached yet, current epoch is %d",
+			toEpoch, headExtra.Checkpoint.EpochNumber)
+	}
+
+	headers := make([]*types.Header, 0, toEpoch-fromEpoch)
+	searchFrom := uint64(1)
+
+	for epoch := fromEpoch; epoch < toEpoch; epoch++ {
+		header, err := getEpochEndingHeader(epoch, searchFrom, head.Number, c.config.blockchain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get epoch-ending header of epoch %d: %w", epoch, err)
+		}
+
+		headers = append(headers, header)
+		searchFrom = header.Number + 1
+	}
+
+	return headers, nil
+}
+
+// getEpochEndingHeader returns the last header of the given epoch, which ends before the head block.
+// Epoch numbers of the blocks don't decrease, so the header is found by the binary search
+func getEpochEndingHeader(epoch, from, head uint64, blockchain blockchainBackend) (*types.Header, error) {
+	var searchErr error
+
+	// find the first block of the following epoch
+	offset := sort.Search(int(head-from+1), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		_, extra, err := getBlockData(from+uint64(i), blockchain)
+		if err != nil {
+			searchErr = err
+
+			return true
+		}
+
+		return extra.Checkpoint.EpochNumber > epoch
+	})
+
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	nextEpochBlock := from + uint64(offset)
+	if nextEpochBlock > head || nextEpochBlock <= 1 {
+		return nil, fmt.Errorf("epoch %d is not finished before block %d", epoch, head)
+	}
+
+	header, extra, err := getBlockData(nextEpochBlock-1, blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	if extra.Checkpoint.EpochNumber != epoch || extra.Validators == nil {
+		return nil, fmt.Errorf("block %d is not epoch-ending block of epoch %d", header.Number, epoch)
+	}
+
+	return header, nil
+}
@@ -0,0 +1,54 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+)
+
+func TestConsensusRuntime_GetHeaderProof(t *testing.T) {
+	t.Parallel()
+
+	const epochSize = uint64(10)
+
+	validators := validator.NewTestValidators(t, 4).GetPublicIdentities()
+	head, headerMap := createTestBlocks(t, 4*epochSize+3, epochSize, validators)
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("CurrentHeader").Return(head)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headerMap.getHeader)
+
+	runtime := &consensusRuntime{
+		logger: hclog.NewNullLogger(),
+		config: &runtimeConfig{blockchain: blockchainMock},
+	}
+
+	headers, err := runtime.GetHeaderProof(2, 5)
+	require.NoError(t, err)
+	require.Len(t, headers, 3)
+
+	for i, header := range headers {
+		require.Equal(t, (uint64(i)+2)*epochSize, header.Number)
+	}
+
+	headers, err = runtime.GetHeaderProof(1, 1)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+
+	_, err = runtime.GetHeaderProof(0, 2)
+	require.ErrorIs(t, err, errInvalidHeaderProofRange)
+
+	_, err = runtime.GetHeaderProof(3, 2)
+	require.ErrorIs(t, err, errInvalidHeaderProofRange)
+
+	_, err = runtime.GetHeaderProof(1, maxHeaderProofEpochs+2)
+	require.ErrorIs(t, err, errHeaderProofTooLong)
+
+	// epoch 6 is not reached yet
+	_, err = runtime.GetHeaderProof(1, 6)
+	require.ErrorContains(t, err, "is not reached yet")
+}
@@ -0,0 +1,578 @@
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/tarality/fastrlp"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/tarality/tan-network/consensus/polybft/bitmap"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/types"
+)
+
+// The types below decode the PolyBFT header extra in the same format as the consensus does,
+// so that the light client doesn't depend on the consensus packages
+
+const (
+	// extraVanity represents a fixed number of extra-data bytes reserved for proposer vanity
+	extraVanity = 32
+
+	// quorumPercentage is the share of the total voting power which has to sign the block
+	quorumPercentage = 0.6
+)
+
+var (
+	validatorSetABIType = abi.MustNewType(`tuple(tuple(address _address, uint256[4] blsKey, uint256 votingPower)[])`)
+
+	checkpointDataABIType = abi.MustNewType(`tuple(
+	uint256 chainId,
+	uint256 blockNumber,
+	bytes32 blockHash,
+	uint256 blockRound,
+	uint256 epochNumber,
+	bytes32 eventRoot,
+	bytes32 currentValidatorsHash,
+	bytes32 nextValidatorsHash)`)
+
+	errInvalidBitmap           = errors.New("invalid bitmap filter provided")
+	errQuorumNotReached        = errors.New("quorum not reached")
+	errInvalidAggregatedSig    = errors.New("could not verify aggregated signature")
+	errUnknownUpdatedValidator = errors.New("validator is marked as updated but not found in the validators")
+)
+
+// Validator is the public identity of the validator
+type Validator struct {
+	Address     types.Address
+	BlsKey      *bls.PublicKey
+	VotingPower *big.Int
+	IsActive    bool
+}
+
+// Copy returns deep copy of the Validator
+func (v *Validator) Copy() *Validator {
+	return &Validator{
+		Address:     v.Address,
+		BlsKey:      v.BlsKey,
+		VotingPower: new(big.Int).Set(v.VotingPower),
+		IsActive:    v.IsActive,
+	}
+}
+
+// MarshalRLPWith marshals Validator to RLP format
+func (v *Validator) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+	vv.Set(ar.NewBytes(v.Address.Bytes()))
+	vv.Set(ar.NewCopyBytes(v.BlsKey.Marshal()))
+	vv.Set(ar.NewBigInt(v.VotingPower))
+	vv.Set(ar.NewBool(v.IsActive))
+
+	return vv
+}
+
+// UnmarshalRLPWith unmarshals Validator from the RLP format
+func (v *Validator) UnmarshalRLPWith(val *fastrlp.Value) error {
+	elems, err := val.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if num := len(elems); num != 4 {
+		return fmt.Errorf("incorrect elements count to decode validator, expected 4 but found %d", num)
+	}
+
+	addressRaw, err := elems[0].GetBytes(nil)
+	if err != nil {
+		return fmt.Errorf("expected 'Address' field encoded as bytes. Error: %w", err)
+	}
+
+	v.Address = types.BytesToAddress(addressRaw)
+
+	blsKeyRaw, err := elems[1].GetBytes(nil)
+	if err != nil {
+		return fmt.Errorf("expected 'BlsKey' encoded as bytes: %w", err)
+	}
+
+	if v.BlsKey, err = bls.UnmarshalPublicKey(blsKeyRaw); err != nil {
+		return fmt.Errorf("failed to unmarshal BLS public key: %w", err)
+	}
+
+	v.VotingPower = new(big.Int)
+	if err := elems[2].GetBigInt(v.VotingPower); err != nil {
+		return fmt.Errorf("expected 'VotingPower' encoded as big int: %w", err)
+	}
+
+	if v.IsActive, err = elems[3].GetBool(); err != nil {
+		return fmt.Errorf("expected 'IsActive' encoded as bool: %w", err)
+	}
+
+	return nil
+}
+
+// ValidatorSet is the ordered set of validators, as it is ordered by the consensus
+type ValidatorSet []*Validator
+
+// Len returns the number of validators
+func (vs ValidatorSet) Len() int {
+	return len(vs)
+}
+
+// Copy returns deep copy of the ValidatorSet
+func (vs ValidatorSet) Copy() ValidatorSet {
+	copied := make(ValidatorSet, len(vs))
+	for i, v := range vs {
+		copied[i] = v.Copy()
+	}
+
+	return copied
+}
+
+// Index returns index of the validator with the given address, or -1 if it is not in the set
+func (vs ValidatorSet) Index(address types.Address) int {
+	for i, v := range vs {
+		if v.Address == address {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Hash returns the hash of the validator set, as it is stored in the checkpoint data
+func (vs ValidatorSet) Hash() (types.Hash, error) {
+	validators := make([]map[string]interface{}, len(vs))
+	for i, v := range vs {
+		validators[i] = map[string]interface{}{
+			"_address":    v.Address,
+			"blsKey":      v.BlsKey.ToBigInt(),
+			"votingPower": new(big.Int).Set(v.VotingPower),
+		}
+	}
+
+	abiEncoded, err := validatorSetABIType.Encode([]interface{}{validators})
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(crypto.Keccak256(abiEncoded)), nil
+}
+
+// ApplyDelta returns the validator set with the given delta applied
+func (vs ValidatorSet) ApplyDelta(delta *ValidatorSetDelta) (ValidatorSet, error) {
+	if delta == nil || delta.isEmpty() {
+		return vs.Copy(), nil
+	}
+
+	validators := make(ValidatorSet, 0, len(vs)+len(delta.Added))
+
+	for i, v := range vs {
+		if !delta.Removed.IsSet(uint64(i)) || delta.Added.Index(v.Address) != -1 {
+			validators = append(validators, v)
+		}
+	}
+
+	for _, added := range delta.Added {
+		if validators.Index(added.Address) != -1 {
+			return nil, fmt.Errorf("validator %v is already present in the validators snapshot", added.Address)
+		}
+
+		validators = append(validators, added)
+	}
+
+	for _, updated := range delta.Updated {
+		i := validators.Index(updated.Address)
+		if i == -1 {
+			return nil, fmt.Errorf("%w: %s", errUnknownUpdatedValidator, updated.Address)
+		}
+
+		validators[i] = updated
+	}
+
+	return validators, nil
+}
+
+// hasQuorum checks if the signers hold the quorum of the voting power of the validator set.
+// The quorum is 60% of the total voting power rounded up, calculated the same way as by the consensus
+func (vs ValidatorSet) hasQuorum(signers ValidatorSet) bool {
+	total, signed := new(big.Int), new(big.Int)
+
+	for _, v := range vs {
+		total.Add(total, v.VotingPower)
+	}
+
+	for _, v := range signers {
+		signed.Add(signed, v.VotingPower)
+	}
+
+	quorum, _ := new(big.Float).Mul(big.NewFloat(quorumPercentage), new(big.Float).SetInt(total)).Float64()
+	quorumSize, _ := new(big.Float).SetFloat64(math.Ceil(quorum)).Int(nil)
+
+	return signed.Cmp(quorumSize) >= 0
+}
+
+// ValidatorSetDelta holds the validators added, updated and removed at the end of the epoch
+type ValidatorSetDelta struct {
+	Added   ValidatorSet
+	Updated ValidatorSet
+	Removed bitmap.Bitmap
+}
+
+func (d *ValidatorSetDelta) isEmpty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && d.Removed.Len() == 0
+}
+
+// MarshalRLPWith marshals ValidatorSetDelta to RLP format
+func (d *ValidatorSetDelta) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+	added := ar.NewArray()
+	updated := ar.NewArray()
+
+	for _, v := range d.Added {
+		added.Set(v.MarshalRLPWith(ar))
+	}
+
+	for _, v := range d.Updated {
+		updated.Set(v.MarshalRLPWith(ar))
+	}
+
+	vv.Set(added)
+	vv.Set(updated)
+	vv.Set(ar.NewCopyBytes(d.Removed))
+
+	return vv
+}
+
+// UnmarshalRLPWith unmarshals ValidatorSetDelta from RLP format
+func (d *ValidatorSetDelta) UnmarshalRLPWith(v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) == 0 {
+		return nil
+	} else if num := len(elems); num != 3 {
+		return fmt.Errorf("incorrect elements count to decode validator set delta, expected 3 but found %d", num)
+	}
+
+	if d.Added, err = unmarshalValidators(elems[0]); err != nil {
+		return fmt.Errorf("failed to decode added validators: %w", err)
+	}
+
+	if d.Updated, err = unmarshalValidators(elems[1]); err != nil {
+		return fmt.Errorf("failed to decode updated validators: %w", err)
+	}
+
+	removed, err := elems[2].GetBytes(nil)
+	if err != nil {
+		return err
+	}
+
+	d.Removed = bitmap.Bitmap(removed)
+
+	return nil
+}
+
+func unmarshalValidators(v *fastrlp.Value) (ValidatorSet, error) {
+	elems, err := v.GetElems()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elems) == 0 {
+		return nil, nil
+	}
+
+	validators := make(ValidatorSet, len(elems))
+
+	for i, elem := range elems {
+		validators[i] = &Validator{}
+		if err := validators[i].UnmarshalRLPWith(elem); err != nil {
+			return nil, err
+		}
+	}
+
+	return validators, nil
+}
+
+// Signature is the aggregated BLS signature of the signers determined by the bitmap
+type Signature struct {
+	AggregatedSignature []byte
+	Bitmap              []byte
+}
+
+// MarshalRLPWith marshals Signature object into RLP format
+func (s *Signature) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+
+	if s.AggregatedSignature == nil {
+		vv.Set(ar.NewNull())
+	} else {
+		vv.Set(ar.NewBytes(s.AggregatedSignature))
+	}
+
+	if s.Bitmap == nil {
+		vv.Set(ar.NewNull())
+	} else {
+		vv.Set(ar.NewBytes(s.Bitmap))
+	}
+
+	return vv
+}
+
+// UnmarshalRLPWith unmarshals Signature object from the RLP format
+func (s *Signature) UnmarshalRLPWith(v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return fmt.Errorf("array type expected for signature struct")
+	}
+
+	if num := len(elems); num != 2 {
+		return fmt.Errorf("incorrect elements count to decode Signature, expected 2 but found %d", num)
+	}
+
+	if s.AggregatedSignature, err = elems[0].GetBytes(nil); err != nil {
+		return err
+	}
+
+	s.Bitmap, err = elems[1].GetBytes(nil)
+
+	return err
+}
+
+// Verify verifies the aggregated signature of the hash is signed by the quorum of the given validators
+func (s *Signature) Verify(validators ValidatorSet, hash types.Hash, domain []byte) error {
+	signersBitmap := bitmap.Bitmap(s.Bitmap)
+
+	for i := uint64(len(validators)); i < signersBitmap.Len(); i++ {
+		if signersBitmap.IsSet(i) {
+			return errInvalidBitmap
+		}
+	}
+
+	var (
+		signers    ValidatorSet
+		publicKeys []*bls.PublicKey
+	)
+
+	for i, v := range validators {
+		if signersBitmap.IsSet(uint64(i)) {
+			signers = append(signers, v)
+			publicKeys = append(publicKeys, v.BlsKey)
+		}
+	}
+
+	if !validators.hasQuorum(signers) {
+		return errQuorumNotReached
+	}
+
+	signature, err := bls.UnmarshalSignature(s.AggregatedSignature)
+	if err != nil {
+		return err
+	}
+
+	if !signature.VerifyAggregated(publicKeys, hash[:], domain) {
+		return errInvalidAggregatedSig
+	}
+
+	return nil
+}
+
+// CheckpointData is the checkpoint data the validators sign together with the block hash
+type CheckpointData struct {
+	BlockRound            uint64
+	EpochNumber           uint64
+	CurrentValidatorsHash types.Hash
+	NextValidatorsHash    types.Hash
+	EventRoot             types.Hash
+}
+
+// MarshalRLPWith marshals CheckpointData to RLP format
+func (c *CheckpointData) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+	vv.Set(ar.NewUint(c.BlockRound))
+	vv.Set(ar.NewUint(c.EpochNumber))
+	vv.Set(ar.NewBytes(c.CurrentValidatorsHash.Bytes()))
+	vv.Set(ar.NewBytes(c.NextValidatorsHash.Bytes()))
+	vv.Set(ar.NewBytes(c.EventRoot.Bytes()))
+
+	return vv
+}
+
+// UnmarshalRLPWith unmarshals CheckpointData from the RLP format
+func (c *CheckpointData) UnmarshalRLPWith(v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return fmt.Errorf("array type expected for CheckpointData struct")
+	}
+
+	if num := len(elems); num != 5 {
+		return fmt.Errorf("incorrect elements count to decode CheckpointData, expected 5 but found %d", num)
+	}
+
+	if c.BlockRound, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if c.EpochNumber, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	hashes := []*types.Hash{&c.CurrentValidatorsHash, &c.NextValidatorsHash, &c.EventRoot}
+	for i, hash := range hashes {
+		raw, err := elems[i+2].GetBytes(nil)
+		if err != nil {
+			return err
+		}
+
+		*hash = types.BytesToHash(raw)
+	}
+
+	return nil
+}
+
+// Hash calculates keccak256 hash of the ABI encoded CheckpointData of the given block
+func (c *CheckpointData) Hash(chainID uint64, blockNumber uint64, blockHash types.Hash) (types.Hash, error) {
+	abiEncoded, err := checkpointDataABIType.Encode(map[string]interface{}{
+		"chainId":               new(big.Int).SetUint64(chainID),
+		"blockNumber":           new(big.Int).SetUint64(blockNumber),
+		"blockHash":             blockHash,
+		"blockRound":            new(big.Int).SetUint64(c.BlockRound),
+		"epochNumber":           new(big.Int).SetUint64(c.EpochNumber),
+		"eventRoot":             c.EventRoot,
+		"currentValidatorsHash": c.CurrentValidatorsHash,
+		"nextValidatorsHash":    c.NextValidatorsHash,
+	})
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(crypto.Keccak256(abiEncoded)), nil
+}
+
+// Extra is the PolyBFT header extra
+type Extra struct {
+	Validators *ValidatorSetDelta
+	Parent     *Signature
+	Committed  *Signature
+	Checkpoint *CheckpointData
+}
+
+// MarshalRLPTo marshals Extra (prefixed by the empty vanity) to RLP format
+func (e *Extra) MarshalRLPTo(dst []byte) []byte {
+	ar := &fastrlp.Arena{}
+
+	return append(make([]byte, extraVanity), e.MarshalRLPWith(ar).MarshalTo(dst)...)
+}
+
+// MarshalRLPWith marshals Extra to RLP format
+func (e *Extra) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+
+	if e.Validators == nil {
+		vv.Set(ar.NewNullArray())
+	} else {
+		vv.Set(e.Validators.MarshalRLPWith(ar))
+	}
+
+	for _, signature := range []*Signature{e.Parent, e.Committed} {
+		if signature == nil {
+			vv.Set(ar.NewNullArray())
+		} else {
+			vv.Set(signature.MarshalRLPWith(ar))
+		}
+	}
+
+	if e.Checkpoint == nil {
+		vv.Set(ar.NewNullArray())
+	} else {
+		vv.Set(e.Checkpoint.MarshalRLPWith(ar))
+	}
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals Extra (prefixed by the vanity) from the RLP format
+func (e *Extra) UnmarshalRLP(input []byte) error {
+	return fastrlp.UnmarshalRLP(input[extraVanity:], e)
+}
+
+// UnmarshalRLPWith unmarshals Extra from the RLP format
+func (e *Extra) UnmarshalRLPWith(v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if num := len(elems); num != 4 {
+		return fmt.Errorf("incorrect elements count to decode Extra, expected 4 but found %d", num)
+	}
+
+	if elems[0].Elems() > 0 {
+		e.Validators = &ValidatorSetDelta{}
+		if err := e.Validators.UnmarshalRLPWith(elems[0]); err != nil {
+			return err
+		}
+	}
+
+	if elems[1].Elems() > 0 {
+		e.Parent = &Signature{}
+		if err := e.Parent.UnmarshalRLPWith(elems[1]); err != nil {
+			return err
+		}
+	}
+
+	if elems[2].Elems() > 0 {
+		e.Committed = &Signature{}
+		if err := e.Committed.UnmarshalRLPWith(elems[2]); err != nil {
+			return err
+		}
+	}
+
+	if elems[3].Elems() > 0 {
+		e.Checkpoint = &CheckpointData{}
+		if err := e.Checkpoint.UnmarshalRLPWith(elems[3]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetExtra decodes the PolyBFT extra of the header
+func GetExtra(extraRaw []byte) (*Extra, error) {
+	if len(extraRaw) < extraVanity {
+		return nil, fmt.Errorf("wrong extra size: %d", len(extraRaw))
+	}
+
+	extra := &Extra{}
+	if err := extra.UnmarshalRLP(extraRaw); err != nil {
+		return nil, err
+	}
+
+	return extra, nil
+}
+
+// HeaderHash calculates PolyBFT header hash, which doesn't include the committed signatures of the header
+func HeaderHash(header *types.Header) (types.Hash, error) {
+	extra, err := GetExtra(header.ExtraData)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	clean := &Extra{
+		Validators: extra.Validators,
+		Parent:     extra.Parent,
+		Committed:  &Signature{},
+		Checkpoint: extra.Checkpoint,
+	}
+
+	h := header.Copy()
+	h.ExtraData = clean.MarshalRLPTo(nil)
+
+	return h.ComputeHash().Hash, nil
+}
@@ -0,0 +1,237 @@
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/types"
+)
+
+var (
+	errGenesisNotVerifiable    = errors.New("genesis header can't be verified, it has to be trusted")
+	errHeaderNotNewer          = errors.New("header is not newer than the last verified header")
+	errHeaderHashMismatch      = errors.New("header hash doesn't match its content")
+	errParentHashMismatch      = errors.New("parent hash doesn't match the last verified header")
+	errSignaturesMissing       = errors.New("committed signatures are not present")
+	errCheckpointMissing       = errors.New("checkpoint data are not present")
+	errEpochNotIncreasing      = errors.New("epoch number is lower than the one of the last verified header")
+	errUnknownValidators       = errors.New("header is not validated by the trusted validator set")
+	errNextValidatorsMismatch  = errors.New("next validators hash doesn't match the validator set delta")
+	errInvalidGenesisHeader    = errors.New("genesis header doesn't contain the initial validator set")
+	errEmptyTrustedValidators  = errors.New("trusted validator set is empty")
+	errDeltaMissingOnSetChange = errors.New("validator set changes, but validator set delta is not present")
+)
+
+// TrustedState is the state the light client starts from. It has to be obtained from a trusted source
+type TrustedState struct {
+	// Epoch is the epoch of the trusted validator set
+	Epoch uint64
+
+	// Validators is the validator set which validates the blocks of the epoch
+	Validators ValidatorSet
+}
+
+// LightClient verifies PolyBFT headers starting from the trusted validator set.
+// It needs only the headers, and follows the validator set changes
+// by verifying the epoch-ending headers, which contain the validator set delta.
+// Headers don't have to be contiguous, so verifying one epoch-ending header per epoch
+// is enough to jump from one epoch to another
+type LightClient struct {
+	chainID    uint64
+	epoch      uint64
+	validators ValidatorSet
+	lastHeader *types.Header
+	logger     hclog.Logger
+
+	// lastHeaderEpoch is the epoch number of the last verified header
+	lastHeaderEpoch uint64
+}
+
+// NewLightClient creates LightClient which trusts the given validator set
+func NewLightClient(chainID uint64, trusted *TrustedState, logger hclog.Logger) (*LightClient, error) {
+	if trusted.Validators.Len() == 0 {
+		return nil, errEmptyTrustedValidators
+	}
+
+	return &LightClient{
+		chainID:    chainID,
+		epoch:      trusted.Epoch,
+		validators: trusted.Validators.Copy(),
+		logger:     logger,
+	}, nil
+}
+
+// NewLightClientFromGenesis creates LightClient which trusts the initial validator set from the given genesis header
+func NewLightClientFromGenesis(chainID uint64, genesis *types.Header, logger hclog.Logger) (*LightClient, error) {
+	if genesis.Number != 0 {
+		return nil, fmt.Errorf("block %d is not a genesis block", genesis.Number)
+	}
+
+	extra, err := GetExtra(genesis.ExtraData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode genesis extra: %w", err)
+	}
+
+	if extra.Validators == nil || len(extra.Validators.Added) == 0 {
+		return nil, errInvalidGenesisHeader
+	}
+
+	validators, err := ValidatorSet{}.ApplyDelta(extra.Validators)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := HeaderHash(genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewLightClient(chainID, &TrustedState{Epoch: 1, Validators: validators}, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	client.lastHeader = withHash(genesis, hash)
+
+	return client, nil
+}
+
+// Epoch returns the epoch of the current validator set
+func (l *LightClient) Epoch() uint64 {
+	return l.epoch
+}
+
+// Validators returns the current validator set,
+// which validates the blocks following the last verified header
+func (l *LightClient) Validators() ValidatorSet {
+	return l.validators.Copy()
+}
+
+// LastHeader returns the last verified header (nil if none is verified yet)
+func (l *LightClient) LastHeader() *types.Header {
+	return l.lastHeader
+}
+
+// VerifyHeaders verifies the given headers in order
+func (l *LightClient) VerifyHeaders(headers []*types.Header) error {
+	for _, header := range headers {
+		if err := l.VerifyHeader(header); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyHeader verifies the header is signed by the quorum of the current validator set.
+// If the header is the epoch-ending one, the validator set delta it contains is applied,
+// so the following headers are verified against the validator set of the next epoch
+func (l *LightClient) VerifyHeader(header *types.Header) error {
+	if header.Number == 0 {
+		return errGenesisNotVerifiable
+	}
+
+	if l.lastHeader != nil && header.Number <= l.lastHeader.Number {
+		return fmt.Errorf("failed to verify header %d: %w", header.Number, errHeaderNotNewer)
+	}
+
+	extra, err := GetExtra(header.ExtraData)
+	if err != nil {
+		return fmt.Errorf("failed to verify header %d, get extra error: %w", header.Number, err)
+	}
+
+	if extra.Committed == nil {
+		return fmt.Errorf("failed to verify header %d: %w", header.Number, errSignaturesMissing)
+	}
+
+	if extra.Checkpoint == nil {
+		return fmt.Errorf("failed to verify header %d: %w", header.Number, errCheckpointMissing)
+	}
+
+	hash, err := HeaderHash(header)
+	if err != nil {
+		return fmt.Errorf("failed to verify header %d: %w", header.Number, err)
+	}
+
+	if header.Hash != types.ZeroHash && header.Hash != hash {
+		return fmt.Errorf("failed to verify header %d: %w", header.Number, errHeaderHashMismatch)
+	}
+
+	if l.lastHeader != nil {
+		if header.Number == l.lastHeader.Number+1 && header.ParentHash != l.lastHeader.Hash {
+			return fmt.Errorf("failed to verify header %d: %w", header.Number, errParentHashMismatch)
+		}
+
+		if extra.Checkpoint.EpochNumber < l.lastHeaderEpoch {
+			return fmt.Errorf("failed to verify header %d: %w", header.Number, errEpochNotIncreasing)
+		}
+	}
+
+	currentValidatorsHash, err := l.validators.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to calculate current validators hash: %w", err)
+	}
+
+	if currentValidatorsHash != extra.Checkpoint.CurrentValidatorsHash {
+		return fmt.Errorf("failed to verify header %d (epoch %d, trusted epoch %d): %w",
+			header.Number, extra.Checkpoint.EpochNumber, l.epoch, errUnknownValidators)
+	}
+
+	checkpointHash, err := extra.Checkpoint.Hash(l.chainID, header.Number, hash)
+	if err != nil {
+		return fmt.Errorf("failed to calculate proposal hash: %w", err)
+	}
+
+	if err := extra.Committed.Verify(l.validators, checkpointHash, bls.DomainCheckpointManager); err != nil {
+		return fmt.Errorf("failed to verify signatures for header %d (proposal hash %s): %w",
+			header.Number, checkpointHash, err)
+	}
+
+	nextValidators := l.validators
+	nextEpoch := extra.Checkpoint.EpochNumber
+
+	if extra.Validators != nil {
+		// epoch-ending header, validator set of the next epoch is the current one with the delta applied
+		if nextValidators, err = l.validators.ApplyDelta(extra.Validators); err != nil {
+			return fmt.Errorf("failed to apply validator set delta of header %d: %w", header.Number, err)
+		}
+
+		nextEpoch++
+	}
+
+	nextValidatorsHash, err := nextValidators.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to calculate next validators hash: %w", err)
+	}
+
+	if nextValidatorsHash != extra.Checkpoint.NextValidatorsHash {
+		if extra.Validators == nil {
+			return fmt.Errorf("failed to verify header %d: %w", header.Number, errDeltaMissingOnSetChange)
+		}
+
+		return fmt.Errorf("failed to verify header %d: %w", header.Number, errNextValidatorsMismatch)
+	}
+
+	if extra.Validators != nil {
+		l.logger.Debug("validator set changed", "block", header.Number, "epoch", nextEpoch,
+			"validators", nextValidators.Len())
+	}
+
+	l.validators = nextValidators
+	l.epoch = nextEpoch
+	l.lastHeader = withHash(header, hash)
+	l.lastHeaderEpoch = extra.Checkpoint.EpochNumber
+
+	return nil
+}
+
+// withHash returns copy of the header with the given hash
+func withHash(header *types.Header, hash types.Hash) *types.Header {
+	h := header.Copy()
+	h.Hash = hash
+
+	return h
+}
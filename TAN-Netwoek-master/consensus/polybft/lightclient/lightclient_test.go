@@ -0,0 +1,341 @@
+package lightclient
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarality/tan-network/consensus/polybft"
+	"github.com/tarality/tan-network/consensus/polybft/bitmap"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	testChainID   = uint64(100)
+	testEpochSize = uint64(5)
+)
+
+func TestLightClient_VerifyAllHeaders(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, [][]string{{"A", "B", "C", "D"}, {"A", "B", "C", "E"}, {"B", "C", "E", "F"}})
+
+	client, err := NewLightClientFromGenesis(testChainID, chain.headers[0], hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), client.Epoch())
+
+	require.NoError(t, client.VerifyHeaders(chain.headers[1:]))
+	require.Equal(t, uint64(4), client.Epoch())
+	require.Equal(t, chain.headers[len(chain.headers)-1].Hash, client.LastHeader().Hash)
+	requireSameValidators(t, chain.validatorSets[3], client.Validators())
+}
+
+func TestLightClient_JumpEpochs(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, [][]string{{"A", "B", "C", "D"}, {"A", "B", "C", "E"}, {"B", "C", "E", "F"}})
+
+	client, err := NewLightClientFromGenesis(testChainID, chain.headers[0], hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	// epoch-ending headers of the epochs 1, 2 and 3 and a header in the middle of the epoch 4
+	require.NoError(t, client.VerifyHeaders([]*types.Header{
+		chain.epochEndingHeader(1), chain.epochEndingHeader(2), chain.epochEndingHeader(3),
+		chain.headers[3*testEpochSize+2],
+	}))
+	require.Equal(t, uint64(4), client.Epoch())
+	requireSameValidators(t, chain.validatorSets[3], client.Validators())
+}
+
+func TestLightClient_TrustedState(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, [][]string{{"A", "B", "C", "D"}, {"A", "B", "C", "E"}})
+
+	client, err := NewLightClient(testChainID,
+		&TrustedState{Epoch: 2, Validators: toValidatorSet(chain.validatorSets[1])}, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	// header of the epoch 1 isn't validated by the trusted validator set
+	require.ErrorIs(t, client.VerifyHeader(chain.headers[2]), errUnknownValidators)
+
+	require.NoError(t, client.VerifyHeader(chain.epochEndingHeader(2)))
+	require.Equal(t, uint64(3), client.Epoch())
+
+	_, err = NewLightClient(testChainID, &TrustedState{Epoch: 1}, hclog.NewNullLogger())
+	require.ErrorIs(t, err, errEmptyTrustedValidators)
+}
+
+func TestLightClient_InvalidHeaders(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, [][]string{{"A", "B", "C", "D"}, {"A", "B", "C", "E"}})
+
+	newClient := func() *LightClient {
+		client, err := NewLightClientFromGenesis(testChainID, chain.headers[0], hclog.NewNullLogger())
+		require.NoError(t, err)
+
+		return client
+	}
+
+	t.Run("genesis", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, newClient().VerifyHeader(chain.headers[0]), errGenesisNotVerifiable)
+	})
+
+	t.Run("skipped epoch-ending header", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, newClient().VerifyHeader(chain.headers[testEpochSize+1]), errUnknownValidators)
+	})
+
+	t.Run("older header", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient()
+		require.NoError(t, client.VerifyHeader(chain.headers[3]))
+		require.ErrorIs(t, client.VerifyHeader(chain.headers[2]), errHeaderNotNewer)
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := chain.headers[2].Copy()
+		tampered.StateRoot = types.StringToHash("0x1")
+		require.ErrorIs(t, newClient().VerifyHeader(tampered), errHeaderHashMismatch)
+
+		// hash is recalculated, so signatures don't match anymore
+		tampered.Hash = types.ZeroHash
+		require.ErrorContains(t, newClient().VerifyHeader(tampered), "could not verify aggregated signature")
+	})
+
+	t.Run("wrong parent", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient()
+		require.NoError(t, client.VerifyHeader(chain.headers[1]))
+
+		header := chain.sealHeader(&types.Header{Number: 2, ParentHash: types.StringToHash("0x1")},
+			&polybft.Extra{}, 1, chain.validatorSets[0], chain.validatorSets[0], 4)
+		require.ErrorIs(t, client.VerifyHeader(header), errParentHashMismatch)
+	})
+
+	t.Run("quorum not reached", func(t *testing.T) {
+		t.Parallel()
+
+		header := chain.sealHeader(&types.Header{Number: 2, ParentHash: chain.headers[1].Hash},
+			&polybft.Extra{}, 1, chain.validatorSets[0], chain.validatorSets[0], 1)
+		require.ErrorContains(t, newClient().VerifyHeader(header), "quorum not reached")
+	})
+
+	t.Run("validator set changed without delta", func(t *testing.T) {
+		t.Parallel()
+
+		header := chain.sealHeader(&types.Header{Number: 2, ParentHash: chain.headers[1].Hash},
+			&polybft.Extra{}, 1, chain.validatorSets[0], chain.validatorSets[1], 4)
+		require.ErrorIs(t, newClient().VerifyHeader(header), errDeltaMissingOnSetChange)
+	})
+}
+
+func TestLightClient_InvalidGenesis(t *testing.T) {
+	t.Parallel()
+
+	extra := &polybft.Extra{Checkpoint: &polybft.CheckpointData{}}
+
+	_, err := NewLightClientFromGenesis(testChainID,
+		&types.Header{Number: 0, ExtraData: extra.MarshalRLPTo(nil)}, hclog.NewNullLogger())
+	require.ErrorIs(t, err, errInvalidGenesisHeader)
+
+	_, err = NewLightClientFromGenesis(testChainID,
+		&types.Header{Number: 1, ExtraData: extra.MarshalRLPTo(nil)}, hclog.NewNullLogger())
+	require.Error(t, err)
+}
+
+// testChain is the chain of sealed headers, which validator set changes every epoch
+type testChain struct {
+	t             *testing.T
+	validators    *validator.TestValidators
+	identities    map[string]*validator.ValidatorMetadata
+	validatorSets []validator.AccountSet
+	headers       []*types.Header
+}
+
+// newTestChain creates the chain with the given validator sets of the epochs.
+// Validator set of the last epoch is kept for one more epoch
+func newTestChain(t *testing.T, epochValidators [][]string) *testChain {
+	t.Helper()
+
+	chain := &testChain{
+		t:          t,
+		validators: validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D", "E", "F"}),
+		identities: map[string]*validator.ValidatorMetadata{},
+	}
+
+	for alias, v := range chain.validators.Validators {
+		chain.identities[alias] = v.ValidatorMetadata()
+	}
+
+	genesisSet := chain.validatorSet(epochValidators[0])
+	genesisExtra := &polybft.Extra{
+		Validators: &validator.ValidatorSetDelta{Added: genesisSet, Removed: bitmap.Bitmap{}},
+		Checkpoint: &polybft.CheckpointData{},
+	}
+	genesis := &types.Header{Number: 0, ExtraData: genesisExtra.MarshalRLPTo(nil)}
+
+	hash, err := polybftHeaderHash(genesis)
+	require.NoError(t, err)
+
+	genesis.Hash = hash
+	chain.headers = append(chain.headers, genesis)
+
+	currentSet := genesisSet
+	epochValidators = append(epochValidators, epochValidators[len(epochValidators)-1])
+
+	for epoch := uint64(1); epoch <= uint64(len(epochValidators)); epoch++ {
+		chain.validatorSets = append(chain.validatorSets, currentSet)
+
+		for i := uint64(1); i <= testEpochSize; i++ {
+			number := (epoch-1)*testEpochSize + i
+			extra := &polybft.Extra{}
+			nextSet := currentSet
+
+			// the last epoch doesn't end
+			if i == testEpochSize && epoch < uint64(len(epochValidators)) {
+				delta, err := validator.CreateValidatorSetDelta(currentSet, chain.validatorSet(epochValidators[epoch]))
+				require.NoError(t, err)
+
+				nextSet, err = currentSet.ApplyDelta(delta)
+				require.NoError(t, err)
+
+				extra.Validators = delta
+			}
+
+			header := chain.sealHeader(&types.Header{Number: number, ParentHash: chain.headers[number-1].Hash},
+				extra, epoch, currentSet, nextSet, currentSet.Len())
+
+			chain.headers = append(chain.headers, header)
+			currentSet = nextSet
+		}
+	}
+
+	return chain
+}
+
+// validatorSet returns the validator set of the given validators.
+// Identities are reused, so the validators are considered equal across validator sets
+func (c *testChain) validatorSet(aliases []string) validator.AccountSet {
+	validators := make(validator.AccountSet, len(aliases))
+	for i, alias := range aliases {
+		validators[i] = c.identities[alias]
+	}
+
+	return validators
+}
+
+// epochEndingHeader returns the last header of the given epoch
+func (c *testChain) epochEndingHeader(epoch uint64) *types.Header {
+	return c.headers[epoch*testEpochSize]
+}
+
+// sealHeader sets the checkpoint of the header and signs it by the given number of the current validators
+func (c *testChain) sealHeader(header *types.Header, extra *polybft.Extra, epoch uint64,
+	currentSet, nextSet validator.AccountSet, signersCount int) *types.Header {
+	c.t.Helper()
+
+	currentHash, err := currentSet.Hash()
+	require.NoError(c.t, err)
+
+	nextHash, err := nextSet.Hash()
+	require.NoError(c.t, err)
+
+	extra.Checkpoint = &polybft.CheckpointData{
+		EpochNumber:           epoch,
+		CurrentValidatorsHash: currentHash,
+		NextValidatorsHash:    nextHash,
+	}
+	extra.Committed = &polybft.Signature{}
+	header.ExtraData = extra.MarshalRLPTo(nil)
+
+	hash, err := polybftHeaderHash(header)
+	require.NoError(c.t, err)
+
+	checkpointHash, err := extra.Checkpoint.Hash(testChainID, header.Number, hash)
+	require.NoError(c.t, err)
+
+	var (
+		signatures bls.Signatures
+		signers    bitmap.Bitmap
+	)
+
+	for i, v := range currentSet[:signersCount] {
+		signer := c.validators.GetValidator(c.aliasOf(v.Address))
+		signatures = append(signatures, signer.MustSign(checkpointHash[:], bls.DomainCheckpointManager))
+		signers.Set(uint64(i))
+	}
+
+	aggregated, err := signatures.Aggregate().Marshal()
+	require.NoError(c.t, err)
+
+	extra.Committed = &polybft.Signature{AggregatedSignature: aggregated, Bitmap: signers}
+	header.ExtraData = extra.MarshalRLPTo(nil)
+	header.Hash = hash
+
+	return header
+}
+
+func (c *testChain) aliasOf(address types.Address) string {
+	for alias, v := range c.validators.Validators {
+		if v.Address() == address {
+			return alias
+		}
+	}
+
+	c.t.Fatalf("unknown validator %s", address)
+
+	return ""
+}
+
+// polybftHeaderHash calculates the header hash the way the consensus does,
+// so the headers of the test chain verify the light client decoding is compatible with the consensus
+func polybftHeaderHash(header *types.Header) (types.Hash, error) {
+	extra, err := polybft.GetIbftExtraClean(header.ExtraData)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	h := header.Copy()
+	h.ExtraData = extra
+
+	return h.ComputeHash().Hash, nil
+}
+
+func toValidatorSet(accounts validator.AccountSet) ValidatorSet {
+	validators := make(ValidatorSet, len(accounts))
+	for i, a := range accounts {
+		validators[i] = &Validator{
+			Address:     a.Address,
+			BlsKey:      a.BlsKey,
+			VotingPower: a.VotingPower,
+			IsActive:    a.IsActive,
+		}
+	}
+
+	return validators
+}
+
+func requireSameValidators(t *testing.T, expected validator.AccountSet, actual ValidatorSet) {
+	t.Helper()
+
+	expectedHash, err := expected.Hash()
+	require.NoError(t, err)
+
+	actualHash, err := actual.Hash()
+	require.NoError(t, err)
+
+	require.Equal(t, expectedHash, actualHash)
+	require.Equal(t, toValidatorSet(expected), actual)
+}
@@ -0,0 +1,213 @@
+package polybft
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/tarality/tan-network/consensus/polybft/proto"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// defaultUpcomingProposersCount is the number of upcoming proposers returned if not specified in the request
+	defaultUpcomingProposersCount = 10
+
+	// maxUpcomingProposersCount is the maximum number of upcoming proposers returned
+	maxUpcomingProposersCount = 1000
+)
+
+type operator struct {
+	proto.UnimplementedPolybftOperatorServer
+
+	polybft *Polybft
+}
+
+// Status returns the status of the polybft client
+func (o *operator) Status(ctx context.Context, req *empty.Empty) (*proto.PolybftStatusResp, error) {
+	data, err := o.polybft.runtime.getGuardedData()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.PolybftStatusResp{
+		Key:             o.polybft.key.String(),
+		ActiveValidator: o.polybft.runtime.IsActiveValidator(),
+		Block:           data.lastBuiltBlock.Number,
+		Epoch:           data.epoch.Number,
+		Sprint:          o.currentSprint(data),
+	}, nil
+}
+
+// Validators returns the validator set of the current epoch,
+// together with the validator set delta that is going to be applied at the end of the epoch
+func (o *operator) Validators(ctx context.Context, req *empty.Empty) (*proto.ValidatorsResp, error) {
+	data, err := o.polybft.runtime.getGuardedData()
+	if err != nil {
+		return nil, err
+	}
+
+	validators := data.epoch.Validators
+	totalVotingPower := new(big.Int)
+
+	for _, v := range validators {
+		totalVotingPower.Add(totalVotingPower, v.VotingPower)
+	}
+
+	delta, err := o.pendingValidatorSetDelta(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ValidatorsResp{
+		Epoch:            data.epoch.Number,
+		Validators:       validatorsToProtoValidators(validators),
+		TotalVotingPower: totalVotingPower.String(),
+		PendingDelta:     validatorSetDeltaToProto(delta, validators),
+	}, nil
+}
+
+// Epoch returns the information about the current epoch,
+// together with the checkpoint and state sync progress
+func (o *operator) Epoch(ctx context.Context, req *empty.Empty) (*proto.EpochResp, error) {
+	data, err := o.polybft.runtime.getGuardedData()
+	if err != nil {
+		return nil, err
+	}
+
+	lastProcessedBlock, err := o.polybft.state.CheckpointStore.getLastSaved()
+	if err != nil && !errors.Is(err, errNoLastSavedEntry) {
+		return nil, err
+	}
+
+	lastEventID, err := o.polybft.state.StateSyncStore.getLastStateSyncEventID()
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := o.polybft.state.StateSyncStore.getLastCommitmentMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	stateSync := &proto.StateSyncProgress{
+		LastEventId: lastEventID,
+	}
+
+	if commitment != nil {
+		stateSync.LastCommitmentStartId = commitment.Message.StartID.Uint64()
+		stateSync.LastCommitmentEndId = commitment.Message.EndID.Uint64()
+	}
+
+	return &proto.EpochResp{
+		Number:     data.epoch.Number,
+		FirstBlock: data.epoch.FirstBlockInEpoch,
+		LastBlock:  data.epoch.FirstBlockInEpoch + o.polybft.consensusConfig.EpochSize - 1,
+		Sprint:     o.currentSprint(data),
+		SprintSize: o.polybft.consensusConfig.SprintSize,
+		Block:      data.lastBuiltBlock.Number,
+		Checkpoint: &proto.CheckpointProgress{
+			LastProcessedBlock: lastProcessedBlock,
+		},
+		StateSync: stateSync,
+	}, nil
+}
+
+// Proposers returns the upcoming proposers order, calculated from the current proposer snapshot
+func (o *operator) Proposers(ctx context.Context, req *proto.ProposersReq) (*proto.ProposersResp, error) {
+	count := req.Count
+	if count == 0 {
+		count = defaultUpcomingProposersCount
+	}
+
+	if count > maxUpcomingProposersCount {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"proposers count %d exceeds the maximum of %d", count, maxUpcomingProposersCount)
+	}
+
+	data, err := o.polybft.runtime.getGuardedData()
+	if err != nil {
+		return nil, err
+	}
+
+	proposers, err := data.proposerSnapshot.UpcomingProposers(count)
+	if err != nil {
+		return nil, err
+	}
+
+	protoProposers := make([]*proto.Proposer, len(proposers))
+
+	for i, p := range proposers {
+		protoProposers[i] = &proto.Proposer{
+			Height:   data.proposerSnapshot.Height + uint64(i),
+			Address:  p.Metadata.Address.String(),
+			Priority: p.ProposerPriority.String(),
+		}
+	}
+
+	return &proto.ProposersResp{
+		Height:    data.proposerSnapshot.Height,
+		Proposers: protoProposers,
+	}, nil
+}
+
+// pendingValidatorSetDelta calculates the validator set delta from the full validator set stored by the stake manager.
+// The BLS keys of the added validators are not needed, so the supernet manager is not queried
+func (o *operator) pendingValidatorSetDelta(validators validator.AccountSet) (*validator.ValidatorSetDelta, error) {
+	fullValidatorSet, err := o.polybft.state.StakeStore.getFullValidatorSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return newValidatorSetDelta(validators,
+		fullValidatorSet.nextValidatorSet(int(o.polybft.consensusConfig.MaxValidatorSetSize))), nil
+}
+
+// currentSprint returns the (1-based) sprint number within the epoch of the block being built
+func (o *operator) currentSprint(data guardedDataDTO) uint64 {
+	sprintSize := o.polybft.consensusConfig.SprintSize
+	height := data.lastBuiltBlock.Number + 1
+
+	if sprintSize == 0 || height < data.epoch.FirstBlockInEpoch {
+		return 0
+	}
+
+	return (height-data.epoch.FirstBlockInEpoch)/sprintSize + 1
+}
+
+// validatorsToProtoValidators converts validator account set to the proto validators
+func validatorsToProtoValidators(validators validator.AccountSet) []*proto.Validator {
+	protoValidators := make([]*proto.Validator, len(validators))
+
+	for i, v := range validators {
+		protoValidators[i] = &proto.Validator{
+			Address:     v.Address.String(),
+			VotingPower: v.VotingPower.String(),
+			IsActive:    v.IsActive,
+		}
+	}
+
+	return protoValidators
+}
+
+// validatorSetDeltaToProto converts validator set delta to the proto delta,
+// resolving removed validators bitmap against the current validator set
+func validatorSetDeltaToProto(delta *validator.ValidatorSetDelta,
+	validators validator.AccountSet) *proto.ValidatorSetDelta {
+	removed := []string{}
+
+	for i, v := range validators {
+		if delta.Removed.IsSet(uint64(i)) {
+			removed = append(removed, v.Address.String())
+		}
+	}
+
+	return &proto.ValidatorSetDelta{
+		Added:   validatorsToProtoValidators(delta.Added),
+		Updated: validatorsToProtoValidators(delta.Updated),
+		Removed: removed,
+	}
+}
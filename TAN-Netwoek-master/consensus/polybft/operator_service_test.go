@@ -0,0 +1,55 @@
+package polybft
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tarality/tan-network/consensus/polybft/proto"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+)
+
+func TestOperator_Proposers_CountLimit(t *testing.T) {
+	t.Parallel()
+
+	o := &operator{polybft: &Polybft{}}
+
+	_, err := o.Proposers(context.Background(), &proto.ProposersReq{Count: maxUpcomingProposersCount + 1})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOperator_PendingValidatorSetDelta(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"}, []uint64{10, 10, 10, 10})
+	state := newTestState(t)
+
+	// B is updated, C is unstaked and D is added
+	fullValidatorSet := validators.GetPublicIdentities("A", "B", "D").Copy()
+	fullValidatorSet[1].VotingPower = big.NewInt(20)
+
+	require.NoError(t, state.StakeStore.insertFullValidatorSet(validatorSetState{
+		Validators: newValidatorStakeMap(fullValidatorSet),
+	}))
+
+	o := &operator{polybft: &Polybft{
+		state:           state,
+		consensusConfig: &PolyBFTConfig{MaxValidatorSetSize: 10},
+	}}
+
+	delta, err := o.pendingValidatorSetDelta(validators.GetPublicIdentities("A", "B", "C"))
+	require.NoError(t, err)
+
+	require.Len(t, delta.Added, 1)
+	require.Equal(t, validators.GetValidator("D").Address(), delta.Added[0].Address)
+	require.Len(t, delta.Updated, 1)
+	require.Equal(t, validators.GetValidator("B").Address(), delta.Updated[0].Address)
+	require.False(t, delta.Removed.IsSet(0))
+	require.False(t, delta.Removed.IsSet(1))
+	require.True(t, delta.Removed.IsSet(2))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/consensus"
 	"github.com/tarality/tan-network/consensus/polybft/contractsapi"
+	polybftProto "github.com/tarality/tan-network/consensus/polybft/proto"
 	bls "github.com/tarality/tan-network/consensus/polybft/signer"
 	"github.com/tarality/tan-network/consensus/polybft/validator"
 	"github.com/tarality/tan-network/consensus/polybft/wallet"
@@ -21,6 +22,7 @@ import (
 	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/helper/progress"
 	"github.com/tarality/tan-network/network"
+	"github.com/tarality/tan-network/secrets"
 	"github.com/tarality/tan-network/state"
 	"github.com/tarality/tan-network/syncer"
 	"github.com/tarality/tan-network/types"
@@ -118,6 +120,9 @@ type Polybft struct {
 
 	// tx pool as interface
 	txPool txPoolInterface
+
+	// operator is a reference to the gRPC service of polybft
+	operator *operator
 }
 
 func GenesisPostHookFactory(config *chain.Chain, engineName string) func(txn *state.Transition) error {
@@ -401,18 +406,40 @@ func ForkManagerFactory(forks *chain.Forks) error {
 	return nil
 }
 
+// createKey creates the validator key, which is held either by the key signer (e.g. remote signer)
+// or read from the secrets manager
+func (p *Polybft) createKey() (*wallet.Key, error) {
+	if p.config.KeySigner != nil {
+		return wallet.NewKeyFromSigner(p.config.KeySigner), nil
+	}
+
+	// node might have stopped while switching to the rotated bls key, in that case finish switching
+	if !p.config.SecretsManager.HasSecret(secrets.ValidatorBLSKey) &&
+		p.config.SecretsManager.HasSecret(secrets.ValidatorBLSKeyNext) {
+		if err := wallet.PromotePendingBls(p.config.SecretsManager); err != nil {
+			return nil, fmt.Errorf("failed to persist rotated bls key. Error: %w", err)
+		}
+	}
+
+	account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account data. Error: %w", err)
+	}
+
+	return wallet.NewKey(account), nil
+}
+
 // Initialize initializes the consensus (e.g. setup data)
 func (p *Polybft) Initialize() error {
 	p.logger.Info("initializing polybft...")
 
-	// read account
-	account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
+	// set key
+	key, err := p.createKey()
 	if err != nil {
-		return fmt.Errorf("failed to read account data. Error: %w", err)
+		return err
 	}
 
-	// set key
-	p.key = wallet.NewKey(account)
+	p.key = key
 
 	// create and set syncer
 	p.syncer = syncer.NewSyncer(
@@ -462,6 +489,12 @@ func (p *Polybft) Initialize() error {
 		return fmt.Errorf("IBFT topic subscription failed: %w", err)
 	}
 
+	// register the grpc operator
+	if p.config.Grpc != nil {
+		p.operator = &operator{polybft: p}
+		polybftProto.RegisterPolybftOperatorServer(p.config.Grpc, p.operator)
+	}
+
 	return nil
 }
 
@@ -528,6 +561,13 @@ func (p *Polybft) initRuntime() error {
 		txPool:                p.txPool,
 		bridgeTopic:           p.bridgeTopic,
 		numBlockConfirmations: p.config.NumBlockConfirmations,
+		uptimeAlertThreshold:  p.config.UptimeAlertThreshold,
+		closeCh:               p.closeCh,
+	}
+
+	if p.config.KeySigner == nil {
+		runtimeConfig.keySwitcher = newValidatorKeySwitcher(
+			p.logger.Named("key_switcher"), p.config.SecretsManager, p.key)
 	}
 
 	runtime, err := newConsensusRuntime(p.logger, runtimeConfig)
@@ -607,6 +647,8 @@ func (p *Polybft) startConsensusProtocol() {
 				continue
 			}
 
+			// proposer may wait for transactions, so the validators must not change the round in the meantime
+			p.ibft.ExtendRoundTimeout(p.runtime.emptyBlockRoundTimeout())
 			sequenceCh, stopSequence = p.ibft.runSequence(latestHeader.Number + 1)
 		}
 
@@ -686,7 +728,7 @@ func (p *Polybft) VerifyHeader(header *types.Header) error {
 
 func (p *Polybft) verifyHeaderImpl(parent, header *types.Header, blockTimeDrift uint64, parents []*types.Header) error {
 	// validate header fields
-	if err := validateHeaderFields(parent, header, blockTimeDrift); err != nil {
+	if err := validateHeaderFields(parent, header, blockTimeDrift, p.consensusConfig.MinBlockTime()); err != nil {
 		return fmt.Errorf("failed to validate header for block %d. error = %w", header.Number, err)
 	}
 
@@ -725,7 +767,7 @@ func (p *Polybft) PreCommitState(block *types.Block, _ *state.Transition) error
 		return err
 	}
 
-	// validate commitment state transactions
+	// validate commitment and double sign evidence state transactions
 	for _, tx := range block.Transactions {
 		if tx.Type != types.StateTx {
 			continue
@@ -736,7 +778,8 @@ func (p *Polybft) PreCommitState(block *types.Block, _ *state.Transition) error
 			return fmt.Errorf("unknown state transaction: tx=%v, error: %w", tx.Hash, err)
 		}
 
-		if signedCommitment, ok := decodedStateTx.(*CommitmentMessageSigned); ok {
+		switch stateTxData := decodedStateTx.(type) {
+		case *CommitmentMessageSigned:
 			if commitmentTxExists {
 				return fmt.Errorf("only one commitment state tx is allowed per block: %v", tx.Hash)
 			}
@@ -746,10 +789,14 @@ func (p *Polybft) PreCommitState(block *types.Block, _ *state.Transition) error
 			if err := verifyBridgeCommitmentTx(
 				block.Number(),
 				tx.Hash,
-				signedCommitment,
+				stateTxData,
 				validator.NewValidatorSet(validators, p.logger)); err != nil {
 				return err
 			}
+		case *DoubleSignEvidence:
+			if err := verifyDoubleSignEvidence(stateTxData, block.Number(), p); err != nil {
+				return fmt.Errorf("invalid double sign evidence state tx %v: %w", tx.Hash, err)
+			}
 		}
 	}
 
@@ -762,6 +809,18 @@ func (p *Polybft) GetBridgeProvider() consensus.BridgeDataProvider {
 	return p.runtime
 }
 
+// GetUptimeProvider is an implementation of Consensus interface
+// Returns an instance of ValidatorUptimeProvider
+func (p *Polybft) GetUptimeProvider() consensus.ValidatorUptimeProvider {
+	return p.runtime
+}
+
+// GetFinalizedBlockNumber is an implementation of Consensus interface
+// Returns the head block number, since every sealed block is final
+func (p *Polybft) GetFinalizedBlockNumber(head uint64) uint64 {
+	return head
+}
+
 // GetBridgeProvider is an implementation of Consensus interface
 // Filters extra data to not contain Committed field
 func (p *Polybft) FilterExtra(extra []byte) ([]byte, error) {
@@ -3,6 +3,7 @@ package polybft
 import (
 	"encoding/json"
 	"math/big"
+	"time"
 
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/consensus/polybft/validator"
@@ -32,6 +33,10 @@ type PolyBFTConfig struct {
 	// BlockTime is target frequency of blocks production
 	BlockTime common.Duration `json:"blockTime"`
 
+	// MaxEmptyBlockInterval is the maximum time the proposer waits for transactions before it proposes an empty block.
+	// If it is zero, blocks are produced every BlockTime, regardless of the txpool
+	MaxEmptyBlockInterval common.Duration `json:"maxEmptyBlockInterval"`
+
 	// Governance is the initial governance address
 	Governance types.Address `json:"governance"`
 
@@ -116,6 +121,16 @@ func (p *PolyBFTConfig) IsBridgeEnabled() bool {
 	return p.Bridge != nil
 }
 
+// MinBlockTime returns the minimum time between the parent and the child block, which the validators enforce
+// once the proposers may delay the empty blocks. It is zero if the empty blocks aren't delayed
+func (p *PolyBFTConfig) MinBlockTime() time.Duration {
+	if p.MaxEmptyBlockInterval.Duration == 0 {
+		return 0
+	}
+
+	return p.BlockTime.Duration
+}
+
 // RootchainConfig contains rootchain metadata (such as JSON RPC endpoint and contract addresses)
 type RootchainConfig struct {
 	JSONRPCAddr string
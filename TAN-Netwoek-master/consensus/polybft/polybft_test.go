@@ -345,3 +345,10 @@ func Test_GenesisPostHookFactory(t *testing.T) {
 		})
 	}
 }
+
+func TestPolybft_GetFinalizedBlockNumber(t *testing.T) {
+	t.Parallel()
+
+	// every sealed block is final, so safe and finalized blocks are the latest one
+	require.Equal(t, uint64(10), (&Polybft{}).GetFinalizedBlockNumber(10))
+}
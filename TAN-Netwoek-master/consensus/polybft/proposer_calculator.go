@@ -121,7 +121,10 @@ func (pcs *ProposerSnapshot) Copy() *ProposerSnapshot {
 	valCopy := make([]*PrioritizedValidator, len(pcs.Validators))
 
 	for i, val := range pcs.Validators {
-		valCopy[i] = &PrioritizedValidator{Metadata: val.Metadata.Copy(), ProposerPriority: val.ProposerPriority}
+		valCopy[i] = &PrioritizedValidator{
+			Metadata:         val.Metadata.Copy(),
+			ProposerPriority: new(big.Int).Set(val.ProposerPriority),
+		}
 
 		if pcs.Proposer != nil && pcs.Proposer.Metadata.Address == val.Metadata.Address {
 			proposer = valCopy[i]
@@ -136,6 +139,27 @@ func (pcs *ProposerSnapshot) Copy() *ProposerSnapshot {
 	}
 }
 
+// UpcomingProposers returns proposers for the next count heights, starting from the snapshot height.
+// Calculation assumes that every block gets finalized in round 0 and that validator set remains unchanged.
+func (pcs *ProposerSnapshot) UpcomingProposers(count uint64) ([]*PrioritizedValidator, error) {
+	snapshot := pcs.Copy()
+	proposers := make([]*PrioritizedValidator, count)
+
+	for i := uint64(0); i < count; i++ {
+		proposer, err := incrementProposerPriorityNTimes(snapshot, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		proposers[i] = &PrioritizedValidator{
+			Metadata:         proposer.Metadata,
+			ProposerPriority: new(big.Int).Set(proposer.ProposerPriority),
+		}
+	}
+
+	return proposers, nil
+}
+
 func (pcs *ProposerSnapshot) toMap() map[types.Address]*PrioritizedValidator {
 	validatorMap := make(map[types.Address]*PrioritizedValidator)
 	for _, v := range pcs.Validators {
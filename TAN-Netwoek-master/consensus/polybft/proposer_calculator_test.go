@@ -170,6 +170,52 @@ func TestProposerCalculator_InversePriorityOrderWithExpectedListOfSelection(t *t
 	}
 }
 
+func TestProposerCalculator_UpcomingProposers(t *testing.T) {
+	t.Parallel()
+
+	keys, err := bls.CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	vset := validator.NewValidatorSet([]*validator.ValidatorMetadata{
+		{
+			BlsKey:      keys[0].PublicKey(),
+			Address:     types.Address{0x1},
+			VotingPower: big.NewInt(1000),
+		},
+		{
+			BlsKey:      keys[1].PublicKey(),
+			Address:     types.Address{0x2},
+			VotingPower: big.NewInt(300),
+		},
+		{
+			BlsKey:      keys[2].PublicKey(),
+			Address:     types.Address{0x3},
+			VotingPower: big.NewInt(330),
+		},
+	}, hclog.NewNullLogger())
+
+	snapshot := NewProposerSnapshot(4, vset.Accounts())
+
+	proposers, err := snapshot.UpcomingProposers(10)
+	require.NoError(t, err)
+	require.Len(t, proposers, 10)
+
+	expectedValidatorAddresses := []types.Address{
+		{0x1}, {0x3}, {0x1}, {0x2}, {0x1}, {0x1}, {0x3}, {0x1}, {0x2}, {0x1},
+	}
+
+	for i, p := range proposers {
+		assert.Equal(t, expectedValidatorAddresses[i], p.Metadata.Address)
+	}
+
+	// original snapshot must stay intact
+	for _, v := range snapshot.Validators {
+		assert.Zero(t, v.ProposerPriority.Sign())
+	}
+
+	assert.Nil(t, snapshot.Proposer)
+}
+
 func TestProposerCalculator_IncrementProposerPrioritySameVotingPower(t *testing.T) {
 	t.Parallel()
 
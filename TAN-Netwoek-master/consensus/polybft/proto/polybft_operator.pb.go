@@ -0,0 +1,982 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.7
+// source: consensus/polybft/proto/polybft_operator.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PolybftStatusResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key             string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	ActiveValidator bool   `protobuf:"varint,2,opt,name=active_validator,json=activeValidator,proto3" json:"active_validator,omitempty"`
+	Block           uint64 `protobuf:"varint,3,opt,name=block,proto3" json:"block,omitempty"`
+	Epoch           uint64 `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Sprint          uint64 `protobuf:"varint,5,opt,name=sprint,proto3" json:"sprint,omitempty"`
+}
+
+func (x *PolybftStatusResp) Reset() {
+	*x = PolybftStatusResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolybftStatusResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolybftStatusResp) ProtoMessage() {}
+
+func (x *PolybftStatusResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolybftStatusResp.ProtoReflect.Descriptor instead.
+func (*PolybftStatusResp) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PolybftStatusResp) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PolybftStatusResp) GetActiveValidator() bool {
+	if x != nil {
+		return x.ActiveValidator
+	}
+	return false
+}
+
+func (x *PolybftStatusResp) GetBlock() uint64 {
+	if x != nil {
+		return x.Block
+	}
+	return 0
+}
+
+func (x *PolybftStatusResp) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *PolybftStatusResp) GetSprint() uint64 {
+	if x != nil {
+		return x.Sprint
+	}
+	return 0
+}
+
+type Validator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	VotingPower string `protobuf:"bytes,2,opt,name=voting_power,json=votingPower,proto3" json:"voting_power,omitempty"`
+	IsActive    bool   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+}
+
+func (x *Validator) Reset() {
+	*x = Validator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Validator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Validator) ProtoMessage() {}
+
+func (x *Validator) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Validator.ProtoReflect.Descriptor instead.
+func (*Validator) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Validator) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Validator) GetVotingPower() string {
+	if x != nil {
+		return x.VotingPower
+	}
+	return ""
+}
+
+func (x *Validator) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type ValidatorsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch            uint64             `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Validators       []*Validator       `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators,omitempty"`
+	TotalVotingPower string             `protobuf:"bytes,3,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
+	PendingDelta     *ValidatorSetDelta `protobuf:"bytes,4,opt,name=pending_delta,json=pendingDelta,proto3" json:"pending_delta,omitempty"`
+}
+
+func (x *ValidatorsResp) Reset() {
+	*x = ValidatorsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorsResp) ProtoMessage() {}
+
+func (x *ValidatorsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorsResp.ProtoReflect.Descriptor instead.
+func (*ValidatorsResp) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidatorsResp) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ValidatorsResp) GetValidators() []*Validator {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+func (x *ValidatorsResp) GetTotalVotingPower() string {
+	if x != nil {
+		return x.TotalVotingPower
+	}
+	return ""
+}
+
+func (x *ValidatorsResp) GetPendingDelta() *ValidatorSetDelta {
+	if x != nil {
+		return x.PendingDelta
+	}
+	return nil
+}
+
+type ValidatorSetDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Added   []*Validator `protobuf:"bytes,1,rep,name=added,proto3" json:"added,omitempty"`
+	Updated []*Validator `protobuf:"bytes,2,rep,name=updated,proto3" json:"updated,omitempty"`
+	Removed []string     `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *ValidatorSetDelta) Reset() {
+	*x = ValidatorSetDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorSetDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSetDelta) ProtoMessage() {}
+
+func (x *ValidatorSetDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSetDelta.ProtoReflect.Descriptor instead.
+func (*ValidatorSetDelta) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidatorSetDelta) GetAdded() []*Validator {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *ValidatorSetDelta) GetUpdated() []*Validator {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *ValidatorSetDelta) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+type EpochResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number     uint64              `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	FirstBlock uint64              `protobuf:"varint,2,opt,name=first_block,json=firstBlock,proto3" json:"first_block,omitempty"`
+	LastBlock  uint64              `protobuf:"varint,3,opt,name=last_block,json=lastBlock,proto3" json:"last_block,omitempty"`
+	Sprint     uint64              `protobuf:"varint,4,opt,name=sprint,proto3" json:"sprint,omitempty"`
+	SprintSize uint64              `protobuf:"varint,5,opt,name=sprint_size,json=sprintSize,proto3" json:"sprint_size,omitempty"`
+	Block      uint64              `protobuf:"varint,6,opt,name=block,proto3" json:"block,omitempty"`
+	Checkpoint *CheckpointProgress `protobuf:"bytes,7,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	StateSync  *StateSyncProgress  `protobuf:"bytes,8,opt,name=state_sync,json=stateSync,proto3" json:"state_sync,omitempty"`
+}
+
+func (x *EpochResp) Reset() {
+	*x = EpochResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EpochResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EpochResp) ProtoMessage() {}
+
+func (x *EpochResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EpochResp.ProtoReflect.Descriptor instead.
+func (*EpochResp) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EpochResp) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *EpochResp) GetFirstBlock() uint64 {
+	if x != nil {
+		return x.FirstBlock
+	}
+	return 0
+}
+
+func (x *EpochResp) GetLastBlock() uint64 {
+	if x != nil {
+		return x.LastBlock
+	}
+	return 0
+}
+
+func (x *EpochResp) GetSprint() uint64 {
+	if x != nil {
+		return x.Sprint
+	}
+	return 0
+}
+
+func (x *EpochResp) GetSprintSize() uint64 {
+	if x != nil {
+		return x.SprintSize
+	}
+	return 0
+}
+
+func (x *EpochResp) GetBlock() uint64 {
+	if x != nil {
+		return x.Block
+	}
+	return 0
+}
+
+func (x *EpochResp) GetCheckpoint() *CheckpointProgress {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return nil
+}
+
+func (x *EpochResp) GetStateSync() *StateSyncProgress {
+	if x != nil {
+		return x.StateSync
+	}
+	return nil
+}
+
+type CheckpointProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastProcessedBlock uint64 `protobuf:"varint,1,opt,name=last_processed_block,json=lastProcessedBlock,proto3" json:"last_processed_block,omitempty"`
+}
+
+func (x *CheckpointProgress) Reset() {
+	*x = CheckpointProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckpointProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckpointProgress) ProtoMessage() {}
+
+func (x *CheckpointProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckpointProgress.ProtoReflect.Descriptor instead.
+func (*CheckpointProgress) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckpointProgress) GetLastProcessedBlock() uint64 {
+	if x != nil {
+		return x.LastProcessedBlock
+	}
+	return 0
+}
+
+type StateSyncProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastEventId           uint64 `protobuf:"varint,1,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	LastCommitmentStartId uint64 `protobuf:"varint,2,opt,name=last_commitment_start_id,json=lastCommitmentStartId,proto3" json:"last_commitment_start_id,omitempty"`
+	LastCommitmentEndId   uint64 `protobuf:"varint,3,opt,name=last_commitment_end_id,json=lastCommitmentEndId,proto3" json:"last_commitment_end_id,omitempty"`
+}
+
+func (x *StateSyncProgress) Reset() {
+	*x = StateSyncProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateSyncProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateSyncProgress) ProtoMessage() {}
+
+func (x *StateSyncProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateSyncProgress.ProtoReflect.Descriptor instead.
+func (*StateSyncProgress) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StateSyncProgress) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+func (x *StateSyncProgress) GetLastCommitmentStartId() uint64 {
+	if x != nil {
+		return x.LastCommitmentStartId
+	}
+	return 0
+}
+
+func (x *StateSyncProgress) GetLastCommitmentEndId() uint64 {
+	if x != nil {
+		return x.LastCommitmentEndId
+	}
+	return 0
+}
+
+type ProposersReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ProposersReq) Reset() {
+	*x = ProposersReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProposersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposersReq) ProtoMessage() {}
+
+func (x *ProposersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposersReq.ProtoReflect.Descriptor instead.
+func (*ProposersReq) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProposersReq) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ProposersResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height    uint64      `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Proposers []*Proposer `protobuf:"bytes,2,rep,name=proposers,proto3" json:"proposers,omitempty"`
+}
+
+func (x *ProposersResp) Reset() {
+	*x = ProposersResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProposersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposersResp) ProtoMessage() {}
+
+func (x *ProposersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposersResp.ProtoReflect.Descriptor instead.
+func (*ProposersResp) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProposersResp) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProposersResp) GetProposers() []*Proposer {
+	if x != nil {
+		return x.Proposers
+	}
+	return nil
+}
+
+type Proposer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Address  string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Priority string `protobuf:"bytes,3,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *Proposer) Reset() {
+	*x = Proposer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proposer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proposer) ProtoMessage() {}
+
+func (x *Proposer) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_polybft_proto_polybft_operator_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proposer.ProtoReflect.Descriptor instead.
+func (*Proposer) Descriptor() ([]byte, []int) {
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Proposer) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Proposer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Proposer) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+var File_consensus_polybft_proto_polybft_operator_proto protoreflect.FileDescriptor
+
+var file_consensus_polybft_proto_polybft_operator_proto_rawDesc = []byte{
+	0x0a, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x70, 0x6f, 0x6c, 0x79,
+	0x62, 0x66, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6f, 0x6c, 0x79, 0x62, 0x66,
+	0x74, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x94, 0x01, 0x0a, 0x11, 0x50, 0x6f, 0x6c, 0x79, 0x62, 0x66, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x22,
+	0xbf, 0x01, 0x0a, 0x0e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x2d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x3a, 0x0a, 0x0d, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x44, 0x65,
+	0x6c, 0x74, 0x61, 0x52, 0x0c, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x74,
+	0x61, 0x22, 0x7b, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65,
+	0x74, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x07, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x07, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0xa0,
+	0x02, 0x0a, 0x09, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x36, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x79, 0x6e,
+	0x63, 0x22, 0x46, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0xa5, 0x01, 0x0a, 0x11, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x18, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x16,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x65, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x6c, 0x61,
+	0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x64, 0x49,
+	0x64, 0x22, 0x24, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x53, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x2a, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65,
+	0x72, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x73, 0x22, 0x58, 0x0a, 0x08,
+	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x32, 0xe6, 0x01, 0x0a, 0x0f, 0x50, 0x6f, 0x6c, 0x79, 0x62,
+	0x66, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x79, 0x62, 0x66, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x38, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2e, 0x0a,
+	0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x30, 0x0a,
+	0x09, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x73, 0x12, 0x10, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x42,
+	0x1a, 0x5a, 0x18, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x70, 0x6f,
+	0x6c, 0x79, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_consensus_polybft_proto_polybft_operator_proto_rawDescOnce sync.Once
+	file_consensus_polybft_proto_polybft_operator_proto_rawDescData = file_consensus_polybft_proto_polybft_operator_proto_rawDesc
+)
+
+func file_consensus_polybft_proto_polybft_operator_proto_rawDescGZIP() []byte {
+	file_consensus_polybft_proto_polybft_operator_proto_rawDescOnce.Do(func() {
+		file_consensus_polybft_proto_polybft_operator_proto_rawDescData = protoimpl.X.CompressGZIP(file_consensus_polybft_proto_polybft_operator_proto_rawDescData)
+	})
+	return file_consensus_polybft_proto_polybft_operator_proto_rawDescData
+}
+
+var file_consensus_polybft_proto_polybft_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_consensus_polybft_proto_polybft_operator_proto_goTypes = []interface{}{
+	(*PolybftStatusResp)(nil),  // 0: v1.PolybftStatusResp
+	(*Validator)(nil),          // 1: v1.Validator
+	(*ValidatorsResp)(nil),     // 2: v1.ValidatorsResp
+	(*ValidatorSetDelta)(nil),  // 3: v1.ValidatorSetDelta
+	(*EpochResp)(nil),          // 4: v1.EpochResp
+	(*CheckpointProgress)(nil), // 5: v1.CheckpointProgress
+	(*StateSyncProgress)(nil),  // 6: v1.StateSyncProgress
+	(*ProposersReq)(nil),       // 7: v1.ProposersReq
+	(*ProposersResp)(nil),      // 8: v1.ProposersResp
+	(*Proposer)(nil),           // 9: v1.Proposer
+	(*emptypb.Empty)(nil),      // 10: google.protobuf.Empty
+}
+var file_consensus_polybft_proto_polybft_operator_proto_depIdxs = []int32{
+	1,  // 0: v1.ValidatorsResp.validators:type_name -> v1.Validator
+	3,  // 1: v1.ValidatorsResp.pending_delta:type_name -> v1.ValidatorSetDelta
+	1,  // 2: v1.ValidatorSetDelta.added:type_name -> v1.Validator
+	1,  // 3: v1.ValidatorSetDelta.updated:type_name -> v1.Validator
+	5,  // 4: v1.EpochResp.checkpoint:type_name -> v1.CheckpointProgress
+	6,  // 5: v1.EpochResp.state_sync:type_name -> v1.StateSyncProgress
+	9,  // 6: v1.ProposersResp.proposers:type_name -> v1.Proposer
+	10, // 7: v1.PolybftOperator.Status:input_type -> google.protobuf.Empty
+	10, // 8: v1.PolybftOperator.Validators:input_type -> google.protobuf.Empty
+	10, // 9: v1.PolybftOperator.Epoch:input_type -> google.protobuf.Empty
+	7,  // 10: v1.PolybftOperator.Proposers:input_type -> v1.ProposersReq
+	0,  // 11: v1.PolybftOperator.Status:output_type -> v1.PolybftStatusResp
+	2,  // 12: v1.PolybftOperator.Validators:output_type -> v1.ValidatorsResp
+	4,  // 13: v1.PolybftOperator.Epoch:output_type -> v1.EpochResp
+	8,  // 14: v1.PolybftOperator.Proposers:output_type -> v1.ProposersResp
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_consensus_polybft_proto_polybft_operator_proto_init() }
+func file_consensus_polybft_proto_polybft_operator_proto_init() {
+	if File_consensus_polybft_proto_polybft_operator_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolybftStatusResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Validator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorSetDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EpochResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckpointProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateSyncProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProposersReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProposersResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_polybft_proto_polybft_operator_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proposer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_consensus_polybft_proto_polybft_operator_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consensus_polybft_proto_polybft_operator_proto_goTypes,
+		DependencyIndexes: file_consensus_polybft_proto_polybft_operator_proto_depIdxs,
+		MessageInfos:      file_consensus_polybft_proto_polybft_operator_proto_msgTypes,
+	}.Build()
+	File_consensus_polybft_proto_polybft_operator_proto = out.File
+	file_consensus_polybft_proto_polybft_operator_proto_rawDesc = nil
+	file_consensus_polybft_proto_polybft_operator_proto_goTypes = nil
+	file_consensus_polybft_proto_polybft_operator_proto_depIdxs = nil
+}
@@ -0,0 +1,1313 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: consensus/polybft/proto/polybft_operator.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on PolybftStatusResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PolybftStatusResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PolybftStatusResp with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// PolybftStatusRespMultiError, or nil if none found.
+func (m *PolybftStatusResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PolybftStatusResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Key
+
+	// no validation rules for ActiveValidator
+
+	// no validation rules for Block
+
+	// no validation rules for Epoch
+
+	// no validation rules for Sprint
+
+	if len(errors) > 0 {
+		return PolybftStatusRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// PolybftStatusRespMultiError is an error wrapping multiple validation errors
+// returned by PolybftStatusResp.ValidateAll() if the designated constraints
+// aren't met.
+type PolybftStatusRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PolybftStatusRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PolybftStatusRespMultiError) AllErrors() []error { return m }
+
+// PolybftStatusRespValidationError is the validation error returned by
+// PolybftStatusResp.Validate if the designated constraints aren't met.
+type PolybftStatusRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PolybftStatusRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PolybftStatusRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PolybftStatusRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PolybftStatusRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PolybftStatusRespValidationError) ErrorName() string {
+	return "PolybftStatusRespValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PolybftStatusRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPolybftStatusResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PolybftStatusRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PolybftStatusRespValidationError{}
+
+// Validate checks the field values on Validator with the rules defined in the
+// proto definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Validator) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Validator with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in ValidatorMultiError, or nil if none
+// found.
+func (m *Validator) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Validator) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Address
+
+	// no validation rules for VotingPower
+
+	// no validation rules for IsActive
+
+	if len(errors) > 0 {
+		return ValidatorMultiError(errors)
+	}
+
+	return nil
+}
+
+// ValidatorMultiError is an error wrapping multiple validation errors returned
+// by Validator.ValidateAll() if the designated constraints aren't met.
+type ValidatorMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ValidatorMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ValidatorMultiError) AllErrors() []error { return m }
+
+// ValidatorValidationError is the validation error returned by
+// Validator.Validate if the designated constraints aren't met.
+type ValidatorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidatorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidatorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidatorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ValidatorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ValidatorValidationError) ErrorName() string { return "ValidatorValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ValidatorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sValidator.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ValidatorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ValidatorValidationError{}
+
+// Validate checks the field values on ValidatorsResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ValidatorsResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ValidatorsResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ValidatorsRespMultiError, or
+// nil if none found.
+func (m *ValidatorsResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ValidatorsResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Epoch
+
+	for idx, item := range m.GetValidators() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ValidatorsRespValidationError{
+						field:  fmt.Sprintf("Validators[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ValidatorsRespValidationError{
+						field:  fmt.Sprintf("Validators[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ValidatorsRespValidationError{
+					field:  fmt.Sprintf("Validators[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for TotalVotingPower
+
+	if all {
+		switch v := interface{}(m.GetPendingDelta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ValidatorsRespValidationError{
+					field:  "PendingDelta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ValidatorsRespValidationError{
+					field:  "PendingDelta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPendingDelta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ValidatorsRespValidationError{
+				field:  "PendingDelta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ValidatorsRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// ValidatorsRespMultiError is an error wrapping multiple validation errors
+// returned by ValidatorsResp.ValidateAll() if the designated constraints aren't
+// met.
+type ValidatorsRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ValidatorsRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ValidatorsRespMultiError) AllErrors() []error { return m }
+
+// ValidatorsRespValidationError is the validation error returned by
+// ValidatorsResp.Validate if the designated constraints aren't met.
+type ValidatorsRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidatorsRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidatorsRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidatorsRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ValidatorsRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ValidatorsRespValidationError) ErrorName() string { return "ValidatorsRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ValidatorsRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sValidatorsResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ValidatorsRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ValidatorsRespValidationError{}
+
+// Validate checks the field values on ValidatorSetDelta with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ValidatorSetDelta) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ValidatorSetDelta with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// ValidatorSetDeltaMultiError, or nil if none found.
+func (m *ValidatorSetDelta) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ValidatorSetDelta) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetAdded() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ValidatorSetDeltaValidationError{
+						field:  fmt.Sprintf("Added[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ValidatorSetDeltaValidationError{
+						field:  fmt.Sprintf("Added[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ValidatorSetDeltaValidationError{
+					field:  fmt.Sprintf("Added[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetUpdated() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ValidatorSetDeltaValidationError{
+						field:  fmt.Sprintf("Updated[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ValidatorSetDeltaValidationError{
+						field:  fmt.Sprintf("Updated[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ValidatorSetDeltaValidationError{
+					field:  fmt.Sprintf("Updated[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ValidatorSetDeltaMultiError(errors)
+	}
+
+	return nil
+}
+
+// ValidatorSetDeltaMultiError is an error wrapping multiple validation errors
+// returned by ValidatorSetDelta.ValidateAll() if the designated constraints
+// aren't met.
+type ValidatorSetDeltaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ValidatorSetDeltaMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ValidatorSetDeltaMultiError) AllErrors() []error { return m }
+
+// ValidatorSetDeltaValidationError is the validation error returned by
+// ValidatorSetDelta.Validate if the designated constraints aren't met.
+type ValidatorSetDeltaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidatorSetDeltaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidatorSetDeltaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidatorSetDeltaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ValidatorSetDeltaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ValidatorSetDeltaValidationError) ErrorName() string {
+	return "ValidatorSetDeltaValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ValidatorSetDeltaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sValidatorSetDelta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ValidatorSetDeltaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ValidatorSetDeltaValidationError{}
+
+// Validate checks the field values on EpochResp with the rules defined in the
+// proto definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *EpochResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EpochResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in EpochRespMultiError, or nil if none
+// found.
+func (m *EpochResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EpochResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Number
+
+	// no validation rules for FirstBlock
+
+	// no validation rules for LastBlock
+
+	// no validation rules for Sprint
+
+	// no validation rules for SprintSize
+
+	// no validation rules for Block
+
+	if all {
+		switch v := interface{}(m.GetCheckpoint()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EpochRespValidationError{
+					field:  "Checkpoint",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EpochRespValidationError{
+					field:  "Checkpoint",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCheckpoint()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EpochRespValidationError{
+				field:  "Checkpoint",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetStateSync()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EpochRespValidationError{
+					field:  "StateSync",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EpochRespValidationError{
+					field:  "StateSync",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStateSync()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EpochRespValidationError{
+				field:  "StateSync",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return EpochRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// EpochRespMultiError is an error wrapping multiple validation errors returned
+// by EpochResp.ValidateAll() if the designated constraints aren't met.
+type EpochRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EpochRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EpochRespMultiError) AllErrors() []error { return m }
+
+// EpochRespValidationError is the validation error returned by
+// EpochResp.Validate if the designated constraints aren't met.
+type EpochRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EpochRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EpochRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EpochRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EpochRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EpochRespValidationError) ErrorName() string { return "EpochRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EpochRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEpochResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EpochRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EpochRespValidationError{}
+
+// Validate checks the field values on CheckpointProgress with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CheckpointProgress) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckpointProgress with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CheckpointProgressMultiError, or nil if none found.
+func (m *CheckpointProgress) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckpointProgress) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for LastProcessedBlock
+
+	if len(errors) > 0 {
+		return CheckpointProgressMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckpointProgressMultiError is an error wrapping multiple validation errors
+// returned by CheckpointProgress.ValidateAll() if the designated constraints
+// aren't met.
+type CheckpointProgressMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckpointProgressMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckpointProgressMultiError) AllErrors() []error { return m }
+
+// CheckpointProgressValidationError is the validation error returned by
+// CheckpointProgress.Validate if the designated constraints aren't met.
+type CheckpointProgressValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckpointProgressValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckpointProgressValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckpointProgressValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckpointProgressValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckpointProgressValidationError) ErrorName() string {
+	return "CheckpointProgressValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckpointProgressValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckpointProgress.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckpointProgressValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckpointProgressValidationError{}
+
+// Validate checks the field values on StateSyncProgress with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *StateSyncProgress) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StateSyncProgress with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// StateSyncProgressMultiError, or nil if none found.
+func (m *StateSyncProgress) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StateSyncProgress) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for LastEventId
+
+	// no validation rules for LastCommitmentStartId
+
+	// no validation rules for LastCommitmentEndId
+
+	if len(errors) > 0 {
+		return StateSyncProgressMultiError(errors)
+	}
+
+	return nil
+}
+
+// StateSyncProgressMultiError is an error wrapping multiple validation errors
+// returned by StateSyncProgress.ValidateAll() if the designated constraints
+// aren't met.
+type StateSyncProgressMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StateSyncProgressMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StateSyncProgressMultiError) AllErrors() []error { return m }
+
+// StateSyncProgressValidationError is the validation error returned by
+// StateSyncProgress.Validate if the designated constraints aren't met.
+type StateSyncProgressValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StateSyncProgressValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StateSyncProgressValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StateSyncProgressValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StateSyncProgressValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StateSyncProgressValidationError) ErrorName() string {
+	return "StateSyncProgressValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StateSyncProgressValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStateSyncProgress.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StateSyncProgressValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StateSyncProgressValidationError{}
+
+// Validate checks the field values on ProposersReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ProposersReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProposersReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in ProposersReqMultiError, or nil if
+// none found.
+func (m *ProposersReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProposersReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Count
+
+	if len(errors) > 0 {
+		return ProposersReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProposersReqMultiError is an error wrapping multiple validation errors
+// returned by ProposersReq.ValidateAll() if the designated constraints aren't
+// met.
+type ProposersReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProposersReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProposersReqMultiError) AllErrors() []error { return m }
+
+// ProposersReqValidationError is the validation error returned by
+// ProposersReq.Validate if the designated constraints aren't met.
+type ProposersReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProposersReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProposersReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProposersReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProposersReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProposersReqValidationError) ErrorName() string { return "ProposersReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProposersReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProposersReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProposersReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProposersReqValidationError{}
+
+// Validate checks the field values on ProposersResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ProposersResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProposersResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ProposersRespMultiError, or
+// nil if none found.
+func (m *ProposersResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProposersResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	for idx, item := range m.GetProposers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ProposersRespValidationError{
+						field:  fmt.Sprintf("Proposers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ProposersRespValidationError{
+						field:  fmt.Sprintf("Proposers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ProposersRespValidationError{
+					field:  fmt.Sprintf("Proposers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ProposersRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProposersRespMultiError is an error wrapping multiple validation errors
+// returned by ProposersResp.ValidateAll() if the designated constraints aren't
+// met.
+type ProposersRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProposersRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProposersRespMultiError) AllErrors() []error { return m }
+
+// ProposersRespValidationError is the validation error returned by
+// ProposersResp.Validate if the designated constraints aren't met.
+type ProposersRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProposersRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProposersRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProposersRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProposersRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProposersRespValidationError) ErrorName() string { return "ProposersRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProposersRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProposersResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProposersRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProposersRespValidationError{}
+
+// Validate checks the field values on Proposer with the rules defined in the
+// proto definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Proposer) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Proposer with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in ProposerMultiError, or nil if none found.
+func (m *Proposer) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Proposer) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for Address
+
+	// no validation rules for Priority
+
+	if len(errors) > 0 {
+		return ProposerMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProposerMultiError is an error wrapping multiple validation errors returned
+// by Proposer.ValidateAll() if the designated constraints aren't met.
+type ProposerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProposerMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProposerMultiError) AllErrors() []error { return m }
+
+// ProposerValidationError is the validation error returned by Proposer.Validate
+// if the designated constraints aren't met.
+type ProposerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProposerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProposerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProposerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProposerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProposerValidationError) ErrorName() string { return "ProposerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProposerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProposer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProposerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProposerValidationError{}
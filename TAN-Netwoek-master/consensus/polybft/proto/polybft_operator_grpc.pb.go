@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.7
+// source: consensus/polybft/proto/polybft_operator.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PolybftOperatorClient is the client API for PolybftOperator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PolybftOperatorClient interface {
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PolybftStatusResp, error)
+	Validators(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ValidatorsResp, error)
+	Epoch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EpochResp, error)
+	Proposers(ctx context.Context, in *ProposersReq, opts ...grpc.CallOption) (*ProposersResp, error)
+}
+
+type polybftOperatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPolybftOperatorClient(cc grpc.ClientConnInterface) PolybftOperatorClient {
+	return &polybftOperatorClient{cc}
+}
+
+func (c *polybftOperatorClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PolybftStatusResp, error) {
+	out := new(PolybftStatusResp)
+	err := c.cc.Invoke(ctx, "/v1.PolybftOperator/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *polybftOperatorClient) Validators(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ValidatorsResp, error) {
+	out := new(ValidatorsResp)
+	err := c.cc.Invoke(ctx, "/v1.PolybftOperator/Validators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *polybftOperatorClient) Epoch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EpochResp, error) {
+	out := new(EpochResp)
+	err := c.cc.Invoke(ctx, "/v1.PolybftOperator/Epoch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *polybftOperatorClient) Proposers(ctx context.Context, in *ProposersReq, opts ...grpc.CallOption) (*ProposersResp, error) {
+	out := new(ProposersResp)
+	err := c.cc.Invoke(ctx, "/v1.PolybftOperator/Proposers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PolybftOperatorServer is the server API for PolybftOperator service.
+// All implementations must embed UnimplementedPolybftOperatorServer
+// for forward compatibility
+type PolybftOperatorServer interface {
+	Status(context.Context, *emptypb.Empty) (*PolybftStatusResp, error)
+	Validators(context.Context, *emptypb.Empty) (*ValidatorsResp, error)
+	Epoch(context.Context, *emptypb.Empty) (*EpochResp, error)
+	Proposers(context.Context, *ProposersReq) (*ProposersResp, error)
+	mustEmbedUnimplementedPolybftOperatorServer()
+}
+
+// UnimplementedPolybftOperatorServer must be embedded to have forward compatible implementations.
+type UnimplementedPolybftOperatorServer struct {
+}
+
+func (UnimplementedPolybftOperatorServer) Status(context.Context, *emptypb.Empty) (*PolybftStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedPolybftOperatorServer) Validators(context.Context, *emptypb.Empty) (*ValidatorsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validators not implemented")
+}
+func (UnimplementedPolybftOperatorServer) Epoch(context.Context, *emptypb.Empty) (*EpochResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Epoch not implemented")
+}
+func (UnimplementedPolybftOperatorServer) Proposers(context.Context, *ProposersReq) (*ProposersResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Proposers not implemented")
+}
+func (UnimplementedPolybftOperatorServer) mustEmbedUnimplementedPolybftOperatorServer() {}
+
+// UnsafePolybftOperatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PolybftOperatorServer will
+// result in compilation errors.
+type UnsafePolybftOperatorServer interface {
+	mustEmbedUnimplementedPolybftOperatorServer()
+}
+
+func RegisterPolybftOperatorServer(s grpc.ServiceRegistrar, srv PolybftOperatorServer) {
+	s.RegisterService(&PolybftOperator_ServiceDesc, srv)
+}
+
+func _PolybftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolybftOperatorServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.PolybftOperator/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolybftOperatorServer).Status(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolybftOperator_Validators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolybftOperatorServer).Validators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.PolybftOperator/Validators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolybftOperatorServer).Validators(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolybftOperator_Epoch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolybftOperatorServer).Epoch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.PolybftOperator/Epoch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolybftOperatorServer).Epoch(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolybftOperator_Proposers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolybftOperatorServer).Proposers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.PolybftOperator/Proposers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolybftOperatorServer).Proposers(ctx, req.(*ProposersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PolybftOperator_ServiceDesc is the grpc.ServiceDesc for PolybftOperator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PolybftOperator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.PolybftOperator",
+	HandlerType: (*PolybftOperatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Status",
+			Handler:    _PolybftOperator_Status_Handler,
+		},
+		{
+			MethodName: "Validators",
+			Handler:    _PolybftOperator_Validators_Handler,
+		},
+		{
+			MethodName: "Epoch",
+			Handler:    _PolybftOperator_Epoch_Handler,
+		},
+		{
+			MethodName: "Proposers",
+			Handler:    _PolybftOperator_Proposers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consensus/polybft/proto/polybft_operator.proto",
+}
@@ -17,6 +17,8 @@ const (
 	DomainCheckpointManagerString = "DOMAIN_CHECKPOINT_MANAGER"
 	DomainCommonSigningString     = "DOMAIN_COMMON_SIGNING"
 	DomainStateReceiverString     = "DOMAIN_STATE_RECEIVER"
+
+	DomainValidatorKeyRotationString = "DOMAIN_VALIDATOR_KEY_ROTATION"
 )
 
 var errInfinityPoint = fmt.Errorf("infinity point")
@@ -36,6 +38,9 @@ var (
 
 	DomainCommonSigning = pcrypto.Keccak256([]byte(DomainCommonSigningString))
 	DomainStateReceiver = pcrypto.Keccak256([]byte(DomainStateReceiverString))
+
+	// domain used to sign the validator key rotation
+	DomainValidatorKeyRotation = pcrypto.Keccak256([]byte(DomainValidatorKeyRotationString))
 )
 
 func mustG2Point(str string) *bn256.G2 {
@@ -55,6 +55,7 @@ type stakeManager struct {
 	key                     ethgo.Key
 	supernetManagerContract types.Address
 	maxValidatorSetSize     int
+	blockchain              blockchainBackend
 	eventsGetter            *eventsGetter[*contractsapi.TransferEvent]
 }
 
@@ -88,6 +89,7 @@ func newStakeManager(
 		key:                     key,
 		supernetManagerContract: supernetManagerAddr,
 		maxValidatorSetSize:     maxValidatorSetSize,
+		blockchain:              blockchain,
 		eventsGetter:            eventsGetter,
 	}
 }
@@ -123,6 +125,15 @@ func (s *stakeManager) PostBlock(req *PostBlockRequest) error {
 		return err
 	}
 
+	s.releaseJailedValidators(&fullValidatorSet, req.FullBlock.Block.Number())
+
+	err = s.jailDoubleSigners(&fullValidatorSet, req.FullBlock.Block)
+	if err != nil {
+		return err
+	}
+
+	s.rotateValidatorKeys(&fullValidatorSet, req.FullBlock)
+
 	fullValidatorSet.EpochID = req.Epoch
 	fullValidatorSet.BlockNumber = req.FullBlock.Block.Number()
 
@@ -162,7 +173,7 @@ func (s *stakeManager) updateWithReceipts(
 
 	for addr, data := range fullValidatorSet.Validators {
 		if data.BlsKey == nil {
-			blsKey, err := s.getBlsKey(data.Address)
+			blsKey, err := s.getBlsKey(fullValidatorSet, data.Address)
 			if err != nil {
 				s.logger.Warn("Could not get info for new validator",
 					"block", fullBlock.Block.Number(), "address", addr)
@@ -182,6 +193,109 @@ func (s *stakeManager) updateWithReceipts(
 	return nil
 }
 
+// releaseJailedValidators releases the validators which served the jail period.
+// Released validators are added back to the validator set at the end of the epoch, if they still have enough stake.
+func (s *stakeManager) releaseJailedValidators(fullValidatorSet *validatorSetState, blockNumber uint64) {
+	for addr, jailedAt := range fullValidatorSet.Jailed {
+		if blockNumber < jailedAt+doubleSignJailPeriod {
+			continue
+		}
+
+		delete(fullValidatorSet.Jailed, addr)
+
+		s.logger.Info("Validator released from jail", "validator", addr, "block", blockNumber, "jailed at", jailedAt)
+	}
+}
+
+// jailDoubleSigners jails validators for which double sign evidence is included in the given block.
+// Jailed validators are removed from the validator set at the end of the epoch,
+// and released after the doubleSignJailPeriod blocks.
+func (s *stakeManager) jailDoubleSigners(fullValidatorSet *validatorSetState, block *types.Block) error {
+	evidence, err := getDoubleSignEvidenceFromBlock(block)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range evidence {
+		offender := e.Offender()
+
+		if _, isJailed := fullValidatorSet.Jailed[offender]; isJailed {
+			continue
+		}
+
+		if fullValidatorSet.Jailed == nil {
+			fullValidatorSet.Jailed = map[types.Address]uint64{}
+		}
+
+		fullValidatorSet.Jailed[offender] = block.Number()
+
+		s.logger.Warn("Validator jailed for double signing", "validator", offender,
+			"block", block.Number(), "evidence height", e.Height())
+	}
+
+	return nil
+}
+
+// rotateValidatorKeys replaces BLS keys of the validators which key rotations are included in the given block.
+// Rotated keys are recorded, so they take precedence over the keys registered in the supernet manager,
+// and are used by the validator set starting from the next epoch.
+func (s *stakeManager) rotateValidatorKeys(fullValidatorSet *validatorSetState, fullBlock *types.FullBlock) {
+	rotations := getValidatorKeyRotationsFromBlock(fullBlock, s.logger)
+	if len(rotations) == 0 {
+		return
+	}
+
+	if !isKeyRotationEnabled(fullBlock.Block.Number()) {
+		s.logger.Warn("Validator key rotations ignored", "block", fullBlock.Block.Number(),
+			"err", errKeyRotationDisabled)
+
+		return
+	}
+
+	chainID := s.blockchain.GetChainID()
+
+	for _, r := range rotations {
+		data, exists := fullValidatorSet.Validators[r.validator]
+		if !exists {
+			s.logger.Warn("Key rotation of unknown validator", "validator", r.validator,
+				"block", fullBlock.Block.Number())
+
+			continue
+		}
+
+		if data.BlsKey == nil {
+			// the key of the validator wasn't retrieved yet, the rotation is verified by its current key
+			blsKey, err := s.getBlsKey(fullValidatorSet, r.validator)
+			if err != nil {
+				s.logger.Warn("Could not get the current key of the rotating validator", "validator", r.validator,
+					"block", fullBlock.Block.Number(), "err", err)
+
+				continue
+			}
+
+			data.BlsKey = blsKey
+		}
+
+		if err := r.rotation.Verify(chainID, r.validator, data.BlsKey); err != nil {
+			s.logger.Warn("Invalid validator key rotation", "validator", r.validator,
+				"block", fullBlock.Block.Number(), "err", err)
+
+			continue
+		}
+
+		data.BlsKey = r.rotation.NewKey
+
+		if fullValidatorSet.RotatedBlsKeys == nil {
+			fullValidatorSet.RotatedBlsKeys = map[types.Address][]byte{}
+		}
+
+		fullValidatorSet.RotatedBlsKeys[r.validator] = r.rotation.NewKey.Marshal()
+
+		s.logger.Info("Validator key rotated", "validator", r.validator,
+			"block", fullBlock.Block.Number(), "key", hex.EncodeToHex(r.rotation.NewKey.Marshal()))
+	}
+}
+
 // UpdateValidatorSet returns an updated validator set
 // based on stake change (transfer) events from ValidatorSet contract
 func (s *stakeManager) UpdateValidatorSet(
@@ -193,11 +307,40 @@ func (s *stakeManager) UpdateValidatorSet(
 		return nil, fmt.Errorf("failed to get full validators set. Epoch: %d. Error: %w", epoch, err)
 	}
 
-	// stake map that holds stakes for all validators
-	stakeMap := fullValidatorSet.Validators
+	delta := newValidatorSetDelta(oldValidatorSet, fullValidatorSet.nextValidatorSet(s.maxValidatorSetSize))
+
+	for _, newValidator := range delta.Added {
+		if newValidator.BlsKey == nil {
+			newValidator.BlsKey, err = s.getBlsKey(&fullValidatorSet, newValidator.Address)
+			if err != nil {
+				return nil, fmt.Errorf("could not retrieve validator data. Address: %v. Error: %w",
+					newValidator.Address, err)
+			}
+		}
+	}
+
+	s.logger.Info("Calculating validators set update finished.", "epoch", epoch)
+
+	if s.logger.IsDebug() {
+		newValidatorSet, err := oldValidatorSet.Copy().ApplyDelta(delta)
+		if err != nil {
+			return nil, err
+		}
+
+		s.logger.Debug("New validator set", "validatorSet", newValidatorSet)
+	}
+
+	return delta, nil
+}
+
+// nextValidatorSet returns the validator set of the next epoch,
+// made of the validators which are not jailed and have the highest stake
+func (v *validatorSetState) nextValidatorSet(maxValidatorSetSize int) validator.AccountSet {
+	return v.Validators.withoutJailed(v.Jailed).getSorted(maxValidatorSetSize)
+}
 
-	// slice of all validator set
-	newValidatorSet := stakeMap.getSorted(s.maxValidatorSetSize)
+// newValidatorSetDelta calculates the delta between the old and the new validator set
+func newValidatorSetDelta(oldValidatorSet, newValidatorSet validator.AccountSet) *validator.ValidatorSetDelta {
 	// set of all addresses that will be in next validator set
 	addressesSet := make(map[types.Address]struct{}, len(newValidatorSet))
 
@@ -221,44 +364,37 @@ func (s *stakeManager) UpdateValidatorSet(
 	for _, newValidator := range newValidatorSet {
 		// check if its already in existing validator set
 		if oldValidator, exists := oldActiveMap[newValidator.Address]; exists {
-			if oldValidator.VotingPower.Cmp(newValidator.VotingPower) != 0 {
+			if oldValidator.VotingPower.Cmp(newValidator.VotingPower) != 0 ||
+				isBlsKeyRotated(oldValidator, newValidator) {
 				updatedValidators = append(updatedValidators, newValidator)
 			}
 		} else {
-			if newValidator.BlsKey == nil {
-				newValidator.BlsKey, err = s.getBlsKey(newValidator.Address)
-				if err != nil {
-					return nil, fmt.Errorf("could not retrieve validator data. Address: %v. Error: %w",
-						newValidator.Address, err)
-				}
-			}
-
 			addedValidators = append(addedValidators, newValidator)
 		}
 	}
 
-	s.logger.Info("Calculating validators set update finished.", "epoch", epoch)
-
-	delta := &validator.ValidatorSetDelta{
+	return &validator.ValidatorSetDelta{
 		Added:   addedValidators,
 		Updated: updatedValidators,
 		Removed: removedBitmap,
 	}
+}
 
-	if s.logger.IsDebug() {
-		newValidatorSet, err := oldValidatorSet.Copy().ApplyDelta(delta)
-		if err != nil {
-			return nil, err
-		}
+// isBlsKeyRotated checks if BLS key of the validator has been rotated since the last validator set update
+func isBlsKeyRotated(oldValidator, newValidator *validator.ValidatorMetadata) bool {
+	return newValidator.BlsKey != nil && oldValidator.BlsKey != nil &&
+		!bytes.Equal(oldValidator.BlsKey.Marshal(), newValidator.BlsKey.Marshal())
+}
 
-		s.logger.Debug("New validator set", "validatorSet", newValidatorSet)
+// getBlsKey returns bls key for validator. The key rotated by the validator takes precedence
+// over the key registered in the supernet contract, which is not updated by the rotation.
+// It is the only place the keys registered in the supernet contract are read from, so they must not be
+// read elsewhere (see docs/validator-key-rotation/key-rotation.md)
+func (s *stakeManager) getBlsKey(fullValidatorSet *validatorSetState, address types.Address) (*bls.PublicKey, error) {
+	if rotatedKey, exists := fullValidatorSet.RotatedBlsKeys[address]; exists {
+		return bls.UnmarshalPublicKey(rotatedKey)
 	}
 
-	return delta, nil
-}
-
-// getBlsKey returns bls key for validator from the supernet contract
-func (s *stakeManager) getBlsKey(address types.Address) (*bls.PublicKey, error) {
 	getValidatorFn := &contractsapi.GetValidatorCustomSupernetManagerFn{
 		Validator_: address,
 	}
@@ -309,6 +445,10 @@ type validatorSetState struct {
 	EpochID              uint64            `json:"epoch"`
 	UpdatedAtBlockNumber uint64            `json:"updated_at_block"`
 	Validators           validatorStakeMap `json:"validators"`
+	// Jailed holds validators which are jailed for double signing, together with the block they were jailed in
+	Jailed map[types.Address]uint64 `json:"jailed,omitempty"`
+	// RotatedBlsKeys holds the marshaled BLS keys the validators rotated to (see ValidatorKeyRotation)
+	RotatedBlsKeys map[types.Address][]byte `json:"rotated_bls_keys,omitempty"`
 }
 
 func (vs validatorSetState) Marshal() ([]byte, error) {
@@ -354,6 +494,23 @@ func (sc *validatorStakeMap) removeStake(address types.Address, amount *big.Int)
 	stakeData.IsActive = stakeData.VotingPower.Cmp(bigZero) > 0
 }
 
+// withoutJailed returns stake map without the given jailed validators
+func (sc validatorStakeMap) withoutJailed(jailed map[types.Address]uint64) validatorStakeMap {
+	if len(jailed) == 0 {
+		return sc
+	}
+
+	stakeMap := make(validatorStakeMap, len(sc))
+
+	for addr, v := range sc {
+		if _, isJailed := jailed[addr]; !isJailed {
+			stakeMap[addr] = v
+		}
+	}
+
+	return stakeMap
+}
+
 // getSorted returns validators (*ValidatorMetadata) in sorted order
 func (sc validatorStakeMap) getSorted(maxValidatorSetSize int) validator.AccountSet {
 	activeValidators := make(validator.AccountSet, 0, len(sc))
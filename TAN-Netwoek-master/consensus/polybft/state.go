@@ -37,6 +37,8 @@ type State struct {
 	EpochStore            *EpochStore
 	ProposerSnapshotStore *ProposerSnapshotStore
 	StakeStore            *StakeStore
+	UptimeStore           *UptimeStore
+	EvidenceStore         *EvidenceStore
 }
 
 // newState creates new instance of State
@@ -54,6 +56,8 @@ func newState(path string, logger hclog.Logger, closeCh chan struct{}) (*State,
 		EpochStore:            &EpochStore{db: db},
 		ProposerSnapshotStore: &ProposerSnapshotStore{db: db},
 		StakeStore:            &StakeStore{db: db},
+		UptimeStore:           &UptimeStore{db: db},
+		EvidenceStore:         &EvidenceStore{db: db},
 	}
 
 	if err = s.initStorages(); err != nil {
@@ -79,8 +83,14 @@ func (s *State) initStorages() error {
 		if err := s.ProposerSnapshotStore.initialize(tx); err != nil {
 			return err
 		}
+		if err := s.StakeStore.initialize(tx); err != nil {
+			return err
+		}
+		if err := s.UptimeStore.initialize(tx); err != nil {
+			return err
+		}
 
-		return s.StakeStore.initialize(tx)
+		return s.EvidenceStore.initialize(tx)
 	})
 }
 
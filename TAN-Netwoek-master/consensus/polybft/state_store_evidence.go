@@ -0,0 +1,93 @@
+package polybft
+
+import (
+	"fmt"
+
+	"github.com/tarality/tan-network/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+/*
+Bolt DB schema:
+
+double sign evidence/
+|--> evidence id -> *DoubleSignEvidence (ABI encoded)
+*/
+var (
+	// bucket to store double sign evidence which is not yet included in a block
+	doubleSignEvidenceBucket = []byte("doubleSignEvidence")
+)
+
+type EvidenceStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *EvidenceStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(doubleSignEvidenceBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(doubleSignEvidenceBucket), err)
+	}
+
+	return nil
+}
+
+// insertDoubleSignEvidence saves double sign evidence, unless evidence with the same id already exists.
+// It returns true if the evidence is inserted.
+func (s *EvidenceStore) insertDoubleSignEvidence(evidence *DoubleSignEvidence) (bool, error) {
+	raw, err := evidence.EncodeAbi()
+	if err != nil {
+		return false, err
+	}
+
+	inserted := false
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(doubleSignEvidenceBucket)
+		id := evidence.ID()
+
+		if bucket.Get(id.Bytes()) != nil {
+			return nil
+		}
+
+		inserted = true
+
+		return bucket.Put(id.Bytes(), raw)
+	})
+
+	return inserted, err
+}
+
+// getDoubleSignEvidence returns all the saved double sign evidence
+func (s *EvidenceStore) getDoubleSignEvidence() ([]*DoubleSignEvidence, error) {
+	var result []*DoubleSignEvidence
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(doubleSignEvidenceBucket).ForEach(func(k, v []byte) error {
+			evidence := &DoubleSignEvidence{}
+			if err := evidence.DecodeAbi(v); err != nil {
+				return err
+			}
+
+			result = append(result, evidence)
+
+			return nil
+		})
+	})
+
+	return result, err
+}
+
+// removeDoubleSignEvidence removes double sign evidence with given ids
+func (s *EvidenceStore) removeDoubleSignEvidence(ids ...types.Hash) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(doubleSignEvidenceBucket)
+
+		for _, id := range ids {
+			if err := bucket.Delete(id.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
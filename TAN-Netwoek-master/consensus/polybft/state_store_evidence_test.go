@@ -0,0 +1,44 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ibftProto "github.com/tarality/0xTaral/messages/proto"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+)
+
+func TestState_insertAndRemoveDoubleSignEvidence(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	key := validator.NewTestValidator(t, "A", 1).Key()
+
+	first, second := createConflictingMessages(t, key, ibftProto.MessageType_PREPARE, 3, 0)
+	prepareEvidence := newDoubleSignEvidence(first, second)
+
+	first, second = createConflictingMessages(t, key, ibftProto.MessageType_COMMIT, 3, 0)
+	commitEvidence := newDoubleSignEvidence(first, second)
+
+	for _, evidence := range []*DoubleSignEvidence{prepareEvidence, commitEvidence} {
+		inserted, err := state.EvidenceStore.insertDoubleSignEvidence(evidence)
+		require.NoError(t, err)
+		require.True(t, inserted)
+	}
+
+	// evidence with the same id is not inserted again
+	inserted, err := state.EvidenceStore.insertDoubleSignEvidence(newDoubleSignEvidence(second, first))
+	require.NoError(t, err)
+	require.False(t, inserted)
+
+	evidence, err := state.EvidenceStore.getDoubleSignEvidence()
+	require.NoError(t, err)
+	require.Len(t, evidence, 2)
+
+	require.NoError(t, state.EvidenceStore.removeDoubleSignEvidence(prepareEvidence.ID()))
+
+	evidence, err = state.EvidenceStore.getDoubleSignEvidence()
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+	require.Equal(t, commitEvidence.ID(), evidence[0].ID())
+}
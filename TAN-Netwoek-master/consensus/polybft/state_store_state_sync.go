@@ -184,6 +184,39 @@ func (s *StateSyncStore) getCommitmentMessage(toIndex uint64) (*CommitmentMessag
 	return commitment, err
 }
 
+// getLastStateSyncEventID returns the id of the latest state sync event saved in db,
+// or zero if there are no state sync events
+func (s *StateSyncStore) getLastStateSyncEventID() (uint64, error) {
+	var lastID uint64
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket(stateSyncEventsBucket).Cursor().Last()
+		if k != nil {
+			lastID = common.EncodeBytesToUint64(k)
+		}
+
+		return nil
+	})
+
+	return lastID, err
+}
+
+// getLastCommitmentMessage queries the signed commitment with the highest end index from the db
+func (s *StateSyncStore) getLastCommitmentMessage() (*CommitmentMessageSigned, error) {
+	var commitment *CommitmentMessageSigned
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		_, raw := tx.Bucket(commitmentsBucket).Cursor().Last()
+		if raw == nil {
+			return nil
+		}
+
+		return json.Unmarshal(raw, &commitment)
+	})
+
+	return commitment, err
+}
+
 // insertMessageVote inserts given vote to signatures bucket of given epoch
 func (s *StateSyncStore) insertMessageVote(epoch uint64, key []byte, vote *MessageSignature) (int, error) {
 	var numSignatures int
@@ -190,6 +190,36 @@ func TestState_getCommitmentForStateSync(t *testing.T) {
 	}
 }
 
+func TestState_getLastStateSyncEventAndCommitment(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+
+	lastID, err := state.StateSyncStore.getLastStateSyncEventID()
+	require.NoError(t, err)
+	require.Zero(t, lastID)
+
+	commitment, err := state.StateSyncStore.getLastCommitmentMessage()
+	require.NoError(t, err)
+	require.Nil(t, commitment)
+
+	for i := int64(1); i <= 300; i++ {
+		require.NoError(t, state.StateSyncStore.insertStateSyncEvent(createTestStateSync(i)))
+	}
+
+	insertTestCommitments(t, state, 2)
+
+	lastID, err = state.StateSyncStore.getLastStateSyncEventID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(300), lastID)
+
+	commitment, err = state.StateSyncStore.getLastCommitmentMessage()
+	require.NoError(t, err)
+	require.NotNil(t, commitment)
+	require.Equal(t, uint64(2*maxCommitmentSize+1), commitment.Message.StartID.Uint64())
+	require.Equal(t, uint64(3*maxCommitmentSize), commitment.Message.EndID.Uint64())
+}
+
 func TestState_GetNestedBucketInEpoch(t *testing.T) {
 	t.Parallel()
 
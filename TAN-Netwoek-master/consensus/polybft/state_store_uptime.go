@@ -0,0 +1,200 @@
+package polybft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+/*
+Bolt DB schema:
+
+uptime blocks/
+|--> block number -> *BlockUptime (json marshalled)
+
+uptime epochs/
+|--> epoch number -> map[types.Address]*types.ValidatorUptime (json marshalled)
+*/
+var (
+	// bucket to store signing information of the latest blocks
+	uptimeBlocksBucket = []byte("uptimeBlocks")
+	// bucket to store validators signing and proposing statistics per epoch
+	uptimeEpochsBucket = []byte("uptimeEpochs")
+)
+
+// BlockUptime holds signing information of a single block
+type BlockUptime struct {
+	Number   uint64
+	Proposer types.Address
+	Signers  []types.Address
+	Missed   []types.Address
+}
+
+type UptimeStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *UptimeStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(uptimeBlocksBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(uptimeBlocksBucket), err)
+	}
+
+	if _, err := tx.CreateBucketIfNotExists(uptimeEpochsBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(uptimeEpochsBucket), err)
+	}
+
+	return nil
+}
+
+// insertBlockUptime saves signing information of the block, updates statistics of the given epoch
+// and removes signing information of blocks which are out of the retention window.
+// Missed streaks are carried over from the previous epoch when epoch statistics are created.
+// It returns updated statistics of the given epoch.
+func (s *UptimeStore) insertBlockUptime(block *BlockUptime, epoch,
+	retention uint64) (map[types.Address]*types.ValidatorUptime, error) {
+	var uptime map[types.Address]*types.ValidatorUptime
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		raw, err := json.Marshal(block)
+		if err != nil {
+			return err
+		}
+
+		blocksBucket := tx.Bucket(uptimeBlocksBucket)
+		if err := blocksBucket.Put(common.EncodeUint64ToBytes(block.Number), raw); err != nil {
+			return err
+		}
+
+		if block.Number > retention {
+			if err := pruneUptimeBlocks(blocksBucket, block.Number-retention); err != nil {
+				return err
+			}
+		}
+
+		epochsBucket := tx.Bucket(uptimeEpochsBucket)
+
+		if uptime, err = getEpochUptimeLocked(epochsBucket, epoch); err != nil {
+			return err
+		}
+
+		if uptime == nil {
+			if uptime, err = newEpochUptime(epochsBucket, epoch); err != nil {
+				return err
+			}
+		}
+
+		updateUptime(uptime, block)
+
+		if raw, err = json.Marshal(uptime); err != nil {
+			return err
+		}
+
+		return epochsBucket.Put(common.EncodeUint64ToBytes(epoch), raw)
+	})
+
+	return uptime, err
+}
+
+// getEpochUptime returns validators signing and proposing statistics for the given epoch,
+// or nil if there are no statistics for the epoch
+func (s *UptimeStore) getEpochUptime(epoch uint64) (map[types.Address]*types.ValidatorUptime, error) {
+	var uptime map[types.Address]*types.ValidatorUptime
+
+	err := s.db.View(func(tx *bolt.Tx) (err error) {
+		uptime, err = getEpochUptimeLocked(tx.Bucket(uptimeEpochsBucket), epoch)
+
+		return err
+	})
+
+	return uptime, err
+}
+
+// getLastBlocksUptime returns signing information of the given number of the latest blocks,
+// ordered from the oldest to the newest one
+func (s *UptimeStore) getLastBlocksUptime(count uint64) ([]*BlockUptime, error) {
+	var blocks []*BlockUptime
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(uptimeBlocksBucket).Cursor()
+
+		for k, v := c.Last(); k != nil && uint64(len(blocks)) < count; k, v = c.Prev() {
+			var block *BlockUptime
+			if err := json.Unmarshal(v, &block); err != nil {
+				return err
+			}
+
+			blocks = append(blocks, block)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// reverse, so the blocks are ordered from the oldest one
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+
+	return blocks, nil
+}
+
+// getEpochUptimeLocked returns epoch statistics from the given bucket, or nil if they do not exist
+func getEpochUptimeLocked(bucket *bolt.Bucket, epoch uint64) (map[types.Address]*types.ValidatorUptime, error) {
+	raw := bucket.Get(common.EncodeUint64ToBytes(epoch))
+	if raw == nil {
+		return nil, nil
+	}
+
+	var uptime map[types.Address]*types.ValidatorUptime
+	if err := json.Unmarshal(raw, &uptime); err != nil {
+		return nil, err
+	}
+
+	return uptime, nil
+}
+
+// newEpochUptime creates empty epoch statistics, carrying over missed streaks from the previous epoch
+func newEpochUptime(bucket *bolt.Bucket, epoch uint64) (map[types.Address]*types.ValidatorUptime, error) {
+	uptime := map[types.Address]*types.ValidatorUptime{}
+
+	if epoch == 0 {
+		return uptime, nil
+	}
+
+	previous, err := getEpochUptimeLocked(bucket, epoch-1)
+	if err != nil {
+		return nil, err
+	}
+
+	for addr, v := range previous {
+		if v.CurrentMissedStreak > 0 {
+			uptime[addr] = &types.ValidatorUptime{
+				Address:             addr,
+				CurrentMissedStreak: v.CurrentMissedStreak,
+			}
+		}
+	}
+
+	return uptime, nil
+}
+
+// pruneUptimeBlocks removes signing information of all the blocks lower than the given block number
+func pruneUptimeBlocks(bucket *bolt.Bucket, blockNumber uint64) error {
+	c := bucket.Cursor()
+	limit := common.EncodeUint64ToBytes(blockNumber)
+
+	for k, _ := c.First(); k != nil && bytes.Compare(k, limit) < 0; k, _ = c.First() {
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
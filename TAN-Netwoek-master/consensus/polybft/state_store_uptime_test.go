@@ -0,0 +1,72 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestState_insertBlockUptime_Retention(t *testing.T) {
+	t.Parallel()
+
+	const retention = 5
+
+	state := newTestState(t)
+	validator := types.StringToAddress("1")
+
+	for i := uint64(1); i <= 10; i++ {
+		_, err := state.UptimeStore.insertBlockUptime(&BlockUptime{
+			Number:  i,
+			Signers: []types.Address{validator},
+		}, 1, retention)
+		require.NoError(t, err)
+	}
+
+	blocks, err := state.UptimeStore.getLastBlocksUptime(100)
+	require.NoError(t, err)
+	require.Len(t, blocks, retention+1)
+
+	for i, b := range blocks {
+		require.Equal(t, uint64(i+5), b.Number)
+	}
+
+	uptime, err := state.UptimeStore.getEpochUptime(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), uptime[validator].Signed)
+}
+
+func TestState_insertBlockUptime_CarryOverMissedStreak(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	signer := types.StringToAddress("1")
+	silent := types.StringToAddress("2")
+
+	for i := uint64(1); i <= 3; i++ {
+		_, err := state.UptimeStore.insertBlockUptime(&BlockUptime{
+			Number:   i,
+			Proposer: signer,
+			Signers:  []types.Address{signer},
+			Missed:   []types.Address{silent},
+		}, 1, uptimeBlocksRetention)
+		require.NoError(t, err)
+	}
+
+	uptime, err := state.UptimeStore.insertBlockUptime(&BlockUptime{
+		Number:   4,
+		Proposer: signer,
+		Signers:  []types.Address{signer},
+		Missed:   []types.Address{silent},
+	}, 2, uptimeBlocksRetention)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(1), uptime[silent].Missed)
+	require.Equal(t, uint64(4), uptime[silent].CurrentMissedStreak)
+	require.Equal(t, uint64(4), uptime[silent].LongestMissedStreak)
+	require.Equal(t, uint64(1), uptime[signer].Proposed)
+
+	uptime, err = state.UptimeStore.getEpochUptime(3)
+	require.NoError(t, err)
+	require.Nil(t, uptime)
+}
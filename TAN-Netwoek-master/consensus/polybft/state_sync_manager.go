@@ -548,7 +548,13 @@ func (s *stateSyncManager) buildCommitment() error {
 
 	hashBytes := hash.Bytes()
 
-	signature, err := s.config.key.SignWithDomain(hashBytes, bls.DomainStateReceiver)
+	// the commitment hash is derived by the key signer from the encoded commitment
+	encodedCommitment, err := commitment.StateSyncCommitment.EncodeAbi()
+	if err != nil {
+		return fmt.Errorf("failed to encode commitment. Error: %w", err)
+	}
+
+	signature, err := s.config.key.SignWithDomain(encodedCommitment, bls.DomainStateReceiver)
 	if err != nil {
 		return fmt.Errorf("failed to sign commitment message. Error: %w", err)
 	}
@@ -32,6 +32,9 @@ func decodeStateTransaction(txData []byte) (contractsapi.StateTransactionInput,
 	} else if bytes.Equal(sig, distributeRewardsFn.Sig()) {
 		// distribute rewards
 		obj = &contractsapi.DistributeRewardForRewardPoolFn{}
+	} else if bytes.Equal(sig, submitDoubleSignEvidenceMethod.ID()) {
+		// double sign evidence
+		obj = &DoubleSignEvidence{}
 	} else {
 		return nil, fmt.Errorf("unknown state transaction")
 	}
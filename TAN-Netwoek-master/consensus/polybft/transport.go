@@ -28,6 +28,8 @@ func (p *Polybft) subscribeToIbftTopic() error {
 			return
 		}
 
+		p.runtime.evidenceCollector.AddMessage(msg)
+
 		p.ibft.AddMessage(msg)
 
 		p.logger.Debug(
@@ -59,7 +61,11 @@ func (p *Polybft) createTopics() (err error) {
 
 // Multicast is implementation of core.Transport interface
 func (p *Polybft) Multicast(msg *ibftProto.Message) {
+	p.runtime.evidenceCollector.AddMessage(msg)
+
 	if err := p.consensusTopic.Publish(msg); err != nil {
 		p.logger.Warn("failed to multicast consensus message", "error", err)
 	}
+
+	p.multicastByzantine(msg)
 }
@@ -0,0 +1,51 @@
+//go:build e2e
+// +build e2e
+
+package polybft
+
+import (
+	ibftProto "github.com/tarality/0xTaral/messages/proto"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/tarality/tan-network/crypto"
+)
+
+// multicastByzantine publishes a conflicting message, if the node is configured to behave as a byzantine validator
+func (p *Polybft) multicastByzantine(msg *ibftProto.Message) {
+	if p.config.ByzantineDoubleSign {
+		p.multicastConflictingMessage(msg)
+	}
+}
+
+// multicastConflictingMessage signs and publishes a prepare or commit message for a different proposal.
+// It makes the node behave as a byzantine validator and it is used only for testing.
+func (p *Polybft) multicastConflictingMessage(msg *ibftProto.Message) {
+	conflicting, ok := protobuf.Clone(msg).(*ibftProto.Message)
+	if !ok {
+		return
+	}
+
+	switch conflicting.Type {
+	case ibftProto.MessageType_PREPARE:
+		data := conflicting.GetPrepareData()
+		data.ProposalHash = crypto.Keccak256(data.ProposalHash)
+	case ibftProto.MessageType_COMMIT:
+		data := conflicting.GetCommitData()
+		data.ProposalHash = crypto.Keccak256(data.ProposalHash)
+	default:
+		return
+	}
+
+	conflicting.Signature = nil
+
+	conflicting, err := p.key.SignIBFTMessage(conflicting)
+	if err != nil {
+		p.logger.Warn("failed to sign conflicting consensus message", "error", err)
+
+		return
+	}
+
+	if err := p.consensusTopic.Publish(conflicting); err != nil {
+		p.logger.Warn("failed to multicast conflicting consensus message", "error", err)
+	}
+}
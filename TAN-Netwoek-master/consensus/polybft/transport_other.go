@@ -0,0 +1,11 @@
+//go:build !e2e
+// +build !e2e
+
+package polybft
+
+import (
+	ibftProto "github.com/tarality/0xTaral/messages/proto"
+)
+
+// multicastByzantine does nothing, the byzantine behavior is available only in the e2e builds
+func (p *Polybft) multicastByzantine(_ *ibftProto.Message) {}
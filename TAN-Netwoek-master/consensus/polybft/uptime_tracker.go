@@ -0,0 +1,246 @@
+package polybft
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/consensus/polybft/bitmap"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	// uptimeBlocksRetention is the number of the latest blocks for which signing information is kept
+	uptimeBlocksRetention = 10000
+
+	// validatorLabel is the metrics label holding validator address
+	validatorLabel = "validator"
+)
+
+var errUptimeBlocksLimitExceeded = fmt.Errorf("number of blocks must not exceed %d", uptimeBlocksRetention)
+
+// UptimeTracker tracks validators signing and proposing activity, based on committed seals
+type UptimeTracker interface {
+	PostBlock(req *PostBlockRequest) error
+	GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error)
+	GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error)
+}
+
+// dummyUptimeTracker is a dummy implementation of UptimeTracker interface
+// used only for unit testing
+type dummyUptimeTracker struct{}
+
+func (d *dummyUptimeTracker) PostBlock(req *PostBlockRequest) error { return nil }
+func (d *dummyUptimeTracker) GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error) {
+	return nil, nil
+}
+func (d *dummyUptimeTracker) GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	return nil, nil
+}
+
+var _ UptimeTracker = (*uptimeTracker)(nil)
+
+// uptimeTracker is a struct that persists and exposes validators uptime statistics
+type uptimeTracker struct {
+	// state is reference to the struct which encapsulates uptime persistence logic
+	state *State
+	// blockchain is abstraction for blockchain
+	blockchain blockchainBackend
+	// polybftBackend is abstraction for polybft backend
+	polybftBackend polybftBackend
+	// alertThreshold is the number of consecutively missed blocks after which a validator is reported as silent
+	// (zero disables alerting)
+	alertThreshold uint64
+	// logger instance
+	logger hclog.Logger
+}
+
+// newUptimeTracker returns a new instance of uptime tracker
+func newUptimeTracker(logger hclog.Logger, state *State, blockchain blockchainBackend,
+	polybftBackend polybftBackend, alertThreshold uint64) *uptimeTracker {
+	return &uptimeTracker{
+		state:          state,
+		blockchain:     blockchain,
+		polybftBackend: polybftBackend,
+		alertThreshold: alertThreshold,
+		logger:         logger,
+	}
+}
+
+// PostBlock is called on every insert of finalized block (either from consensus or syncer).
+// Committed seals of a block are included in its child, so it records signing information of the parent block.
+func (u *uptimeTracker) PostBlock(req *PostBlockRequest) error {
+	header := req.FullBlock.Block.Header
+
+	// genesis block is not sealed
+	if header.Number < 2 {
+		return nil
+	}
+
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return err
+	}
+
+	if extra.Parent == nil {
+		return nil
+	}
+
+	parentHeader, found := u.blockchain.GetHeaderByNumber(header.Number - 1)
+	if !found {
+		return fmt.Errorf("failed to get parent header for block %d", header.Number)
+	}
+
+	validators, err := u.polybftBackend.GetValidators(header.Number-2, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get validators for block %d: %w", parentHeader.Number, err)
+	}
+
+	block := newBlockUptime(parentHeader, validators, extra.Parent.Bitmap)
+
+	uptime, err := u.state.UptimeStore.insertBlockUptime(block, req.Epoch, uptimeBlocksRetention)
+	if err != nil {
+		return err
+	}
+
+	u.updateUptimeMetrics(block, uptime)
+
+	return nil
+}
+
+// GetValidatorsUptime returns signing statistics of validators for the given number of the latest blocks
+func (u *uptimeTracker) GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error) {
+	if blocks > uptimeBlocksRetention {
+		return nil, errUptimeBlocksLimitExceeded
+	}
+
+	blocksUptime, err := u.state.UptimeStore.getLastBlocksUptime(blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	uptime := map[types.Address]*types.ValidatorUptime{}
+
+	for _, block := range blocksUptime {
+		updateUptime(uptime, block)
+	}
+
+	return sortedUptime(uptime), nil
+}
+
+// GetEpochUptime returns signing statistics of validators for the given epoch
+func (u *uptimeTracker) GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	uptime, err := u.state.UptimeStore.getEpochUptime(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedUptime(uptime), nil
+}
+
+// updateUptimeMetrics updates per validator uptime metrics of the current epoch
+// and reports validators which went silent
+func (u *uptimeTracker) updateUptimeMetrics(block *BlockUptime, uptime map[types.Address]*types.ValidatorUptime) {
+	addresses := make([]types.Address, 0, len(block.Signers)+len(block.Missed))
+	addresses = append(addresses, block.Signers...)
+	addresses = append(addresses, block.Missed...)
+
+	for _, addr := range addresses {
+		v := uptime[addr]
+		labels := []metrics.Label{{Name: validatorLabel, Value: addr.String()}}
+
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_signed_blocks"},
+			float32(v.Signed), labels)
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_missed_blocks"},
+			float32(v.Missed), labels)
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_proposed_blocks"},
+			float32(v.Proposed), labels)
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_missed_streak"},
+			float32(v.CurrentMissedStreak), labels)
+
+		if u.alertThreshold == 0 {
+			continue
+		}
+
+		silent := float32(0)
+		if v.CurrentMissedStreak >= u.alertThreshold {
+			silent = 1
+		}
+
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_silent"}, silent, labels)
+
+		if v.CurrentMissedStreak == u.alertThreshold {
+			u.logger.Warn("validator went silent", "validator", addr,
+				"missed blocks", v.CurrentMissedStreak, "block", block.Number)
+		}
+	}
+}
+
+// newBlockUptime creates signing information of the block, based on its committed seals bitmap
+func newBlockUptime(header *types.Header, validators validator.AccountSet, signers bitmap.Bitmap) *BlockUptime {
+	block := &BlockUptime{
+		Number:   header.Number,
+		Proposer: types.BytesToAddress(header.Miner),
+	}
+
+	for i, v := range validators {
+		if signers.IsSet(uint64(i)) {
+			block.Signers = append(block.Signers, v.Address)
+		} else {
+			block.Missed = append(block.Missed, v.Address)
+		}
+	}
+
+	return block
+}
+
+// updateUptime updates validators signing and proposing statistics with the signing information of the block
+func updateUptime(uptime map[types.Address]*types.ValidatorUptime, block *BlockUptime) {
+	get := func(addr types.Address) *types.ValidatorUptime {
+		v, ok := uptime[addr]
+		if !ok {
+			v = &types.ValidatorUptime{Address: addr}
+			uptime[addr] = v
+		}
+
+		return v
+	}
+
+	for _, addr := range block.Signers {
+		v := get(addr)
+		v.Blocks++
+		v.Signed++
+		v.CurrentMissedStreak = 0
+	}
+
+	for _, addr := range block.Missed {
+		v := get(addr)
+		v.Blocks++
+		v.Missed++
+		v.CurrentMissedStreak++
+
+		if v.CurrentMissedStreak > v.LongestMissedStreak {
+			v.LongestMissedStreak = v.CurrentMissedStreak
+		}
+	}
+
+	get(block.Proposer).Proposed++
+}
+
+// sortedUptime returns validators statistics sorted by validator address
+func sortedUptime(uptime map[types.Address]*types.ValidatorUptime) []*types.ValidatorUptime {
+	result := make([]*types.ValidatorUptime, 0, len(uptime))
+
+	for _, v := range uptime {
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Address[:], result[j].Address[:]) < 0
+	})
+
+	return result
+}
@@ -0,0 +1,116 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/consensus"
+	"github.com/tarality/tan-network/consensus/polybft/bitmap"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestUptimeTracker_PostBlock(t *testing.T) {
+	t.Parallel()
+
+	const epoch = uint64(1)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"})
+	accounts := validators.GetPublicIdentities("A", "B", "C", "D")
+
+	addrA := validators.GetValidator("A").Address()
+	addrB := validators.GetValidator("B").Address()
+	addrC := validators.GetValidator("C").Address()
+	addrD := validators.GetValidator("D").Address()
+
+	headers := map[uint64]*types.Header{}
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(func(number uint64) *types.Header {
+		return headers[number]
+	})
+
+	polybftBackendMock := new(polybftBackendMock)
+	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(accounts)
+
+	tracker := newUptimeTracker(hclog.NewNullLogger(), newTestState(t), blockchainMock, polybftBackendMock, 3)
+
+	// signers of the parent blocks (A, B and C sign every block, except C missing block 2 and D missing all of them)
+	signers := map[uint64][]int{
+		1: {0, 1, 2},
+		2: {0, 1},
+		3: {0, 1, 2},
+		4: {0, 1, 2},
+	}
+
+	for number := uint64(1); number <= 5; number++ {
+		proposer := addrA
+		if number%2 == 0 {
+			proposer = addrB
+		}
+
+		header := &types.Header{Number: number, Miner: proposer.Bytes()}
+
+		if parentSigners, ok := signers[number-1]; ok {
+			b := bitmap.Bitmap{}
+			for _, idx := range parentSigners {
+				b.Set(uint64(idx))
+			}
+
+			header.ExtraData = createTestExtraForAccounts(t, epoch, accounts, b)
+		} else {
+			header.ExtraData = createTestExtraForAccounts(t, epoch, accounts, bitmap.Bitmap{})
+		}
+
+		headers[number] = header
+
+		require.NoError(t, tracker.PostBlock(&PostBlockRequest{
+			FullBlock: &types.FullBlock{Block: consensus.BuildBlock(consensus.BuildBlockParams{Header: header})},
+			Epoch:     epoch,
+		}))
+	}
+
+	epochUptime, err := tracker.GetEpochUptime(epoch)
+	require.NoError(t, err)
+	require.Len(t, epochUptime, 4)
+
+	byAddress := map[types.Address]*types.ValidatorUptime{}
+	for _, u := range epochUptime {
+		byAddress[u.Address] = u
+	}
+
+	// blocks 1 to 4 are tracked, since genesis block is not sealed
+	require.Equal(t, uint64(4), byAddress[addrA].Blocks)
+	require.Equal(t, uint64(4), byAddress[addrA].Signed)
+	require.Equal(t, uint64(2), byAddress[addrA].Proposed)
+	require.Equal(t, uint64(2), byAddress[addrB].Proposed)
+
+	require.Equal(t, uint64(3), byAddress[addrC].Signed)
+	require.Equal(t, uint64(1), byAddress[addrC].Missed)
+	require.Equal(t, uint64(1), byAddress[addrC].LongestMissedStreak)
+	require.Equal(t, uint64(0), byAddress[addrC].CurrentMissedStreak)
+
+	require.Equal(t, uint64(4), byAddress[addrD].Missed)
+	require.Equal(t, uint64(4), byAddress[addrD].LongestMissedStreak)
+	require.Equal(t, uint64(4), byAddress[addrD].CurrentMissedStreak)
+
+	// only the last two blocks (3 and 4)
+	uptime, err := tracker.GetValidatorsUptime(2)
+	require.NoError(t, err)
+	require.Len(t, uptime, 4)
+
+	for _, u := range uptime {
+		require.Equal(t, uint64(2), u.Blocks)
+
+		if u.Address == addrD {
+			require.Equal(t, uint64(2), u.Missed)
+		} else {
+			require.Equal(t, uint64(2), u.Signed)
+		}
+	}
+
+	_, err = tracker.GetValidatorsUptime(uptimeBlocksRetention + 1)
+	require.ErrorIs(t, err, errUptimeBlocksLimitExceeded)
+}
@@ -0,0 +1,274 @@
+package polybft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/tarality/tan-network/chain"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+	"github.com/tarality/tan-network/consensus/polybft/wallet"
+	"github.com/tarality/tan-network/contracts"
+	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/helper/hex"
+	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/types"
+)
+
+var (
+	// rotateValidatorKeyMethod is the ABI method of the validator key rotation transaction,
+	// which is sent by the validator to the ValidatorKeyRotationContract address
+	rotateValidatorKeyMethod = abi.MustNewMethod("function rotateValidatorKey(" +
+		"uint256[4] newKey, uint256[2] oldKeySignature, uint256[2] newKeySignature)")
+
+	errKeyRotationSameKey      = errors.New("new validator key is the same as the current one")
+	errKeyRotationOldSignature = errors.New("invalid signature by the current validator key")
+	errKeyRotationNewSignature = errors.New("invalid signature by the new validator key")
+	errKeyRotationDisabled     = errors.New("key rotation fork is not enabled")
+)
+
+// ValidatorKeyRotation replaces the BLS key of the validator, without the validator leaving the validator set.
+// The supernet manager contract has no method to replace the registered key, so the rotation is applied
+// by the stake manager, which records the rotated key and prefers it to the key registered in the supernet manager.
+// Rotation is a plain transaction to an address without code, it changes no contract state: the supernet manager
+// keeps returning the registered key, and the rotated key is found only in the validator set of the block extra
+// (which is also sent to the rootchain checkpoint manager) and in the stake store of the nodes.
+// It is signed by the current key, which proves the validator owns it, and by the new key,
+// which proves the validator owns the new key as well (prevents the rogue key attack).
+// Rotation is sent as a transaction from the validator address, so it is authorized by the ECDSA key too.
+// The new key is used by the validator set starting from the next epoch.
+type ValidatorKeyRotation struct {
+	NewKey          *bls.PublicKey
+	OldKeySignature *bls.Signature
+	NewKeySignature *bls.Signature
+}
+
+// NewValidatorKeyRotation creates key rotation of the given validator signed by both the current and the new key
+func NewValidatorKeyRotation(chainID uint64, validatorAddr types.Address,
+	oldKey, newKey *bls.PrivateKey) (*ValidatorKeyRotation, error) {
+	message, err := keyRotationMessage(chainID, validatorAddr, newKey.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	oldKeySignature, err := oldKey.Sign(message, bls.DomainValidatorKeyRotation)
+	if err != nil {
+		return nil, err
+	}
+
+	newKeySignature, err := newKey.Sign(message, bls.DomainValidatorKeyRotation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidatorKeyRotation{
+		NewKey:          newKey.PublicKey(),
+		OldKeySignature: oldKeySignature,
+		NewKeySignature: newKeySignature,
+	}, nil
+}
+
+// isKeyRotationEnabled returns true if the validator key rotations included in the block of given height are applied
+func isKeyRotationEnabled(height uint64) bool {
+	return forkmanager.GetInstance().IsForkEnabled(chain.KeyRotation, height)
+}
+
+// Verify verifies that the key rotation of the given validator is signed by its current and by the new key
+func (r *ValidatorKeyRotation) Verify(chainID uint64, validatorAddr types.Address, currentKey *bls.PublicKey) error {
+	if bytes.Equal(r.NewKey.Marshal(), currentKey.Marshal()) {
+		return errKeyRotationSameKey
+	}
+
+	message, err := keyRotationMessage(chainID, validatorAddr, r.NewKey)
+	if err != nil {
+		return err
+	}
+
+	if !r.OldKeySignature.Verify(currentKey, message, bls.DomainValidatorKeyRotation) {
+		return errKeyRotationOldSignature
+	}
+
+	if !r.NewKeySignature.Verify(r.NewKey, message, bls.DomainValidatorKeyRotation) {
+		return errKeyRotationNewSignature
+	}
+
+	return nil
+}
+
+// EncodeAbi contains logic for encoding arbitrary data into ABI format
+func (r *ValidatorKeyRotation) EncodeAbi() ([]byte, error) {
+	oldKeySignature, err := r.OldKeySignature.ToBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	newKeySignature, err := r.NewKeySignature.ToBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	return rotateValidatorKeyMethod.Encode([]interface{}{r.NewKey.ToBigInt(), oldKeySignature, newKeySignature})
+}
+
+// DecodeAbi contains logic for decoding given ABI data
+func (r *ValidatorKeyRotation) DecodeAbi(b []byte) error {
+	if len(b) < abiMethodIDLength || !bytes.Equal(b[:abiMethodIDLength], rotateValidatorKeyMethod.ID()) {
+		return errors.New("invalid validator key rotation signature")
+	}
+
+	raw, err := abi.Decode(rotateValidatorKeyMethod.Inputs, b[abiMethodIDLength:])
+	if err != nil {
+		return err
+	}
+
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return errors.New("could not decode validator key rotation")
+	}
+
+	newKey, ok := values["newKey"].([4]*big.Int)
+	if !ok {
+		return errors.New("could not decode new validator key")
+	}
+
+	oldKeySignature, ok := values["oldKeySignature"].([2]*big.Int)
+	if !ok {
+		return errors.New("could not decode signature by the current validator key")
+	}
+
+	newKeySignature, ok := values["newKeySignature"].([2]*big.Int)
+	if !ok {
+		return errors.New("could not decode signature by the new validator key")
+	}
+
+	if r.NewKey, err = bls.UnmarshalPublicKeyFromBigInt(newKey); err != nil {
+		return fmt.Errorf("could not unmarshal new validator key: %w", err)
+	}
+
+	if r.OldKeySignature, err = unmarshalSignatureFromBigInt(oldKeySignature); err != nil {
+		return fmt.Errorf("could not unmarshal signature by the current validator key: %w", err)
+	}
+
+	if r.NewKeySignature, err = unmarshalSignatureFromBigInt(newKeySignature); err != nil {
+		return fmt.Errorf("could not unmarshal signature by the new validator key: %w", err)
+	}
+
+	return nil
+}
+
+// keyRotationMessage returns the message signed by both keys of the validator key rotation.
+// It binds the new key to the validator and to the chain, so the signatures can't be replayed elsewhere.
+func keyRotationMessage(chainID uint64, validatorAddr types.Address, newKey *bls.PublicKey) ([]byte, error) {
+	chainIDABI, err := abi.MustNewType("uint256").Encode(new(big.Int).SetUint64(chainID))
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Join([][]byte{chainIDABI, validatorAddr.Bytes(), newKey.Marshal()}, nil), nil
+}
+
+// unmarshalSignatureFromBigInt unmarshals BLS signature from its two coordinates
+func unmarshalSignatureFromBigInt(b [2]*big.Int) (*bls.Signature, error) {
+	raw := make([]byte, 0, 64)
+	for _, c := range b {
+		raw = append(raw, common.PadLeftOrTrim(c.Bytes(), 32)...)
+	}
+
+	return bls.UnmarshalSignature(raw)
+}
+
+// validatorKeyRotationTx is the successful validator key rotation transaction
+type validatorKeyRotationTx struct {
+	validator types.Address
+	rotation  *ValidatorKeyRotation
+}
+
+// getValidatorKeyRotationsFromBlock returns successful validator key rotation transactions included in the block.
+// Key rotations are sent by the validators (not proposers), so the ones which can't be decoded are skipped.
+func getValidatorKeyRotationsFromBlock(fullBlock *types.FullBlock, logger hclog.Logger) []*validatorKeyRotationTx {
+	var result []*validatorKeyRotationTx
+
+	for i, tx := range fullBlock.Block.Transactions {
+		if tx.To == nil || *tx.To != contracts.ValidatorKeyRotationContract {
+			continue
+		}
+
+		if i >= len(fullBlock.Receipts) || fullBlock.Receipts[i].Status == nil ||
+			*fullBlock.Receipts[i].Status != types.ReceiptSuccess {
+			continue
+		}
+
+		rotation := &ValidatorKeyRotation{}
+		if err := rotation.DecodeAbi(tx.Input); err != nil {
+			logger.Warn("Invalid validator key rotation transaction", "hash", tx.Hash, "from", tx.From, "err", err)
+
+			continue
+		}
+
+		result = append(result, &validatorKeyRotationTx{validator: tx.From, rotation: rotation})
+	}
+
+	return result
+}
+
+// validatorKeySwitcher switches the node to the pending (rotated) BLS key,
+// once the key rotation takes effect and the validator set contains the new key.
+// It is used only if the keys are held in memory. The keys held by the remote signer
+// are switched by the remote signer operator, see docs/remote-signer/protocol.md
+type validatorKeySwitcher struct {
+	logger         hclog.Logger
+	secretsManager secrets.SecretsManager
+	key            *wallet.Key
+}
+
+// newValidatorKeySwitcher creates validatorKeySwitcher
+func newValidatorKeySwitcher(logger hclog.Logger, secretsManager secrets.SecretsManager,
+	key *wallet.Key) *validatorKeySwitcher {
+	return &validatorKeySwitcher{
+		logger:         logger,
+		secretsManager: secretsManager,
+		key:            key,
+	}
+}
+
+// onNewEpoch switches to the pending BLS key if the validator set of the new epoch contains it
+func (s *validatorKeySwitcher) onNewEpoch(validators validator.AccountSet) error {
+	if !s.secretsManager.HasSecret(secrets.ValidatorBLSKeyNext) {
+		return nil
+	}
+
+	metadata := validators.GetValidatorMetadata(types.Address(s.key.Address()))
+	if metadata == nil {
+		return nil
+	}
+
+	pendingKey, err := wallet.GetPendingBlsFromSecret(s.secretsManager)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(metadata.BlsKey.Marshal(), pendingKey.PublicKey().Marshal()) {
+		// key rotation didn't take effect yet
+		return nil
+	}
+
+	// switch the key in memory first, so the validator signs by the correct key even if persisting fails
+	if err := s.key.RotateBLSKey(pendingKey); err != nil {
+		return err
+	}
+
+	if err := wallet.PromotePendingBls(s.secretsManager); err != nil {
+		return fmt.Errorf("failed to persist rotated bls key: %w", err)
+	}
+
+	s.logger.Info("Switched to the rotated validator BLS key",
+		"key", hex.EncodeToHex(pendingKey.PublicKey().Marshal()))
+
+	return nil
+}
@@ -0,0 +1,326 @@
+package polybft
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/0xTaral/messages/proto"
+
+	"github.com/tarality/tan-network/chain"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/consensus/polybft/validator"
+	"github.com/tarality/tan-network/consensus/polybft/wallet"
+	"github.com/tarality/tan-network/contracts"
+	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/helper"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	testKeyRotationChainID = uint64(100)
+
+	// keyRotationForkBlock is the block the key rotation fork is enabled from in the tests
+	keyRotationForkBlock = 5
+)
+
+var keyRotationForkOnce sync.Once
+
+// enableKeyRotation registers the key rotation fork in the fork manager
+func enableKeyRotation(t *testing.T) {
+	t.Helper()
+
+	keyRotationForkOnce.Do(func() {
+		fm := forkmanager.GetInstance()
+		fm.RegisterFork(chain.KeyRotation, nil)
+		require.NoError(t, fm.ActivateFork(chain.KeyRotation, keyRotationForkBlock))
+	})
+}
+
+func TestValidatorKeyRotation_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	v := validator.NewTestValidator(t, "A", 1)
+	newKey := generateTestBlsKey(t)
+
+	rotation, err := NewValidatorKeyRotation(testKeyRotationChainID, v.Address(), v.Account.Bls, newKey)
+	require.NoError(t, err)
+
+	input, err := rotation.EncodeAbi()
+	require.NoError(t, err)
+
+	decoded := &ValidatorKeyRotation{}
+	require.NoError(t, decoded.DecodeAbi(input))
+	require.Equal(t, rotation.NewKey.Marshal(), decoded.NewKey.Marshal())
+	require.NoError(t, decoded.Verify(testKeyRotationChainID, v.Address(), v.Account.Bls.PublicKey()))
+
+	require.Error(t, decoded.DecodeAbi(input[:abiMethodIDLength]))
+	require.Error(t, decoded.DecodeAbi([]byte{1, 2, 3, 4, 5}))
+}
+
+func TestValidatorKeyRotation_Verify(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	a, b := validators.GetValidator("A"), validators.GetValidator("B")
+	newKey := generateTestBlsKey(t)
+
+	rotation, err := NewValidatorKeyRotation(testKeyRotationChainID, a.Address(), a.Account.Bls, newKey)
+	require.NoError(t, err)
+	require.NoError(t, rotation.Verify(testKeyRotationChainID, a.Address(), a.Account.Bls.PublicKey()))
+
+	// rotation can't be replayed for another validator or on another chain
+	require.ErrorIs(t, rotation.Verify(testKeyRotationChainID, b.Address(), a.Account.Bls.PublicKey()),
+		errKeyRotationOldSignature)
+	require.ErrorIs(t, rotation.Verify(testKeyRotationChainID+1, a.Address(), a.Account.Bls.PublicKey()),
+		errKeyRotationOldSignature)
+
+	// rotation is not signed by the current key of the validator
+	require.ErrorIs(t, rotation.Verify(testKeyRotationChainID, a.Address(), b.Account.Bls.PublicKey()),
+		errKeyRotationOldSignature)
+
+	// rotation is applied already
+	require.ErrorIs(t, rotation.Verify(testKeyRotationChainID, a.Address(), newKey.PublicKey()),
+		errKeyRotationSameKey)
+
+	// new key is not owned by the validator
+	forged, err := NewValidatorKeyRotation(testKeyRotationChainID, a.Address(), a.Account.Bls, newKey)
+	require.NoError(t, err)
+
+	forged.NewKey = b.Account.Bls.PublicKey()
+	forged.OldKeySignature = a.MustSign(mustKeyRotationMessage(t, a.Address(), forged.NewKey),
+		bls.DomainValidatorKeyRotation)
+	require.ErrorIs(t, forged.Verify(testKeyRotationChainID, a.Address(), a.Account.Bls.PublicKey()),
+		errKeyRotationNewSignature)
+}
+
+func TestStakeManager_PostBlock_RotateValidatorKey(t *testing.T) {
+	t.Parallel()
+
+	enableKeyRotation(t)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C"}, []uint64{10, 10, 10})
+	a, b := validators.GetValidator("A"), validators.GetValidator("B")
+	state := newTestState(t)
+
+	blockchainMock := new(blockchainMock)
+	chainID := blockchainMock.GetChainID()
+
+	stakeManager := newStakeManager(
+		hclog.NewNullLogger(),
+		state,
+		nil,
+		wallet.NewEcdsaSigner(a.Key()),
+		types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
+		blockchainMock,
+		10,
+	)
+
+	require.NoError(t, state.StakeStore.insertFullValidatorSet(validatorSetState{
+		Validators:  newValidatorStakeMap(validators.GetPublicIdentities()),
+		BlockNumber: 9,
+	}))
+
+	newKeyA, newKeyB := generateTestBlsKey(t), generateTestBlsKey(t)
+
+	validRotation, err := NewValidatorKeyRotation(chainID, a.Address(), a.Account.Bls, newKeyA)
+	require.NoError(t, err)
+
+	// B signs the rotation by the new key only
+	invalidRotation, err := NewValidatorKeyRotation(chainID, b.Address(), newKeyB, newKeyB)
+	require.NoError(t, err)
+
+	block := &types.Block{Header: &types.Header{Number: keyRotationForkBlock - 1}}
+	receipts := []*types.Receipt{}
+
+	for _, r := range []struct {
+		from     types.Address
+		rotation *ValidatorKeyRotation
+	}{{a.Address(), validRotation}, {b.Address(), invalidRotation}} {
+		input, err := r.rotation.EncodeAbi()
+		require.NoError(t, err)
+
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			From:  r.from,
+			To:    &contracts.ValidatorKeyRotationContract,
+			Input: input,
+		})
+
+		receipt := &types.Receipt{}
+		receipt.SetStatus(types.ReceiptSuccess)
+		receipts = append(receipts, receipt)
+	}
+
+	// rotations are ignored before the fork
+	require.NoError(t, stakeManager.PostBlock(&PostBlockRequest{
+		FullBlock: &types.FullBlock{Block: block, Receipts: receipts},
+		Epoch:     1,
+	}))
+
+	fullValidatorSet, err := state.StakeStore.getFullValidatorSet()
+	require.NoError(t, err)
+	require.Equal(t, a.Account.Bls.PublicKey().Marshal(), fullValidatorSet.Validators[a.Address()].BlsKey.Marshal())
+	require.Empty(t, fullValidatorSet.RotatedBlsKeys)
+
+	block.Header.Number = keyRotationForkBlock
+
+	require.NoError(t, stakeManager.PostBlock(&PostBlockRequest{
+		FullBlock: &types.FullBlock{Block: block, Receipts: receipts},
+		Epoch:     1,
+	}))
+
+	fullValidatorSet, err = state.StakeStore.getFullValidatorSet()
+	require.NoError(t, err)
+	require.Equal(t, newKeyA.PublicKey().Marshal(), fullValidatorSet.Validators[a.Address()].BlsKey.Marshal())
+	require.Equal(t, b.Account.Bls.PublicKey().Marshal(), fullValidatorSet.Validators[b.Address()].BlsKey.Marshal())
+
+	// rotated key is used by the validator set of the next epoch
+	delta, err := stakeManager.UpdateValidatorSet(2, validators.GetPublicIdentities())
+	require.NoError(t, err)
+	require.Empty(t, delta.Added)
+	require.Len(t, delta.Updated, 1)
+	require.Equal(t, a.Address(), delta.Updated[0].Address)
+	require.Equal(t, newKeyA.PublicKey().Marshal(), delta.Updated[0].BlsKey.Marshal())
+
+	// rotated key takes precedence over the key registered in the supernet manager
+	blsKey, err := stakeManager.getBlsKey(&fullValidatorSet, a.Address())
+	require.NoError(t, err)
+	require.Equal(t, newKeyA.PublicKey().Marshal(), blsKey.Marshal())
+}
+
+func TestStakeManager_PostBlock_RotateValidatorKey_KeyNotRetrieved(t *testing.T) {
+	t.Parallel()
+
+	enableKeyRotation(t)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"}, []uint64{10, 10})
+	a := validators.GetValidator("A")
+	state := newTestState(t)
+
+	blockchainMock := new(blockchainMock)
+
+	// supernet manager returns the registered key of A
+	txRelayerMock := newDummyStakeTxRelayer(t, func() *validator.ValidatorMetadata {
+		return a.ValidatorMetadata()
+	})
+	txRelayerMock.On("Call", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+
+	stakeManager := newStakeManager(
+		hclog.NewNullLogger(),
+		state,
+		txRelayerMock,
+		wallet.NewEcdsaSigner(a.Key()),
+		types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
+		blockchainMock,
+		10,
+	)
+
+	stakeMap := newValidatorStakeMap(validators.GetPublicIdentities())
+	stakeMap[a.Address()].BlsKey = nil
+
+	require.NoError(t, state.StakeStore.insertFullValidatorSet(validatorSetState{
+		Validators:  stakeMap,
+		BlockNumber: 9,
+	}))
+
+	newKey := generateTestBlsKey(t)
+
+	rotation, err := NewValidatorKeyRotation(blockchainMock.GetChainID(), a.Address(), a.Account.Bls, newKey)
+	require.NoError(t, err)
+
+	input, err := rotation.EncodeAbi()
+	require.NoError(t, err)
+
+	receipt := &types.Receipt{}
+	receipt.SetStatus(types.ReceiptSuccess)
+
+	block := &types.Block{
+		Header: &types.Header{Number: keyRotationForkBlock},
+		Transactions: []*types.Transaction{{
+			From:  a.Address(),
+			To:    &contracts.ValidatorKeyRotationContract,
+			Input: input,
+		}},
+	}
+
+	require.NoError(t, stakeManager.PostBlock(&PostBlockRequest{
+		FullBlock: &types.FullBlock{Block: block, Receipts: []*types.Receipt{receipt}},
+		Epoch:     1,
+	}))
+
+	// rotation is verified by the key registered in the supernet manager
+	fullValidatorSet, err := state.StakeStore.getFullValidatorSet()
+	require.NoError(t, err)
+	require.Equal(t, newKey.PublicKey().Marshal(), fullValidatorSet.Validators[a.Address()].BlsKey.Marshal())
+	require.Equal(t, newKey.PublicKey().Marshal(), fullValidatorSet.RotatedBlsKeys[a.Address()])
+
+	txRelayerMock.AssertExpectations(t)
+}
+
+func TestValidatorKeySwitcher_OnNewEpoch(t *testing.T) {
+	t.Parallel()
+
+	v := validator.NewTestValidator(t, "A", 1)
+
+	secretsManager, err := helper.SetupLocalSecretsManager(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, v.Account.Save(secretsManager))
+
+	key := wallet.NewKey(v.Account)
+	switcher := newValidatorKeySwitcher(hclog.NewNullLogger(), secretsManager, key)
+	digest := []byte("digest")
+
+	// there is no pending key
+	require.NoError(t, switcher.onNewEpoch(validator.AccountSet{v.ValidatorMetadata()}))
+
+	newKey := generateTestBlsKey(t)
+	require.NoError(t, wallet.SavePendingBls(secretsManager, newKey))
+
+	// rotation didn't take effect yet
+	require.NoError(t, switcher.onNewEpoch(validator.AccountSet{v.ValidatorMetadata()}))
+	require.True(t, secretsManager.HasSecret(secrets.ValidatorBLSKeyNext))
+	requireSignedBy(t, key, digest, v.Account.Bls.PublicKey())
+
+	rotated := v.ValidatorMetadata()
+	rotated.BlsKey = newKey.PublicKey()
+
+	require.NoError(t, switcher.onNewEpoch(validator.AccountSet{rotated}))
+	require.False(t, secretsManager.HasSecret(secrets.ValidatorBLSKeyNext))
+	requireSignedBy(t, key, digest, newKey.PublicKey())
+
+	current, err := wallet.GetBlsFromSecret(secretsManager)
+	require.NoError(t, err)
+	require.Equal(t, newKey.PublicKey().Marshal(), current.PublicKey().Marshal())
+}
+
+func generateTestBlsKey(t *testing.T) *bls.PrivateKey {
+	t.Helper()
+
+	key, err := bls.GenerateBlsKey()
+	require.NoError(t, err)
+
+	return key
+}
+
+func mustKeyRotationMessage(t *testing.T, validatorAddr types.Address, newKey *bls.PublicKey) []byte {
+	t.Helper()
+
+	message, err := keyRotationMessage(testKeyRotationChainID, validatorAddr, newKey)
+	require.NoError(t, err)
+
+	return message
+}
+
+func requireSignedBy(t *testing.T, key *wallet.Key, digest []byte, publicKey *bls.PublicKey) {
+	t.Helper()
+
+	raw, err := key.SignCommittedSeal(digest, &proto.View{Height: 1})
+	require.NoError(t, err)
+
+	signature, err := bls.UnmarshalSignature(raw)
+	require.NoError(t, err)
+	require.True(t, signature.Verify(publicKey, digest, bls.DomainCheckpointManager))
+}
@@ -85,6 +85,58 @@ func GetBlsFromSecret(secretsManager secrets.SecretsManager) (*bls.PrivateKey, e
 	return blsKey, nil
 }
 
+// GetPendingBlsFromSecret retrieves pending (rotated) BLS key by using provided secretsManager
+func GetPendingBlsFromSecret(secretsManager secrets.SecretsManager) (*bls.PrivateKey, error) {
+	encodedKey, err := secretsManager.GetSecret(secrets.ValidatorBLSKeyNext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve pending bls key: %w", err)
+	}
+
+	blsKey, err := bls.UnmarshalPrivateKey(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve pending bls key: %w", err)
+	}
+
+	return blsKey, nil
+}
+
+// SavePendingBls persists the pending BLS private key to the SecretsManager.
+// It replaces the current BLS key once the key rotation takes effect (see PromotePendingBls)
+func SavePendingBls(secretsManager secrets.SecretsManager, blsKey *bls.PrivateKey) error {
+	if secretsManager.HasSecret(secrets.ValidatorBLSKeyNext) {
+		return fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorBLSKeyNext)
+	}
+
+	blsRaw, err := blsKey.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return secretsManager.SetSecret(secrets.ValidatorBLSKeyNext, blsRaw)
+}
+
+// PromotePendingBls replaces the current BLS private key with the pending one in the SecretsManager.
+// The pending key is removed only after it is saved as the current one, so the promotion can be
+// repeated if it gets interrupted
+func PromotePendingBls(secretsManager secrets.SecretsManager) error {
+	pendingRaw, err := secretsManager.GetSecret(secrets.ValidatorBLSKeyNext)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve pending bls key: %w", err)
+	}
+
+	if secretsManager.HasSecret(secrets.ValidatorBLSKey) {
+		if err := secretsManager.RemoveSecret(secrets.ValidatorBLSKey); err != nil {
+			return fmt.Errorf("failed to remove bls key: %w", err)
+		}
+	}
+
+	if err := secretsManager.SetSecret(secrets.ValidatorBLSKey, pendingRaw); err != nil {
+		return fmt.Errorf("failed to save bls key: %w", err)
+	}
+
+	return secretsManager.RemoveSecret(secrets.ValidatorBLSKeyNext)
+}
+
 // Save persists ECDSA and BLS private keys to the SecretsManager
 func (a *Account) Save(secretsManager secrets.SecretsManager) (err error) {
 	var (
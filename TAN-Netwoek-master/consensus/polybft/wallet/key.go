@@ -1,50 +1,120 @@
 package wallet
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math/big"
+	"sync"
 
 	"github.com/tarality/0xTaral/messages/proto"
+	"github.com/tarality/fastrlp"
 	bls "github.com/tarality/tan-network/consensus/polybft/signer"
 	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/secrets/keysigner"
 	"github.com/tarality/tan-network/types"
 	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
 	protobuf "google.golang.org/protobuf/proto"
 )
 
+var (
+	// errBLSKeyRotationNotSupported is returned when rotating the BLS key held by the remote signer.
+	// The node has no access to such keys, so the remote signer switches to the rotated key itself
+	errBLSKeyRotationNotSupported = errors.New("bls key rotation is supported only for the keys held in memory")
+
+	// errRawDigestNotSigned is returned when ECDSASigner is asked to sign a raw digest.
+	// The KeySigner derives the digests of the other data from their preimages, so only transactions are signed
+	errRawDigestNotSigned = errors.New("raw digests are not signed by the ecdsa key, transactions are signed by SignTx")
+)
+
+// Key signs by the validator keys which are held by the KeySigner
 type Key struct {
-	raw *Account
+	signer keysigner.KeySigner
+	lock   sync.RWMutex
 }
 
+// NewKey creates Key which signs by the keys of the given account
 func NewKey(raw *Account) *Key {
+	var blsKey keysigner.BLSKey
+	if raw.Bls != nil {
+		blsKey = keysigner.NewPolyBFTBLSKey(raw.Bls)
+	}
+
+	return NewKeyFromSigner(keysigner.NewLocalSigner(&ecdsaKey{key: raw.Ecdsa}, blsKey, nil))
+}
+
+// NewKeyFromSigner creates Key which signs through the given KeySigner (e.g. remote signer)
+func NewKeyFromSigner(signer keysigner.KeySigner) *Key {
 	return &Key{
-		raw: raw,
+		signer: signer,
 	}
 }
 
+// RotateBLSKey replaces the BLS key used for signing.
+// It is supported only if the keys are held in memory (not by the remote signer)
+func (k *Key) RotateBLSKey(blsKey *bls.PrivateKey) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	localSigner, ok := k.signer.(*keysigner.LocalSigner)
+	if !ok {
+		return errBLSKeyRotationNotSupported
+	}
+
+	k.signer = localSigner.WithBLSKey(keysigner.NewPolyBFTBLSKey(blsKey))
+
+	return nil
+}
+
+// getSigner returns the KeySigner which currently holds the keys
+func (k *Key) getSigner() keysigner.KeySigner {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.signer
+}
+
 // String returns hex encoded ECDSA address
 func (k *Key) String() string {
-	return k.raw.Ecdsa.Address().String()
+	return k.Address().String()
 }
 
 // Address returns ECDSA address
 func (k *Key) Address() ethgo.Address {
-	return k.raw.Ecdsa.Address()
+	return ethgo.Address(k.getSigner().Address())
+}
+
+// Sign signs the keccak256 hash of the provided data with BLS key
+func (k *Key) Sign(data []byte) ([]byte, error) {
+	return k.SignWithDomain(data, bls.DomainCommonSigning)
 }
 
-// Sign signs the provided digest with BLS key
-// Used only to sign transactions
-func (k *Key) Sign(digest []byte) ([]byte, error) {
-	return k.SignWithDomain(digest, bls.DomainCommonSigning)
+// SignWithDomain signs the keccak256 hash of the provided data with BLS key and provided domain.
+// The hash is derived by the KeySigner, which refuses to sign consensus data this way
+// (committed seals are signed by SignCommittedSeal)
+func (k *Key) SignWithDomain(data, domain []byte) ([]byte, error) {
+	return k.getSigner().Sign(&keysigner.SignRequest{
+		Metadata: keysigner.Metadata{Kind: keysigner.KindOther},
+		Scheme:   keysigner.SchemeBLS,
+		Preimage: data,
+		Domain:   domain,
+	})
 }
 
-// SignWithDomain signs the provided digest with BLS key and provided domain
-func (k *Key) SignWithDomain(digest, domain []byte) ([]byte, error) {
-	signature, err := k.raw.Bls.Sign(digest, domain)
+// SignCommittedSeal signs the proposal hash with BLS key for the given view
+func (k *Key) SignCommittedSeal(proposalHash []byte, view *proto.View) ([]byte, error) {
+	meta, err := keysigner.CommittedSealMetadata(view, proposalHash)
 	if err != nil {
 		return nil, err
 	}
 
-	return signature.Marshal()
+	return k.getSigner().Sign(&keysigner.SignRequest{
+		Metadata: meta,
+		Scheme:   keysigner.SchemeBLS,
+		Digest:   proposalHash,
+		Domain:   bls.DomainCheckpointManager,
+	})
 }
 
 // SignIBFTMessage signs the IBFT consensus message with ECDSA key
@@ -54,7 +124,11 @@ func (k *Key) SignIBFTMessage(msg *proto.Message) (*proto.Message, error) {
 		return nil, fmt.Errorf("cannot marshal message: %w", err)
 	}
 
-	if msg.Signature, err = k.raw.Ecdsa.Sign(crypto.Keccak256(msgRaw)); err != nil {
+	if msg.Signature, err = k.getSigner().Sign(&keysigner.SignRequest{
+		Metadata: keysigner.IBFTMessageMetadata(msg, msgRaw),
+		Scheme:   keysigner.SchemeECDSA,
+		Digest:   crypto.Keccak256(msgRaw),
+	}); err != nil {
 		return nil, fmt.Errorf("cannot create message signature: %w", err)
 	}
 
@@ -81,6 +155,118 @@ func NewEcdsaSigner(ecdsaKey *Key) *ECDSASigner {
 	return &ECDSASigner{Key: ecdsaKey}
 }
 
+// Sign refuses to sign the raw digest, since it could be the digest of consensus data
 func (k *ECDSASigner) Sign(b []byte) ([]byte, error) {
-	return k.raw.Ecdsa.Sign(b)
+	return nil, errRawDigestNotSigned
+}
+
+// SignTx signs the transaction (EIP-155 for the legacy transactions) with ECDSA key.
+// The KeySigner derives the signed hash from the signing payload of the transaction
+func (k *ECDSASigner) SignTx(txn *ethgo.Transaction, chainID uint64) (*ethgo.Transaction, error) {
+	sig, err := k.getSigner().Sign(&keysigner.SignRequest{
+		Metadata: keysigner.Metadata{Kind: keysigner.KindOther},
+		Scheme:   keysigner.SchemeECDSA,
+		Preimage: txSigningPayload(txn, chainID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v := uint64(sig[64])
+	if txn.Type == ethgo.TransactionLegacy {
+		v += 35 + chainID*2
+	}
+
+	txn.R = bytes.TrimLeft(sig[:32], "\x00")
+	txn.S = bytes.TrimLeft(sig[32:64], "\x00")
+	txn.V = new(big.Int).SetUint64(v).Bytes()
+
+	return txn, nil
+}
+
+// txSigningPayload returns the payload whose keccak256 hash is signed by the transaction sender
+// (the same as the payload hashed by the ethgo EIP-155 signer)
+func txSigningPayload(txn *ethgo.Transaction, chainID uint64) []byte {
+	arena := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(arena)
+
+	vv := arena.NewArray()
+
+	if txn.Type != ethgo.TransactionLegacy {
+		vv.Set(arena.NewBigInt(txn.ChainID))
+	}
+
+	vv.Set(arena.NewUint(txn.Nonce))
+
+	if txn.Type == ethgo.TransactionDynamicFee {
+		vv.Set(arena.NewBigInt(txn.MaxPriorityFeePerGas))
+		vv.Set(arena.NewBigInt(txn.MaxFeePerGas))
+	} else {
+		vv.Set(arena.NewUint(txn.GasPrice))
+	}
+
+	vv.Set(arena.NewUint(txn.Gas))
+
+	if txn.To == nil {
+		vv.Set(arena.NewNull())
+	} else {
+		vv.Set(arena.NewCopyBytes(txn.To[:]))
+	}
+
+	vv.Set(arena.NewBigInt(txn.Value))
+	vv.Set(arena.NewCopyBytes(txn.Input))
+
+	if txn.Type != ethgo.TransactionLegacy {
+		accessList := arena.NewNullArray()
+
+		if len(txn.AccessList) > 0 {
+			accessList = arena.NewArray()
+
+			for _, entry := range txn.AccessList {
+				storageKeys := arena.NewNullArray()
+
+				if len(entry.Storage) > 0 {
+					storageKeys = arena.NewArray()
+
+					for _, key := range entry.Storage {
+						storageKeys.Set(arena.NewCopyBytes(key[:]))
+					}
+				}
+
+				account := arena.NewArray()
+				account.Set(arena.NewCopyBytes(entry.Address[:]))
+				account.Set(storageKeys)
+				accessList.Set(account)
+			}
+		}
+
+		vv.Set(accessList)
+	}
+
+	if chainID != 0 && txn.Type == ethgo.TransactionLegacy {
+		vv.Set(arena.NewUint(chainID))
+		vv.Set(arena.NewUint(0))
+		vv.Set(arena.NewUint(0))
+	}
+
+	payload := vv.MarshalTo(nil)
+
+	if txn.Type != ethgo.TransactionLegacy {
+		payload = append([]byte{byte(txn.Type)}, payload...)
+	}
+
+	return payload
+}
+
+// ecdsaKey adapts ethgo ECDSA key to the KeySigner
+type ecdsaKey struct {
+	key *wallet.Key
+}
+
+func (k *ecdsaKey) Address() types.Address {
+	return types.Address(k.key.Address())
+}
+
+func (k *ecdsaKey) Sign(digest []byte) ([]byte, error) {
+	return k.key.Sign(digest)
 }
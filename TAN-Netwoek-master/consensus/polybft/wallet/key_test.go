@@ -1,12 +1,18 @@
 package wallet
 
 import (
+	"math/big"
+	"path/filepath"
 	"testing"
 
 	"github.com/tarality/0xTaral/messages/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/secrets/keysigner"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
 )
 
 func Test_RecoverAddressFromSignature(t *testing.T) {
@@ -15,6 +21,7 @@ func Test_RecoverAddressFromSignature(t *testing.T) {
 	for _, account := range []*Account{generateTestAccount(t), generateTestAccount(t), generateTestAccount(t)} {
 		key := NewKey(account)
 		msgNoSig := &proto.Message{
+			View:    &proto.View{Height: 1},
 			From:    key.Address().Bytes(),
 			Type:    proto.MessageType_COMMIT,
 			Payload: &proto.Message_CommitData{},
@@ -39,14 +46,60 @@ func Test_Sign(t *testing.T) {
 
 	for _, account := range []*Account{generateTestAccount(t), generateTestAccount(t)} {
 		key := NewKey(account)
-		ser, err := key.SignWithDomain(msg, bls.DomainCheckpointManager)
+		ser, err := key.SignWithDomain(msg, bls.DomainStateReceiver)
 
 		require.NoError(t, err)
 
 		sig, err := bls.UnmarshalSignature(ser)
 		require.NoError(t, err)
 
-		assert.True(t, sig.Verify(key.raw.Bls.PublicKey(), msg, bls.DomainCheckpointManager))
+		assert.True(t, sig.Verify(account.Bls.PublicKey(), crypto.Keccak256(msg), bls.DomainStateReceiver))
+
+		// committed seals are signed only by SignCommittedSeal
+		_, err = key.SignWithDomain(msg, bls.DomainCheckpointManager)
+		require.Error(t, err)
+	}
+}
+
+func Test_ECDSASigner_SignTx(t *testing.T) {
+	t.Parallel()
+
+	const chainID = 100
+
+	account := generateTestAccount(t)
+	signer := NewEcdsaSigner(NewKey(account))
+	to := ethgo.Address{0x1}
+
+	_, err := signer.Sign(crypto.Keccak256([]byte("digest")))
+	require.Error(t, err)
+
+	for _, txn := range []*ethgo.Transaction{
+		{
+			Type:     ethgo.TransactionLegacy,
+			Nonce:    1,
+			GasPrice: 10,
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+			Input:    []byte{0x1, 0x2},
+		},
+		{
+			Type:                 ethgo.TransactionDynamicFee,
+			ChainID:              big.NewInt(chainID),
+			Nonce:                2,
+			MaxPriorityFeePerGas: big.NewInt(1),
+			MaxFeePerGas:         big.NewInt(10),
+			Gas:                  50000,
+			Input:                []byte{0x1, 0x2},
+			AccessList:           ethgo.AccessList{{Address: to, Storage: []ethgo.Hash{{0x2}}}},
+		},
+	} {
+		signed, err := signer.SignTx(txn, chainID)
+		require.NoError(t, err)
+
+		sender, err := wallet.NewEIP155Signer(chainID).RecoverSender(signed)
+		require.NoError(t, err)
+		require.Equal(t, signer.Address(), sender)
 	}
 }
 
@@ -58,3 +111,43 @@ func Test_String(t *testing.T) {
 		assert.Equal(t, key.Address().String(), key.String())
 	}
 }
+
+func Test_NewKeyFromSigner_SlashingProtection(t *testing.T) {
+	t.Parallel()
+
+	account := generateTestAccount(t)
+
+	protection, err := keysigner.NewSlashingProtection(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, protection.Close())
+	})
+
+	key := NewKeyFromSigner(keysigner.NewLocalSigner(
+		&ecdsaKey{key: account.Ecdsa}, keysigner.NewPolyBFTBLSKey(account.Bls), protection))
+	require.Equal(t, account.Ecdsa.Address(), key.Address())
+
+	newPrepare := func(proposalHash []byte) *proto.Message {
+		return &proto.Message{
+			View: &proto.View{Height: 3, Round: 1},
+			From: key.Address().Bytes(),
+			Type: proto.MessageType_PREPARE,
+			Payload: &proto.Message_PrepareData{
+				PrepareData: &proto.PrepareMessage{ProposalHash: proposalHash},
+			},
+		}
+	}
+
+	_, err = key.SignIBFTMessage(newPrepare([]byte{1}))
+	require.NoError(t, err)
+
+	_, err = key.SignIBFTMessage(newPrepare([]byte{2}))
+	require.ErrorIs(t, err, keysigner.ErrSlashingProtection)
+
+	_, err = key.SignCommittedSeal([]byte{1}, &proto.View{Height: 3, Round: 1})
+	require.NoError(t, err)
+
+	_, err = key.SignCommittedSeal([]byte{2}, &proto.View{Height: 3, Round: 1})
+	require.ErrorIs(t, err, keysigner.ErrSlashingProtection)
+}
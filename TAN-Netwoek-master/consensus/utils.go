@@ -37,3 +37,13 @@ func BuildBlock(params BuildBlockParams) *types.Block {
 		Transactions: txs,
 	}
 }
+
+// FinalizedByConfirmations returns the number of the latest finalized block for consensus engines
+// without instant finality, where a block is final once the given number of blocks is built on top of it
+func FinalizedByConfirmations(head, confirmations uint64) uint64 {
+	if head < confirmations {
+		return 0
+	}
+
+	return head - confirmations
+}
@@ -67,7 +67,6 @@ func createCallViewTx(
 		Gas:      queryGasLimit,
 		Value:    big.NewInt(0),
 		GasPrice: big.NewInt(0),
-		Type:     3,
 	}
 }
 
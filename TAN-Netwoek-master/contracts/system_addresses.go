@@ -13,6 +13,12 @@ var (
 	RewardTokenContract = types.StringToAddress("0x104")
 	// RewardPoolContract is an address of RewardPoolContract contract on the child chain
 	RewardPoolContract = types.StringToAddress("0x105")
+	// DoubleSignEvidenceContract is an address to which double sign evidence state transactions are sent
+	// (evidence is processed natively by the consensus, so there is no contract code deployed to it)
+	DoubleSignEvidenceContract = types.StringToAddress("0x106")
+	// ValidatorKeyRotationContract is an address to which validators send their BLS key rotation transactions
+	// (key rotation is processed natively by the consensus, so there is no contract code deployed to it)
+	ValidatorKeyRotationContract = types.StringToAddress("0x107")
 	// StateReceiverContract is an address of bridge contract on the child chain
 	StateReceiverContract = types.StringToAddress("0x1001")
 	// NativeERC20TokenContract is an address of bridge contract (used for transferring ERC20 native tokens on child chain)
@@ -60,4 +66,8 @@ var (
 	AllowListBridgeAddr = types.StringToAddress("0x0200000000000000000000000000000000000004")
 	// BlockListBridgeAddr is the address of the bridge block list
 	BlockListBridgeAddr = types.StringToAddress("0x0300000000000000000000000000000000000004")
+	// AllowListGasFeeAddr is the address of the gas fee allow list
+	AllowListGasFeeAddr = types.StringToAddress("0x0200000000000000000000000000000000000006")
+	// CallPermissionListAddr is the address of the call permission list
+	CallPermissionListAddr = types.StringToAddress("0x0200000000000000000000000000000000000007")
 )
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/tarality/fastrlp"
+
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/helper/keccak"
 	"github.com/tarality/tan-network/types"
@@ -64,7 +66,41 @@ func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 // calcTxHash calculates the transaction hash (keccak256 hash of the RLP value)
 func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	a := signerPool.Get()
-	isDynamicFeeTx := tx.Type == types.DynamicFeeTx
+
+	v := signingPayload(a, tx, chainID)
+
+	var hash []byte
+	if tx.Type.IsDynamicFee() {
+		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
+	} else {
+		hash = keccak.Keccak256Rlp(nil, v)
+	}
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// calcFeePayerHash calculates the hash the fee payer of the fee delegated transaction signs.
+// It commits to the sender signature, so the fee payer sponsors only the transaction signed by the sender
+func calcFeePayerHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := signingPayload(a, tx, chainID)
+	v.Set(a.NewBigInt(tx.V))
+	v.Set(a.NewBigInt(tx.R))
+	v.Set(a.NewBigInt(tx.S))
+
+	hash := keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// signingPayload returns the RLP value of the transaction fields signed by the sender
+func signingPayload(a *fastrlp.Arena, tx *types.Transaction, chainID uint64) *fastrlp.Value {
+	isDynamicFeeTx := tx.Type.IsDynamicFee()
 
 	v := a.NewArray()
 
@@ -104,14 +140,14 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 		}
 	}
 
-	var hash []byte
-	if isDynamicFeeTx {
-		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
-	} else {
-		hash = keccak.Keccak256Rlp(nil, v)
+	// the sender signs over the fee payer, so it can't be replaced by anyone else
+	if tx.Type == types.FeeDelegatedTx {
+		if tx.FeePayer == nil {
+			v.Set(a.NewNull())
+		} else {
+			v.Set(a.NewCopyBytes(tx.FeePayer.Bytes()))
+		}
 	}
 
-	signerPool.Put(a)
-
-	return types.BytesToHash(hash)
+	return v
 }
@@ -2,12 +2,34 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/tarality/tan-network/types"
 )
 
-// LondonSigner implements signer for EIP-1559
+var (
+	// ErrFeePayerMissing is returned if the fee delegated transaction doesn't specify its fee payer
+	ErrFeePayerMissing = errors.New("fee payer of the fee delegated transaction is missing")
+	// ErrFeePayerMismatch is returned if the fee payer signature isn't made by the fee payer of the transaction
+	ErrFeePayerMismatch = errors.New("fee payer signature does not match the fee payer")
+	// ErrNotFeeDelegatedTx is returned if the fee payer is requested for the transaction of another type
+	ErrNotFeeDelegatedTx = errors.New("transaction is not fee delegated")
+)
+
+// FeePayerSigner is implemented by the signers supporting the fee delegated transactions
+type FeePayerSigner interface {
+	// FeePayer returns the fee payer of the transaction, verifying its signature
+	FeePayer(tx *types.Transaction) (types.Address, error)
+
+	// SignFeePayerTx signs the transaction already signed by the sender as its fee payer
+	SignFeePayerTx(tx *types.Transaction, priv *ecdsa.PrivateKey) (*types.Transaction, error)
+}
+
+var _ FeePayerSigner = (*LondonSigner)(nil)
+
+// LondonSigner implements signer for EIP-1559 and the fee delegated transactions
 type LondonSigner struct {
 	chainID        uint64
 	isHomestead    bool
@@ -31,29 +53,40 @@ func (e *LondonSigner) Hash(tx *types.Transaction) types.Hash {
 // Sender returns the transaction sender
 func (e *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	// Apply fallback signer for non-dynamic-fee-txs
-	if tx.Type != types.DynamicFeeTx {
+	if !tx.Type.IsDynamicFee() {
 		return e.fallbackSigner.Sender(tx)
 	}
 
-	sig, err := encodeSignature(tx.R, tx.S, tx.V, e.isHomestead)
-	if err != nil {
-		return types.Address{}, err
+	return e.recover(e.Hash(tx), tx.R, tx.S, tx.V)
+}
+
+// FeePayer returns the fee payer of the fee delegated transaction.
+// The address recovered from the fee payer signature must be the fee payer the sender signed over
+func (e *LondonSigner) FeePayer(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.FeeDelegatedTx {
+		return types.Address{}, ErrNotFeeDelegatedTx
+	}
+
+	if tx.FeePayer == nil {
+		return types.Address{}, ErrFeePayerMissing
 	}
 
-	pub, err := Ecrecover(e.Hash(tx).Bytes(), sig)
+	feePayer, err := e.recover(calcFeePayerHash(tx, e.chainID), tx.FeePayerR, tx.FeePayerS, tx.FeePayerV)
 	if err != nil {
 		return types.Address{}, err
 	}
 
-	buf := Keccak256(pub[1:])[12:]
+	if feePayer != *tx.FeePayer {
+		return types.Address{}, ErrFeePayerMismatch
+	}
 
-	return types.BytesToAddress(buf), nil
+	return feePayer, nil
 }
 
 // SignTx signs the transaction using the passed in private key
 func (e *LondonSigner) SignTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*types.Transaction, error) {
 	// Apply fallback signer for non-dynamic-fee-txs
-	if tx.Type != types.DynamicFeeTx {
+	if !tx.Type.IsDynamicFee() {
 		return e.fallbackSigner.SignTx(tx, pk)
 	}
 
@@ -73,6 +106,58 @@ func (e *LondonSigner) SignTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*typ
 	return tx, nil
 }
 
+// SignFeePayerTx signs the fee delegated transaction as its fee payer using the passed in private key.
+// The transaction must be signed by the sender first
+func (e *LondonSigner) SignFeePayerTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*types.Transaction, error) {
+	if tx.Type != types.FeeDelegatedTx {
+		return nil, ErrNotFeeDelegatedTx
+	}
+
+	if tx.FeePayer == nil {
+		return nil, ErrFeePayerMissing
+	}
+
+	if PubKeyToAddress(&pk.PublicKey) != *tx.FeePayer {
+		return nil, ErrFeePayerMismatch
+	}
+
+	tx = tx.Copy()
+
+	h := calcFeePayerHash(tx, e.chainID)
+
+	sig, err := Sign(pk, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.FeePayerR = new(big.Int).SetBytes(sig[:32])
+	tx.FeePayerS = new(big.Int).SetBytes(sig[32:64])
+	tx.FeePayerV = new(big.Int).SetBytes(e.calculateV(sig[64]))
+
+	return tx, nil
+}
+
+// recover returns the address which made the signature of the given hash
+func (e *LondonSigner) recover(hash types.Hash, r, s, v *big.Int) (types.Address, error) {
+	if v == nil {
+		return types.Address{}, fmt.Errorf("invalid txn signature")
+	}
+
+	sig, err := encodeSignature(r, s, v, e.isHomestead)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(hash.Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
 // calculateV returns the V value for transaction signatures. Based on EIP155
 func (e *LondonSigner) calculateV(parity byte) []byte {
 	return big.NewInt(int64(parity)).Bytes()
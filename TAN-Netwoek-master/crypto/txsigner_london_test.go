@@ -143,3 +143,87 @@ func Test_LondonSigner_Sender(t *testing.T) {
 		})
 	}
 }
+
+func TestLondonSigner_FeeDelegatedTx(t *testing.T) {
+	t.Parallel()
+
+	senderKey, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	feePayerKey, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := PubKeyToAddress(&senderKey.PublicKey)
+	feePayer := PubKeyToAddress(&feePayerKey.PublicKey)
+	to := types.StringToAddress("1")
+
+	signer := NewLondonSigner(100, true, NewEIP155Signer(100, true))
+
+	signedTx, err := signer.SignTx(&types.Transaction{
+		Type:      types.FeeDelegatedTx,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		FeePayer:  &feePayer,
+	}, senderKey)
+	require.NoError(t, err)
+
+	// the fee payer can't sign until it's the fee payer of the transaction
+	_, err = signer.SignFeePayerTx(signedTx, senderKey)
+	require.ErrorIs(t, err, ErrFeePayerMismatch)
+
+	signedTx, err = signer.SignFeePayerTx(signedTx, feePayerKey)
+	require.NoError(t, err)
+
+	t.Run("recovers the sender and the fee payer", func(t *testing.T) {
+		t.Parallel()
+
+		recoveredSender, err := signer.Sender(signedTx)
+		require.NoError(t, err)
+		assert.Equal(t, sender, recoveredSender)
+
+		recoveredFeePayer, err := signer.FeePayer(signedTx)
+		require.NoError(t, err)
+		assert.Equal(t, feePayer, recoveredFeePayer)
+	})
+
+	t.Run("the fee payer can't be replaced", func(t *testing.T) {
+		t.Parallel()
+
+		tx := signedTx.Copy()
+		tx.FeePayer = &to
+
+		recoveredSender, err := signer.Sender(tx)
+		require.NoError(t, err)
+		assert.NotEqual(t, sender, recoveredSender)
+
+		_, err = signer.FeePayer(tx)
+		require.Error(t, err)
+	})
+
+	t.Run("the fee payer signs the sender signature", func(t *testing.T) {
+		t.Parallel()
+
+		// the sender re-signs the changed transaction, keeping the fee payer signature
+		tx := signedTx.Copy()
+		tx.Value = big.NewInt(2)
+
+		tx, err := signer.SignTx(tx, senderKey)
+		require.NoError(t, err)
+
+		_, err = signer.FeePayer(tx)
+		require.ErrorIs(t, err, ErrFeePayerMismatch)
+	})
+
+	t.Run("non fee delegated transaction", func(t *testing.T) {
+		t.Parallel()
+
+		tx := signedTx.Copy()
+		tx.Type = types.DynamicFeeTx
+
+		_, err := signer.FeePayer(tx)
+		require.ErrorIs(t, err, ErrNotFeeDelegatedTx)
+	})
+}
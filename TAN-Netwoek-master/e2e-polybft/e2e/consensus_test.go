@@ -119,6 +119,80 @@ func TestE2E_Consensus_BulkDrop(t *testing.T) {
 	require.NoError(t, cluster.WaitForBlock(epochSize+1, 2*time.Minute))
 }
 
+func TestE2E_Consensus_DoubleSign_ValidatorJailed(t *testing.T) {
+	const epochSize = 5
+
+	// the first validator signs conflicting prepare and commit messages
+	cluster := framework.NewTestCluster(t, 5,
+		framework.WithEpochSize(epochSize),
+		framework.WithByzantineValidator(1))
+	defer cluster.Stop()
+
+	cluster.WaitForReady(t)
+
+	byzantineAcc, err := sidechain.GetAccountFromDir(cluster.Servers[0].DataDir())
+	require.NoError(t, err)
+
+	byzantineAddr := types.Address(byzantineAcc.Ecdsa.Address())
+	client := cluster.Servers[1].JSONRPC()
+
+	var (
+		evidenceBlock uint64
+		checkedBlock  uint64
+	)
+
+	// wait for double sign evidence against the byzantine validator to be included in a block
+	err = cluster.WaitUntil(2*time.Minute, 2*time.Second, func() bool {
+		latest, err := client.Eth().BlockNumber()
+		if err != nil {
+			return false
+		}
+
+		for ; checkedBlock < latest; checkedBlock++ {
+			block, err := client.Eth().GetBlockByNumber(ethgo.BlockNumber(checkedBlock+1), true)
+			if err != nil {
+				return false
+			}
+
+			for _, tx := range block.Transactions {
+				if tx.To == nil || types.Address(*tx.To) != contracts.DoubleSignEvidenceContract {
+					continue
+				}
+
+				evidence := &polybft.DoubleSignEvidence{}
+				require.NoError(t, evidence.DecodeAbi(tx.Input))
+				require.NoError(t, evidence.Verify())
+				require.Equal(t, byzantineAddr, evidence.Offender())
+
+				evidenceBlock = block.Number
+
+				return true
+			}
+		}
+
+		return false
+	})
+	require.NoError(t, err)
+
+	t.Logf("Double sign evidence included in block %d\n", evidenceBlock)
+
+	// jailed validator is removed from the validator set at the end of the next epoch (at the latest)
+	removedAt := (evidenceBlock/epochSize + 2) * epochSize
+	require.NoError(t, cluster.WaitForBlock(removedAt+2*epochSize, 2*time.Minute))
+
+	var uptime []*struct {
+		Address types.Address `json:"address"`
+	}
+
+	// byzantine validator must not be part of the validator set anymore
+	require.NoError(t, client.Call("validator_getUptime", &uptime, fmt.Sprintf("0x%x", epochSize)))
+	require.Len(t, uptime, 4)
+
+	for _, v := range uptime {
+		require.NotEqual(t, byzantineAddr, v.Address)
+	}
+}
+
 func TestE2E_Consensus_RegisterValidator(t *testing.T) {
 	const (
 		validatorSetSize = 5
@@ -7,6 +7,7 @@ import (
 
 	"github.com/tarality/tan-network/e2e-polybft/framework"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -35,3 +36,58 @@ func TestE2E_NetworkDiscoveryProtocol(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestE2E_NetworkSentryTopology(t *testing.T) {
+	const (
+		validatorCount    = 4
+		sentryCount       = 2
+		nonValidatorCount = 2
+		testTimeout       = time.Minute
+	)
+
+	// create cluster with validators hidden behind sentry nodes
+	cluster := framework.NewTestCluster(t, validatorCount,
+		framework.WithSentries(sentryCount),
+		framework.WithNonValidators(nonValidatorCount))
+	defer cluster.Stop()
+
+	validators := cluster.Servers[:validatorCount]
+	sentries := cluster.Servers[validatorCount : validatorCount+sentryCount]
+	nonValidators := cluster.Servers[validatorCount+sentryCount:]
+
+	isSentry := make(map[string]bool, sentryCount)
+	for _, sentry := range sentries {
+		isSentry[sentry.NodeID()] = true
+	}
+
+	isValidator := make(map[string]bool, validatorCount)
+	for _, validator := range validators {
+		isValidator[validator.NodeID()] = true
+	}
+
+	// consensus messages are relayed between validators through the sentries
+	require.NoError(t, cluster.WaitForBlock(10, testTimeout))
+
+	ctx := context.Background()
+
+	// validators are connected to their sentries only
+	for _, validator := range validators {
+		peerList, err := validator.Conn().PeersList(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		require.NotEmpty(t, peerList.GetPeers())
+
+		for _, p := range peerList.GetPeers() {
+			assert.True(t, isSentry[p.Id], "validator connected to non-sentry peer %s", p.Id)
+		}
+	}
+
+	// full nodes never learn about the validators
+	for _, nonValidator := range nonValidators {
+		peerList, err := nonValidator.Conn().PeersList(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+
+		for _, p := range peerList.GetPeers() {
+			assert.False(t, isValidator[p.Id], "full node connected to validator %s", p.Id)
+		}
+	}
+}
@@ -19,9 +19,11 @@ import (
 	"time"
 
 	"github.com/tarality/tan-network/command/genesis"
+	"github.com/tarality/tan-network/command/polybftsecrets"
 	"github.com/tarality/tan-network/consensus/polybft"
 	"github.com/tarality/tan-network/consensus/polybft/contractsapi"
 	"github.com/tarality/tan-network/helper/common"
+	secretsHelper "github.com/tarality/tan-network/secrets/helper"
 	"github.com/tarality/tan-network/txrelayer"
 	"github.com/tarality/tan-network/types"
 	"github.com/stretchr/testify/require"
@@ -49,6 +51,9 @@ const (
 
 	// prefix for non validators directory
 	nonValidatorPrefix = "test-non-validator-"
+
+	// prefix for sentry nodes directory
+	sentryPrefix = "test-sentry-"
 )
 
 var (
@@ -79,6 +84,7 @@ type TestClusterConfig struct {
 	WithoutBridge        bool
 	BootnodeCount        int
 	NonValidatorCount    int
+	SentryCount          int
 	WithLogs             bool
 	WithStdout           bool
 	LogsDir              string
@@ -108,12 +114,20 @@ type TestClusterConfig struct {
 
 	NumBlockConfirmations uint64
 
+	// ByzantineValidators holds (1-based) indexes of validators which sign conflicting consensus messages
+	ByzantineValidators map[int]struct{}
+
 	InitialTrieDB    string
 	InitialStateRoot types.Hash
 
 	IsPropertyTest  bool
 	TestRewardToken string
 
+	// sentryAddrs holds the libp2p addresses of the sentry nodes validators connect to
+	sentryAddrs []string
+	// validatorNodeIDs holds the libp2p peer IDs of the validators hidden behind the sentry nodes
+	validatorNodeIDs []string
+
 	logsDirOnce sync.Once
 }
 
@@ -121,6 +135,22 @@ func (c *TestClusterConfig) Dir(name string) string {
 	return filepath.Join(c.TmpDir, name)
 }
 
+// isByzantineValidator returns true if the validator with given data dir name signs conflicting consensus messages
+func (c *TestClusterConfig) isByzantineValidator(name string) bool {
+	for index := range c.ByzantineValidators {
+		if c.ValidatorPrefix+strconv.Itoa(index) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSentry returns true if the node with given data dir name is a sentry node
+func (c *TestClusterConfig) isSentry(name string) bool {
+	return strings.HasPrefix(name, sentryPrefix)
+}
+
 func (c *TestClusterConfig) GetStdout(name string, custom ...io.Writer) io.Writer {
 	writers := []io.Writer{}
 
@@ -217,6 +247,14 @@ func WithNonValidators(num int) ClusterOption {
 	}
 }
 
+// WithSentries hides all the validators behind the given number of sentry nodes.
+// Validators connect only to the sentries, and the sentries never advertise the validators to other peers
+func WithSentries(num int) ClusterOption {
+	return func(h *TestClusterConfig) {
+		h.SentryCount = num
+	}
+}
+
 func WithValidatorSnapshot(validatorsLen uint64) ClusterOption {
 	return func(h *TestClusterConfig) {
 		h.ValidatorSetSize = validatorsLen
@@ -266,6 +304,16 @@ func WithNumBlockConfirmations(numBlockConfirmations uint64) ClusterOption {
 	}
 }
 
+func WithByzantineValidator(index int) ClusterOption {
+	return func(h *TestClusterConfig) {
+		if h.ByzantineValidators == nil {
+			h.ByzantineValidators = map[int]struct{}{}
+		}
+
+		h.ByzantineValidators[index] = struct{}{}
+	}
+}
+
 func WithContractDeployerAllowListAdmin(addr types.Address) ClusterOption {
 	return func(h *TestClusterConfig) {
 		h.ContractDeployerAllowListAdmin = append(h.ContractDeployerAllowListAdmin, addr)
@@ -435,6 +483,10 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 		require.NoError(t, err)
 	}
 
+	if config.SentryCount > 0 {
+		require.NoError(t, cluster.initSentryTopology())
+	}
+
 	genesisPath := path.Join(config.TmpDir, "genesis.json")
 
 	{
@@ -478,7 +530,12 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 			cluster.Config.TmpDir, cluster.Config.ValidatorPrefix)
 		require.NoError(t, err)
 
-		if cluster.Config.BootnodeCount > 0 {
+		if cluster.Config.SentryCount > 0 {
+			// sentries are the only nodes validators are reachable through
+			for _, sentryAddr := range cluster.Config.sentryAddrs {
+				args = append(args, "--bootnode", sentryAddr)
+			}
+		} else if cluster.Config.BootnodeCount > 0 {
 			bootNodesCnt := cluster.Config.BootnodeCount
 			if len(validators) < bootNodesCnt {
 				bootNodesCnt = len(validators)
@@ -597,6 +654,12 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 			true, !cluster.Config.WithoutBridge && i == 1 /* relayer */)
 	}
 
+	for i := 1; i <= cluster.Config.SentryCount; i++ {
+		dir := sentryPrefix + strconv.Itoa(i)
+		cluster.InitTestServer(t, dir, cluster.Bridge.JSONRPCAddr(),
+			false, false /* relayer */)
+	}
+
 	for i := 1; i <= cluster.Config.NonValidatorCount; i++ {
 		dir := nonValidatorPrefix + strconv.Itoa(i)
 		cluster.InitTestServer(t, dir, cluster.Bridge.JSONRPCAddr(),
@@ -611,6 +674,8 @@ func (c *TestCluster) InitTestServer(t *testing.T,
 	t.Helper()
 
 	logLevel := os.Getenv(envLogLevel)
+	isByzantine := isValidator && c.Config.isByzantineValidator(dataDir)
+	isSentry := c.Config.isSentry(dataDir)
 
 	dataDir = c.Config.Dir(dataDir)
 	if c.Config.InitialTrieDB != "" {
@@ -629,6 +694,13 @@ func (c *TestCluster) InitTestServer(t *testing.T,
 		config.Relayer = relayer
 		config.NumBlockConfirmations = c.Config.NumBlockConfirmations
 		config.BridgeJSONRPC = bridgeJSONRPC
+		config.ByzantineDoubleSign = isByzantine
+
+		if isValidator {
+			config.Sentries = c.Config.sentryAddrs
+		} else if isSentry {
+			config.PrivatePeerIDs = c.Config.validatorNodeIDs
+		}
 	})
 
 	// watch the server for stop signals. It is important to fix the specific
@@ -644,6 +716,42 @@ func (c *TestCluster) InitTestServer(t *testing.T,
 	c.Servers = append(c.Servers, srv)
 }
 
+// initSentryTopology creates the secrets of the sentry nodes and resolves
+// the sentry addresses and validator peer IDs used to wire the nodes together
+func (c *TestCluster) initSentryTopology() error {
+	if _, err := c.InitSecrets(sentryPrefix, c.Config.SentryCount); err != nil {
+		return err
+	}
+
+	c.Config.validatorNodeIDs = make([]string, 0, c.Config.ValidatorSetSize)
+
+	for i := 1; i <= int(c.Config.ValidatorSetSize); i++ {
+		nodeID, err := loadNodeID(c.Config.Dir(c.Config.ValidatorPrefix + strconv.Itoa(i)))
+		if err != nil {
+			return err
+		}
+
+		c.Config.validatorNodeIDs = append(c.Config.validatorNodeIDs, nodeID)
+	}
+
+	c.Config.sentryAddrs = make([]string, 0, c.Config.SentryCount)
+
+	for i := 1; i <= c.Config.SentryCount; i++ {
+		nodeID, err := loadNodeID(c.Config.Dir(sentryPrefix + strconv.Itoa(i)))
+		if err != nil {
+			return err
+		}
+
+		// sentries are started right after the validators, so their ports follow the validator ones
+		port := c.initialPort + int64(c.Config.ValidatorSetSize) + int64(i)
+
+		c.Config.sentryAddrs = append(c.Config.sentryAddrs,
+			fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", hostIP, port, nodeID))
+	}
+
+	return nil
+}
+
 func (c *TestCluster) cmdRun(args ...string) error {
 	return runCommand(c.Config.Binary, args, c.Config.GetStdout(args[0]))
 }
@@ -997,6 +1105,16 @@ func (t *TestTxn) Wait() error {
 	}
 }
 
+// loadNodeID reads the libp2p peer ID from the secrets stored in the given data dir
+func loadNodeID(dataDir string) (string, error) {
+	secretsManager, err := polybftsecrets.GetSecretsManager(dataDir, "", true)
+	if err != nil {
+		return "", err
+	}
+
+	return secretsHelper.LoadNodeID(secretsManager)
+}
+
 func sliceAddressToSliceString(addrs []types.Address) []string {
 	res := make([]string, len(addrs))
 	for indx, addr := range addrs {
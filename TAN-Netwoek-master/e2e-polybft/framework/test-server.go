@@ -16,6 +16,7 @@ import (
 	"github.com/tarality/tan-network/consensus/polybft"
 	"github.com/tarality/tan-network/consensus/polybft/validator"
 	"github.com/tarality/tan-network/consensus/polybft/wallet"
+	secretsHelper "github.com/tarality/tan-network/secrets/helper"
 	"github.com/tarality/tan-network/server/proto"
 	txpoolProto "github.com/tarality/tan-network/txpool/proto"
 	"github.com/tarality/tan-network/types"
@@ -38,6 +39,9 @@ type TestServerConfig struct {
 	Relayer               bool
 	NumBlockConfirmations uint64
 	BridgeJSONRPC         string
+	ByzantineDoubleSign   bool
+	PrivatePeerIDs        []string
+	Sentries              []string
 }
 
 type TestServerConfigCallback func(*TestServerConfig)
@@ -54,6 +58,7 @@ type TestServer struct {
 	t *testing.T
 
 	address       types.Address
+	nodeID        string
 	clusterConfig *TestClusterConfig
 	config        *TestServerConfig
 	node          *node
@@ -89,6 +94,11 @@ func (t *TestServer) Conn() proto.SystemClient {
 	return proto.NewSystemClient(conn)
 }
 
+// NodeID returns the libp2p peer ID of the server
+func (t *TestServer) NodeID() string {
+	return t.nodeID
+}
+
 func (t *TestServer) DataDir() string {
 	return t.config.DataDir
 }
@@ -131,10 +141,14 @@ func NewTestServer(t *testing.T, clusterConfig *TestClusterConfig,
 	key, err := wallet.GetEcdsaFromSecret(secretsManager)
 	require.NoError(t, err)
 
+	nodeID, err := secretsHelper.LoadNodeID(secretsManager)
+	require.NoError(t, err)
+
 	srv := &TestServer{
 		t:             t,
 		clusterConfig: clusterConfig,
 		address:       types.Address(key.Address()),
+		nodeID:        nodeID,
 		config:        config,
 	}
 	srv.Start()
@@ -180,6 +194,18 @@ func (t *TestServer) Start() {
 		args = append(args, "--relayer")
 	}
 
+	if config.ByzantineDoubleSign {
+		args = append(args, "--byzantine-double-sign")
+	}
+
+	for _, peerID := range config.PrivatePeerIDs {
+		args = append(args, "--private-peer-ids", peerID)
+	}
+
+	for _, sentry := range config.Sentries {
+		args = append(args, "--sentries", sentry)
+	}
+
 	// Start the server
 	stdout := t.clusterConfig.GetStdout(t.config.Name)
 
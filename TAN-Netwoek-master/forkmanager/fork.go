@@ -1,6 +1,11 @@
 package forkmanager
 
-import "github.com/tarality/tan-network/helper/common"
+import (
+	"errors"
+	"math/big"
+
+	"github.com/tarality/tan-network/helper/common"
+)
 
 const InitialFork = "initialfork"
 
@@ -38,6 +43,50 @@ type ForkParams struct {
 
 	// BlockTimeDrift defines the time slot in which a new block can be created
 	BlockTimeDrift *uint64 `json:"blockTimeDrift,omitempty"`
+
+	// BaseFeeDistribution splits the base fee between the burn contract, the treasury and the block proposer
+	BaseFeeDistribution *BaseFeeDistribution `json:"baseFeeDistribution,omitempty"`
+}
+
+var errInvalidBaseFeeDistribution = errors.New("base fee distribution percentages must sum up to 100")
+
+// BaseFeeDistribution defines the percentages of the base fee paid to each destination
+type BaseFeeDistribution struct {
+	// BurnPercent is the percentage of the base fee sent to the burn contract
+	BurnPercent uint64 `json:"burnPercent"`
+
+	// TreasuryPercent is the percentage of the base fee sent to the community treasury
+	TreasuryPercent uint64 `json:"treasuryPercent"`
+
+	// ProposerPercent is the percentage of the base fee sent to the block proposer
+	ProposerPercent uint64 `json:"proposerPercent"`
+}
+
+// Validate checks the percentages sum up to 100
+func (d *BaseFeeDistribution) Validate() error {
+	if d.BurnPercent+d.TreasuryPercent+d.ProposerPercent != 100 ||
+		d.BurnPercent > 100 || d.TreasuryPercent > 100 || d.ProposerPercent > 100 {
+		return errInvalidBaseFeeDistribution
+	}
+
+	return nil
+}
+
+// Split splits the base fee amount into the burnt, treasury and proposer parts.
+// The rounding remainder goes to the proposer, so the parts always sum up to the amount
+func (d *BaseFeeDistribution) Split(amount *big.Int) (burn, treasury, proposer *big.Int) {
+	percentOf := func(percent uint64) *big.Int {
+		part := new(big.Int).Mul(amount, new(big.Int).SetUint64(percent))
+
+		return part.Div(part, big.NewInt(100))
+	}
+
+	burn = percentOf(d.BurnPercent)
+	treasury = percentOf(d.TreasuryPercent)
+	proposer = new(big.Int).Sub(amount, burn)
+	proposer.Sub(proposer, treasury)
+
+	return burn, treasury, proposer
 }
 
 // forkHandler defines one custom handler
@@ -1,6 +1,8 @@
 package forkmanager
 
 import (
+	"math"
+	"math/big"
 	"testing"
 	"time"
 
@@ -274,3 +276,19 @@ func TestForkManager_HandlerReplacement(t *testing.T) {
 		assert.Equal(t, "ADH", execute(HandlerA, i+10))
 	}
 }
+
+func TestBaseFeeDistribution(t *testing.T) {
+	t.Parallel()
+
+	distribution := &BaseFeeDistribution{BurnPercent: 50, TreasuryPercent: 30, ProposerPercent: 20}
+	require.NoError(t, distribution.Validate())
+
+	burn, treasury, proposer := distribution.Split(big.NewInt(1001))
+	assert.Equal(t, big.NewInt(500), burn)
+	assert.Equal(t, big.NewInt(300), treasury)
+	// the rounding remainder goes to the proposer
+	assert.Equal(t, big.NewInt(201), proposer)
+
+	require.Error(t, (&BaseFeeDistribution{BurnPercent: 50, TreasuryPercent: 30}).Validate())
+	require.Error(t, (&BaseFeeDistribution{BurnPercent: 150, ProposerPercent: math.MaxUint64 - 49}).Validate())
+}
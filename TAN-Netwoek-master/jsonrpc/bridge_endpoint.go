@@ -8,6 +8,7 @@ import (
 type bridgeStore interface {
 	GenerateExitProof(exitID uint64) (types.Proof, error)
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+	GetHeaderProof(fromEpoch, toEpoch uint64) ([]*types.Header, error)
 }
 
 // Bridge is the bridge jsonrpc endpoint
@@ -24,3 +25,31 @@ func (b *Bridge) GenerateExitProof(exitID argUint64) (interface{}, error) {
 func (b *Bridge) GetStateSyncProof(stateSyncID argUint64) (interface{}, error) {
 	return b.store.GetStateSyncProof(uint64(stateSyncID))
 }
+
+// EpochHeader is the epoch-ending header of the header proof.
+// Header is RLP encoded, so the verifier is able to recalculate its hash
+type EpochHeader struct {
+	Number argUint64  `json:"number"`
+	Hash   types.Hash `json:"hash"`
+	Header argBytes   `json:"header"`
+}
+
+// GetHeaderProof returns the epoch-ending headers, which allow the light client that trusts
+// the validator set of fromEpoch to verify the validator set of toEpoch
+func (b *Bridge) GetHeaderProof(fromEpoch, toEpoch argUint64) (interface{}, error) {
+	headers, err := b.store.GetHeaderProof(uint64(fromEpoch), uint64(toEpoch))
+	if err != nil {
+		return nil, err
+	}
+
+	proof := make([]*EpochHeader, len(headers))
+	for i, header := range headers {
+		proof[i] = &EpochHeader{
+			Number: argUint64(header.Number),
+			Hash:   header.Hash,
+			Header: header.MarshalRLP(),
+		}
+	}
+
+	return proof, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
+
+	"github.com/tarality/tan-network/types"
 )
 
 func TestBridgeEndpoint(t *testing.T) {
@@ -51,4 +53,29 @@ func TestBridgeEndpoint(t *testing.T) {
 	require.NoError(t, json.Unmarshal(data, resp))
 	require.Nil(t, resp.Error)
 	require.NotNil(t, resp.Result)
+
+	msg = []byte(`{
+		"method": "bridge_getHeaderProof",
+		"params": ["0x1", "0x3"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var proof []*EpochHeader
+	require.NoError(t, json.Unmarshal(resp.Result, &proof))
+	require.Len(t, proof, 2)
+
+	for i, epochHeader := range proof {
+		header := &types.Header{}
+		require.NoError(t, header.UnmarshalRLP(epochHeader.Header))
+		require.Equal(t, uint64(i+1)*10, header.Number)
+		require.Equal(t, uint64(epochHeader.Number), header.Number)
+		require.Equal(t, epochHeader.Hash, header.Hash)
+	}
 }
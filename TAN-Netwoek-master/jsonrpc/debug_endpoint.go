@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/tarality/tan-network/blockchain/storage"
 	"github.com/tarality/tan-network/helper/hex"
 	"github.com/tarality/tan-network/state/runtime/tracer"
 	"github.com/tarality/tan-network/state/runtime/tracer/structtracer"
 	"github.com/tarality/tan-network/types"
+	"github.com/tarality/tan-network/types/buildroot"
 )
 
 var (
@@ -27,6 +29,9 @@ type debugBlockchainStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// FinalizedHeader returns the header of the latest finalized block, as resolved by the consensus engine
+	FinalizedHeader() *types.Header
+
 	// GetHeaderByNumber gets a header using the provided number
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 
@@ -47,6 +52,9 @@ type debugBlockchainStore interface {
 
 	// TraceCall traces a single call at the point when the given header is mined
 	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+
+	// BadBlocks returns the blocks rejected by the local node, the most recent first
+	BadBlocks() ([]*storage.BadBlock, error)
 }
 
 type debugTxPoolStore interface {
@@ -175,6 +183,108 @@ func (d *Debug) TraceCall(
 	return d.store.TraceCall(tx, header, tracer)
 }
 
+// badBlock is a block rejected by the local node
+type badBlock struct {
+	Hash   types.Hash `json:"hash"`
+	Block  *block     `json:"block"`
+	RLP    argBytes   `json:"rlp"`
+	Reason string     `json:"reason"`
+	Time   argUint64  `json:"time"`
+	// Result is the locally computed execution result, nil if the block wasn't executed
+	Result *badBlockResult `json:"result"`
+}
+
+type badBlockResult struct {
+	StateRoot    types.Hash `json:"stateRoot"`
+	GasUsed      argUint64  `json:"gasUsed"`
+	ReceiptsRoot types.Hash `json:"receiptsRoot"`
+	Receipts     []*receipt `json:"receipts"`
+}
+
+// GetBadBlocks returns the blocks rejected by the local node, the most recent first,
+// along with the reason and the locally computed execution result
+func (d *Debug) GetBadBlocks() (interface{}, error) {
+	badBlocks, err := d.store.BadBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*badBlock, len(badBlocks))
+
+	for i, bad := range badBlocks {
+		res[i] = &badBlock{
+			Hash:   bad.Block.Hash(),
+			Block:  toBlock(bad.Block, true),
+			RLP:    argBytes(bad.Block.MarshalRLP()),
+			Reason: bad.Reason,
+			Time:   argUint64(bad.Time),
+		}
+
+		if bad.StateRoot != types.ZeroHash {
+			res[i].Result = &badBlockResult{
+				StateRoot:    bad.StateRoot,
+				GasUsed:      argUint64(bad.GasUsed),
+				ReceiptsRoot: buildroot.CalculateReceiptsRoot(bad.Receipts),
+				Receipts:     toBadBlockReceipts(bad.Block, bad.Receipts),
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// toBadBlockReceipts converts the locally computed receipts of the bad block
+func toBadBlockReceipts(b *types.Block, receipts []*types.Receipt) []*receipt {
+	res := make([]*receipt, len(receipts))
+	logIndex := 0
+
+	for txIndex, raw := range receipts {
+		logs := make([]*Log, len(raw.Logs))
+		for i, elem := range raw.Logs {
+			logs[i] = &Log{
+				Address:     elem.Address,
+				Topics:      elem.Topics,
+				Data:        argBytes(elem.Data),
+				BlockHash:   b.Hash(),
+				BlockNumber: argUint64(b.Number()),
+				TxHash:      raw.TxHash,
+				TxIndex:     argUint64(txIndex),
+				LogIndex:    argUint64(logIndex),
+			}
+
+			logIndex++
+		}
+
+		res[txIndex] = &receipt{
+			Root:              raw.Root,
+			CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
+			LogsBloom:         raw.LogsBloom,
+			TxHash:            raw.TxHash,
+			TxIndex:           argUint64(txIndex),
+			BlockHash:         b.Hash(),
+			BlockNumber:       argUint64(b.Number()),
+			GasUsed:           argUint64(raw.GasUsed),
+			ContractAddress:   raw.ContractAddress,
+			Logs:              logs,
+		}
+
+		if raw.Status != nil {
+			res[txIndex].Status = argUint64(*raw.Status)
+		}
+
+		if len(raw.RevertReason) > 0 {
+			res[txIndex].RevertReason = argBytesPtr(raw.RevertReason)
+		}
+
+		if txIndex < len(b.Transactions) {
+			res[txIndex].FromAddr = b.Transactions[txIndex].From
+			res[txIndex].ToAddr = b.Transactions[txIndex].To
+		}
+	}
+
+	return res
+}
+
 func (d *Debug) traceBlock(
 	block *types.Block,
 	config *TraceConfig,
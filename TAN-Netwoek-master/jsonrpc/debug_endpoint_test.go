@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/tarality/tan-network/blockchain/storage"
 	"github.com/tarality/tan-network/helper/hex"
 	"github.com/tarality/tan-network/state/runtime/tracer"
 	"github.com/tarality/tan-network/types"
@@ -14,6 +15,7 @@ import (
 
 type debugEndpointMockStore struct {
 	headerFn            func() *types.Header
+	finalizedHeaderFn   func() *types.Header
 	getHeaderByNumberFn func(uint64) (*types.Header, bool)
 	readTxLookupFn      func(types.Hash) (types.Hash, bool)
 	getBlockByHashFn    func(types.Hash, bool) (*types.Block, bool)
@@ -23,12 +25,17 @@ type debugEndpointMockStore struct {
 	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	badBlocksFn         func() ([]*storage.BadBlock, error)
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
 	return s.headerFn()
 }
 
+func (s *debugEndpointMockStore) FinalizedHeader() *types.Header {
+	return s.finalizedHeaderFn()
+}
+
 func (s *debugEndpointMockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	return s.getHeaderByNumberFn(num)
 }
@@ -65,6 +72,10 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) BadBlocks() ([]*storage.BadBlock, error) {
+	return s.badBlocksFn()
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
 
@@ -694,6 +705,61 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+func TestGetBadBlocks(t *testing.T) {
+	t.Parallel()
+
+	status := types.ReceiptSuccess
+	receipts := []*types.Receipt{
+		{
+			CumulativeGasUsed: 21000,
+			GasUsed:           21000,
+			Status:            &status,
+			TxHash:            types.StringToHash("1"),
+			Logs:              []*types.Log{{Address: types.StringToAddress("2")}},
+		},
+	}
+
+	endpoint := &Debug{&debugEndpointMockStore{
+		badBlocksFn: func() ([]*storage.BadBlock, error) {
+			return []*storage.BadBlock{
+				{
+					Block:     testLatestBlock,
+					Reason:    "invalid block state root",
+					StateRoot: types.StringToHash("3"),
+					GasUsed:   21000,
+					Receipts:  receipts,
+					Time:      100,
+				},
+				{
+					Block:  testBlock10,
+					Reason: "failed to verify the header",
+					Time:   90,
+				},
+			}, nil
+		},
+	}}
+
+	res, err := endpoint.GetBadBlocks()
+	assert.NoError(t, err)
+
+	badBlocks, ok := res.([]*badBlock)
+	assert.True(t, ok)
+	assert.Len(t, badBlocks, 2)
+
+	assert.Equal(t, testLatestBlock.Hash(), badBlocks[0].Hash)
+	assert.Equal(t, argBytes(testLatestBlock.MarshalRLP()), badBlocks[0].RLP)
+	assert.Equal(t, "invalid block state root", badBlocks[0].Reason)
+	assert.Equal(t, argUint64(100), badBlocks[0].Time)
+	assert.Equal(t, types.StringToHash("3"), badBlocks[0].Result.StateRoot)
+	assert.Equal(t, argUint64(21000), badBlocks[0].Result.GasUsed)
+	assert.Len(t, badBlocks[0].Result.Receipts, 1)
+	assert.Equal(t, argUint64(types.ReceiptSuccess), badBlocks[0].Result.Receipts[0].Status)
+	assert.Equal(t, types.StringToHash("1"), badBlocks[0].Result.Receipts[0].Logs[0].TxHash)
+
+	assert.Equal(t, testBlock10.Hash(), badBlocks[1].Hash)
+	assert.Nil(t, badBlocks[1].Result)
+}
+
 func Test_newTracer(t *testing.T) {
 	t.Parallel()
 
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,12 +34,14 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth    *Eth
-	Web3   *Web3
-	Net    *Net
-	TxPool *TxPool
-	Bridge *Bridge
-	Debug  *Debug
+	Eth       *Eth
+	Web3      *Web3
+	Net       *Net
+	TxPool    *TxPool
+	Bridge    *Bridge
+	Validator *Validator
+	Debug     *Debug
+	Tan       *Tan
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -109,9 +112,15 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 	d.endpoints.Bridge = &Bridge{
 		store,
 	}
+	d.endpoints.Validator = &Validator{
+		store,
+	}
 	d.endpoints.Debug = &Debug{
 		store,
 	}
+	d.endpoints.Tan = &Tan{
+		store,
+	}
 
 	var err error
 
@@ -135,7 +144,15 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		return err
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	if err = d.registerService("validator", d.endpoints.Validator); err != nil {
+		return err
+	}
+
+	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
+		return err
+	}
+
+	return d.registerService("tan", d.endpoints.Tan)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -431,6 +448,12 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		metrics.IncrCounter([]string{jsonRPCMetric, req.Method + "_errors"}, 1)
 		d.logInternalError(req.Method, err)
 
+		// reverted executions return the revert data along with the error, as geth does
+		var revertErr *revertError
+		if errors.As(err, &revertErr) {
+			return []byte(hex.EncodeToString(revertErr.data)), &revertError{err: err, data: revertErr.data}
+		}
+
 		if res := output[0].Interface(); res != nil {
 			data, ok = res.([]byte)
 
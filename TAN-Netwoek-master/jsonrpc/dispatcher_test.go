@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -214,6 +215,13 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 	return nil, nil
 }
 
+func (m *mockService) Revert() (interface{}, error) {
+	return nil, fmt.Errorf("unable to apply transaction: %w", &revertError{
+		err:  errors.New("execution reverted: reason"),
+		data: []byte{0x1, 0x2},
+	})
+}
+
 func TestDispatcherFuncDecode(t *testing.T) {
 	t.Parallel()
 
@@ -294,6 +302,32 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+func TestDispatcher_RevertError(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	require.NoError(t, dispatcher.registerService("mock", &mockService{}))
+
+	resp, err := dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"mock_revert","params":[]}`))
+	require.NoError(t, err)
+
+	var res ErrorResponse
+
+	require.NoError(t, json.Unmarshal(resp, &res))
+	require.NotNil(t, res.Error)
+	assert.Equal(t, revertErrorCode, res.Error.Code)
+	assert.Equal(t, "unable to apply transaction: execution reverted: reason", res.Error.Message)
+	assert.Equal(t, "0x0102", res.Error.Data)
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
 	t.Parallel()
 
@@ -1,8 +1,10 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/tarality/tan-network/state/runtime"
 	"github.com/umbracle/ethgo/abi"
@@ -94,11 +96,78 @@ func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
 
+// revertErrorCode is the error code of a reverted execution, the same as used by geth
+const revertErrorCode = 3
+
+var (
+	// panicSelector is the selector of the Panic(uint256) error raised by the solidity checks
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+	// panicReasons are the descriptions of the solidity panic codes
+	panicReasons = map[uint64]string{
+		0x00: "generic panic",
+		0x01: "assert(false)",
+		0x11: "arithmetic underflow or overflow",
+		0x12: "division or modulo by zero",
+		0x21: "enum overflow",
+		0x22: "invalid encoded storage byte array accessed",
+		0x31: "out-of-bounds array access; popping on an empty array",
+		0x32: "out-of-bounds access of an array or bytesN",
+		0x41: "out of memory",
+		0x51: "uninitialized function",
+	}
+)
+
+// revertError is returned when the execution reverted. It carries the raw revert data,
+// which is returned in the data field of the error
+type revertError struct {
+	err  error
+	data []byte
+}
+
+func (e *revertError) Error() string {
+	return e.err.Error()
+}
+
+func (e *revertError) Unwrap() error {
+	return e.err
+}
+
+func (e *revertError) ErrorCode() int {
+	return revertErrorCode
+}
+
+// constructErrorFromRevert returns the revert error of the reverted execution,
+// with the decoded revert reason in the message if there is one
 func constructErrorFromRevert(result *runtime.ExecutionResult) error {
-	revertErrMsg, unpackErr := abi.UnpackRevertError(result.ReturnValue)
-	if unpackErr != nil {
-		return result.Err
+	err := result.Err
+	if reason, ok := decodeRevertReason(result.ReturnValue); ok {
+		err = fmt.Errorf("%w: %s", result.Err, reason)
+	}
+
+	return &revertError{
+		err:  err,
+		data: result.ReturnValue,
+	}
+}
+
+// decodeRevertReason decodes the Error(string) and Panic(uint256) revert data
+func decodeRevertReason(data []byte) (string, bool) {
+	if len(data) == len(panicSelector)+32 && bytes.Equal(data[:len(panicSelector)], panicSelector) {
+		code := new(big.Int).SetBytes(data[len(panicSelector):])
+
+		reason, ok := panicReasons[code.Uint64()]
+		if !code.IsUint64() || !ok {
+			reason = "unknown panic code"
+		}
+
+		return fmt.Sprintf("panic: %s (0x%x)", reason, code), true
+	}
+
+	reason, err := abi.UnpackRevertError(data)
+	if err != nil {
+		return "", false
 	}
 
-	return fmt.Errorf("%w: %s", result.Err, revertErrMsg)
+	return reason, true
 }
@@ -6,11 +6,13 @@ import (
 	"testing"
 
 	"github.com/tarality/tan-network/blockchain"
+	"github.com/tarality/tan-network/blockchain/bloombits"
 	"github.com/tarality/tan-network/helper/hex"
 	"github.com/tarality/tan-network/helper/progress"
 	"github.com/tarality/tan-network/state/runtime"
 	"github.com/tarality/tan-network/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -241,6 +243,31 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 		assert.Len(t, response.Logs, 1)
 		assert.Equal(t, uint64(3), uint64(response.Logs[0].LogIndex))
 		assert.Equal(t, uint64(1), uint64(response.Logs[0].TxIndex))
+		assert.Nil(t, response.RevertReason)
+	})
+
+	t.Run("returns the revert reason of a reverted transaction", func(t *testing.T) {
+		t.Parallel()
+
+		revertReason := []byte{0x1, 0x2, 0x3}
+
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		store.add(block)
+		txn := newTestTransaction(uint64(0), addr0)
+		block.Transactions = []*types.Transaction{txn}
+		rawReceipt := &types.Receipt{RevertReason: revertReason}
+		rawReceipt.SetStatus(types.ReceiptFailed)
+		store.receipts[hash4] = []*types.Receipt{rawReceipt}
+
+		res, err := eth.GetTransactionReceipt(txn.Hash)
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		response := res.(*receipt)
+		assert.Equal(t, argUint64(types.ReceiptFailed), response.Status)
+		assert.Equal(t, argBytesPtr(revertReason), response.RevertReason)
 	})
 }
 
@@ -389,6 +416,29 @@ func TestEth_Call(t *testing.T) {
 		bres := res.([]byte) //nolint:forcetypeassert
 		assert.Equal(t, []byte(hex.EncodeToString(returnValue)), bres)
 	})
+
+	t.Run("returns the decoded panic of a reverted transaction execution", func(t *testing.T) {
+		t.Parallel()
+
+		// Panic(uint256) with the arithmetic overflow code
+		returnValue := append([]byte{0x4e, 0x48, 0x7b, 0x71}, types.BytesToHash([]byte{0x11}).Bytes()...)
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		store.ethCallError = runtime.ErrExecutionReverted
+		store.returnValue = returnValue
+		eth := newTestEthEndpoint(store)
+
+		_, err := eth.Call(&txnArgs{To: &addr1}, BlockNumberOrHash{}, nil)
+		assert.ErrorIs(t, err, runtime.ErrExecutionReverted)
+		assert.EqualError(t, err, "execution reverted: panic: arithmetic underflow or overflow (0x11)")
+
+		var revertErr *revertError
+
+		require.ErrorAs(t, err, &revertErr)
+		assert.Equal(t, revertErrorCode, revertErr.ErrorCode())
+		assert.Equal(t, returnValue, revertErr.data)
+	})
 }
 
 type testStore interface {
@@ -405,6 +455,10 @@ type mockBlockStore struct {
 	averageGasPrice int64
 	ethCallError    error
 	returnValue     []byte
+	bloomSections   uint64
+	bloomBits       map[uint64]*bloombits.Generator
+
+	simulateBlocksFn func(*types.Header, []*SimulatedBlock) ([][]*SimulatedCallResult, error)
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -535,6 +589,10 @@ func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
 
+func (m *mockBlockStore) FinalizedHeader() *types.Header {
+	return m.Header()
+}
+
 func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	for _, block := range m.blocks {
 		for _, txn := range block.Transactions {
@@ -581,10 +639,30 @@ func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction,
 	}, nil
 }
 
+func (m *mockBlockStore) SimulateBlocks(
+	header *types.Header,
+	blocks []*SimulatedBlock,
+) ([][]*SimulatedCallResult, error) {
+	return m.simulateBlocksFn(header, blocks)
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) BloomBitsSections() uint64 {
+	return m.bloomSections
+}
+
+func (m *mockBlockStore) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	generator, ok := m.bloomBits[section]
+	if !ok {
+		return nil, blockchain.ErrBloomBitsNotIndexed
+	}
+
+	return generator.Bitset(bit)
+}
+
 func (m *mockBlockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
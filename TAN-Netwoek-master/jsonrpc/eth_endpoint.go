@@ -44,6 +44,9 @@ type ethBlockchainStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// FinalizedHeader returns the header of the latest finalized block, as resolved by the consensus engine
+	FinalizedHeader() *types.Header
+
 	// GetHeaderByNumber gets a header using the provided number
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 
@@ -65,6 +68,9 @@ type ethBlockchainStore interface {
 	// ApplyTxn applies a transaction object to the blockchain
 	ApplyTxn(header *types.Header, txn *types.Transaction, override types.StateOverride) (*runtime.ExecutionResult, error)
 
+	// SimulateBlocks applies the calls of the simulated blocks in order on top of the state of the given header
+	SimulateBlocks(header *types.Header, blocks []*SimulatedBlock) ([][]*SimulatedCallResult, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -363,6 +369,10 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		Logs:              logs,
 	}
 
+	if len(raw.RevertReason) > 0 {
+		res.RevertReason = argBytesPtr(raw.RevertReason)
+	}
+
 	return res, nil
 }
 
@@ -0,0 +1,289 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	// maxSimulatedBlocks is the maximum number of blocks simulated by a single eth_simulateV1 request
+	maxSimulatedBlocks = 256
+	// maxSimulatedCalls is the maximum number of calls simulated by a single eth_simulateV1 request
+	maxSimulatedCalls = 1000
+	// simulatedVMErrorCode is the error code of a simulated call failed in the EVM
+	simulatedVMErrorCode = -32015
+)
+
+var (
+	ErrNoSimulatedBlocks            = errors.New("no blocks to simulate")
+	ErrTooManySimulatedBlocks       = fmt.Errorf("too many blocks to simulate, the maximum is %d", maxSimulatedBlocks)
+	ErrTooManySimulatedCalls        = fmt.Errorf("too many calls to simulate, the maximum is %d", maxSimulatedCalls)
+	ErrSimulatedBlockNumberOrder    = errors.New("simulated block numbers must be increasing")
+	ErrSimulatedBlockTimestampOrder = errors.New("simulated block timestamps must be increasing")
+)
+
+// SimulatedBlock is a block simulated on top of the state of the previous simulated block
+type SimulatedBlock struct {
+	// Header is the header the calls are executed in
+	Header *types.Header
+	// Coinbase overrides the block creator resolved by the consensus, if set
+	Coinbase *types.Address
+	// Override is applied to the state before the calls are executed
+	Override types.StateOverride
+	Calls    []*SimulatedCall
+}
+
+// SimulatedCall is a single call of the simulated block
+type SimulatedCall struct {
+	Tx *types.Transaction
+	// FillNonce is set if the nonce of the call is taken from the simulated state
+	FillNonce bool
+}
+
+// SimulatedCallResult is the result of the simulated call
+type SimulatedCallResult struct {
+	Result *runtime.ExecutionResult
+	Logs   []*types.Log
+}
+
+// SimulateOpts is the request of eth_simulateV1
+type SimulateOpts struct {
+	BlockStateCalls []*simulateBlockStateCall `json:"blockStateCalls"`
+}
+
+type simulateBlockStateCall struct {
+	BlockOverrides *blockOverrides `json:"blockOverrides"`
+	StateOverrides *stateOverride  `json:"stateOverrides"`
+	Calls          []*txnArgs      `json:"calls"`
+}
+
+type blockOverrides struct {
+	Number       *argUint64     `json:"number"`
+	Time         *argUint64     `json:"time"`
+	GasLimit     *argUint64     `json:"gasLimit"`
+	FeeRecipient *types.Address `json:"feeRecipient"`
+	BaseFee      *argUint64     `json:"baseFeePerGas"`
+}
+
+type simulatedBlockResult struct {
+	Number       argUint64              `json:"number"`
+	Hash         types.Hash             `json:"hash"`
+	ParentHash   types.Hash             `json:"parentHash"`
+	Timestamp    argUint64              `json:"timestamp"`
+	GasLimit     argUint64              `json:"gasLimit"`
+	GasUsed      argUint64              `json:"gasUsed"`
+	FeeRecipient *types.Address         `json:"feeRecipient"`
+	BaseFee      argUint64              `json:"baseFeePerGas"`
+	Calls        []*simulatedCallResult `json:"calls"`
+}
+
+type simulatedCallResult struct {
+	Status     argUint64           `json:"status"`
+	ReturnData argBytes            `json:"returnData"`
+	GasUsed    argUint64           `json:"gasUsed"`
+	Logs       []*Log              `json:"logs"`
+	Error      *simulatedCallError `json:"error,omitempty"`
+}
+
+type simulatedCallError struct {
+	Code    int       `json:"code"`
+	Message string    `json:"message"`
+	Data    *argBytes `json:"data,omitempty"`
+}
+
+// SimulateV1 applies the calls of the simulated blocks in order, on top of the state of the given block.
+// Every simulated block continues from the state left by the previous one and can override
+// the header fields and the state. Nothing is committed
+func (e *Eth) SimulateV1(opts *SimulateOpts, filter BlockNumberOrHash) (interface{}, error) {
+	if opts == nil || len(opts.BlockStateCalls) == 0 {
+		return nil, ErrNoSimulatedBlocks
+	}
+
+	if len(opts.BlockStateCalls) > maxSimulatedBlocks {
+		return nil, ErrTooManySimulatedBlocks
+	}
+
+	base, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		blocks = make([]*SimulatedBlock, len(opts.BlockStateCalls))
+		parent = base
+		calls  = 0
+	)
+
+	for i, blockCall := range opts.BlockStateCalls {
+		block, err := e.newSimulatedBlock(parent, blockCall)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		if calls += len(block.Calls); calls > maxSimulatedCalls {
+			return nil, ErrTooManySimulatedCalls
+		}
+
+		blocks[i] = block
+		parent = block.Header
+	}
+
+	results, err := e.store.SimulateBlocks(base, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*simulatedBlockResult, len(blocks))
+	for i, block := range blocks {
+		res[i] = toSimulatedBlockResult(block, results[i])
+	}
+
+	return res, nil
+}
+
+// newSimulatedBlock builds the simulated block on top of the parent header, applying the block overrides
+func (e *Eth) newSimulatedBlock(parent *types.Header, blockCall *simulateBlockStateCall) (*SimulatedBlock, error) {
+	header := parent.Copy()
+	header.ParentHash = parent.Hash
+	header.Number = parent.Number + 1
+	header.Timestamp = parent.Timestamp + 1
+	header.GasUsed = 0
+
+	block := &SimulatedBlock{Header: header}
+
+	if o := blockCall.BlockOverrides; o != nil {
+		if o.Number != nil {
+			if uint64(*o.Number) <= parent.Number {
+				return nil, ErrSimulatedBlockNumberOrder
+			}
+
+			header.Number = uint64(*o.Number)
+		}
+
+		if o.Time != nil {
+			if uint64(*o.Time) <= parent.Timestamp {
+				return nil, ErrSimulatedBlockTimestampOrder
+			}
+
+			header.Timestamp = uint64(*o.Time)
+		}
+
+		if o.GasLimit != nil {
+			header.GasLimit = uint64(*o.GasLimit)
+		}
+
+		if o.BaseFee != nil {
+			header.BaseFee = uint64(*o.BaseFee)
+		}
+
+		if o.FeeRecipient != nil {
+			header.Miner = o.FeeRecipient.Bytes()
+			block.Coinbase = o.FeeRecipient
+		}
+	}
+
+	if blockCall.StateOverrides != nil {
+		block.Override = types.StateOverride{}
+		for addr, o := range *blockCall.StateOverrides {
+			block.Override[addr] = o.ToType()
+		}
+	}
+
+	block.Calls = make([]*SimulatedCall, len(blockCall.Calls))
+
+	for i, arg := range blockCall.Calls {
+		if arg == nil {
+			return nil, fmt.Errorf("call %d: missing call object", i)
+		}
+
+		// the nonce of the sender changes with every call, so it is taken from the simulated state
+		fillNonce := arg.Nonce == nil
+
+		tx, err := DecodeTxn(arg, header.Number, e.store)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+
+		block.Calls[i] = &SimulatedCall{Tx: tx, FillNonce: fillNonce}
+	}
+
+	header.ComputeHash()
+
+	return block, nil
+}
+
+func toSimulatedBlockResult(block *SimulatedBlock, results []*SimulatedCallResult) *simulatedBlockResult {
+	header := block.Header
+
+	res := &simulatedBlockResult{
+		Number:     argUint64(header.Number),
+		Hash:       header.Hash,
+		ParentHash: header.ParentHash,
+		Timestamp:  argUint64(header.Timestamp),
+		GasLimit:   argUint64(header.GasLimit),
+		BaseFee:    argUint64(header.BaseFee),
+		Calls:      make([]*simulatedCallResult, len(results)),
+	}
+
+	if block.Coinbase != nil {
+		res.FeeRecipient = block.Coinbase
+	} else if len(header.Miner) > 0 {
+		res.FeeRecipient = argAddrPtr(types.BytesToAddress(header.Miner))
+	}
+
+	logIndex := 0
+
+	for i, result := range results {
+		txHash := block.Calls[i].Tx.Hash
+
+		call := &simulatedCallResult{
+			Status:     argUint64(types.ReceiptSuccess),
+			ReturnData: argBytes(result.Result.ReturnValue),
+			GasUsed:    argUint64(result.Result.GasUsed),
+			Logs:       make([]*Log, len(result.Logs)),
+		}
+
+		for j, log := range result.Logs {
+			call.Logs[j] = &Log{
+				Address:     log.Address,
+				Topics:      log.Topics,
+				Data:        argBytes(log.Data),
+				BlockNumber: argUint64(header.Number),
+				TxHash:      txHash,
+				TxIndex:     argUint64(i),
+				BlockHash:   header.Hash,
+				LogIndex:    argUint64(logIndex),
+			}
+
+			logIndex++
+		}
+
+		if result.Result.Failed() {
+			call.Status = argUint64(types.ReceiptFailed)
+			call.Error = toSimulatedCallError(result.Result)
+		}
+
+		res.GasUsed += call.GasUsed
+		res.Calls[i] = call
+	}
+
+	return res
+}
+
+func toSimulatedCallError(result *runtime.ExecutionResult) *simulatedCallError {
+	if result.Reverted() {
+		return &simulatedCallError{
+			Code:    revertErrorCode,
+			Message: constructErrorFromRevert(result).Error(),
+			Data:    argBytesPtr(result.ReturnValue),
+		}
+	}
+
+	return &simulatedCallError{
+		Code:    simulatedVMErrorCode,
+		Message: result.Err.Error(),
+	}
+}
@@ -0,0 +1,186 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/types"
+)
+
+func newSimulateTestStore() *mockBlockStore {
+	store := newMockBlockStore()
+
+	block := newTestBlock(100, hash1)
+	block.Header.Timestamp = 1000
+	block.Header.GasLimit = 30000000
+	block.Header.BaseFee = 10
+
+	store.add(block)
+
+	return store
+}
+
+func TestEth_SimulateV1(t *testing.T) {
+	t.Parallel()
+
+	t.Run("simulates the blocks on top of each other", func(t *testing.T) {
+		t.Parallel()
+
+		store := newSimulateTestStore()
+		store.simulateBlocksFn = func(header *types.Header, blocks []*SimulatedBlock) ([][]*SimulatedCallResult, error) {
+			assert.Equal(t, uint64(100), header.Number)
+
+			results := make([][]*SimulatedCallResult, len(blocks))
+			for i, block := range blocks {
+				results[i] = make([]*SimulatedCallResult, len(block.Calls))
+				for j := range block.Calls {
+					results[i][j] = &SimulatedCallResult{
+						Result: &runtime.ExecutionResult{GasUsed: 21000},
+						Logs:   []*types.Log{{Address: addr1}, {Address: addr2}},
+					}
+				}
+			}
+
+			return results, nil
+		}
+
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: []*simulateBlockStateCall{
+				{
+					Calls: []*txnArgs{{To: &addr1}, {To: &addr2}},
+				},
+				{
+					BlockOverrides: &blockOverrides{
+						Number:       argUintPtr(110),
+						Time:         argUintPtr(2000),
+						FeeRecipient: &addr2,
+						BaseFee:      argUintPtr(7),
+					},
+					Calls: []*txnArgs{{To: &addr1}},
+				},
+			},
+		}, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		blocks, ok := res.([]*simulatedBlockResult)
+		require.True(t, ok)
+		require.Len(t, blocks, 2)
+
+		assert.Equal(t, argUint64(101), blocks[0].Number)
+		assert.Equal(t, argUint64(1001), blocks[0].Timestamp)
+		assert.Equal(t, argUint64(10), blocks[0].BaseFee)
+		assert.Equal(t, hash1, blocks[0].ParentHash)
+		assert.Equal(t, argUint64(42000), blocks[0].GasUsed)
+		require.Len(t, blocks[0].Calls, 2)
+		assert.Equal(t, argUint64(types.ReceiptSuccess), blocks[0].Calls[0].Status)
+		assert.Equal(t, argUint64(0), blocks[0].Calls[0].Logs[0].LogIndex)
+		assert.Equal(t, argUint64(3), blocks[0].Calls[1].Logs[1].LogIndex)
+		assert.Equal(t, argUint64(1), blocks[0].Calls[1].Logs[1].TxIndex)
+
+		assert.Equal(t, argUint64(110), blocks[1].Number)
+		assert.Equal(t, argUint64(2000), blocks[1].Timestamp)
+		assert.Equal(t, argUint64(7), blocks[1].BaseFee)
+		assert.Equal(t, &addr2, blocks[1].FeeRecipient)
+		assert.Equal(t, blocks[0].Hash, blocks[1].ParentHash)
+		assert.Equal(t, argUint64(21000), blocks[1].GasUsed)
+	})
+
+	t.Run("calls without a nonce take it from the simulated state", func(t *testing.T) {
+		t.Parallel()
+
+		store := newSimulateTestStore()
+		store.simulateBlocksFn = func(header *types.Header, blocks []*SimulatedBlock) ([][]*SimulatedCallResult, error) {
+			assert.True(t, blocks[0].Calls[0].FillNonce)
+			assert.False(t, blocks[0].Calls[1].FillNonce)
+
+			return [][]*SimulatedCallResult{{
+				{Result: &runtime.ExecutionResult{}},
+				{Result: &runtime.ExecutionResult{}},
+			}}, nil
+		}
+
+		eth := newTestEthEndpoint(store)
+
+		_, err := eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: []*simulateBlockStateCall{
+				{
+					Calls: []*txnArgs{
+						{To: &addr1},
+						{From: &addr0, To: &addr1, Nonce: argUintPtr(5)},
+					},
+				},
+			},
+		}, BlockNumberOrHash{})
+		require.NoError(t, err)
+	})
+
+	t.Run("returns the revert data of a reverted call", func(t *testing.T) {
+		t.Parallel()
+
+		returnValue := []byte("Reverted()")
+
+		store := newSimulateTestStore()
+		store.simulateBlocksFn = func(header *types.Header, blocks []*SimulatedBlock) ([][]*SimulatedCallResult, error) {
+			return [][]*SimulatedCallResult{{
+				{Result: &runtime.ExecutionResult{Err: runtime.ErrExecutionReverted, ReturnValue: returnValue}},
+				{Result: &runtime.ExecutionResult{Err: runtime.ErrOutOfGas}},
+			}}, nil
+		}
+
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: []*simulateBlockStateCall{
+				{Calls: []*txnArgs{{To: &addr1}, {To: &addr1}}},
+			},
+		}, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		blocks, ok := res.([]*simulatedBlockResult)
+		require.True(t, ok)
+
+		reverted := blocks[0].Calls[0]
+		assert.Equal(t, argUint64(types.ReceiptFailed), reverted.Status)
+		require.NotNil(t, reverted.Error)
+		assert.Equal(t, revertErrorCode, reverted.Error.Code)
+		assert.Equal(t, argBytesPtr(returnValue), reverted.Error.Data)
+
+		failed := blocks[0].Calls[1]
+		assert.Equal(t, argUint64(types.ReceiptFailed), failed.Status)
+		require.NotNil(t, failed.Error)
+		assert.Equal(t, simulatedVMErrorCode, failed.Error.Code)
+		assert.Nil(t, failed.Error.Data)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestEthEndpoint(newSimulateTestStore())
+
+		_, err := eth.SimulateV1(&SimulateOpts{}, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrNoSimulatedBlocks)
+
+		_, err = eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: make([]*simulateBlockStateCall, maxSimulatedBlocks+1),
+		}, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrTooManySimulatedBlocks)
+
+		_, err = eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: []*simulateBlockStateCall{
+				{BlockOverrides: &blockOverrides{Number: argUintPtr(100)}},
+			},
+		}, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrSimulatedBlockNumberOrder)
+
+		_, err = eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: []*simulateBlockStateCall{
+				{BlockOverrides: &blockOverrides{Time: argUintPtr(1000)}},
+			},
+		}, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrSimulatedBlockTimestampOrder)
+	})
+}
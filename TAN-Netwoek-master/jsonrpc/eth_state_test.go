@@ -801,6 +801,10 @@ func (m *mockSpecialStore) Header() *types.Header {
 	return m.block.Header
 }
 
+func (m *mockSpecialStore) FinalizedHeader() *types.Header {
+	return m.block.Header
+}
+
 func (m *mockSpecialStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	if m.block.Header.Number != num {
 		return nil, false
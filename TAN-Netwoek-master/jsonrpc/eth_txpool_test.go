@@ -83,6 +83,10 @@ func (m *mockStoreTxn) Header() *types.Header {
 	return &types.Header{}
 }
 
+func (m *mockStoreTxn) FinalizedHeader() *types.Header {
+	return &types.Header{}
+}
+
 func (m *mockStoreTxn) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	acct, ok := m.accounts[addr]
 	if !ok {
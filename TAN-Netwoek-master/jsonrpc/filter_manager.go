@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/tarality/tan-network/blockchain"
+	"github.com/tarality/tan-network/blockchain/bloombits"
+	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -217,6 +219,9 @@ type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// FinalizedHeader returns the header of the latest finalized block, as resolved by the consensus engine
+	FinalizedHeader() *types.Header
+
 	// SubscribeEvents subscribes for chain head events
 	SubscribeEvents() blockchain.Subscription
 
@@ -228,6 +233,12 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// BloomBitsSections returns the number of sections indexed by the bloom bits log index
+	BloomBitsSections() uint64
+
+	// GetBloomBits returns the bit vector of the bloom bit in the indexed section
+	GetBloomBits(bit uint, section uint64) ([]byte, error)
 }
 
 // FilterManager manages all running filters
@@ -431,28 +442,62 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 
 	logs := make([]*Log, 0)
 
-	for i := from; i <= to; i++ {
-		block, ok := f.store.GetBlockByNumber(i, true)
-		if !ok {
-			break
-		}
+	matcher := bloombits.NewMatcher(blockchain.BloomBitsBlocks, query.bloomFilters())
+	sections := f.store.BloomBitsSections()
 
-		if len(block.Transactions) == 0 {
-			// do not check logs if no txs
-			continue
+	for i := from; i <= to; {
+		section := i / blockchain.BloomBitsBlocks
+
+		if !matcher.Empty() && section < sections {
+			// use the bloom bits index to visit the candidate blocks of the section only
+			candidates, err := matcher.Match(section, f.store.GetBloomBits)
+			if err == nil {
+				sectionStart := section * blockchain.BloomBitsBlocks
+				end := common.Min(to, sectionStart+blockchain.BloomBitsBlocks-1)
+
+				for ; i <= end; i++ {
+					if !bloombits.IsSet(candidates, i-sectionStart) {
+						continue
+					}
+
+					if logs, err = f.appendLogsFromBlock(query, i, logs); err != nil {
+						return nil, err
+					}
+				}
+
+				continue
+			}
+
+			// fall back to iterating the blocks
+			f.logger.Debug("failed to match bloom bits section", "section", section, "err", err)
 		}
 
-		blockLogs, err := f.getLogsFromBlock(query, block)
-		if err != nil {
+		if logs, err = f.appendLogsFromBlock(query, i, logs); err != nil {
 			return nil, err
 		}
 
-		logs = append(logs, blockLogs...)
+		i++
 	}
 
 	return logs, nil
 }
 
+// appendLogsFromBlock appends the logs of the block matching the query
+func (f *FilterManager) appendLogsFromBlock(query *LogQuery, number uint64, logs []*Log) ([]*Log, error) {
+	block, ok := f.store.GetBlockByNumber(number, true)
+	if !ok || len(block.Transactions) == 0 {
+		// do not check logs if no txs
+		return logs, nil
+	}
+
+	blockLogs, err := f.getLogsFromBlock(query, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(logs, blockLogs...), nil
+}
+
 // GetLogsForQuery return array of logs for given query
 func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 	if query.BlockHash != nil {
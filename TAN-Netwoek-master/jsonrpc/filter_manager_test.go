@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/tarality/tan-network/blockchain"
+	"github.com/tarality/tan-network/blockchain/bloombits"
 	"github.com/tarality/tan-network/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -231,6 +232,102 @@ func Test_getLogsFromBlock(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_BloomBits(t *testing.T) {
+	t.Parallel()
+
+	address := types.StringToAddress("1")
+	matchingReceipts := []*types.Receipt{
+		{Logs: []*types.Log{{Address: address, Topics: []types.Hash{hash1}}}},
+	}
+
+	store := newMockBlockStore()
+	blocks := make([]*types.Block, 0, blockchain.BloomBitsBlocks+10)
+
+	for i := uint64(0); i < blockchain.BloomBitsBlocks+10; i++ {
+		block := &types.Block{
+			Header: &types.Header{
+				Number: i,
+				Hash:   types.StringToHash(strconv.FormatUint(i, 10)),
+			},
+			Transactions: []*types.Transaction{
+				createTestTransaction(types.StringToHash(strconv.FormatUint(i, 10))),
+			},
+		}
+
+		switch i {
+		case 10, 2000, blockchain.BloomBitsBlocks + 5:
+			block.Header.LogsBloom = types.CreateBloom(matchingReceipts)
+			store.receipts[block.Hash()] = matchingReceipts
+		case 20, blockchain.BloomBitsBlocks + 6:
+			// bloom mismatching the receipts, only found by iterating the blocks
+			store.receipts[block.Hash()] = matchingReceipts
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	store.appendBlocksToStore(blocks)
+
+	// index the first section
+	generator, err := bloombits.NewGenerator(blockchain.BloomBitsBlocks)
+	require.NoError(t, err)
+
+	for i := uint64(0); i < blockchain.BloomBitsBlocks; i++ {
+		require.NoError(t, generator.AddBloom(i, blocks[i].Header.LogsBloom))
+	}
+
+	store.bloomSections = 1
+	store.bloomBits = map[uint64]*bloombits.Generator{0: generator}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 0)
+
+	t.Cleanup(func() {
+		defer f.Close()
+	})
+
+	blockNumbers := func(query *LogQuery) []uint64 {
+		logs, err := f.GetLogsForQuery(query)
+		require.NoError(t, err)
+
+		numbers := make([]uint64, len(logs))
+		for i, log := range logs {
+			numbers[i] = uint64(log.BlockNumber)
+		}
+
+		return numbers
+	}
+
+	assert.Equal(t,
+		[]uint64{10, 2000, blockchain.BloomBitsBlocks + 5, blockchain.BloomBitsBlocks + 6},
+		blockNumbers(&LogQuery{
+			fromBlock: 0,
+			toBlock:   LatestBlockNumber,
+			Addresses: []types.Address{address},
+		}),
+	)
+
+	assert.Equal(t,
+		[]uint64{2000},
+		blockNumbers(&LogQuery{
+			fromBlock: 11,
+			toBlock:   3000,
+			Topics:    [][]types.Hash{{hash1, hash2}},
+		}),
+	)
+
+	assert.Empty(t, blockNumbers(&LogQuery{
+		fromBlock: 0,
+		toBlock:   100,
+		Addresses: []types.Address{types.StringToAddress("2")},
+	}))
+
+	// queries without criteria match every block
+	assert.Equal(t,
+		[]uint64{10, 20},
+		blockNumbers(&LogQuery{fromBlock: 0, toBlock: 100}),
+	)
+}
+
 func Test_GetLogFilterFromID(t *testing.T) {
 	t.Parallel()
 
@@ -11,6 +11,7 @@ import (
 var (
 	ErrHeaderNotFound           = errors.New("header not found")
 	ErrLatestNotFound           = errors.New("latest header not found")
+	ErrFinalizedNotFound        = errors.New("finalized header not found")
 	ErrNegativeBlockNumber      = errors.New("invalid argument 0: block number must not be negative")
 	ErrFailedFetchGenesis       = errors.New("error fetching genesis block header")
 	ErrNoDataInContractCreation = errors.New("contract creation without data provided")
@@ -18,6 +19,7 @@ var (
 
 type latestHeaderGetter interface {
 	Header() *types.Header
+	FinalizedHeader() *types.Header
 }
 
 // GetNumericBlockNumber returns block number based on current state or specified number
@@ -31,6 +33,14 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 		return latest.Number, nil
 
+	case SafeBlockNumber, FinalizedBlockNumber:
+		finalized := store.FinalizedHeader()
+		if finalized == nil {
+			return 0, ErrFinalizedNotFound
+		}
+
+		return finalized.Number, nil
+
 	case EarliestBlockNumber:
 		return 0, nil
 
@@ -45,6 +55,7 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 type headerGetter interface {
 	Header() *types.Header
+	FinalizedHeader() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 }
 
@@ -54,6 +65,14 @@ func GetBlockHeader(number BlockNumber, store headerGetter) (*types.Header, erro
 	case PendingBlockNumber, LatestBlockNumber:
 		return store.Header(), nil
 
+	case SafeBlockNumber, FinalizedBlockNumber:
+		header := store.FinalizedHeader()
+		if header == nil {
+			return nil, ErrFinalizedNotFound
+		}
+
+		return header, nil
+
 	case EarliestBlockNumber:
 		header, ok := store.GetHeaderByNumber(uint64(0))
 		if !ok {
@@ -99,6 +118,7 @@ func GetTxAndBlockByTxHash(txHash types.Hash, store txLookupAndBlockGetter) (*ty
 
 type blockGetter interface {
 	Header() *types.Header
+	FinalizedHeader() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 }
@@ -130,6 +150,7 @@ func GetHeaderFromBlockNumberOrHash(bnh BlockNumberOrHash, store blockGetter) (*
 
 type nonceGetter interface {
 	Header() *types.Header
+	FinalizedHeader() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetNonce(types.Address) uint64
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
@@ -238,6 +259,10 @@ func DecodeTxn(arg *txnArgs, blockNumber uint64, store nonceGetter) (*types.Tran
 		txn.To = arg.To
 	}
 
+	if txType == types.FeeDelegatedTx {
+		txn.FeePayer = arg.FeePayer
+	}
+
 	txn.ComputeHash(blockNumber)
 
 	return txn, nil
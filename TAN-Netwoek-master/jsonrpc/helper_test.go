@@ -139,9 +139,46 @@ func TestGetNumericBlockNumber(t *testing.T) {
 			expected: 10,
 			err:      nil,
 		},
+		{
+			name: "should return the finalized block's number if finalized is given",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() *types.Header {
+					return &types.Header{
+						Number: 7,
+					}
+				},
+			},
+			expected: 7,
+			err:      nil,
+		},
+		{
+			name: "should return the finalized block's number if safe is given",
+			num:  SafeBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() *types.Header {
+					return &types.Header{
+						Number: 7,
+					}
+				},
+			},
+			expected: 7,
+			err:      nil,
+		},
+		{
+			name: "should return error if finalized block is not found",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() *types.Header {
+					return nil
+				},
+			},
+			expected: 0,
+			err:      ErrFinalizedNotFound,
+		},
 		{
 			name:     "should return error if negative number is given",
-			num:      -5,
+			num:      -10,
 			store:    &debugEndpointMockStore{},
 			expected: 0,
 			err:      ErrNegativeBlockNumber,
@@ -227,6 +264,28 @@ func TestGetBlockHeader(t *testing.T) {
 			expected: testLatestHeader,
 			err:      nil,
 		},
+		{
+			name: "should return finalized header if finalized is given",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() *types.Header {
+					return testHeader10
+				},
+			},
+			expected: testHeader10,
+			err:      nil,
+		},
+		{
+			name: "should return error if finalized header not found",
+			num:  SafeBlockNumber,
+			store: &debugEndpointMockStore{
+				finalizedHeaderFn: func() *types.Header {
+					return nil
+				},
+			},
+			expected: nil,
+			err:      ErrFinalizedNotFound,
+		},
 		{
 			name: "should return header at arbitrary height",
 			num:  10,
@@ -56,7 +56,9 @@ type JSONRPCStore interface {
 	txPoolStore
 	filterManagerStore
 	bridgeStore
+	validatorStore
 	debugStore
+	tanStore
 }
 
 type Config struct {
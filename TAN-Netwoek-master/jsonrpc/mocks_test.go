@@ -124,6 +124,10 @@ func (m *mockStore) Header() *types.Header {
 	return m.header
 }
 
+func (m *mockStore) FinalizedHeader() *types.Header {
+	return m.header
+}
+
 func (m *mockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
 	m.receiptsLock.Lock()
 	defer m.receiptsLock.Unlock()
@@ -161,6 +165,14 @@ func (m *mockStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 	return &types.Block{Header: header}, header != nil
 }
 
+func (m *mockStore) BloomBitsSections() uint64 {
+	return 0
+}
+
+func (m *mockStore) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	return nil, blockchain.ErrBloomBitsNotIndexed
+}
+
 func (m *mockStore) GetTxs(inclQueued bool) (
 	map[types.Address][]*types.Transaction,
 	map[types.Address][]*types.Transaction,
@@ -197,6 +209,37 @@ func (m *mockStore) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return ssp, nil
 }
 
+func (m *mockStore) GetHeaderProof(fromEpoch, toEpoch uint64) ([]*types.Header, error) {
+	headers := make([]*types.Header, 0, toEpoch-fromEpoch)
+	for epoch := fromEpoch; epoch < toEpoch; epoch++ {
+		headers = append(headers, (&types.Header{Number: epoch * 10}).ComputeHash())
+	}
+
+	return headers, nil
+}
+
+func (m *mockStore) GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error) {
+	return []*types.ValidatorUptime{
+		{
+			Address: types.StringToAddress("1"),
+			Blocks:  blocks,
+			Signed:  blocks - 1,
+			Missed:  1,
+		},
+	}, nil
+}
+
+func (m *mockStore) GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	return []*types.ValidatorUptime{
+		{
+			Address:  types.StringToAddress("1"),
+			Blocks:   10,
+			Signed:   10,
+			Proposed: 2,
+		},
+	}, nil
+}
+
 func (m *mockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
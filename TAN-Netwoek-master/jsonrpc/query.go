@@ -213,3 +213,27 @@ func (q *LogQuery) Match(log *types.Log) bool {
 
 	return true
 }
+
+// bloomFilters returns the query criteria as bloom bits matcher filters:
+// the addresses followed by the topics of every position
+func (q *LogQuery) bloomFilters() [][][]byte {
+	filters := make([][][]byte, 0, len(q.Topics)+1)
+
+	addresses := make([][]byte, len(q.Addresses))
+	for i, addr := range q.Addresses {
+		addresses[i] = addr.Bytes()
+	}
+
+	filters = append(filters, addresses)
+
+	for _, sub := range q.Topics {
+		topics := make([][]byte, len(sub))
+		for i, topic := range sub {
+			topics[i] = topic.Bytes()
+		}
+
+		filters = append(filters, topics)
+	}
+
+	return filters
+}
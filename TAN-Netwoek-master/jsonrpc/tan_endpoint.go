@@ -0,0 +1,382 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/helper/common"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	// defaultAddressTxsLimit is the default page size of tan_getTransactionsByAddress
+	defaultAddressTxsLimit uint64 = 100
+	// maxAddressTxsLimit is the maximum page size of tan_getTransactionsByAddress
+	maxAddressTxsLimit uint64 = 1000
+
+	// addressTxCursorSize is the size of the pagination cursor: block number (8) + tx index (4) + tx hash (32)
+	addressTxCursorSize = 8 + 4 + types.HashLength
+)
+
+var (
+	ErrInvalidAddressTxsDirection = errors.New(`invalid direction, must be one of "all", "sent" or "received"`)
+	ErrInvalidAddressTxsOrder     = errors.New(`invalid order, must be one of "asc" or "desc"`)
+	ErrInvalidAddressTxsCursor    = errors.New("invalid cursor")
+	ErrAddressTxsLimitExceeded    = fmt.Errorf("limit must not exceed %d", maxAddressTxsLimit)
+)
+
+// tanStore provides access to the methods needed by tan endpoint
+type tanStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// FinalizedHeader returns the header of the latest finalized block, as resolved by the consensus engine
+	FinalizedHeader() *types.Header
+
+	// GetAddressTxs calls the handler for the canonical transactions of the address within the block range
+	GetAddressTxs(addr types.Address, from, to uint64, reverse bool, handler func(*storage.AddressTx) bool) error
+
+	// GetHeaderByNumber gets a header using the provided number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetReceiptsByHash returns the receipts for a block hash
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// GetBaseFeeRecipients returns the recipients of the base fee of the block,
+	// or nil if the base fee isn't charged in the block
+	GetBaseFeeRecipients(header *types.Header) (*BaseFeeRecipients, error)
+}
+
+// BaseFeeRecipients are the recipients of the base fee of a block and their percentages
+type BaseFeeRecipients struct {
+	forkmanager.BaseFeeDistribution
+
+	BurnContract types.Address
+	Treasury     types.Address
+	Proposer     types.Address
+}
+
+// Tan is the tan jsonrpc endpoint, exposing the TAN Network specific queries
+type Tan struct {
+	store tanStore
+}
+
+// AddressTxsQuery is the query of tan_getTransactionsByAddress
+type AddressTxsQuery struct {
+	FromBlock *BlockNumber `json:"fromBlock"`
+	ToBlock   *BlockNumber `json:"toBlock"`
+	// Direction is one of "all" (default), "sent" or "received"
+	Direction string `json:"direction"`
+	// Order is one of "asc" (default) or "desc"
+	Order  string     `json:"order"`
+	Limit  *argUint64 `json:"limit"`
+	Cursor *argBytes  `json:"cursor"`
+}
+
+type addressTx struct {
+	BlockNumber argUint64  `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	TxIndex     argUint64  `json:"transactionIndex"`
+	Hash        types.Hash `json:"hash"`
+	Relations   []string   `json:"relations"`
+}
+
+type addressTxsResult struct {
+	Transactions []*addressTx `json:"transactions"`
+	// NextCursor is set if there are more transactions to fetch
+	NextCursor *argBytes `json:"nextCursor"`
+}
+
+var addressTxRelations = []struct {
+	kind storage.AddressTxKind
+	name string
+}{
+	{storage.AddressTxSender, "sender"},
+	{storage.AddressTxRecipient, "recipient"},
+	{storage.AddressTxCreation, "creation"},
+	{storage.AddressTxInternalSender, "internalSender"},
+	{storage.AddressTxInternalRecipient, "internalRecipient"},
+}
+
+type baseFeeDistribution struct {
+	BlockNumber    argUint64     `json:"blockNumber"`
+	BlockHash      types.Hash    `json:"blockHash"`
+	BaseFee        argUint64     `json:"baseFee"`
+	BurnContract   types.Address `json:"burnContract"`
+	Burnt          argBig        `json:"burnt"`
+	Treasury       types.Address `json:"treasury"`
+	TreasuryAmount argBig        `json:"treasuryAmount"`
+	Proposer       types.Address `json:"proposer"`
+	ProposerAmount argBig        `json:"proposerAmount"`
+}
+
+// GetBaseFeeDistribution returns the amounts of the base fee of the block paid to each destination,
+// or nil if the base fee isn't charged in the block
+func (t *Tan) GetBaseFeeDistribution(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := t.store.GetBaseFeeRecipients(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipients == nil {
+		return nil, nil
+	}
+
+	receipts, err := t.store.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	burnt, treasury, proposer := big.NewInt(0), big.NewInt(0), big.NewInt(0)
+
+	// the base fee is split per transaction, so are the amounts
+	for _, receipt := range receipts {
+		baseFeePaid := receipt.BaseFeePaid
+		if baseFeePaid == nil {
+			// the receipts stored before the base fee paid was kept
+			if receipt.TransactionType == types.StateTx {
+				continue
+			}
+
+			baseFeePaid = new(big.Int).Mul(
+				new(big.Int).SetUint64(receipt.GasUsed),
+				new(big.Int).SetUint64(header.BaseFee),
+			)
+		}
+
+		burntPart, treasuryPart, proposerPart := recipients.Split(baseFeePaid)
+
+		burnt.Add(burnt, burntPart)
+		treasury.Add(treasury, treasuryPart)
+		proposer.Add(proposer, proposerPart)
+	}
+
+	return &baseFeeDistribution{
+		BlockNumber:    argUint64(header.Number),
+		BlockHash:      header.Hash,
+		BaseFee:        argUint64(header.BaseFee),
+		BurnContract:   recipients.BurnContract,
+		Burnt:          argBig(*burnt),
+		Treasury:       recipients.Treasury,
+		TreasuryAmount: argBig(*treasury),
+		Proposer:       recipients.Proposer,
+		ProposerAmount: argBig(*proposer),
+	}, nil
+}
+
+// GetTransactionsByAddress returns a page of the transactions sent from or to the address
+func (t *Tan) GetTransactionsByAddress(address types.Address, query *AddressTxsQuery) (interface{}, error) {
+	if query == nil {
+		query = &AddressTxsQuery{}
+	}
+
+	kinds, err := query.directionKinds()
+	if err != nil {
+		return nil, err
+	}
+
+	reverse, err := query.reverse()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := defaultAddressTxsLimit
+	if query.Limit != nil && *query.Limit != 0 {
+		limit = uint64(*query.Limit)
+	}
+
+	if limit > maxAddressTxsLimit {
+		return nil, ErrAddressTxsLimitExceeded
+	}
+
+	from, to, err := query.blockRange(t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor *storage.AddressTx
+
+	if query.Cursor != nil {
+		if cursor, err = decodeAddressTxCursor(*query.Cursor); err != nil {
+			return nil, err
+		}
+
+		// continue from the block of the cursor
+		if reverse {
+			to = common.Min(to, cursor.BlockNumber)
+		} else {
+			from = common.Max(from, cursor.BlockNumber)
+		}
+	}
+
+	result := &addressTxsResult{
+		Transactions: make([]*addressTx, 0),
+	}
+
+	var last *storage.AddressTx
+
+	if err := t.store.GetAddressTxs(address, from, to, reverse, func(tx *storage.AddressTx) bool {
+		if cursor != nil && !isAfterCursor(tx, cursor, reverse) {
+			return true
+		}
+
+		if !tx.Has(kinds) {
+			return true
+		}
+
+		if uint64(len(result.Transactions)) == limit {
+			// there are more entries, return the cursor of the last one
+			result.NextCursor = encodeAddressTxCursor(last)
+
+			return false
+		}
+
+		result.Transactions = append(result.Transactions, toAddressTx(tx))
+		last = tx
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// directionKinds returns the address relations matching the query direction
+func (q *AddressTxsQuery) directionKinds() (storage.AddressTxKind, error) {
+	sent := storage.AddressTxSender | storage.AddressTxInternalSender
+	received := storage.AddressTxRecipient | storage.AddressTxCreation | storage.AddressTxInternalRecipient
+
+	switch q.Direction {
+	case "", "all":
+		return sent | received, nil
+	case "sent":
+		return sent, nil
+	case "received":
+		return received, nil
+	default:
+		return 0, ErrInvalidAddressTxsDirection
+	}
+}
+
+// reverse returns true if the transactions should be returned from the newest to the oldest
+func (q *AddressTxsQuery) reverse() (bool, error) {
+	switch q.Order {
+	case "", "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	default:
+		return false, ErrInvalidAddressTxsOrder
+	}
+}
+
+// blockRange resolves the block range of the query, which defaults to [earliest, latest]
+func (q *AddressTxsQuery) blockRange(store latestHeaderGetter) (uint64, uint64, error) {
+	var (
+		from uint64
+		err  error
+	)
+
+	if q.FromBlock != nil {
+		if from, err = GetNumericBlockNumber(*q.FromBlock, store); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	toBlock := LatestBlockNumber
+	if q.ToBlock != nil {
+		toBlock = *q.ToBlock
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, store)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from > to {
+		return 0, 0, ErrIncorrectBlockRange
+	}
+
+	return from, to, nil
+}
+
+// isAfterCursor checks if the entry comes after the cursor in the iteration order
+func isAfterCursor(tx, cursor *storage.AddressTx, reverse bool) bool {
+	cmp := compareAddressTx(tx, cursor)
+	if reverse {
+		return cmp < 0
+	}
+
+	return cmp > 0
+}
+
+// compareAddressTx compares the index entries by their position in the chain
+func compareAddressTx(a, b *storage.AddressTx) int {
+	switch {
+	case a.BlockNumber < b.BlockNumber:
+		return -1
+	case a.BlockNumber > b.BlockNumber:
+		return 1
+	case a.TxIndex < b.TxIndex:
+		return -1
+	case a.TxIndex > b.TxIndex:
+		return 1
+	default:
+		return bytes.Compare(a.TxHash.Bytes(), b.TxHash.Bytes())
+	}
+}
+
+// encodeAddressTxCursor encodes the position of the entry as the pagination cursor
+func encodeAddressTxCursor(tx *storage.AddressTx) *argBytes {
+	cursor := make([]byte, 0, addressTxCursorSize)
+	cursor = binary.BigEndian.AppendUint64(cursor, tx.BlockNumber)
+	cursor = binary.BigEndian.AppendUint32(cursor, tx.TxIndex)
+	cursor = append(cursor, tx.TxHash.Bytes()...)
+
+	return argBytesPtr(cursor)
+}
+
+// decodeAddressTxCursor decodes the pagination cursor
+func decodeAddressTxCursor(cursor argBytes) (*storage.AddressTx, error) {
+	if len(cursor) != addressTxCursorSize {
+		return nil, ErrInvalidAddressTxsCursor
+	}
+
+	return &storage.AddressTx{
+		BlockNumber: binary.BigEndian.Uint64(cursor[:8]),
+		TxIndex:     binary.BigEndian.Uint32(cursor[8:12]),
+		TxHash:      types.BytesToHash(cursor[12:]),
+	}, nil
+}
+
+func toAddressTx(tx *storage.AddressTx) *addressTx {
+	relations := make([]string, 0, 1)
+
+	for _, relation := range addressTxRelations {
+		if tx.Has(relation.kind) {
+			relations = append(relations, relation.name)
+		}
+	}
+
+	return &addressTx{
+		BlockNumber: argUint64(tx.BlockNumber),
+		BlockHash:   tx.BlockHash,
+		TxIndex:     argUint64(tx.TxIndex),
+		Hash:        tx.TxHash,
+		Relations:   relations,
+	}
+}
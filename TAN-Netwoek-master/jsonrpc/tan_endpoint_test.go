@@ -0,0 +1,268 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/blockchain/storage"
+	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/types"
+)
+
+type tanEndpointMockStore struct {
+	header *types.Header
+	txs    map[types.Address][]*storage.AddressTx
+
+	receipts   []*types.Receipt
+	recipients *BaseFeeRecipients
+}
+
+func (s *tanEndpointMockStore) Header() *types.Header {
+	return s.header
+}
+
+func (s *tanEndpointMockStore) FinalizedHeader() *types.Header {
+	return s.header
+}
+
+func (s *tanEndpointMockStore) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	if number != s.header.Number {
+		return nil, false
+	}
+
+	return s.header, true
+}
+
+func (s *tanEndpointMockStore) GetBlockByHash(hash types.Hash, _ bool) (*types.Block, bool) {
+	if hash != s.header.Hash {
+		return nil, false
+	}
+
+	return &types.Block{Header: s.header}, true
+}
+
+func (s *tanEndpointMockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	if hash != s.header.Hash {
+		return nil, nil
+	}
+
+	return s.receipts, nil
+}
+
+func (s *tanEndpointMockStore) GetBaseFeeRecipients(*types.Header) (*BaseFeeRecipients, error) {
+	return s.recipients, nil
+}
+
+func (s *tanEndpointMockStore) GetAddressTxs(
+	addr types.Address, from, to uint64, reverse bool, handler func(*storage.AddressTx) bool) error {
+	txs := make([]*storage.AddressTx, 0)
+
+	for _, tx := range s.txs[addr] {
+		if tx.BlockNumber >= from && tx.BlockNumber <= to {
+			txs = append(txs, tx)
+		}
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if reverse {
+			return compareAddressTx(txs[i], txs[j]) > 0
+		}
+
+		return compareAddressTx(txs[i], txs[j]) < 0
+	})
+
+	for _, tx := range txs {
+		if !handler(tx) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func newTanEndpointMockStore(addr types.Address) *tanEndpointMockStore {
+	txs := make([]*storage.AddressTx, 0, 10)
+
+	for i := uint64(1); i <= 5; i++ {
+		txs = append(txs,
+			&storage.AddressTx{
+				BlockNumber: i, TxIndex: 0, TxHash: types.BytesToHash([]byte{byte(i), 0}), Kind: storage.AddressTxSender,
+			},
+			&storage.AddressTx{
+				BlockNumber: i, TxIndex: 1, TxHash: types.BytesToHash([]byte{byte(i), 1}), Kind: storage.AddressTxRecipient,
+			},
+		)
+	}
+
+	return &tanEndpointMockStore{
+		header: &types.Header{Number: 5},
+		txs:    map[types.Address][]*storage.AddressTx{addr: txs},
+	}
+}
+
+func collectTxHashes(t *testing.T, res interface{}) ([]types.Hash, *argBytes) {
+	t.Helper()
+
+	result, ok := res.(*addressTxsResult)
+	require.True(t, ok)
+
+	hashes := make([]types.Hash, len(result.Transactions))
+	for i, tx := range result.Transactions {
+		hashes[i] = tx.Hash
+	}
+
+	return hashes, result.NextCursor
+}
+
+func TestTanEndpoint_GetTransactionsByAddress(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	endpoint := &Tan{store: newTanEndpointMockStore(addr)}
+	hash := func(number, index byte) types.Hash {
+		return types.BytesToHash([]byte{number, index})
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetTransactionsByAddress(addr, nil)
+		require.NoError(t, err)
+
+		hashes, cursor := collectTxHashes(t, res)
+		assert.Len(t, hashes, 10)
+		assert.Equal(t, hash(1, 0), hashes[0])
+		assert.Nil(t, cursor)
+
+		result, _ := res.(*addressTxsResult)
+		assert.Equal(t, []string{"sender"}, result.Transactions[0].Relations)
+		assert.Equal(t, []string{"recipient"}, result.Transactions[1].Relations)
+	})
+
+	t.Run("direction and range", func(t *testing.T) {
+		t.Parallel()
+
+		from, to := BlockNumber(2), BlockNumber(3)
+
+		res, err := endpoint.GetTransactionsByAddress(addr, &AddressTxsQuery{
+			FromBlock: &from,
+			ToBlock:   &to,
+			Direction: "received",
+			Order:     "desc",
+		})
+		require.NoError(t, err)
+
+		hashes, _ := collectTxHashes(t, res)
+		assert.Equal(t, []types.Hash{hash(3, 1), hash(2, 1)}, hashes)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		t.Parallel()
+
+		for _, order := range []string{"asc", "desc"} {
+			limit := argUint64(3)
+			query := &AddressTxsQuery{Order: order, Limit: &limit}
+			all := make([]types.Hash, 0, 10)
+
+			for {
+				res, err := endpoint.GetTransactionsByAddress(addr, query)
+				require.NoError(t, err)
+
+				hashes, cursor := collectTxHashes(t, res)
+				all = append(all, hashes...)
+
+				if cursor == nil {
+					break
+				}
+
+				query.Cursor = cursor
+			}
+
+			require.Len(t, all, 10)
+
+			if order == "asc" {
+				assert.Equal(t, hash(1, 0), all[0])
+				assert.Equal(t, hash(5, 1), all[9])
+			} else {
+				assert.Equal(t, hash(5, 1), all[0])
+				assert.Equal(t, hash(1, 0), all[9])
+			}
+		}
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		limit := argUint64(maxAddressTxsLimit + 1)
+		from, to := BlockNumber(4), BlockNumber(3)
+
+		for query, expectedErr := range map[*AddressTxsQuery]error{
+			{Direction: "up"}:                ErrInvalidAddressTxsDirection,
+			{Order: "random"}:                ErrInvalidAddressTxsOrder,
+			{Limit: &limit}:                  ErrAddressTxsLimitExceeded,
+			{Cursor: argBytesPtr([]byte{1})}: ErrInvalidAddressTxsCursor,
+			{FromBlock: &from, ToBlock: &to}: ErrIncorrectBlockRange,
+		} {
+			_, err := endpoint.GetTransactionsByAddress(addr, query)
+			assert.ErrorIs(t, err, expectedErr)
+		}
+	})
+}
+
+func TestTanEndpoint_GetBaseFeeDistribution(t *testing.T) {
+	t.Parallel()
+
+	recipients := &BaseFeeRecipients{
+		BaseFeeDistribution: forkmanager.BaseFeeDistribution{
+			BurnPercent:     50,
+			TreasuryPercent: 30,
+			ProposerPercent: 20,
+		},
+		BurnContract: types.StringToAddress("1"),
+		Treasury:     types.StringToAddress("2"),
+		Proposer:     types.StringToAddress("3"),
+	}
+
+	store := &tanEndpointMockStore{
+		header: &types.Header{Number: 5, Hash: types.StringToHash("5"), BaseFee: 10},
+		receipts: []*types.Receipt{
+			{GasUsed: 21000, BaseFeePaid: big.NewInt(210001)},
+			// the base fee of the receipts stored without it is derived from the gas used
+			{GasUsed: 100},
+			{GasUsed: 100, TransactionType: types.StateTx},
+		},
+		recipients: recipients,
+	}
+
+	endpoint := &Tan{store: store}
+
+	t.Run("splits the base fee by transaction", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetBaseFeeDistribution(BlockNumberOrHash{BlockHash: &store.header.Hash})
+		require.NoError(t, err)
+
+		distribution, ok := res.(*baseFeeDistribution)
+		require.True(t, ok)
+
+		assert.Equal(t, argUint64(5), distribution.BlockNumber)
+		assert.Equal(t, recipients.Treasury, distribution.Treasury)
+		assert.Equal(t, big.NewInt(105000+500), (*big.Int)(&distribution.Burnt))
+		assert.Equal(t, big.NewInt(63000+300), (*big.Int)(&distribution.TreasuryAmount))
+		// the rounding remainder goes to the proposer
+		assert.Equal(t, big.NewInt(42001+200), (*big.Int)(&distribution.ProposerAmount))
+	})
+
+	t.Run("the base fee isn't charged", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Tan{store: &tanEndpointMockStore{header: store.header}}
+
+		res, err := endpoint.GetBaseFeeDistribution(BlockNumberOrHash{})
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
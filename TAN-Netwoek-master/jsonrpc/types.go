@@ -35,6 +35,10 @@ type transaction struct {
 	TxIndex     *argUint64     `json:"transactionIndex"`
 	ChainID     *argBig        `json:"chainID,omitempty"`
 	Type        argUint64      `json:"type"`
+	FeePayer    *types.Address `json:"feePayer,omitempty"`
+	FeePayerV   *argBig        `json:"feePayerV,omitempty"`
+	FeePayerR   *argBig        `json:"feePayerR,omitempty"`
+	FeePayerS   *argBig        `json:"feePayerS,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -98,6 +102,15 @@ func toTransaction(
 		res.TxIndex = argUintPtr(uint64(*txIndex))
 	}
 
+	if t.Type == types.FeeDelegatedTx {
+		res.FeePayer = t.FeePayer
+
+		if t.FeePayerV != nil && t.FeePayerR != nil && t.FeePayerS != nil {
+			feePayerV, feePayerR, feePayerS := argBig(*t.FeePayerV), argBig(*t.FeePayerR), argBig(*t.FeePayerS)
+			res.FeePayerV, res.FeePayerR, res.FeePayerS = &feePayerV, &feePayerR, &feePayerS
+		}
+	}
+
 	return res
 }
 
@@ -210,6 +223,7 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	RevertReason      *argBytes      `json:"revertReason,omitempty"`
 }
 
 type Log struct {
@@ -346,6 +360,7 @@ type txnArgs struct {
 	Input     *argBytes
 	Nonce     *argUint64
 	Type      *argUint64
+	FeePayer  *types.Address
 }
 
 type progression struct {
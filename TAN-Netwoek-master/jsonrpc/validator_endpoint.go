@@ -0,0 +1,80 @@
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/tarality/tan-network/types"
+)
+
+// defaultUptimeBlocks is the number of the latest blocks used by validator_getUptime if not specified
+const defaultUptimeBlocks uint64 = 100
+
+var ErrUptimeNotSupported = errors.New("validators uptime is not supported by the consensus")
+
+// validatorStore interface provides access to the methods needed by validator endpoint
+type validatorStore interface {
+	// GetValidatorsUptime returns signing statistics of validators for the given number of the latest blocks
+	GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error)
+
+	// GetEpochUptime returns signing statistics of validators for the given epoch
+	GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error)
+}
+
+// Validator is the validator jsonrpc endpoint
+type Validator struct {
+	store validatorStore
+}
+
+// ValidatorUptime is the signing and proposing statistics of a validator
+type ValidatorUptime struct {
+	Address             types.Address `json:"address"`
+	Blocks              argUint64     `json:"blocks"`
+	Signed              argUint64     `json:"signed"`
+	Missed              argUint64     `json:"missed"`
+	Proposed            argUint64     `json:"proposed"`
+	LongestMissedStreak argUint64     `json:"longestMissedStreak"`
+	CurrentMissedStreak argUint64     `json:"currentMissedStreak"`
+}
+
+// GetUptime returns signing statistics of validators for the given number of the latest blocks
+func (v *Validator) GetUptime(blocks *argUint64) (interface{}, error) {
+	count := defaultUptimeBlocks
+	if blocks != nil {
+		count = uint64(*blocks)
+	}
+
+	uptime, err := v.store.GetValidatorsUptime(count)
+	if err != nil {
+		return nil, err
+	}
+
+	return toValidatorsUptime(uptime), nil
+}
+
+// GetEpochUptime returns signing statistics of validators for the given epoch
+func (v *Validator) GetEpochUptime(epoch argUint64) (interface{}, error) {
+	uptime, err := v.store.GetEpochUptime(uint64(epoch))
+	if err != nil {
+		return nil, err
+	}
+
+	return toValidatorsUptime(uptime), nil
+}
+
+func toValidatorsUptime(uptime []*types.ValidatorUptime) []*ValidatorUptime {
+	res := make([]*ValidatorUptime, len(uptime))
+
+	for i, u := range uptime {
+		res[i] = &ValidatorUptime{
+			Address:             u.Address,
+			Blocks:              argUint64(u.Blocks),
+			Signed:              argUint64(u.Signed),
+			Missed:              argUint64(u.Missed),
+			Proposed:            argUint64(u.Proposed),
+			LongestMissedStreak: argUint64(u.LongestMissedStreak),
+			CurrentMissedStreak: argUint64(u.CurrentMissedStreak),
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,80 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestValidatorEndpoint(t *testing.T) {
+	store := newMockStore()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	msg := []byte(`{
+		"method": "validator_getUptime",
+		"params": ["0x20"],
+		"id": 1
+	}`)
+
+	data, err := dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp := new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var uptime []*ValidatorUptime
+
+	require.NoError(t, json.Unmarshal(resp.Result, &uptime))
+	require.Len(t, uptime, 1)
+	require.Equal(t, types.StringToAddress("1"), uptime[0].Address)
+	require.Equal(t, argUint64(0x20), uptime[0].Blocks)
+	require.Equal(t, argUint64(0x1f), uptime[0].Signed)
+	require.Equal(t, argUint64(1), uptime[0].Missed)
+
+	msg = []byte(`{
+		"method": "validator_getUptime",
+		"params": [],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.NoError(t, json.Unmarshal(resp.Result, &uptime))
+	require.Equal(t, argUint64(defaultUptimeBlocks), uptime[0].Blocks)
+
+	msg = []byte(`{
+		"method": "validator_getEpochUptime",
+		"params": ["0x1"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection)
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.NoError(t, json.Unmarshal(resp.Result, &uptime))
+	require.Len(t, uptime, 1)
+	require.Equal(t, argUint64(2), uptime[0].Proposed)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -20,6 +21,14 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+	PrivatePeerIDs   []peer.ID              // the peers that are never advertised through discovery (sentry mode)
+	Sentries         []*peer.AddrInfo       // the only peers a validator behind sentries connects to
+}
+
+// isSentryProtected checks if the node runs behind sentries,
+// in which case it connects only to the configured sentry nodes
+func (c *Config) isSentryProtected() bool {
+	return len(c.Sentries) > 0
 }
 
 func DefaultConfig() *Config {
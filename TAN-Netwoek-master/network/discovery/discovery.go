@@ -67,6 +67,9 @@ type networkingServer interface {
 	// GetRandomPeer fetches a random peer from the server's peer store
 	GetRandomPeer() *peer.ID
 
+	// IsPrivatePeer checks if the peer must not be advertised to other peers [Thread safe]
+	IsPrivatePeer(peerID peer.ID) bool
+
 	// TEMPORARY DIALING //
 
 	// FetchOrSetTemporaryDial checks if the peer connection is a temporary dial,
@@ -137,6 +140,12 @@ func (d *DiscoveryService) HandleNetworkEvent(peerEvent *event.PeerEvent) {
 
 	switch peerEvent.Type {
 	case event.PeerConnected:
+		if d.baseServer.IsPrivatePeer(peerID) {
+			// Private peers are never part of the routing table,
+			// so they are not handed out to other peers
+			return
+		}
+
 		// Add peer to the routing table and to our local peer table
 		_, err := d.routingTable.TryAddPeer(peerID, false, false)
 		if err != nil {
@@ -303,6 +312,12 @@ func (d *DiscoveryService) regularPeerDiscovery() {
 		return
 	}
 
+	if d.baseServer.IsPrivatePeer(*peerID) {
+		// Private peers (validators behind sentries)
+		// do not run the discovery service
+		return
+	}
+
 	d.logger.Debug("running regular peer discovery", "peer", peerID.String())
 	// Try to discover the peers connected to the reference peer
 	if err := d.attemptToFindPeers(*peerID); err != nil {
@@ -418,8 +433,9 @@ func (d *DiscoveryService) FindPeers(
 	filteredPeers := make([]string, 0)
 
 	for _, id := range nearestPeers {
-		if id == from {
-			// Skip the peer that's initializing the request
+		if id == from || d.baseServer.IsPrivatePeer(id) {
+			// Skip the peer that's initializing the request,
+			// as well as the peers that must stay hidden
 			continue
 		}
 
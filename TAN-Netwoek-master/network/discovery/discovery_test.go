@@ -8,6 +8,8 @@ import (
 
 	"github.com/tarality/tan-network/helper/tests"
 	"github.com/tarality/tan-network/network/common"
+	"github.com/tarality/tan-network/network/event"
+	grpcPeer "github.com/tarality/tan-network/network/grpc"
 	"github.com/tarality/tan-network/network/proto"
 	networkTesting "github.com/tarality/tan-network/network/testing"
 	"github.com/hashicorp/go-hclog"
@@ -301,3 +303,63 @@ func TestDiscoveryService_RegularPeerDiscoveryUnconnected(t *testing.T) {
 	// Make sure that no peers were added to the peer store
 	assert.Len(t, peerStore, 0)
 }
+
+// TestDiscoveryService_PrivatePeers makes sure private peers
+// are never added to the routing table, nor handed out to other peers
+func TestDiscoveryService_PrivatePeers(t *testing.T) {
+	randomPeers := getRandomPeers(t, 2)
+	publicPeer, privatePeer := randomPeers[0], randomPeers[1]
+	requesterID := peer.ID("Requester")
+
+	// Create an instance of the discovery service
+	discoveryService, setupErr := newDiscoveryService(
+		// Set the relevant hook responses from the mock server
+		func(server *networkTesting.MockNetworkingServer) {
+			// Define the private peer hook
+			server.HookIsPrivatePeer(func(id peer.ID) bool {
+				return id == privatePeer.ID
+			})
+
+			// Define the peer info hook
+			server.HookGetPeerInfo(func(id peer.ID) *peer.AddrInfo {
+				for _, info := range randomPeers {
+					if info.ID == id {
+						return info
+					}
+				}
+
+				return &peer.AddrInfo{ID: id}
+			})
+		},
+	)
+	if setupErr != nil {
+		t.Fatalf("Unable to setup the discovery service")
+	}
+
+	for _, info := range randomPeers {
+		discoveryService.HandleNetworkEvent(&event.PeerEvent{
+			PeerID: info.ID,
+			Type:   event.PeerConnected,
+		})
+	}
+
+	// Make sure only the public peer is in the routing table
+	assert.Equal(t, []peer.ID{publicPeer.ID}, discoveryService.RoutingTablePeers())
+
+	resp, err := discoveryService.FindPeers(
+		&grpcPeer.Context{
+			Context: context.Background(),
+			PeerID:  requesterID,
+		},
+		&proto.FindPeersReq{
+			Count: maxDiscoveryPeerReqCount,
+		},
+	)
+	assert.NoError(t, err)
+
+	publicAddr, err := common.AddrInfoToString(publicPeer)
+	assert.NoError(t, err)
+
+	// Make sure the private peer is not advertised
+	assert.Equal(t, []string{publicAddr}, resp.Nodes)
+}
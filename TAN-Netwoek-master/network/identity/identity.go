@@ -19,6 +19,7 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrPeerNotAllowed   = errors.New("peer not allowed")
 )
 
 // networkingServer defines the base communication interface between
@@ -48,6 +49,9 @@ type networkingServer interface {
 	// IsTemporaryDial checks if the peer connection is a temporary dial [Thread safe]
 	IsTemporaryDial(peerID peer.ID) bool
 
+	// IsAllowedPeer checks if a connection with the peer is allowed [Thread safe]
+	IsAllowedPeer(peerID peer.ID) bool
+
 	// CONNECTION INFORMATION //
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
@@ -160,6 +164,11 @@ func (i *IdentityService) disconnectFromPeer(peerID peer.ID, reason string) {
 
 // handleConnected handles new network connections (handshakes)
 func (i *IdentityService) handleConnected(peerID peer.ID, direction network.Direction) error {
+	// A validator behind sentries refuses everything but its sentry nodes
+	if !i.baseServer.IsAllowedPeer(peerID) {
+		return ErrPeerNotAllowed
+	}
+
 	clt, clientErr := i.baseServer.NewIdentityClient(peerID)
 	if clientErr != nil {
 		return fmt.Errorf(
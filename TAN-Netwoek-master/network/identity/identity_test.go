@@ -122,3 +122,56 @@ func TestHandshake_Errors(t *testing.T) {
 	// Make sure no peers have been  added to the base networking server
 	assert.Len(t, peersArray, 0)
 }
+
+// TestHandshake_PeerNotAllowed makes sure a validator behind sentries
+// refuses connections from peers other than its sentries
+func TestHandshake_PeerNotAllowed(t *testing.T) {
+	peersArray := make([]peer.ID, 0)
+	helloCalled := false
+
+	// Create an instance of the identity service
+	identityService := newIdentityService(
+		// Set the relevant hook responses from the mock server
+		func(server *networkTesting.MockNetworkingServer) {
+			// Define the allowed peer hook
+			server.HookIsAllowedPeer(func(peerID peer.ID) bool {
+				return peerID == "SentryPeer"
+			})
+
+			// Define the add peer hook
+			server.HookAddPeer(func(
+				id peer.ID,
+				direction network.Direction,
+			) {
+				peersArray = append(peersArray, id)
+			})
+
+			// Define the mock IdentityClient response
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				helloCalled = true
+
+				return &proto.Status{}, nil
+			})
+		},
+	)
+
+	// Check that the handshake with a non-sentry peer is refused
+	assert.ErrorIs(
+		t,
+		identityService.handleConnected("TestPeer", network.DirInbound),
+		ErrPeerNotAllowed,
+	)
+	assert.False(t, helloCalled)
+	assert.Len(t, peersArray, 0)
+
+	// Check that the sentry peer is accepted
+	assert.NoError(
+		t,
+		identityService.handleConnected("SentryPeer", network.DirOutbound),
+	)
+	assert.Equal(t, []peer.ID{"SentryPeer"}, peersArray)
+}
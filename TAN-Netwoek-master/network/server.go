@@ -88,6 +88,9 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	privatePeers map[peer.ID]struct{}       // peers that are never advertised through discovery
+	sentries     map[peer.ID]*peer.AddrInfo // the only peers allowed when running behind sentries
 }
 
 // NewServer returns a new instance of the networking server
@@ -154,6 +157,16 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		privatePeers: make(map[peer.ID]struct{}, len(config.PrivatePeerIDs)),
+		sentries:     make(map[peer.ID]*peer.AddrInfo, len(config.Sentries)),
+	}
+
+	for _, id := range config.PrivatePeerIDs {
+		srv.privatePeers[id] = struct{}{}
+	}
+
+	for _, sentry := range config.Sentries {
+		srv.sentries[sentry.ID] = sentry
 	}
 
 	// start gossip protocol
@@ -161,6 +174,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		context.Background(),
 		host, pubsub.WithPeerOutboundQueueSize(peerOutboundBufferSize),
 		pubsub.WithValidateQueueSize(validateBufferSize),
+		pubsub.WithDirectPeers(srv.gossipDirectPeers()),
 	)
 	if err != nil {
 		return nil, err
@@ -254,7 +268,7 @@ func (s *Server) Start() error {
 	}
 
 	// Set up the peer discovery mechanism if needed
-	if !s.config.NoDiscover {
+	if s.isDiscoveryEnabled() {
 		// Parse the bootnode data
 		if setupErr := s.setupBootnodes(); setupErr != nil {
 			return fmt.Errorf("unable to parse bootnode data, %w", setupErr)
@@ -269,6 +283,11 @@ func (s *Server) Start() error {
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
 
+	// A validator behind sentries connects only to its sentry nodes
+	if s.config.isSentryProtected() {
+		s.connectToSentries()
+	}
+
 	// watch for disconnected peers
 	s.host.Network().Notify(&network.NotifyBundle{
 		DisconnectedF: func(net network.Network, conn network.Conn) {
@@ -333,8 +352,15 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 			return
 		}
 
+		if s.config.isSentryProtected() {
+			// reconnect to any sentry that dropped off
+			s.connectToSentries()
+
+			continue
+		}
+
 		if s.numPeers() < MinimumPeerConnections {
-			if s.config.NoDiscover || !s.bootnodes.hasBootnodes() {
+			if !s.isDiscoveryEnabled() || !s.bootnodes.hasBootnodes() {
 				// dial unconnected peer
 				randPeer := s.GetRandomPeer()
 				if randPeer != nil && !s.IsConnected(*randPeer) {
@@ -521,7 +547,7 @@ func (s *Server) removePeerInfo(peerID peer.ID) *PeerConnInfo {
 // updateBootnodeConnCount attempts to update the bootnode connection count
 // by delta if the action is valid [Thread safe]
 func (s *Server) updateBootnodeConnCount(peerID peer.ID, delta int64) {
-	if s.config.NoDiscover || !s.bootnodes.isBootnode(peerID) {
+	if !s.isDiscoveryEnabled() || !s.bootnodes.isBootnode(peerID) {
 		// If the discovery service is not running
 		// or the peer is not a bootnode, there is no need
 		// to update bootnode connection counters
@@ -583,7 +609,7 @@ func (s *Server) Close() error {
 	err := s.host.Close()
 	s.dialQueue.Close()
 
-	if !s.config.NoDiscover {
+	if s.isDiscoveryEnabled() {
 		s.discovery.Close()
 	}
 
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/tarality/tan-network/network/common"
+)
+
+// isDiscoveryEnabled checks if the discovery service should run.
+// A validator behind sentries never takes part in discovery,
+// so its address is known only to its sentry nodes
+func (s *Server) isDiscoveryEnabled() bool {
+	return !s.config.NoDiscover && !s.config.isSentryProtected()
+}
+
+// IsPrivatePeer checks if the peer must not be advertised to other peers
+// through the discovery service [Thread safe]
+func (s *Server) IsPrivatePeer(peerID peer.ID) bool {
+	_, ok := s.privatePeers[peerID]
+
+	return ok
+}
+
+// IsAllowedPeer checks if a connection with the peer is allowed.
+// A validator behind sentries accepts connections only from its sentry nodes [Thread safe]
+func (s *Server) IsAllowedPeer(peerID peer.ID) bool {
+	if !s.config.isSentryProtected() {
+		return true
+	}
+
+	_, ok := s.sentries[peerID]
+
+	return ok
+}
+
+// connectToSentries adds every sentry node that is not connected to the dial queue
+func (s *Server) connectToSentries() {
+	for _, sentry := range s.sentries {
+		if s.IsConnected(sentry.ID) {
+			continue
+		}
+
+		s.host.Peerstore().AddAddrs(sentry.ID, sentry.Addrs, peerstore.PermanentAddrTTL)
+		s.addToDialQueue(sentry, common.PriorityRequestedDial)
+	}
+}
+
+// gossipDirectPeers returns the peers that always exchange gossip messages with the node,
+// regardless of the gossip mesh. Validators and their sentries are direct peers,
+// so consensus messages are always relayed between them
+func (s *Server) gossipDirectPeers() []peer.AddrInfo {
+	directPeers := make([]peer.AddrInfo, 0, len(s.sentries)+len(s.privatePeers))
+
+	for _, sentry := range s.sentries {
+		directPeers = append(directPeers, *sentry)
+	}
+
+	for id := range s.privatePeers {
+		directPeers = append(directPeers, peer.AddrInfo{ID: id})
+	}
+
+	return directPeers
+}
@@ -486,6 +486,51 @@ func TestPeerReconnection(t *testing.T) {
 	assert.True(t, reconnected)
 }
 
+// TestSentryProtectedValidator checks that a validator behind sentries connects to its sentry
+// on startup and refuses connections from any other peer
+func TestSentryProtectedValidator(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	sentry, outsider := servers[0], servers[1]
+
+	validator, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.Sentries = []*peer.AddrInfo{sentry.AddrInfo()}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		assert.NoError(t, validator.Close())
+	})
+
+	// the validator dials its sentry on its own
+	connectCtx, connectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer connectFn()
+
+	connected, err := WaitUntilPeerConnectsTo(connectCtx, validator, sentry.AddrInfo().ID)
+	if err != nil {
+		t.Fatalf("Unable to wait for peer connect, %v", err)
+	}
+
+	assert.True(t, connected)
+	assert.Nil(t, validator.discovery)
+
+	// any other peer is refused by the validator
+	joinErr := JoinAndWait(outsider, validator, 5*time.Second, 5*time.Second)
+	assert.Error(t, joinErr)
+	assert.False(t, validator.hasPeer(outsider.AddrInfo().ID))
+}
+
 func TestReconnectionWithNewIP(t *testing.T) {
 	natIP := "127.0.0.1"
 
@@ -0,0 +1,102 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/local"
+)
+
+var (
+	errEmptyPassphrase    = errors.New("passphrase is empty")
+	errPassphraseMismatch = errors.New("passphrases do not match")
+	errNotTerminal        = errors.New(
+		"passphrase can't be prompted, because stdin is not a terminal, " +
+			"supply it by a file or an environment variable")
+)
+
+// PassphraseSource defines where the passphrase of the encrypted local secrets is read from.
+// If neither the file nor the environment variable is set, the passphrase is prompted interactively
+type PassphraseSource struct {
+	// File is the path to the file containing the passphrase
+	File string
+
+	// Env is the name of the environment variable containing the passphrase
+	Env string
+}
+
+// IsSet checks if the passphrase is read from the file or the environment variable
+func (s *PassphraseSource) IsSet() bool {
+	return s.File != "" || s.Env != ""
+}
+
+// ReadPassphrase reads the passphrase from the given source.
+// When the passphrase is prompted and confirm is set, it has to be entered twice
+func ReadPassphrase(source PassphraseSource, confirm bool) (string, error) {
+	var passphrase string
+
+	switch {
+	case source.File != "":
+		raw, err := os.ReadFile(source.File)
+		if err != nil {
+			return "", fmt.Errorf("unable to read passphrase file, %w", err)
+		}
+
+		passphrase = strings.TrimRight(string(raw), "\r\n")
+	case source.Env != "":
+		passphrase = os.Getenv(source.Env)
+	default:
+		prompted, err := promptPassphrase("Enter passphrase: ")
+		if err != nil {
+			return "", err
+		}
+
+		if confirm && prompted != "" {
+			repeated, err := promptPassphrase("Repeat passphrase: ")
+			if err != nil {
+				return "", err
+			}
+
+			if prompted != repeated {
+				return "", errPassphraseMismatch
+			}
+		}
+
+		passphrase = prompted
+	}
+
+	if passphrase == "" {
+		return "", errEmptyPassphrase
+	}
+
+	return passphrase, nil
+}
+
+// promptPassphrase prints the prompt to stderr and reads the passphrase from the terminal without echoing it
+func promptPassphrase(prompt string) (string, error) {
+	passphrase, err := readPassphraseFromTerminal(int(os.Stdin.Fd()), prompt)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(passphrase), "\r\n"), nil
+}
+
+// SetupEncryptedLocalSecretsManager is a helper method for boilerplate local secrets manager setup,
+// which encrypts the secrets with the given passphrase
+func SetupEncryptedLocalSecretsManager(dataDir, passphrase string) (secrets.SecretsManager, error) {
+	return local.SecretsManagerFactory(
+		nil, // Local secrets manager doesn't require a config
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path:       dataDir,
+				secrets.Passphrase: passphrase,
+			},
+		},
+	)
+}
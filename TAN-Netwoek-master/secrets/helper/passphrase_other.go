@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package helper
+
+// readPassphraseFromTerminal is not supported on this platform,
+// so the passphrase has to be supplied by a file or an environment variable
+func readPassphraseFromTerminal(_ int, _ string) ([]byte, error) {
+	return nil, errNotTerminal
+}
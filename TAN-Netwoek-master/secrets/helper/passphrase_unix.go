@@ -0,0 +1,53 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package helper
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// readPassphraseFromTerminal prints the prompt and reads a line from the terminal with echo disabled
+func readPassphraseFromTerminal(fd int, prompt string) ([]byte, error) {
+	state, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, errNotTerminal
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+
+	noEcho := *state
+	noEcho.Lflag &^= unix.ECHO
+	noEcho.Lflag |= unix.ICANON | unix.ISIG
+	noEcho.Iflag |= unix.ICRNL
+
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &noEcho); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = unix.IoctlSetTermios(fd, ioctlSetTermios, state)
+	}()
+
+	var (
+		line []byte
+		buf  [1]byte
+	)
+
+	for {
+		n, err := unix.Read(fd, buf[:])
+		if err != nil {
+			return nil, err
+		}
+
+		if n == 0 || buf[0] == '\n' {
+			return line, nil
+		}
+
+		line = append(line, buf[0])
+	}
+}
@@ -0,0 +1,309 @@
+// Package keysigner provides an abstraction over the validator keys, which allows signing consensus data
+// without holding the private keys in the node's memory.
+//
+// The keys are either held by a LocalSigner (in process, the default) or by a remote signing service,
+// which is accessed through a RemoteSigner. Both of them can enforce slashing protection, which guarantees
+// that two different digests are never signed for the same height and round.
+// The remote signing protocol is described in docs/remote-signer/protocol.md.
+package keysigner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/tarality/0xTaral/messages/proto"
+	"github.com/tarality/fastrlp"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/types"
+)
+
+// Scheme is the signature scheme which is used to sign a digest
+type Scheme string
+
+const (
+	// SchemeECDSA signs the digest with the validator ECDSA key
+	SchemeECDSA Scheme = "ecdsa"
+
+	// SchemeBLS signs the digest with the validator BLS key
+	SchemeBLS Scheme = "bls"
+)
+
+// Kind describes the consensus data which is being signed
+type Kind string
+
+const (
+	// KindProposal is a proposal (pre-prepare) consensus message
+	KindProposal Kind = "proposal"
+
+	// KindPrepare is a prepare consensus message
+	KindPrepare Kind = "prepare"
+
+	// KindCommit is a commit consensus message
+	KindCommit Kind = "commit"
+
+	// KindRoundChange is a round change consensus message
+	KindRoundChange Kind = "round-change"
+
+	// KindCommittedSeal is a committed seal of the proposal
+	KindCommittedSeal Kind = "committed-seal"
+
+	// KindProposerSeal is a proposer seal of the IBFT block header
+	KindProposerSeal Kind = "proposer-seal"
+
+	// KindOther is any other data (transactions, bridge commitments...)
+	KindOther Kind = "other"
+)
+
+var (
+	// ErrSlashingProtection is returned when signing would result in two different digests
+	// being signed for the same height and round
+	ErrSlashingProtection = errors.New("refusing to sign conflicting digest for the same height and round")
+
+	// ErrUnsupportedScheme is returned when the signer doesn't hold a key of the requested scheme
+	ErrUnsupportedScheme = errors.New("signature scheme is not supported by the signer")
+
+	errEmptyDigest      = errors.New("digest is empty")
+	errMissingMessage   = errors.New("consensus message is required to sign consensus data")
+	errInvalidMessage   = errors.New("invalid consensus message")
+	errDigestMismatched = errors.New("digest doesn't match the consensus message")
+	errMissingPreimage  = errors.New("preimage is required to sign other data")
+	errInvalidPreimage  = errors.New("invalid preimage")
+)
+
+const (
+	// legacyCommitCode is appended to the proposal hash of IBFT committed seals
+	// (see wrapCommitHash in consensus/ibft/signer)
+	legacyCommitCode = 2
+
+	// headerNumberField is the index of the block number in the RLP encoded IBFT header
+	// the proposer seal is signed for (see calculateHeaderPreimage in consensus/ibft/signer)
+	headerNumberField = 9
+)
+
+// Metadata describes the consensus data which is being signed. It is used for slashing protection
+type Metadata struct {
+	Kind   Kind
+	Height uint64
+	Round  uint64
+
+	// Message is the protobuf encoded consensus message (without the signature) the data belongs to.
+	// It is required for the consensus message kinds, whose kind, height, round and digest
+	// are derived from it by the signer
+	Message []byte
+}
+
+// SignRequest is a request to sign the digest with the given scheme
+type SignRequest struct {
+	Metadata
+
+	// Scheme is the signature scheme used to sign the digest
+	Scheme Scheme
+
+	// Digest is the data to be signed. It is derived by the signer from the preimage
+	// of the proposer seals and the other data, so it can be omitted for them
+	Digest []byte
+
+	// Preimage is the data the digest of the proposer seals and the other data is derived from.
+	// The signer checks that it can't be mistaken for consensus data, since these kinds are not protected
+	Preimage []byte
+
+	// Domain is the BLS signing domain (used only by PolyBFT BLS keys)
+	Domain []byte
+}
+
+// KeySigner signs the consensus data by the validator keys
+type KeySigner interface {
+	// Address returns the address of the validator ECDSA key
+	Address() types.Address
+
+	// Sign signs the digest of the request
+	Sign(req *SignRequest) ([]byte, error)
+}
+
+// IsProtected returns true if the slashing protection is applied when signing data of the given kind
+func (k Kind) IsProtected() bool {
+	switch k {
+	case KindProposal, KindPrepare, KindCommit, KindCommittedSeal:
+		return true
+	default:
+		return false
+	}
+}
+
+// IBFTMessageMetadata returns metadata of the given IBFT consensus message, encoded as raw
+func IBFTMessageMetadata(msg *proto.Message, raw []byte) Metadata {
+	meta := ibftMessageMetadata(msg)
+	meta.Message = raw
+
+	return meta
+}
+
+// CommittedSealMetadata returns metadata of the committed seal of the given proposal hash for the given view
+func CommittedSealMetadata(view *proto.View, proposalHash []byte) (Metadata, error) {
+	raw, err := protobuf.Marshal(&proto.Message{
+		View: view,
+		Type: proto.MessageType_COMMIT,
+		Payload: &proto.Message_CommitData{
+			CommitData: &proto.CommitMessage{ProposalHash: proposalHash},
+		},
+	})
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to encode committed seal message: %w", err)
+	}
+
+	return Metadata{
+		Kind:    KindCommittedSeal,
+		Height:  view.GetHeight(),
+		Round:   view.GetRound(),
+		Message: raw,
+	}, nil
+}
+
+// resolve derives the kind, height and round of the request from its consensus message
+// and checks that the digest is the one of the message.
+// The proposer seals and the other data are signed by the digests derived from their preimages,
+// the requests of the other kinds without the consensus message are refused
+func (r *SignRequest) resolve() error {
+	if len(r.Message) == 0 {
+		switch r.Kind {
+		case KindProposerSeal:
+			return r.resolveProposerSeal()
+		case KindOther:
+			return r.resolveOther()
+		default:
+			return errMissingMessage
+		}
+	}
+
+	msg := &proto.Message{}
+	if err := protobuf.Unmarshal(r.Message, msg); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidMessage, err)
+	}
+
+	if msg.View == nil {
+		return errInvalidMessage
+	}
+
+	meta := ibftMessageMetadata(msg)
+	digest := crypto.Keccak256(r.Message)
+
+	if r.Kind == KindCommittedSeal {
+		if meta.Kind != KindCommit || msg.GetCommitData() == nil {
+			return errInvalidMessage
+		}
+
+		meta.Kind = KindCommittedSeal
+		digest = committedSealDigest(msg.GetCommitData().GetProposalHash(), r.Domain)
+	}
+
+	r.Kind, r.Height, r.Round = meta.Kind, meta.Height, meta.Round
+
+	if !bytes.Equal(digest, r.Digest) {
+		return errDigestMismatched
+	}
+
+	return nil
+}
+
+// resolveProposerSeal derives the digest of the proposer seal from the RLP encoded header (without the seals)
+// and takes the height from it. The preimage must be a list starting with the parent hash,
+// so it can't be the preimage of a committed seal
+func (r *SignRequest) resolveProposerSeal() error {
+	if len(r.Preimage) == 0 {
+		return errMissingPreimage
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(r.Preimage)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidPreimage, err)
+	}
+
+	elems, err := v.GetElems()
+	if err != nil || len(p.Raw(v)) != len(r.Preimage) || len(elems) <= headerNumberField {
+		return fmt.Errorf("%w: not an encoded header", errInvalidPreimage)
+	}
+
+	parentHash, err := elems[0].Bytes()
+	if err != nil || len(parentHash) != types.HashLength {
+		return fmt.Errorf("%w: not an encoded header", errInvalidPreimage)
+	}
+
+	if r.Height, err = elems[headerNumberField].GetUint64(); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidPreimage, err)
+	}
+
+	r.Round = 0
+
+	return r.setDigest(crypto.Keccak256(crypto.Keccak256(r.Preimage)))
+}
+
+// resolveOther derives the digest of the other data from its preimage. The preimages which could be
+// mistaken for consensus data are refused: consensus messages, hashes (the IBFT seals are signatures of hashes)
+// and any data in the domain of the PolyBFT committed seals
+func (r *SignRequest) resolveOther() error {
+	if len(r.Preimage) == 0 {
+		return errMissingPreimage
+	}
+
+	if msg := (&proto.Message{}); protobuf.Unmarshal(r.Preimage, msg) == nil && msg.View != nil {
+		return fmt.Errorf("%w: consensus message", errInvalidPreimage)
+	}
+
+	if len(r.Preimage) == types.HashLength {
+		return fmt.Errorf("%w: hash", errInvalidPreimage)
+	}
+
+	if r.Scheme == SchemeBLS && bytes.Equal(r.Domain, bls.DomainCheckpointManager) {
+		return fmt.Errorf("%w: committed seal domain", errInvalidPreimage)
+	}
+
+	return r.setDigest(crypto.Keccak256(r.Preimage))
+}
+
+// setDigest sets the digest derived by the signer, the digest supplied with the request must match it
+func (r *SignRequest) setDigest(digest []byte) error {
+	if len(r.Digest) > 0 && !bytes.Equal(digest, r.Digest) {
+		return errDigestMismatched
+	}
+
+	r.Digest = digest
+
+	return nil
+}
+
+// committedSealDigest returns the digest signed as the committed seal of the given proposal hash.
+// PolyBFT signs the proposal hash in the checkpoint domain, while IBFT signs the legacy wrapped hash
+func committedSealDigest(proposalHash, domain []byte) []byte {
+	if len(domain) > 0 {
+		return proposalHash
+	}
+
+	return crypto.Keccak256(crypto.Keccak256(proposalHash, []byte{legacyCommitCode}))
+}
+
+func ibftMessageMetadata(msg *proto.Message) Metadata {
+	meta := Metadata{
+		Kind:   KindOther,
+		Height: msg.View.GetHeight(),
+		Round:  msg.View.GetRound(),
+	}
+
+	switch msg.Type {
+	case proto.MessageType_PREPREPARE:
+		meta.Kind = KindProposal
+	case proto.MessageType_PREPARE:
+		meta.Kind = KindPrepare
+	case proto.MessageType_COMMIT:
+		meta.Kind = KindCommit
+	case proto.MessageType_ROUND_CHANGE:
+		meta.Kind = KindRoundChange
+	}
+
+	return meta
+}
@@ -0,0 +1,201 @@
+package keysigner
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/types"
+)
+
+// BLSScheme is the BLS implementation used by the consensus
+type BLSScheme string
+
+const (
+	// BLSSchemeIBFT is the BLS implementation used by IBFT (BLS12-381)
+	BLSSchemeIBFT BLSScheme = "ibft"
+
+	// BLSSchemePolyBFT is the BLS implementation used by PolyBFT (BN254)
+	BLSSchemePolyBFT BLSScheme = "polybft"
+)
+
+// ECDSAKey signs digests with ECDSA private key
+type ECDSAKey interface {
+	// Address returns the address of the key
+	Address() types.Address
+
+	// Sign signs the digest and returns 65 bytes [R || S || V] signature
+	Sign(digest []byte) ([]byte, error)
+}
+
+// BLSKey signs digests with BLS private key
+type BLSKey interface {
+	// Sign signs the digest in the given domain and returns marshaled signature
+	Sign(digest, domain []byte) ([]byte, error)
+}
+
+// LocalSigner holds the validator keys in memory
+type LocalSigner struct {
+	ecdsaKey   ECDSAKey
+	blsKey     BLSKey
+	protection *SlashingProtection
+}
+
+// NewLocalSigner creates LocalSigner from the given keys.
+// BLS key and slashing protection are optional
+func NewLocalSigner(ecdsaKey ECDSAKey, blsKey BLSKey, protection *SlashingProtection) *LocalSigner {
+	return &LocalSigner{
+		ecdsaKey:   ecdsaKey,
+		blsKey:     blsKey,
+		protection: protection,
+	}
+}
+
+// NewLocalSignerFromSecrets creates LocalSigner from the keys loaded from SecretsManager.
+// BLS key is loaded only if it exists
+func NewLocalSignerFromSecrets(
+	manager secrets.SecretsManager,
+	scheme BLSScheme,
+	protection *SlashingProtection,
+) (*LocalSigner, error) {
+	ecdsaRaw, err := manager.GetSecret(secrets.ValidatorKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ecdsa key: %w", err)
+	}
+
+	ecdsaKey, err := crypto.BytesToECDSAPrivateKey(ecdsaRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ecdsa key: %w", err)
+	}
+
+	var blsKey BLSKey
+
+	if manager.HasSecret(secrets.ValidatorBLSKey) {
+		blsRaw, err := manager.GetSecret(secrets.ValidatorBLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve bls key: %w", err)
+		}
+
+		if blsKey, err = unmarshalBLSKey(scheme, blsRaw); err != nil {
+			return nil, fmt.Errorf("failed to retrieve bls key: %w", err)
+		}
+	}
+
+	return NewLocalSigner(NewECDSAKey(ecdsaKey), blsKey, protection), nil
+}
+
+// WithBLSKey returns LocalSigner which signs by the same ECDSA key and slashing protection,
+// but by the given BLS key (used when the validator BLS key is rotated)
+func (s *LocalSigner) WithBLSKey(blsKey BLSKey) *LocalSigner {
+	return NewLocalSigner(s.ecdsaKey, blsKey, s.protection)
+}
+
+// Address returns the address of the validator ECDSA key
+func (s *LocalSigner) Address() types.Address {
+	return s.ecdsaKey.Address()
+}
+
+// Sign derives the metadata and the digest of the request from its consensus message or preimage,
+// checks the request against slashing protection and signs its digest
+func (s *LocalSigner) Sign(req *SignRequest) ([]byte, error) {
+	if req.Scheme != SchemeECDSA && (req.Scheme != SchemeBLS || s.blsKey == nil) {
+		return nil, ErrUnsupportedScheme
+	}
+
+	if err := req.resolve(); err != nil {
+		return nil, err
+	}
+
+	if len(req.Digest) == 0 {
+		return nil, errEmptyDigest
+	}
+
+	if s.protection != nil {
+		if err := s.protection.CheckAndRecord(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Scheme == SchemeECDSA {
+		return s.ecdsaKey.Sign(req.Digest)
+	}
+
+	return s.blsKey.Sign(req.Digest, req.Domain)
+}
+
+// unmarshalBLSKey parses BLS key stored in SecretsManager, which format depends on the BLS scheme
+func unmarshalBLSKey(scheme BLSScheme, raw []byte) (BLSKey, error) {
+	switch scheme {
+	case BLSSchemeIBFT:
+		key, err := crypto.BytesToBLSSecretKey(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIBFTBLSKey(key), nil
+	case BLSSchemePolyBFT:
+		key, err := bls.UnmarshalPrivateKey(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewPolyBFTBLSKey(key), nil
+	default:
+		return nil, fmt.Errorf("unsupported bls scheme: %s", scheme)
+	}
+}
+
+type ecdsaKey struct {
+	key     *ecdsa.PrivateKey
+	address types.Address
+}
+
+// NewECDSAKey wraps ECDSA private key
+func NewECDSAKey(key *ecdsa.PrivateKey) ECDSAKey {
+	return &ecdsaKey{
+		key:     key,
+		address: crypto.PubKeyToAddress(&key.PublicKey),
+	}
+}
+
+func (k *ecdsaKey) Address() types.Address {
+	return k.address
+}
+
+func (k *ecdsaKey) Sign(digest []byte) ([]byte, error) {
+	return crypto.Sign(k.key, digest)
+}
+
+type ibftBLSKey struct {
+	key *bls_sig.SecretKey
+}
+
+// NewIBFTBLSKey wraps IBFT BLS key. Domain is ignored when signing
+func NewIBFTBLSKey(key *bls_sig.SecretKey) BLSKey {
+	return &ibftBLSKey{key: key}
+}
+
+func (k *ibftBLSKey) Sign(digest, _ []byte) ([]byte, error) {
+	return crypto.SignByBLS(k.key, digest)
+}
+
+type polyBFTBLSKey struct {
+	key *bls.PrivateKey
+}
+
+// NewPolyBFTBLSKey wraps PolyBFT BLS key
+func NewPolyBFTBLSKey(key *bls.PrivateKey) BLSKey {
+	return &polyBFTBLSKey{key: key}
+}
+
+func (k *polyBFTBLSKey) Sign(digest, domain []byte) ([]byte, error) {
+	signature, err := k.key.Sign(digest, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature.Marshal()
+}
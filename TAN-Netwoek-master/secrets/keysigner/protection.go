@@ -0,0 +1,119 @@
+package keysigner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// protectionRetention is the number of heights for which signed digests are kept
+	protectionRetention = uint64(1024)
+)
+
+/*
+Bolt DB schema:
+
+signed digests/
+|--> height (8 bytes) | round (8 bytes) | scheme | kind -> digest
+*/
+var (
+	// bucket to store digests signed for each height and round
+	signedDigestsBucket = []byte("signedDigests")
+)
+
+// SlashingProtection keeps the digests signed for each height and round,
+// so that the signer never signs two different digests for the same height and round
+type SlashingProtection struct {
+	db *bolt.DB
+}
+
+// NewSlashingProtection opens (or creates) slashing protection database at the given path
+func NewSlashingProtection(path string) (*SlashingProtection, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open slashing protection db: %w", err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(signedDigestsBucket)
+
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create bucket=%s: %w", string(signedDigestsBucket), err)
+	}
+
+	return &SlashingProtection{db: db}, nil
+}
+
+// CheckAndRecord returns ErrSlashingProtection if a different digest is already signed
+// for the same scheme, kind, height and round. Otherwise, it records the digest of the request
+func (p *SlashingProtection) CheckAndRecord(req *SignRequest) error {
+	if !req.Kind.IsProtected() {
+		return nil
+	}
+
+	key := protectionKey(req)
+
+	return p.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(signedDigestsBucket)
+
+		if signed := bucket.Get(key); signed != nil {
+			if !bytes.Equal(signed, req.Digest) {
+				return ErrSlashingProtection
+			}
+
+			return nil
+		}
+
+		if err := bucket.Put(key, req.Digest); err != nil {
+			return err
+		}
+
+		return prune(bucket, req.Height)
+	})
+}
+
+// Close closes the slashing protection database
+func (p *SlashingProtection) Close() error {
+	return p.db.Close()
+}
+
+// prune removes digests signed at heights which are too old to be relevant
+func prune(bucket *bolt.Bucket, height uint64) error {
+	if height <= protectionRetention {
+		return nil
+	}
+
+	var (
+		cutoff = height - protectionRetention
+		keys   [][]byte
+		cursor = bucket.Cursor()
+	)
+
+	// keys are collected first, since deleting while iterating makes the cursor skip elements
+	for k, _ := cursor.First(); k != nil && binary.BigEndian.Uint64(k[:8]) < cutoff; k, _ = cursor.Next() {
+		keys = append(keys, k)
+	}
+
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func protectionKey(req *SignRequest) []byte {
+	key := make([]byte, 16, 16+len(req.Scheme)+len(req.Kind)+1)
+	binary.BigEndian.PutUint64(key[:8], req.Height)
+	binary.BigEndian.PutUint64(key[8:], req.Round)
+	key = append(key, req.Scheme...)
+	key = append(key, '/')
+
+	return append(key, req.Kind...)
+}
@@ -0,0 +1,48 @@
+package keysigner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingProtection_CheckAndRecord(t *testing.T) {
+	t.Parallel()
+
+	protection, err := NewSlashingProtection(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, protection.Close())
+	})
+
+	newRequest := func(kind Kind, height, round uint64, digest byte) *SignRequest {
+		return &SignRequest{
+			Metadata: Metadata{Kind: kind, Height: height, Round: round},
+			Scheme:   SchemeECDSA,
+			Digest:   []byte{digest},
+		}
+	}
+
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindPrepare, 5, 0, 1)))
+
+	// the same digest can be signed again
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindPrepare, 5, 0, 1)))
+
+	// different digest for the same height and round is refused
+	require.ErrorIs(t, protection.CheckAndRecord(newRequest(KindPrepare, 5, 0, 2)), ErrSlashingProtection)
+
+	// different digest for other round, height or kind is signed
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindPrepare, 5, 1, 2)))
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindPrepare, 6, 0, 2)))
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindCommit, 5, 0, 2)))
+
+	// data which is not protected can always be signed
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindOther, 5, 0, 1)))
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindOther, 5, 0, 2)))
+
+	// digests of old heights are pruned
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindPrepare, 5+protectionRetention+1, 0, 1)))
+	require.NoError(t, protection.CheckAndRecord(newRequest(KindPrepare, 5, 0, 2)))
+}
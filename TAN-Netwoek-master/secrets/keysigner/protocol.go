@@ -0,0 +1,107 @@
+package keysigner
+
+import (
+	"github.com/tarality/tan-network/helper/hex"
+)
+
+// Remote signing protocol endpoints
+const (
+	// AddressEndpoint returns the address of the validator ECDSA key
+	AddressEndpoint = "/v1/address"
+
+	// SignEndpoint signs the digest of the request
+	SignEndpoint = "/v1/sign"
+)
+
+// addressResponse is the response of the address endpoint
+type addressResponse struct {
+	Address string `json:"address"`
+}
+
+// signRequestJSON is the body of the sign endpoint request
+type signRequestJSON struct {
+	Scheme   Scheme `json:"scheme"`
+	Kind     Kind   `json:"kind"`
+	Height   uint64 `json:"height"`
+	Round    uint64 `json:"round"`
+	Digest   string `json:"digest,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Preimage string `json:"preimage,omitempty"`
+}
+
+// signResponse is the response of the sign endpoint
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// errorResponse is returned by the endpoints in case of an error
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func toSignRequestJSON(req *SignRequest) *signRequestJSON {
+	res := &signRequestJSON{
+		Scheme: req.Scheme,
+		Kind:   req.Kind,
+		Height: req.Height,
+		Round:  req.Round,
+	}
+
+	if len(req.Digest) > 0 {
+		res.Digest = hex.EncodeToHex(req.Digest)
+	}
+
+	if len(req.Domain) > 0 {
+		res.Domain = hex.EncodeToHex(req.Domain)
+	}
+
+	if len(req.Message) > 0 {
+		res.Message = hex.EncodeToHex(req.Message)
+	}
+
+	if len(req.Preimage) > 0 {
+		res.Preimage = hex.EncodeToHex(req.Preimage)
+	}
+
+	return res
+}
+
+func (r *signRequestJSON) toSignRequest() (*SignRequest, error) {
+	var err error
+
+	req := &SignRequest{
+		Metadata: Metadata{
+			Kind:   r.Kind,
+			Height: r.Height,
+			Round:  r.Round,
+		},
+		Scheme: r.Scheme,
+	}
+
+	if r.Digest != "" {
+		if req.Digest, err = hex.DecodeHex(r.Digest); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Domain != "" {
+		if req.Domain, err = hex.DecodeHex(r.Domain); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Message != "" {
+		if req.Message, err = hex.DecodeHex(r.Message); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Preimage != "" {
+		if req.Preimage, err = hex.DecodeHex(r.Preimage); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
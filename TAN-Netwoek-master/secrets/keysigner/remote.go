@@ -0,0 +1,181 @@
+package keysigner
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tarality/tan-network/helper/hex"
+	"github.com/tarality/tan-network/types"
+)
+
+const (
+	// defaultRemoteTimeout is the timeout of a single request to the remote signer
+	defaultRemoteTimeout = 5 * time.Second
+)
+
+var (
+	// ErrInsecureRemoteSigner is returned when the remote signer would be accessed without the bearer token.
+	// TLS only encrypts the connection, it doesn't authorize the requests
+	ErrInsecureRemoteSigner = errors.New("remote signer must be authorized by a token")
+)
+
+// RemoteConfig is the configuration of the remote signer
+type RemoteConfig struct {
+	// URL is the base URL of the remote signer
+	URL string
+
+	// Token is the bearer token used to authorize the requests
+	Token string
+
+	// Timeout is the timeout of a single request (optional)
+	Timeout time.Duration
+
+	// CACertFile is the path to the PEM encoded CA certificate the TLS certificate
+	// of the remote signer is verified with (optional, system roots are used if not set)
+	CACertFile string
+}
+
+// httpClient validates the config and creates the http client accessing the remote signer
+func (c *RemoteConfig) httpClient() (*http.Client, error) {
+	endpoint, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signer url: %w", err)
+	}
+
+	if endpoint.Scheme != "https" && endpoint.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported remote signer url scheme: %s", endpoint.Scheme)
+	}
+
+	if c.Token == "" {
+		return nil, ErrInsecureRemoteSigner
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultRemoteTimeout
+	}
+
+	client := &http.Client{Timeout: timeout}
+
+	if c.CACertFile != "" {
+		pem, err := os.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read remote signer CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("invalid remote signer CA certificate: %s", c.CACertFile)
+		}
+
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				MinVersion: tls.VersionTLS12,
+			},
+		}
+	}
+
+	return client, nil
+}
+
+// RemoteSigner signs the consensus data through the remote signing service,
+// so that the validator keys never reach the node's memory
+type RemoteSigner struct {
+	config  *RemoteConfig
+	client  *http.Client
+	address types.Address
+}
+
+// NewRemoteSigner creates RemoteSigner and fetches validator address from the remote signer.
+// The remote signer must be accessed with the bearer token, preferably over TLS
+func NewRemoteSigner(config *RemoteConfig) (*RemoteSigner, error) {
+	client, err := config.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &RemoteSigner{
+		config: config,
+		client: client,
+	}
+
+	var res addressResponse
+	if err := s.do(http.MethodGet, AddressEndpoint, nil, &res); err != nil {
+		return nil, fmt.Errorf("failed to retrieve address from remote signer: %w", err)
+	}
+
+	if err := s.address.UnmarshalText([]byte(res.Address)); err != nil {
+		return nil, fmt.Errorf("invalid address returned by remote signer: %w", err)
+	}
+
+	return s, nil
+}
+
+// Address returns the address of the validator ECDSA key
+func (s *RemoteSigner) Address() types.Address {
+	return s.address
+}
+
+// Sign sends the request to the remote signer and returns the signature
+func (s *RemoteSigner) Sign(req *SignRequest) ([]byte, error) {
+	var res signResponse
+	if err := s.do(http.MethodPost, SignEndpoint, toSignRequestJSON(req), &res); err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeHex(res.Signature)
+}
+
+func (s *RemoteSigner) do(method, endpoint string, body, result interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(raw)
+	}
+
+	httpReq, err := http.NewRequest(method, strings.TrimSuffix(s.config.URL, "/")+endpoint, reader)
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpReq.Header.Set("Authorization", "Bearer "+s.config.Token)
+
+	httpRes, err := s.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != http.StatusOK {
+		if httpRes.StatusCode == http.StatusConflict {
+			return ErrSlashingProtection
+		}
+
+		var errRes errorResponse
+
+		_ = json.NewDecoder(httpRes.Body).Decode(&errRes)
+
+		return fmt.Errorf("remote signer returned status %d: %s", httpRes.StatusCode, errRes.Error)
+	}
+
+	return json.NewDecoder(httpRes.Body).Decode(result)
+}
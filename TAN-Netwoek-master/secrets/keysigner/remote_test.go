@@ -0,0 +1,281 @@
+package keysigner
+
+import (
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/tarality/0xTaral/messages/proto"
+	bls "github.com/tarality/tan-network/consensus/polybft/signer"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/types"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	const token = "secret"
+
+	ecdsaKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	blsKey, err := bls.GenerateBlsKey()
+	require.NoError(t, err)
+
+	protection, err := NewSlashingProtection(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, protection.Close())
+	})
+
+	local := NewLocalSigner(NewECDSAKey(ecdsaKey), NewPolyBFTBLSKey(blsKey), protection)
+
+	// reference signer serving the remote signing protocol
+	server := httptest.NewServer(NewServer(hclog.NewNullLogger(), local, token).Handler())
+	t.Cleanup(server.Close)
+
+	_, err = NewRemoteSigner(&RemoteConfig{URL: server.URL})
+	require.ErrorIs(t, err, ErrInsecureRemoteSigner)
+
+	_, err = NewRemoteSigner(&RemoteConfig{URL: server.URL, Token: "wrong"})
+	require.ErrorContains(t, err, "401")
+
+	remote, err := NewRemoteSigner(&RemoteConfig{URL: server.URL, Token: token})
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubKeyToAddress(&ecdsaKey.PublicKey), remote.Address())
+
+	view := &proto.View{Height: 10, Round: 1}
+	proposalHash := crypto.Keccak256([]byte("proposal"))
+
+	prepareRequest := func(t *testing.T, proposalHash []byte) *SignRequest {
+		t.Helper()
+
+		msg := &proto.Message{
+			View: view,
+			Type: proto.MessageType_PREPARE,
+			Payload: &proto.Message_PrepareData{
+				PrepareData: &proto.PrepareMessage{ProposalHash: proposalHash},
+			},
+		}
+
+		raw, err := protobuf.Marshal(msg)
+		require.NoError(t, err)
+
+		return &SignRequest{
+			Metadata: IBFTMessageMetadata(msg, raw),
+			Scheme:   SchemeECDSA,
+			Digest:   crypto.Keccak256(raw),
+		}
+	}
+
+	t.Run("ECDSA", func(t *testing.T) {
+		t.Parallel()
+
+		req := prepareRequest(t, proposalHash)
+
+		signature, err := remote.Sign(req)
+		require.NoError(t, err)
+
+		pub, err := crypto.RecoverPubkey(signature, req.Digest)
+		require.NoError(t, err)
+		require.Equal(t, remote.Address(), crypto.PubKeyToAddress(pub))
+
+		// signing different digest in the same height and round is refused
+		_, err = remote.Sign(prepareRequest(t, crypto.Keccak256([]byte("conflicting proposal"))))
+		require.ErrorIs(t, err, ErrSlashingProtection)
+
+		// the height and round are taken from the message rather than from the supplied metadata
+		conflicting := prepareRequest(t, crypto.Keccak256([]byte("conflicting proposal")))
+		conflicting.Height, conflicting.Round = 11, 0
+
+		_, err = remote.Sign(conflicting)
+		require.ErrorIs(t, err, ErrSlashingProtection)
+	})
+
+	t.Run("BLS", func(t *testing.T) {
+		t.Parallel()
+
+		meta, err := CommittedSealMetadata(view, proposalHash)
+		require.NoError(t, err)
+
+		signature, err := remote.Sign(&SignRequest{
+			Metadata: meta,
+			Scheme:   SchemeBLS,
+			Digest:   proposalHash,
+			Domain:   bls.DomainCheckpointManager,
+		})
+		require.NoError(t, err)
+
+		blsSignature, err := bls.UnmarshalSignature(signature)
+		require.NoError(t, err)
+		require.True(t, blsSignature.Verify(blsKey.PublicKey(), proposalHash, bls.DomainCheckpointManager))
+	})
+
+	t.Run("digest not matching the message", func(t *testing.T) {
+		t.Parallel()
+
+		req := prepareRequest(t, proposalHash)
+		req.Digest = crypto.Keccak256([]byte("other"))
+
+		_, err := remote.Sign(req)
+		require.ErrorContains(t, err, errDigestMismatched.Error())
+	})
+
+	t.Run("protected kind without message", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindCommit, Height: 20},
+			Scheme:   SchemeECDSA,
+			Digest:   proposalHash,
+		})
+		require.ErrorContains(t, err, errMissingMessage.Error())
+	})
+
+	t.Run("round change without message", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindRoundChange, Height: 20},
+			Scheme:   SchemeECDSA,
+			Digest:   proposalHash,
+		})
+		require.ErrorContains(t, err, errMissingMessage.Error())
+	})
+
+	t.Run("other data", func(t *testing.T) {
+		t.Parallel()
+
+		preimage := []byte("transaction signing payload")
+
+		// the digest is derived from the preimage
+		signature, err := remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindOther},
+			Scheme:   SchemeECDSA,
+			Preimage: preimage,
+		})
+		require.NoError(t, err)
+
+		pub, err := crypto.RecoverPubkey(signature, crypto.Keccak256(preimage))
+		require.NoError(t, err)
+		require.Equal(t, remote.Address(), crypto.PubKeyToAddress(pub))
+
+		// raw digests are not signed
+		_, err = remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindOther},
+			Scheme:   SchemeECDSA,
+			Digest:   proposalHash,
+		})
+		require.ErrorContains(t, err, errMissingPreimage.Error())
+
+		_, err = remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindOther},
+			Scheme:   SchemeECDSA,
+			Digest:   proposalHash,
+			Preimage: preimage,
+		})
+		require.ErrorContains(t, err, errDigestMismatched.Error())
+
+		// hashes could be the preimages of the IBFT committed seals
+		_, err = remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindOther},
+			Scheme:   SchemeECDSA,
+			Preimage: proposalHash,
+		})
+		require.ErrorContains(t, err, errInvalidPreimage.Error())
+
+		// consensus messages are signed only as consensus messages
+		_, err = remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindOther},
+			Scheme:   SchemeECDSA,
+			Preimage: prepareRequest(t, crypto.Keccak256([]byte("other proposal"))).Message,
+		})
+		require.ErrorContains(t, err, errInvalidPreimage.Error())
+
+		// committed seals are signed only as committed seals
+		_, err = remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindOther},
+			Scheme:   SchemeBLS,
+			Preimage: preimage,
+			Domain:   bls.DomainCheckpointManager,
+		})
+		require.ErrorContains(t, err, errInvalidPreimage.Error())
+	})
+
+	t.Run("proposer seal", func(t *testing.T) {
+		t.Parallel()
+
+		header := (&types.Header{
+			ParentHash: types.BytesToHash(crypto.Keccak256([]byte("parent"))),
+			Number:     30,
+		}).MarshalRLP()
+
+		signature, err := remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindProposerSeal},
+			Scheme:   SchemeECDSA,
+			Preimage: header,
+		})
+		require.NoError(t, err)
+
+		pub, err := crypto.RecoverPubkey(signature, crypto.Keccak256(crypto.Keccak256(header)))
+		require.NoError(t, err)
+		require.Equal(t, remote.Address(), crypto.PubKeyToAddress(pub))
+
+		_, err = remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindProposerSeal},
+			Scheme:   SchemeECDSA,
+			Preimage: append(crypto.Keccak256([]byte("proposal")), legacyCommitCode),
+		})
+		require.ErrorContains(t, err, errInvalidPreimage.Error())
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := remote.Sign(&SignRequest{
+			Metadata: Metadata{Kind: KindOther},
+			Scheme:   "rsa",
+			Digest:   proposalHash,
+		})
+		require.ErrorContains(t, err, ErrUnsupportedScheme.Error())
+	})
+}
+
+func TestRemoteSigner_TLS(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	const token = "secret"
+
+	local := NewLocalSigner(NewECDSAKey(ecdsaKey), nil, nil)
+
+	server := httptest.NewTLSServer(NewServer(hclog.NewNullLogger(), local, token).Handler())
+	t.Cleanup(server.Close)
+
+	// the certificate of the test server is not trusted by the system roots
+	_, err = NewRemoteSigner(&RemoteConfig{URL: server.URL, Token: token})
+	require.Error(t, err)
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caCertFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0600))
+
+	// tls doesn't authorize the requests, so the token is still required
+	_, err = NewRemoteSigner(&RemoteConfig{URL: server.URL, CACertFile: caCertFile})
+	require.ErrorIs(t, err, ErrInsecureRemoteSigner)
+
+	remote, err := NewRemoteSigner(&RemoteConfig{URL: server.URL, Token: token, CACertFile: caCertFile})
+	require.NoError(t, err)
+	require.Equal(t, local.Address(), remote.Address())
+}
@@ -0,0 +1,118 @@
+package keysigner
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/tarality/tan-network/helper/hex"
+)
+
+// Server serves the remote signing protocol for the given KeySigner
+type Server struct {
+	logger hclog.Logger
+	signer KeySigner
+	token  string
+}
+
+// NewServer creates Server. Requests must be authorized with the token,
+// all of them are refused if the token is empty
+func NewServer(logger hclog.Logger, signer KeySigner, token string) *Server {
+	return &Server{
+		logger: logger,
+		signer: signer,
+		token:  token,
+	}
+}
+
+// Handler returns http handler serving the remote signing protocol endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(AddressEndpoint, s.authorized(s.handleAddress))
+	mux.HandleFunc(SignEndpoint, s.authorized(s.handleSign))
+
+	return mux
+}
+
+func (s *Server) authorized(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.token == "" ||
+			subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleAddress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &addressResponse{Address: s.signer.Address().String()})
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+
+		return
+	}
+
+	var body signRequestJSON
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	req, err := body.toSignRequest()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	signature, err := s.signer.Sign(req)
+	if err != nil {
+		status := http.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, ErrSlashingProtection):
+			status = http.StatusConflict
+
+			s.logger.Warn("refused to sign conflicting digest", "scheme", req.Scheme, "kind", req.Kind,
+				"height", req.Height, "round", req.Round)
+		case errors.Is(err, ErrUnsupportedScheme), errors.Is(err, errEmptyDigest),
+			errors.Is(err, errMissingMessage), errors.Is(err, errInvalidMessage), errors.Is(err, errDigestMismatched),
+			errors.Is(err, errMissingPreimage), errors.Is(err, errInvalidPreimage):
+			status = http.StatusBadRequest
+		}
+
+		writeError(w, status, err)
+
+		return
+	}
+
+	s.logger.Debug("signed", "scheme", req.Scheme, "kind", req.Kind, "height", req.Height, "round", req.Round)
+
+	writeJSON(w, http.StatusOK, &signResponse{Signature: hex.EncodeToHex(signature)})
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, &errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,77 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/tarality/tan-network/secrets"
+	"github.com/umbracle/ethgo/keystore"
+)
+
+// keystoreVersion is the version of the Ethereum keystore format used to encrypt the secrets
+const keystoreVersion = 3
+
+// keystoreScryptN is the scrypt CPU/memory cost parameter used to encrypt the secrets
+var keystoreScryptN = 1 << 18
+
+var (
+	// ErrPassphraseRequired is returned when the secret is encrypted, but the passphrase is not provided
+	ErrPassphraseRequired = errors.New("secret is encrypted, passphrase is required")
+
+	errEmptyPassphrase = errors.New("passphrase is empty")
+)
+
+// localSecretFiles are the paths of the secrets relative to the base working directory
+var localSecretFiles = []string{
+	filepath.Join(secrets.ConsensusFolderLocal, secrets.ValidatorKeyLocal),
+	filepath.Join(secrets.ConsensusFolderLocal, secrets.ValidatorBLSKeyLocal),
+	filepath.Join(secrets.ConsensusFolderLocal, secrets.ValidatorBLSKeyNextLocal),
+	filepath.Join(secrets.NetworkFolderLocal, secrets.NetworkKeyLocal),
+}
+
+// encryptSecret encrypts the secret in the Ethereum v3 keystore format (scrypt + AES-128-CTR)
+func encryptSecret(value []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errEmptyPassphrase
+	}
+
+	return keystore.EncryptV3(value, passphrase, keystoreScryptN)
+}
+
+// decryptSecret decrypts the secret stored in the Ethereum v3 keystore format
+func decryptSecret(encrypted []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	return keystore.DecryptV3(encrypted, passphrase)
+}
+
+// isEncrypted checks if the secret read from disk is stored in the Ethereum v3 keystore format.
+// Plaintext secrets are hex encoded, so they can't be mistaken for a keystore JSON
+func isEncrypted(raw []byte) bool {
+	var header struct {
+		Version int             `json:"version"`
+		Crypto  json.RawMessage `json:"crypto"`
+	}
+
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return false
+	}
+
+	return header.Version == keystoreVersion && len(header.Crypto) > 0
+}
+
+// HasEncryptedSecrets checks if any of the secrets stored in the given base working directory is encrypted
+func HasEncryptedSecrets(path string) bool {
+	for _, file := range localSecretFiles {
+		raw, err := os.ReadFile(filepath.Join(path, file))
+		if err == nil && isEncrypted(raw) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -21,6 +21,10 @@ type LocalSecretsManager struct {
 	// Path to the base working directory
 	path string
 
+	// Passphrase used to encrypt the secrets (optional).
+	// If it is empty, the secrets are stored in plaintext
+	passphrase string
+
 	// Map of known secrets and their paths
 	secretPathMap map[string]string
 
@@ -50,6 +54,13 @@ func SecretsManagerFactory(
 		return nil, errors.New("invalid type assertion")
 	}
 
+	// Grab the passphrase, if the secrets are encrypted
+	if passphrase, ok := params.Extra[secrets.Passphrase]; ok {
+		if localManager.passphrase, ok = passphrase.(string); !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+	}
+
 	// Run the initial setup
 	_ = localManager.Setup()
 
@@ -84,6 +95,13 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.ValidatorBLSKeyLocal,
 	)
 
+	// baseDir/consensus/validator-bls-next.key
+	l.secretPathMap[secrets.ValidatorBLSKeyNext] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorBLSKeyNextLocal,
+	)
+
 	// baseDir/libp2p/libp2p.key
 	l.secretPathMap[secrets.NetworkKey] = filepath.Join(
 		l.path,
@@ -94,30 +112,60 @@ func (l *LocalSecretsManager) Setup() error {
 	return nil
 }
 
-// GetSecret gets the local SecretsManager's secret from disk
+// GetSecret gets the local SecretsManager's secret from disk,
+// and decrypts it if it is stored encrypted
 func (l *LocalSecretsManager) GetSecret(name string) ([]byte, error) {
+	secretPath, secret, err := l.readSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEncrypted(secret) {
+		if l.passphrase != "" {
+			l.logger.Warn("secret is stored unencrypted, run the secrets migrate command to encrypt it",
+				"path", secretPath)
+		}
+
+		return secret, nil
+	}
+
+	decrypted, err := decryptSecret(secret, l.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to decrypt secret (%s), %w",
+			secretPath,
+			err,
+		)
+	}
+
+	return decrypted, nil
+}
+
+// readSecret reads the raw secret from disk and returns it together with its path
+func (l *LocalSecretsManager) readSecret(name string) (string, []byte, error) {
 	l.secretPathMapLock.RLock()
 	secretPath, ok := l.secretPathMap[name]
 	l.secretPathMapLock.RUnlock()
 
 	if !ok {
-		return nil, secrets.ErrSecretNotFound
+		return "", nil, secrets.ErrSecretNotFound
 	}
 
 	// Read the secret from disk
 	secret, err := os.ReadFile(secretPath)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return "", nil, fmt.Errorf(
 			"unable to read secret from disk (%s), %w",
 			secretPath,
 			err,
 		)
 	}
 
-	return secret, nil
+	return secretPath, secret, nil
 }
 
-// SetSecret saves the local SecretsManager's secret to disk
+// SetSecret saves the local SecretsManager's secret to disk.
+// The secret is encrypted if the passphrase is set
 func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 	// If the data directory is not specified, skip write
 	if l.path == "" {
@@ -139,6 +187,16 @@ func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 			secretPath,
 		)
 	}
+
+	if l.passphrase != "" {
+		encrypted, err := encryptSecret(value, l.passphrase)
+		if err != nil {
+			return fmt.Errorf("unable to encrypt secret, %w", err)
+		}
+
+		value = encrypted
+	}
+
 	// Write the secret to disk
 	if err := common.SaveFileSafe(secretPath, value, 0440); err != nil {
 		return fmt.Errorf(
@@ -153,11 +211,60 @@ func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 
 // HasSecret checks if the secret is present on disk
 func (l *LocalSecretsManager) HasSecret(name string) bool {
-	_, err := l.GetSecret(name)
+	_, _, err := l.readSecret(name)
 
 	return err == nil
 }
 
+// EncryptSecret encrypts the plaintext secret in place, using the passphrase of the SecretsManager.
+// It returns false if the secret is not present on disk or it is already encrypted
+func (l *LocalSecretsManager) EncryptSecret(name string) (bool, error) {
+	if l.passphrase == "" {
+		return false, errEmptyPassphrase
+	}
+
+	secretPath, secret, err := l.readSecret(name)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if isEncrypted(secret) {
+		return false, nil
+	}
+
+	encrypted, err := encryptSecret(secret, l.passphrase)
+	if err != nil {
+		return false, fmt.Errorf("unable to encrypt secret, %w", err)
+	}
+
+	// Secrets are read-only, so the encrypted secret is written
+	// to a temporary file, which then atomically replaces the plaintext one
+	tmpPath := secretPath + ".tmp"
+
+	// Remove leftover of the interrupted migration, since it can't be overwritten
+	_ = os.Remove(tmpPath)
+
+	if err := common.SaveFileSafe(tmpPath, encrypted, 0440); err != nil {
+		return false, fmt.Errorf(
+			"unable to write secret to disk (%s), %w",
+			tmpPath,
+			err,
+		)
+	}
+
+	if err := os.Rename(tmpPath, secretPath); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return false, fmt.Errorf("unable to replace secret (%s), %w", secretPath, err)
+	}
+
+	return true, nil
+}
+
 // RemoveSecret removes the local SecretsManager's secret from disk
 func (l *LocalSecretsManager) RemoveSecret(name string) error {
 	l.secretPathMapLock.Lock()
@@ -168,8 +275,6 @@ func (l *LocalSecretsManager) RemoveSecret(name string) error {
 		return secrets.ErrSecretNotFound
 	}
 
-	delete(l.secretPathMap, name)
-
 	if removeErr := os.Remove(secretPath); removeErr != nil {
 		return fmt.Errorf("unable to remove secret, %w", removeErr)
 	}
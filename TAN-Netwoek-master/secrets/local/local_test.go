@@ -3,6 +3,7 @@ package local
 import (
 	"encoding/hex"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/tarality/tan-network/crypto"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLocalSecretsManagerFactory(t *testing.T) {
@@ -272,3 +274,119 @@ func TestLocalSecretsManager_RemoveSecret(t *testing.T) {
 		})
 	}
 }
+
+// newEncryptedLocalSecretsManager is a helper method for creating an instance of the
+// local secrets manager, which encrypts the secrets stored in the given working directory
+func newEncryptedLocalSecretsManager(t *testing.T, workingDirectory, passphrase string) *LocalSecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path:       workingDirectory,
+			secrets.Passphrase: passphrase,
+		},
+	})
+	require.NoError(t, err)
+
+	localManager, ok := manager.(*LocalSecretsManager)
+	require.True(t, ok)
+
+	return localManager
+}
+
+// useLightScrypt lowers the scrypt cost parameter to speed up the tests
+func useLightScrypt(t *testing.T) {
+	t.Helper()
+
+	scryptN := keystoreScryptN
+	keystoreScryptN = 1 << 12
+
+	t.Cleanup(func() {
+		keystoreScryptN = scryptN
+	})
+}
+
+func TestLocalSecretsManager_Encrypted(t *testing.T) {
+	useLightScrypt(t)
+
+	workingDirectory := t.TempDir()
+
+	_, validatorKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	require.NoError(t, err)
+
+	manager := newEncryptedLocalSecretsManager(t, workingDirectory, "passphrase")
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, validatorKeyEncoded))
+
+	// the secret is stored in the v3 keystore format
+	raw, err := os.ReadFile(filepath.Join(workingDirectory, secrets.ConsensusFolderLocal, secrets.ValidatorKeyLocal))
+	require.NoError(t, err)
+	assert.True(t, isEncrypted(raw))
+	assert.NotContains(t, string(raw), string(validatorKeyEncoded))
+	assert.True(t, HasEncryptedSecrets(workingDirectory))
+
+	secret, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, validatorKeyEncoded, secret)
+
+	t.Run("Without passphrase", func(t *testing.T) {
+		manager := newEncryptedLocalSecretsManager(t, workingDirectory, "")
+
+		assert.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+		_, err := manager.GetSecret(secrets.ValidatorKey)
+		assert.ErrorIs(t, err, ErrPassphraseRequired)
+	})
+
+	t.Run("Wrong passphrase", func(t *testing.T) {
+		manager := newEncryptedLocalSecretsManager(t, workingDirectory, "wrong")
+
+		_, err := manager.GetSecret(secrets.ValidatorKey)
+		assert.Error(t, err)
+	})
+}
+
+func TestLocalSecretsManager_EncryptSecret(t *testing.T) {
+	useLightScrypt(t)
+
+	workingDirectory := t.TempDir()
+
+	_, validatorKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	require.NoError(t, err)
+
+	plaintextManager := newEncryptedLocalSecretsManager(t, workingDirectory, "")
+	require.NoError(t, plaintextManager.SetSecret(secrets.ValidatorKey, validatorKeyEncoded))
+	assert.False(t, HasEncryptedSecrets(workingDirectory))
+
+	_, err = plaintextManager.EncryptSecret(secrets.ValidatorKey)
+	assert.ErrorIs(t, err, errEmptyPassphrase)
+
+	manager := newEncryptedLocalSecretsManager(t, workingDirectory, "passphrase")
+
+	// plaintext secret is readable before the migration
+	secret, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, validatorKeyEncoded, secret)
+
+	encrypted, err := manager.EncryptSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+	assert.True(t, HasEncryptedSecrets(workingDirectory))
+
+	secret, err = manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, validatorKeyEncoded, secret)
+
+	// already encrypted secret is skipped
+	encrypted, err = manager.EncryptSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+
+	// missing secret is skipped
+	encrypted, err = manager.EncryptSecret(secrets.NetworkKey)
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+
+	_, err = plaintextManager.GetSecret(secrets.ValidatorKey)
+	assert.ErrorIs(t, err, ErrPassphraseRequired)
+}
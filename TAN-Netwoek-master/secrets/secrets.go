@@ -19,6 +19,9 @@ const (
 
 	// Name is the name of the current node
 	Name = "name"
+
+	// Passphrase is the passphrase used to encrypt the secrets of the local secrets manager
+	Passphrase = "passphrase"
 )
 
 // Define constant names for available secrets
@@ -29,15 +32,20 @@ const (
 	// ValidatorBLSKey is the bls secret key of the validator node
 	ValidatorBLSKey = "validator-bls-key"
 
+	// ValidatorBLSKeyNext is the pending bls secret key of the validator node,
+	// which replaces the current one once the key rotation takes effect
+	ValidatorBLSKeyNext = "validator-bls-key-next"
+
 	// NetworkKey is the libp2p private key secret used for networking
 	NetworkKey = "network-key"
 )
 
 // Define constant file names for the local StorageManager
 const (
-	ValidatorKeyLocal    = "validator.key"
-	ValidatorBLSKeyLocal = "validator-bls.key"
-	NetworkKeyLocal      = "libp2p.key"
+	ValidatorKeyLocal        = "validator.key"
+	ValidatorBLSKeyLocal     = "validator-bls.key"
+	ValidatorBLSKeyNextLocal = "validator-bls-next.key"
+	NetworkKeyLocal          = "libp2p.key"
 )
 
 // Define constant folder names for the local StorageManager
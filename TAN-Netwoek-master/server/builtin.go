@@ -7,7 +7,6 @@ import (
 	consensusDummy "github.com/tarality/tan-network/consensus/dummy"
 	consensusIBFT "github.com/tarality/tan-network/consensus/ibft"
 	consensusPolyBFT "github.com/tarality/tan-network/consensus/polybft"
-	"github.com/tarality/tan-network/forkmanager"
 	"github.com/tarality/tan-network/secrets"
 	"github.com/tarality/tan-network/secrets/awsssm"
 	"github.com/tarality/tan-network/secrets/gcpssm"
@@ -20,10 +19,6 @@ type GenesisFactoryHook func(config *chain.Chain, engineName string) func(*state
 
 type ConsensusType string
 
-type ForkManagerFactory func(forks *chain.Forks) error
-
-type ForkManagerInitialParamsFactory func(config *chain.Chain) (*forkmanager.ForkParams, error)
-
 const (
 	DevConsensus     ConsensusType = "dev"
 	IBFTConsensus    ConsensusType = "ibft"
@@ -51,14 +46,6 @@ var genesisCreationFactory = map[ConsensusType]GenesisFactoryHook{
 	PolyBFTConsensus: consensusPolyBFT.GenesisPostHookFactory,
 }
 
-var forkManagerFactory = map[ConsensusType]ForkManagerFactory{
-	PolyBFTConsensus: consensusPolyBFT.ForkManagerFactory,
-}
-
-var forkManagerInitialParamsFactory = map[ConsensusType]ForkManagerInitialParamsFactory{
-	PolyBFTConsensus: consensusPolyBFT.ForkManagerInitialParamsFactory,
-}
-
 func ConsensusSupported(value string) bool {
 	_, ok := consensusBackends[ConsensusType(value)]
 
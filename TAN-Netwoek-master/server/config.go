@@ -8,6 +8,7 @@ import (
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/network"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
 )
 
 const DefaultGRPCPort int = 9632
@@ -31,10 +32,38 @@ type Config struct {
 	DataDir     string
 	RestoreFile *string
 
+	// FreezerDir is the directory of the ancient store, defaults to the blockchain data directory
+	FreezerDir string
+	// FreezerThreshold is the number of the most recent blocks kept in the key-value store,
+	// older canonical blocks are moved to the ancient store. Value of 0 stops freezing the new blocks,
+	// but the existing ancient store is still opened, so the already frozen blocks stay readable
+	FreezerThreshold uint64
+
+	// AddressIndex enables the address to transaction index
+	AddressIndex bool
+	// AddressIndexInternalTransfers enables indexing of the internal value transfers (requires block tracing)
+	AddressIndexInternalTransfers bool
+
+	// BloomIndex enables the bloom bits log index used by eth_getLogs
+	BloomIndex bool
+
+	// ParallelExecutionWorkers is the number of workers executing the block transactions in parallel
+	ParallelExecutionWorkers int
+
+	// StoreRevertReasons stores the revert data of the reverted transactions with their receipts
+	StoreRevertReasons bool
+
 	Seal bool
 
 	SecretsManager *secrets.SecretsManagerConfig
 
+	// SecretsPassphrase is the passphrase of the encrypted local secrets (optional)
+	SecretsPassphrase string
+
+	// RemoteSigner is the configuration of the remote signer holding the validator keys.
+	// If it is not set, validator keys are loaded from the secrets manager
+	RemoteSigner *keysigner.RemoteConfig
+
 	LogLevel hclog.Level
 
 	JSONLogFormat bool
@@ -44,6 +73,14 @@ type Config struct {
 	Relayer bool
 
 	NumBlockConfirmations uint64
+
+	// UptimeAlertThreshold is the number of consecutively missed blocks
+	// after which a validator is reported as silent (PolyBFT only). Value of 0 disables alerting
+	UptimeAlertThreshold uint64
+
+	// ByzantineDoubleSign makes the validator sign conflicting consensus messages
+	// (PolyBFT only, used only for testing double sign detection)
+	ByzantineDoubleSign bool
 }
 
 // Telemetry holds the config details for metric services
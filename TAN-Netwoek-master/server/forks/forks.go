@@ -0,0 +1,107 @@
+package forks
+
+import (
+	"fmt"
+
+	"github.com/tarality/tan-network/chain"
+	consensusPolyBFT "github.com/tarality/tan-network/consensus/polybft"
+	"github.com/tarality/tan-network/forkmanager"
+	"github.com/tarality/tan-network/types"
+)
+
+type ForkManagerFactory func(forks *chain.Forks) error
+
+type ForkManagerInitialParamsFactory func(config *chain.Chain) (*forkmanager.ForkParams, error)
+
+var forkManagerFactory = map[string]ForkManagerFactory{
+	consensusPolyBFT.ConsensusName: consensusPolyBFT.ForkManagerFactory,
+}
+
+var forkManagerInitialParamsFactory = map[string]ForkManagerInitialParamsFactory{
+	consensusPolyBFT.ConsensusName: consensusPolyBFT.ForkManagerInitialParamsFactory,
+}
+
+// validateBaseFeeDistribution checks the percentages of the base fee distribution and its changes by the forks
+func validateBaseFeeDistribution(params *chain.Params) error {
+	if params.BaseFeeDistribution != nil {
+		if err := params.BaseFeeDistribution.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for name, f := range *params.Forks {
+		if f.Params == nil || f.Params.BaseFeeDistribution == nil {
+			continue
+		}
+
+		if params.BaseFeeDistribution == nil {
+			return fmt.Errorf("fork %s sets the base fee distribution, but it is not configured", name)
+		}
+
+		if err := f.Params.BaseFeeDistribution.Validate(); err != nil {
+			return fmt.Errorf("fork %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Init registers and activates the forks of the chain config for the given consensus engine.
+// It is used by the server and by the offline tools which execute blocks outside of a running server
+func Init(engineName string, config *chain.Chain) error {
+	var initialParams *forkmanager.ForkParams
+
+	if factory := forkManagerInitialParamsFactory[engineName]; factory != nil {
+		params, err := factory(config)
+		if err != nil {
+			return err
+		}
+
+		initialParams = params
+	}
+
+	if err := validateBaseFeeDistribution(config.Params); err != nil {
+		return err
+	}
+
+	fm := forkmanager.GetInstance()
+
+	// clear everything in forkmanager (if there was something because of tests) and register initial fork
+	fm.Clear()
+	fm.RegisterFork(forkmanager.InitialFork, initialParams)
+
+	// Register forks
+	for name, f := range *config.Params.Forks {
+		// check if fork is not supported by current node version
+		if _, found := (*chain.AllForksEnabled)[name]; !found {
+			return fmt.Errorf("fork is not available: %s", name)
+		}
+
+		fm.RegisterFork(name, f.Params)
+	}
+
+	// Register handlers and additional forks here
+	if err := types.RegisterTxHashFork(chain.TxHashWithType); err != nil {
+		return err
+	}
+
+	if factory := forkManagerFactory[engineName]; factory != nil {
+		if err := factory(config.Params.Forks); err != nil {
+			return err
+		}
+	}
+
+	// Activate initial fork
+	if err := fm.ActivateFork(forkmanager.InitialFork, uint64(0)); err != nil {
+		return err
+	}
+
+	// Activate forks
+	for name, f := range *config.Params.Forks {
+		if err := fm.ActivateFork(name, f.Block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1135,6 +1135,8 @@ func (m *ExportRequest) validate(all bool) error {
 
 	// no validation rules for To
 
+	// no validation rules for Receipts
+
 	if len(errors) > 0 {
 		return ExportRequestMultiError(errors)
 	}
@@ -16,7 +16,6 @@ import (
 	"github.com/tarality/tan-network/blockchain/storage/leveldb"
 	"github.com/tarality/tan-network/blockchain/storage/memory"
 	consensusPolyBFT "github.com/tarality/tan-network/consensus/polybft"
-	"github.com/tarality/tan-network/forkmanager"
 	"github.com/tarality/tan-network/gasprice"
 
 	"github.com/tarality/tan-network/archive"
@@ -32,6 +31,8 @@ import (
 	"github.com/tarality/tan-network/jsonrpc"
 	"github.com/tarality/tan-network/network"
 	"github.com/tarality/tan-network/secrets"
+	"github.com/tarality/tan-network/secrets/keysigner"
+	"github.com/tarality/tan-network/server/forks"
 	"github.com/tarality/tan-network/server/proto"
 	"github.com/tarality/tan-network/state"
 	itrie "github.com/tarality/tan-network/state/immutable-trie"
@@ -86,6 +87,9 @@ type Server struct {
 	// secrets manager
 	secretsManager secrets.SecretsManager
 
+	// keySigner holds the validator keys when they are not loaded from the secrets manager
+	keySigner keysigner.KeySigner
+
 	// restore
 	restoreProgression *progress.ProgressionWrapper
 
@@ -183,6 +187,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to set up the secrets manager: %w", err)
 	}
 
+	// Set up the remote signer
+	if err := m.setupRemoteSigner(); err != nil {
+		return nil, fmt.Errorf("failed to set up the remote signer: %w", err)
+	}
+
 	// start libp2p
 	{
 		netConfig := config.Network
@@ -209,6 +218,8 @@ func NewServer(config *Config) (*Server, error) {
 	m.state = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
+	m.executor.ParallelWorkers = config.ParallelExecutionWorkers
+	m.executor.StoreRevertReasons = config.StoreRevertReasons
 
 	// custom write genesis hook per consensus engine
 	engineName := m.config.Chain.Params.GetEngine()
@@ -252,6 +263,18 @@ func NewServer(config *Config) (*Server, error) {
 			m.config.Chain.Params.BridgeBlockList)
 	}
 
+	// apply gas fee allow list genesis data
+	if m.config.Chain.Params.GasFeeAllowList != nil {
+		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListGasFeeAddr,
+			&m.config.Chain.Params.GasFeeAllowList.AddressListConfig)
+	}
+
+	// apply call permission list genesis data
+	if m.config.Chain.Params.CallPermissionList != nil {
+		addresslist.ApplyCallPermissionGenesisAllocs(m.config.Chain.Genesis, contracts.CallPermissionListAddr,
+			m.config.Chain.Params.CallPermissionList)
+	}
+
 	var initialStateRoot = types.ZeroHash
 
 	if ConsensusType(engineName) == PolyBFTConsensus {
@@ -281,7 +304,7 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
-	if err := initForkManager(engineName, config.Chain); err != nil {
+	if err := forks.Init(engineName, config.Chain); err != nil {
 		return nil, err
 	}
 
@@ -306,6 +329,18 @@ func NewServer(config *Config) (*Server, error) {
 			if err != nil {
 				return nil, err
 			}
+		} else if freezerDir := m.freezerDir(); m.config.FreezerThreshold > 0 || common.DirectoryExists(freezerDir) {
+			// the already frozen blocks have to stay readable, so the existing ancient store is always opened.
+			// Threshold of 0 only stops freezing the new blocks
+			db, err = leveldb.NewLevelDBStorageWithAncients(
+				filepath.Join(m.config.DataDir, "blockchain"),
+				freezerDir,
+				m.config.FreezerThreshold,
+				m.logger,
+			)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			db, err = leveldb.NewLevelDBStorage(
 				filepath.Join(m.config.DataDir, "blockchain"),
@@ -330,6 +365,16 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if m.config.AddressIndex {
+		if err := m.blockchain.EnableAddressIndex(m.config.AddressIndexInternalTransfers); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.config.BloomIndex {
+		m.blockchain.EnableBloomIndexer()
+	}
+
 	// here we can provide some other configuration
 	m.gasHelper, err = gasprice.NewGasHelper(gasprice.DefaultGasHelperConfig, m.blockchain)
 	if err != nil {
@@ -356,6 +401,7 @@ func NewServer(config *Config) (*Server, error) {
 				PriceLimit:         m.config.PriceLimit,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
 				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
+				GasFeeAllowList:    m.config.Chain.Params.GasFeeAllowList,
 			},
 		)
 		if err != nil {
@@ -435,6 +481,15 @@ func unaryInterceptor(
 	return handler(ctx, req)
 }
 
+// freezerDir returns the directory of the ancient store
+func (s *Server) freezerDir() string {
+	if s.config.FreezerDir != "" {
+		return s.config.FreezerDir
+	}
+
+	return filepath.Join(s.config.DataDir, "blockchain", "ancient")
+}
+
 func (s *Server) restoreChain() error {
 	if s.config.RestoreFile == nil {
 		return nil
@@ -495,6 +550,23 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 	return account.Balance, nil
 }
 
+// IsGasFeeAllowListed returns true if the address is enabled in the gas fee allow list
+func (t *txpoolHub) IsGasFeeAllowListed(root types.Hash, addr types.Address) bool {
+	snap, err := t.state.NewSnapshotAt(root)
+	if err != nil {
+		return false
+	}
+
+	account, err := snap.GetAccount(contracts.AllowListGasFeeAddr)
+	if err != nil || account == nil {
+		return false
+	}
+
+	role := snap.GetStorage(contracts.AllowListGasFeeAddr, account.Root, types.BytesToHash(addr.Bytes()))
+
+	return addresslist.Role(role).Enabled()
+}
+
 // setupSecretsManager sets up the secrets manager
 func (s *Server) setupSecretsManager() error {
 	secretsManagerConfig := s.config.SecretsManager
@@ -516,6 +588,11 @@ func (s *Server) setupSecretsManager() error {
 		secretsManagerParams.Extra = map[string]interface{}{
 			secrets.Path: s.config.DataDir,
 		}
+
+		// The secrets are encrypted if the passphrase is provided
+		if s.config.SecretsPassphrase != "" {
+			secretsManagerParams.Extra[secrets.Passphrase] = s.config.SecretsPassphrase
+		}
 	}
 
 	// Grab the factory method
@@ -539,6 +616,25 @@ func (s *Server) setupSecretsManager() error {
 	return nil
 }
 
+// setupRemoteSigner sets up the remote signer holding the validator keys, if it is configured
+func (s *Server) setupRemoteSigner() error {
+	if s.config.RemoteSigner == nil || s.config.RemoteSigner.URL == "" {
+		return nil
+	}
+
+	remoteSigner, err := keysigner.NewRemoteSigner(s.config.RemoteSigner)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("validator keys are held by the remote signer",
+		"url", s.config.RemoteSigner.URL, "address", remoteSigner.Address())
+
+	s.keySigner = remoteSigner
+
+	return nil
+}
+
 // setupConsensus sets up the consensus mechanism
 func (s *Server) setupConsensus() error {
 	engineName := s.config.Chain.Params.GetEngine()
@@ -582,8 +678,11 @@ func (s *Server) setupConsensus() error {
 			Grpc:                  s.grpcServer,
 			Logger:                s.logger,
 			SecretsManager:        s.secretsManager,
+			KeySigner:             s.keySigner,
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
+			UptimeAlertThreshold:  s.config.UptimeAlertThreshold,
+			ByzantineDoubleSign:   s.config.ByzantineDoubleSign,
 		},
 	)
 
@@ -624,9 +723,9 @@ func extractBlockTime(engineConfig map[string]interface{}) (common.Duration, err
 
 // setupRelayer sets up the relayer
 func (s *Server) setupRelayer() error {
-	account, err := wallet.NewAccountFromSecret(s.secretsManager)
+	key, err := s.relayerKey()
 	if err != nil {
-		return fmt.Errorf("failed to create account from secret: %w", err)
+		return err
 	}
 
 	polyBFTConfig, err := consensusPolyBFT.GetPolyBFTConfig(s.config.Chain)
@@ -645,7 +744,7 @@ func (s *Server) setupRelayer() error {
 		ethgo.Address(contracts.StateReceiverContract),
 		trackerStartBlockConfig[contracts.StateReceiverContract],
 		s.logger.Named("relayer"),
-		wallet.NewEcdsaSigner(wallet.NewKey(account)),
+		wallet.NewEcdsaSigner(key),
 	)
 
 	// start relayer
@@ -656,6 +755,20 @@ func (s *Server) setupRelayer() error {
 	return nil
 }
 
+// relayerKey returns the validator key used by the relayer to sign transactions
+func (s *Server) relayerKey() (*wallet.Key, error) {
+	if s.keySigner != nil {
+		return wallet.NewKeyFromSigner(s.keySigner), nil
+	}
+
+	account, err := wallet.NewAccountFromSecret(s.secretsManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account from secret: %w", err)
+	}
+
+	return wallet.NewKey(account), nil
+}
+
 type jsonRPCHub struct {
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
@@ -687,11 +800,65 @@ func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.A
 	return account, nil
 }
 
+// GetBaseFeeRecipients returns the recipients of the base fee of the block,
+// or nil if the base fee isn't charged in the block
+func (j *jsonRPCHub) GetBaseFeeRecipients(header *types.Header) (*jsonrpc.BaseFeeRecipients, error) {
+	if !j.Executor.GetForksInTime(header.Number).London {
+		return nil, nil
+	}
+
+	params := j.Blockchain.Config()
+
+	burnContract, err := params.CalculateBurnContract(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	proposer, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := &jsonrpc.BaseFeeRecipients{
+		BurnContract: burnContract,
+		Proposer:     proposer,
+	}
+
+	if distribution := params.GetBaseFeeDistribution(header.Number); distribution != nil {
+		recipients.BaseFeeDistribution = distribution.BaseFeeDistribution
+		recipients.Treasury = distribution.Treasury
+	} else {
+		recipients.BurnPercent = 100
+	}
+
+	return recipients, nil
+}
+
 // GetForksInTime returns the active forks at the given block height
 func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return j.Executor.GetForksInTime(blockNumber)
 }
 
+// FinalizedHeader returns the header of the latest block which is considered final by the consensus engine
+func (j *jsonRPCHub) FinalizedHeader() *types.Header {
+	head := j.Blockchain.Header()
+	if head == nil {
+		return nil
+	}
+
+	number := j.Consensus.GetFinalizedBlockNumber(head.Number)
+	if number == head.Number {
+		return head
+	}
+
+	header, ok := j.Blockchain.GetHeaderByNumber(number)
+	if !ok {
+		return nil
+	}
+
+	return header
+}
+
 func (j *jsonRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot types.Hash) ([]byte, error) {
 	account, err := getAccountImpl(j.state, stateRoot, addr)
 	if err != nil {
@@ -748,6 +915,82 @@ func (j *jsonRPCHub) ApplyTxn(
 	return
 }
 
+// SimulateBlocks applies the calls of the simulated blocks in order on top of the state of the given header.
+// Every block continues from the uncommitted state of the previous one
+func (j *jsonRPCHub) SimulateBlocks(
+	header *types.Header,
+	blocks []*jsonrpc.SimulatedBlock,
+) ([][]*jsonrpc.SimulatedCallResult, error) {
+	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		transition *state.Transition
+		results    = make([][]*jsonrpc.SimulatedCallResult, len(blocks))
+	)
+
+	for i, block := range blocks {
+		coinbase := blockCreator
+		if block.Coinbase != nil {
+			coinbase = *block.Coinbase
+		}
+
+		if transition == nil {
+			transition, err = j.BeginTxn(header.StateRoot, block.Header, coinbase)
+		} else {
+			transition, err = j.ContinueTxn(transition, block.Header, coinbase)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Override != nil {
+			if err := transition.WithStateOverride(block.Override); err != nil {
+				return nil, err
+			}
+		}
+
+		results[i] = make([]*jsonrpc.SimulatedCallResult, len(block.Calls))
+		gasUsed := uint64(0)
+		eip158 := j.Blockchain.Config().Forks.IsActive(chain.EIP158, block.Header.Number)
+
+		for k, call := range block.Calls {
+			txn := call.Tx
+
+			if call.FillNonce {
+				txn.Nonce = transition.GetNonce(txn.From)
+				txn.ComputeHash(block.Header.Number)
+			}
+
+			// If the caller didn't supply the gas limit, the gas left in the block is used
+			if txn.Gas == 0 {
+				txn.Gas = block.Header.GasLimit - gasUsed
+			}
+
+			result, err := transition.Apply(txn)
+			if err != nil {
+				return nil, fmt.Errorf("block %d, call %d: %w", block.Header.Number, k, err)
+			}
+
+			logs := transition.Txn().Logs()
+
+			// The suicided accounts are set as deleted for the next call,
+			// the empty ones only if EIP-158 is active at the simulated block
+			if err := transition.Txn().CleanDeleteObjects(eip158); err != nil {
+				return nil, err
+			}
+
+			gasUsed += result.GasUsed
+			results[i][k] = &jsonrpc.SimulatedCallResult{Result: result, Logs: logs}
+		}
+	}
+
+	return results, nil
+}
+
 // TraceBlock traces all transactions in the given block and returns all results
 func (j *jsonRPCHub) TraceBlock(
 	block *types.Block,
@@ -882,6 +1125,24 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+func (j *jsonRPCHub) GetValidatorsUptime(blocks uint64) ([]*types.ValidatorUptime, error) {
+	provider := j.Consensus.GetUptimeProvider()
+	if provider == nil {
+		return nil, jsonrpc.ErrUptimeNotSupported
+	}
+
+	return provider.GetValidatorsUptime(blocks)
+}
+
+func (j *jsonRPCHub) GetEpochUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	provider := j.Consensus.GetUptimeProvider()
+	if provider == nil {
+		return nil, jsonrpc.ErrUptimeNotSupported
+	}
+
+	return provider.GetEpochUptime(epoch)
+}
+
 // SETUP //
 
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
@@ -1020,57 +1281,3 @@ func (s *Server) startPrometheusServer(listenAddr *net.TCPAddr) *http.Server {
 
 	return srv
 }
-
-func initForkManager(engineName string, config *chain.Chain) error {
-	var initialParams *forkmanager.ForkParams
-
-	if factory := forkManagerInitialParamsFactory[ConsensusType(engineName)]; factory != nil {
-		params, err := factory(config)
-		if err != nil {
-			return err
-		}
-
-		initialParams = params
-	}
-
-	fm := forkmanager.GetInstance()
-
-	// clear everything in forkmanager (if there was something because of tests) and register initial fork
-	fm.Clear()
-	fm.RegisterFork(forkmanager.InitialFork, initialParams)
-
-	// Register forks
-	for name, f := range *config.Params.Forks {
-		// check if fork is not supported by current node version
-		if _, found := (*chain.AllForksEnabled)[name]; !found {
-			return fmt.Errorf("fork is not available: %s", name)
-		}
-
-		fm.RegisterFork(name, f.Params)
-	}
-
-	// Register handlers and additional forks here
-	if err := types.RegisterTxHashFork(chain.TxHashWithType); err != nil {
-		return err
-	}
-
-	if factory := forkManagerFactory[ConsensusType(engineName)]; factory != nil {
-		if err := factory(config.Params.Forks); err != nil {
-			return err
-		}
-	}
-
-	// Activate initial fork
-	if err := fm.ActivateFork(forkmanager.InitialFork, uint64(0)); err != nil {
-		return err
-	}
-
-	// Activate forks
-	for name, f := range *config.Params.Forks {
-		if err := fm.ActivateFork(name, f.Block); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/tarality/tan-network/blockchain"
+	"github.com/tarality/tan-network/blockchain/storage"
 	"github.com/tarality/tan-network/network/common"
 	"github.com/tarality/tan-network/server/proto"
 	"github.com/tarality/tan-network/types"
@@ -187,8 +188,8 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	if req.To != 0 {
-		if from >= req.To {
-			return errors.New("to must be greater than from")
+		if from > req.To {
+			return errors.New("to must be greater than or equal to from")
 		}
 
 		to = &req.To
@@ -213,7 +214,19 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 			break
 		}
 
-		if err := writer.appendBlock(block); err != nil {
+		var receipts *types.Receipts
+
+		if req.Receipts {
+			// genesis has no receipts stored
+			blockReceipts, err := s.server.blockchain.GetReceiptsByHash(block.Hash())
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return err
+			}
+
+			receipts = (*types.Receipts)(&blockReceipts)
+		}
+
+		if err := writer.appendBlock(block, receipts); err != nil {
 			return err
 		}
 
@@ -256,8 +269,12 @@ func newBlockStreamWriter(
 	}
 }
 
-func (w *blockStreamWriter) appendBlock(b *types.Block) error {
+// appendBlock appends the block to the buffer, followed by its receipts (in the store format) if given
+func (w *blockStreamWriter) appendBlock(b *types.Block, receipts *types.Receipts) error {
 	data := b.MarshalRLP()
+	if receipts != nil {
+		data = receipts.MarshalStoreRLPTo(data)
+	}
 	if uint64(maxHeaderInfoSize+w.buf.Len()+len(data)) >= w.maxPayload {
 		// send buffered data to client first
 		if err := w.flush(); err != nil {
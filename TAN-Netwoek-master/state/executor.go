@@ -11,6 +11,7 @@ import (
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/contracts"
 	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/helper/common"
 	"github.com/tarality/tan-network/state/runtime"
 	"github.com/tarality/tan-network/state/runtime/addresslist"
 	"github.com/tarality/tan-network/state/runtime/evm"
@@ -25,6 +26,9 @@ const (
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address of the access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key of the access list
 )
 
 // GetHashByNumber returns the hash function of a block number
@@ -40,16 +44,28 @@ type Executor struct {
 	state   State
 	GetHash GetHashByNumberHelper
 
+	// codeCache is the code cache shared by the transitions of the executor
+	codeCache *evm.CodeCache
+
 	PostHook        func(txn *Transition)
 	GenesisPostHook func(*Transition) error
+
+	// ParallelWorkers is the number of workers executing the block transactions in parallel,
+	// the transactions are executed sequentially if it's less than 2
+	ParallelWorkers int
+	// StoreRevertReasons sets the return data of the reverted transactions on their receipts
+	StoreRevertReasons bool
 }
 
 // NewExecutor creates a new executor
 func NewExecutor(config *chain.Params, s State, logger hclog.Logger) *Executor {
+	codeCache, _ := evm.NewCodeCache(evm.DefaultCodeCacheSize)
+
 	return &Executor{
-		logger: logger,
-		config: config,
-		state:  s,
+		logger:    logger,
+		config:    config,
+		state:     s,
+		codeCache: codeCache,
 	}
 }
 
@@ -142,6 +158,14 @@ func (e *Executor) ProcessBlock(
 		return nil, err
 	}
 
+	if e.ParallelWorkers > 1 {
+		if err := txn.writeParallel(block, e.ParallelWorkers); err != nil {
+			return nil, err
+		}
+
+		return txn, nil
+	}
+
 	for _, t := range block.Transactions {
 		if t.Gas > block.Header.GasLimit {
 			continue
@@ -175,14 +199,35 @@ func (e *Executor) BeginTxn(
 	header *types.Header,
 	coinbaseReceiver types.Address,
 ) (*Transition, error) {
-	// fmt.Println("------------line no 178-----", header.BaseFee, header.GasLimit, header.Difficulty)
-	forkConfig := e.config.Forks.At(header.Number)
-
 	auxSnap2, err := e.state.NewSnapshotAt(parentRoot)
 	if err != nil {
 		return nil, err
 	}
 
+	return e.newTransition(auxSnap2, NewTxn(auxSnap2), header, coinbaseReceiver)
+}
+
+// ContinueTxn starts a transition for the header on top of the uncommitted state changes of the parent transition.
+// It is used to simulate consecutive blocks without committing the state of the previous ones
+func (e *Executor) ContinueTxn(
+	parent *Transition,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	return e.newTransition(parent.snap, parent.state, header, coinbaseReceiver)
+}
+
+func (e *Executor) newTransition(
+	auxSnap2 Snapshot,
+	newTxn *Txn,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	// fmt.Println("------------line no 178-----", header.BaseFee, header.GasLimit, header.Difficulty)
+	forkConfig := e.config.Forks.At(header.Number)
+
+	var err error
+
 	burnContract := types.ZeroAddress
 	if forkConfig.London {
 		burnContract, err = e.config.CalculateBurnContract(header.Number)
@@ -191,8 +236,6 @@ func (e *Executor) BeginTxn(
 		}
 	}
 
-	newTxn := NewTxn(auxSnap2)
-
 	txCtx := runtime.TxContext{
 		Coinbase:        coinbaseReceiver,
 		Timestamp:       int64(header.Timestamp),
@@ -221,9 +264,17 @@ func (e *Executor) BeginTxn(
 		receipts: []*types.Receipt{},
 		totalGas: 0,
 
-		evm:         evm.NewEVM(),
+		evm:         evm.NewEVMWithCodeCache(e.codeCache),
 		precompiles: precompiled.NewPrecompiled(),
 		PostHook:    e.PostHook,
+		codeCache:   e.codeCache,
+
+		storeRevertReasons: e.StoreRevertReasons,
+	}
+
+	// the base fee is distributed only once it's charged
+	if forkConfig.London {
+		txn.baseFeeDistribution = e.config.GetBaseFeeDistribution(header.Number)
 	}
 
 	// enable contract deployment allow list (if any)
@@ -253,6 +304,17 @@ func (e *Executor) BeginTxn(
 		txn.bridgeBlockList = addresslist.NewAddressList(txn, contracts.BlockListBridgeAddr)
 	}
 
+	// enable gas fee allow list (if any)
+	if e.config.GasFeeAllowList != nil {
+		txn.gasFeeAllowList = addresslist.NewAddressList(txn, contracts.AllowListGasFeeAddr)
+		txn.gasFeeAllowListConfig = e.config.GasFeeAllowList
+	}
+
+	// enable call permission list (if any)
+	if e.config.CallPermissionList != nil {
+		txn.callPermissionList = addresslist.NewCallPermissionList(txn, contracts.CallPermissionListAddr)
+	}
+
 	return txn, nil
 }
 
@@ -287,6 +349,27 @@ type Transition struct {
 	txnBlockList        *addresslist.AddressList
 	bridgeAllowList     *addresslist.AddressList
 	bridgeBlockList     *addresslist.AddressList
+	gasFeeAllowList     *addresslist.AddressList
+
+	// gasFeeAllowListConfig sets the gas price discount of the gas fee allow list
+	gasFeeAllowListConfig *chain.GasFeeAllowListConfig
+
+	// callPermissionList restricts the callers of the contract functions
+	callPermissionList *addresslist.CallPermissionList
+
+	// baseFeeDistribution splits the base fee of the block, nil if it's burnt.
+	// baseFeePaid is the base fee paid by the last applied transaction
+	baseFeeDistribution *chain.BaseFeeDistributionConfig
+	baseFeePaid         *big.Int
+
+	// speculative is set for the transitions executing transactions in parallel,
+	// deferredFees are the fees they pay once their transaction is written
+	speculative  bool
+	deferredFees []*deferredFee
+	// storeRevertReasons is set if the revert data is kept on the receipts
+	storeRevertReasons bool
+	// codeCache is the code cache shared with the other transitions, nil if the code isn't cached
+	codeCache *evm.CodeCache
 }
 
 func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -342,10 +425,28 @@ var emptyFrom = types.Address{}
 
 // Write writes another transaction to the executor
 func (t *Transition) Write(txn *types.Transaction) error {
+	if err := t.recoverSender(txn); err != nil {
+		return err
+	}
+
+	// Make a local copy and apply the transaction
+	msg := txn.Copy()
+	result, e := t.Apply(msg)
+	if e != nil {
+		t.logger.Error("failed to apply tx", "err", e)
+
+		return e
+	}
+
+	return t.addReceipt(txn, msg, result, t.state.Logs(), t.baseFeePaid)
+}
+
+// recoverSender sets the sender of the transaction if it isn't known yet
+func (t *Transition) recoverSender(txn *types.Transaction) error {
 	var err error
 
 	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
+		(txn.Type == types.LegacyTx || txn.Type.IsDynamicFee()) {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -355,24 +456,44 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
-	// Make a local copy and apply the transaction
-	msg := txn.Copy()
-	result, e := t.Apply(msg)
-	if e != nil {
-		t.logger.Error("failed to apply tx", "err", e)
+	if txn.Type == types.FeeDelegatedTx {
+		// The fee payer is charged for the gas, so its signature is always verified
+		if err = t.verifyFeePayer(txn); err != nil {
+			return NewTransitionApplicationError(err, false)
+		}
+	}
 
-		return e
+	return nil
+}
+
+// verifyFeePayer checks the fee payer of the fee delegated transaction signed it
+func (t *Transition) verifyFeePayer(txn *types.Transaction) error {
+	signer, ok := crypto.NewSigner(t.config, uint64(t.ctx.ChainID)).(crypto.FeePayerSigner)
+	if !ok {
+		return ErrFeeDelegationDisabled
 	}
 
-	t.totalGas += result.GasUsed
+	_, err := signer.FeePayer(txn)
 
-	logs := t.state.Logs()
+	return err
+}
+
+// addReceipt adds the receipt of the applied transaction
+func (t *Transition) addReceipt(
+	txn *types.Transaction,
+	msg *types.Transaction,
+	result *runtime.ExecutionResult,
+	logs []*types.Log,
+	baseFeePaid *big.Int,
+) error {
+	t.totalGas += result.GasUsed
 
 	receipt := &types.Receipt{
 		CumulativeGasUsed: t.totalGas,
 		TransactionType:   txn.Type,
 		TxHash:            txn.Hash,
 		GasUsed:           result.GasUsed,
+		BaseFeePaid:       baseFeePaid,
 	}
 
 	// The suicided accounts are set as deleted for the next iteration
@@ -382,6 +503,10 @@ func (t *Transition) Write(txn *types.Transaction) error {
 
 	if result.Failed() {
 		receipt.SetStatus(types.ReceiptFailed)
+
+		if t.storeRevertReasons && result.Reverted() && len(result.ReturnValue) > 0 {
+			receipt.RevertReason = append([]byte(nil), result.ReturnValue...)
+		}
 	} else {
 		receipt.SetStatus(types.ReceiptSuccess)
 	}
@@ -453,6 +578,43 @@ func (t *Transition) addGasPool(amount uint64) {
 	t.gasPool += amount
 }
 
+// distributeBaseFee pays the base fee part of the transaction fee by the base fee distribution of the block,
+// or burns it if there is no distribution
+func (t *Transition) distributeBaseFee(amount *big.Int) {
+	t.baseFeePaid = amount
+
+	if t.baseFeeDistribution == nil {
+		t.creditFee(t.ctx.BurnContract, amount)
+
+		return
+	}
+
+	burn, treasury, proposer := t.baseFeeDistribution.Split(amount)
+
+	t.creditFee(t.ctx.BurnContract, burn)
+
+	// the destinations without a share are not touched
+	if treasury.Sign() > 0 {
+		t.creditFee(t.baseFeeDistribution.Treasury, treasury)
+	}
+
+	if proposer.Sign() > 0 {
+		t.creditFee(t.ctx.Coinbase, proposer)
+	}
+}
+
+// creditFee pays the fee of the transaction to the address. The fees of a transaction
+// executed speculatively are paid once it's written, so the transactions don't conflict on them
+func (t *Transition) creditFee(addr types.Address, amount *big.Int) {
+	if t.speculative {
+		t.deferredFees = append(t.deferredFees, &deferredFee{addr: addr, amount: amount})
+
+		return
+	}
+
+	t.state.AddBalance(addr, amount)
+}
+
 func (t *Transition) Txn() *Txn {
 	return t.state
 }
@@ -483,7 +645,15 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	upfrontGasCost := GetLondonFixHandler(uint64(t.ctx.Number)).getUpfrontGasCost(msg, t.ctx.BaseFee)
 
-	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
+	if t.gasFeeDiscounted(msg) {
+		upfrontGasCost = new(big.Int).Mul(
+			new(big.Int).SetUint64(msg.Gas),
+			t.gasFeeAllowListConfig.Discount(msg.GetGasPrice(t.ctx.BaseFee.Uint64())),
+		)
+	}
+
+	// the gas is bought by the fee payer of the fee delegated transaction
+	if err := t.state.SubBalance(msg.GasPayer(), upfrontGasCost); err != nil {
 		if errors.Is(err, runtime.ErrNotEnoughFunds) {
 			return ErrNotEnoughFundsForGas
 		}
@@ -494,6 +664,16 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	return nil
 }
 
+// gasFeeDiscounted returns true if the gas payer of the transaction is enabled in the gas fee allow list,
+// so it pays the discounted gas price
+func (t *Transition) gasFeeDiscounted(msg *types.Transaction) bool {
+	if t.gasFeeAllowList == nil || msg.Type == types.StateTx {
+		return false
+	}
+
+	return t.gasFeeAllowList.GetRole(msg.GasPayer()).Enabled()
+}
+
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
@@ -537,6 +717,8 @@ var (
 	ErrFeeCapTooLow = errors.New("max fee per gas less than block base fee")
 	// ErrNonceUintOverflow is returned if uint64 overflow happens
 	ErrNonceUintOverflow = errors.New("nonce uint64 overflow")
+	// ErrFeeDelegationDisabled is returned for the fee delegated transaction if the fee delegation fork isn't active
+	ErrFeeDelegationDisabled = errors.New("fee delegated transactions are not enabled")
 )
 
 type TransitionApplicationError struct {
@@ -568,6 +750,10 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	// the discount is resolved before the execution, which may update the gas fee allow list
+	gasFeeDiscounted := t.gasFeeDiscounted(msg)
+	t.baseFeePaid = nil
+
 	if msg.Type == types.StateTx {
 		err = checkAndProcessStateTx(msg)
 	} else {
@@ -606,6 +792,12 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// set the specific transaction fields in the context
 	t.ctx.GasPrice = types.BytesToHash(gasPrice.Bytes())
 	t.ctx.Origin = msg.From
+
+	// eip-2929 the sender, the recipient and the precompiles are accessed by the transaction
+	if t.config.Berlin {
+		t.state.PrepareAccessList(msg.From, msg.To, t.precompiles.Addresses(&t.config), msg.AccessList)
+	}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
@@ -618,18 +810,32 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 
 	refund := t.state.GetRefund()
 	// fmt.Println("----------------refund", refund)
-	result.UpdateGasUsed(msg.Gas, refund)
+	refundQuotient := runtime.RefundQuotient
+	if t.config.London2 {
+		refundQuotient = runtime.RefundQuotientEIP3529
+	}
+
+	result.UpdateGasUsed(msg.Gas, refund, refundQuotient)
 
 	if t.ctx.Tracer != nil {
 		t.ctx.Tracer.TxEnd(result.GasLeft)
 	}
 
-	// Refund the sender
+	if gasFeeDiscounted {
+		t.payDiscountedGasFee(msg, result, gasPrice)
+
+		// return gas to the pool
+		t.addGasPool(result.GasLeft)
+
+		return result, nil
+	}
+
+	// Refund the gas payer, which is the sender unless the transaction is fee delegated
 	// remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
 
 	// fmt.Println("------------remaining-----------", result.GasLeft, gasPrice, remaining, msg.From)
-	t.state.AddBalance(msg.From, remaining)
+	t.state.AddBalance(msg.GasPayer(), remaining)
 
 	// Spec: https://eips.ethereum.org/EIPS/eip-1559#specification
 	// Define effective tip based on tx type.
@@ -642,13 +848,13 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// Pay the coinbase fee as a miner reward using the calculated effective tip.
 	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), effectiveTip)
 	fmt.Print("------------------tip ---------", effectiveTip, coinbaseFee)
-	t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	t.creditFee(t.ctx.Coinbase, coinbaseFee)
 	// Burn some amount if the london hardfork is applied.
 	// Basically, burn amount is just transferred to the current burn contract.
 	if t.config.London && msg.Type != types.StateTx {
 		burnAmount := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), t.ctx.BaseFee)
 		fmt.Println("-----burn amount-----", burnAmount, t.ctx.BaseFee, result.GasUsed)
-		t.state.AddBalance(t.ctx.BurnContract, burnAmount)
+		t.distributeBaseFee(burnAmount)
 	}
 
 	// return gas to the pool
@@ -657,6 +863,31 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, nil
 }
 
+// payDiscountedGasFee refunds the unused gas of the transaction paid with the discounted gas price
+// and splits the paid fee between the burn contract and the coinbase.
+// The base fee share is discounted the same way and the coinbase gets the rest, so no fee is minted
+func (t *Transition) payDiscountedGasFee(msg *types.Transaction, result *runtime.ExecutionResult, gasPrice *big.Int) {
+	discountedPrice := t.gasFeeAllowListConfig.Discount(gasPrice)
+
+	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), discountedPrice)
+	t.state.AddBalance(msg.GasPayer(), remaining)
+
+	paid := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), discountedPrice)
+
+	if t.config.London {
+		burnAmount := new(big.Int).Mul(
+			new(big.Int).SetUint64(result.GasUsed),
+			t.gasFeeAllowListConfig.Discount(t.ctx.BaseFee),
+		)
+		burnAmount = common.BigMin(burnAmount, paid)
+
+		t.distributeBaseFee(burnAmount)
+		paid.Sub(paid, burnAmount)
+	}
+
+	t.creditFee(t.ctx.Coinbase, paid)
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -676,7 +907,11 @@ func (t *Transition) Call2(
 	value *big.Int,
 	gas uint64,
 ) *runtime.ExecutionResult {
-	c := runtime.NewContractCall(1, caller, caller, to, value, gas, t.state.GetCode(to), input)
+	c := runtime.NewContractCall(1, caller, caller, to, value, gas, t.GetCode(to), input)
+
+	if t.codeCache != nil {
+		c.CodeHash = t.state.GetCodeHash(to)
+	}
 
 	return t.applyCall(c, runtime.Call, t)
 }
@@ -765,6 +1000,14 @@ func (t *Transition) applyCall(
 		}
 	}
 
+	// check the call permission list (if any), the delegated calls run in the context of the caller
+	if t.callPermissionList != nil && (callType == runtime.Call || callType == runtime.StaticCall) &&
+		c.Caller != contracts.SystemCaller {
+		if result := t.checkCallPermission(c); result != nil {
+			return result
+		}
+	}
+
 	snapshot := t.state.Snapshot()
 	t.state.TouchAccount(c.Address)
 
@@ -797,6 +1040,41 @@ func (t *Transition) applyCall(
 	return result
 }
 
+// checkCallPermission charges the caller for the storage reads of the call permission check,
+// and returns the result of the failed call if the call is not permitted (nil otherwise).
+// Calls of the accounts without code (such as the plain transfers) and of the functions without
+// any restriction are neither checked nor charged, so they cost the same as without the list
+func (t *Transition) checkCallPermission(c *runtime.Contract) *runtime.ExecutionResult {
+	if len(c.Code) == 0 || !t.callPermissionList.IsCallRestricted(c.Address, c.Input) {
+		return nil
+	}
+
+	if c.Gas < addresslist.CallPermissionCheckCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	c.Gas -= addresslist.CallPermissionCheckCost
+
+	if t.callPermissionList.IsCallPermitted(c.Caller, c.Address, c.Input) {
+		return nil
+	}
+
+	t.logger.Debug(
+		"Failing call. Caller is not permitted to call the contract",
+		"contract.Caller", c.Caller,
+		"contract.Address", c.Address,
+	)
+
+	return &runtime.ExecutionResult{
+		GasLeft:     c.Gas,
+		ReturnValue: addresslist.CallNotPermittedRevert,
+		Err:         runtime.ErrExecutionReverted,
+	}
+}
+
 func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	if t.state.GetNonce(addr) != 0 {
 		return true
@@ -822,6 +1100,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		return &runtime.ExecutionResult{Err: err}
 	}
 
+	// eip-2929 the created address is accessed even if the creation fails
+	if t.config.Berlin {
+		t.state.AddAddressToAccessList(c.Address)
+	}
+
 	// Check if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -965,6 +1248,16 @@ func (t *Transition) handleAllowBlockListsUpdate(contract *runtime.Contract,
 		return t.bridgeBlockList.Run(contract, host, &t.config)
 	}
 
+	// check gas fee allow list (if any)
+	if t.gasFeeAllowList != nil && t.gasFeeAllowList.Addr() == contract.CodeAddress {
+		return t.gasFeeAllowList.Run(contract, host, &t.config)
+	}
+
+	// check call permission list (if any)
+	if t.callPermissionList != nil && t.callPermissionList.Addr() == contract.CodeAddress {
+		return t.callPermissionList.Run(contract, host, &t.config)
+	}
+
 	// check transaction allow list (if any)
 	if t.txnAllowList != nil && t.txnAllowList.Addr() == contract.CodeAddress {
 		return t.txnAllowList.Run(contract, host, &t.config)
@@ -1004,15 +1297,35 @@ func (t *Transition) EmitLog(addr types.Address, topics []types.Hash, data []byt
 }
 
 func (t *Transition) GetCodeSize(addr types.Address) int {
-	return t.state.GetCodeSize(addr)
+	return len(t.GetCode(addr))
 }
 
 func (t *Transition) GetCodeHash(addr types.Address) (res types.Hash) {
 	return t.state.GetCodeHash(addr)
 }
 
+// GetCode returns the code of the account. The code is served from the code cache, if the transition has one
 func (t *Transition) GetCode(addr types.Address) []byte {
-	return t.state.GetCode(addr)
+	if t.codeCache == nil {
+		return t.state.GetCode(addr)
+	}
+
+	hash := t.state.GetCodeHash(addr)
+	if hash == types.ZeroHash || hash == types.EmptyCodeHash {
+		return t.state.GetCode(addr)
+	}
+
+	if code, ok := t.codeCache.Code(hash); ok {
+		return code
+	}
+
+	// missing code is not cached, so it's read again once it's available
+	code := t.state.GetCode(addr)
+	if len(code) > 0 {
+		t.codeCache.AddCode(hash, code)
+	}
+
+	return code
 }
 
 func (t *Transition) GetBalance(addr types.Address) *big.Int {
@@ -1036,7 +1349,8 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 }
 
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
-	if !t.state.HasSuicided(addr) {
+	// eip-3529 removes the selfdestruct refund
+	if !t.config.London2 && !t.state.HasSuicided(addr) {
 		t.state.AddRefund(24000)
 	}
 
@@ -1097,6 +1411,22 @@ func (t *Transition) GetRefund() uint64 {
 	return t.state.GetRefund()
 }
 
+func (t *Transition) AddressInAccessList(addr types.Address) bool {
+	return t.state.AddressInAccessList(addr)
+}
+
+func (t *Transition) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.state.SlotInAccessList(addr, slot)
+}
+
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.state.AddAddressToAccessList(addr)
+}
+
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.state.AddSlotToAccessList(addr, slot)
+}
+
 func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
 	cost := uint64(0)
 
@@ -1137,6 +1467,12 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		cost += zeros * 4
 	}
 
+	// eip-2930 the addresses and the storage keys of the access list are paid upfront
+	if len(msg.AccessList) > 0 {
+		cost += uint64(len(msg.AccessList)) * TxAccessListAddressGas
+		cost += uint64(msg.AccessList.StorageKeys()) * TxAccessListStorageKeyGas
+	}
+
 	return cost, nil
 }
 
@@ -1145,6 +1481,17 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 // 1. the nonce of the message caller is correct
 // 2. caller has enough balance to cover transaction fee(gaslimit * gasprice * val) or fee(gasfeecap * gasprice * val)
 func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
+	// 0. the fee delegated transaction is allowed and specifies its fee payer
+	if msg.Type == types.FeeDelegatedTx {
+		if !t.config.FeeDelegation {
+			return NewTransitionApplicationError(ErrFeeDelegationDisabled, false)
+		}
+
+		if msg.FeePayer == nil {
+			return NewTransitionApplicationError(crypto.ErrFeePayerMissing, false)
+		}
+	}
+
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
@@ -5,11 +5,16 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/contracts"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/forkmanager"
 	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/state/runtime/addresslist"
 	"github.com/tarality/tan-network/types"
 )
 
@@ -154,3 +159,648 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_ContinueTxn(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	snap := newStateWithPreState(map[types.Address]*PreState{
+		addr: {Nonce: 1, Balance: 10},
+	})
+
+	executor := NewExecutor(&chain.Params{
+		Forks:        chain.AllForksEnabled,
+		ChainID:      100,
+		BurnContract: map[uint64]types.Address{0: types.ZeroAddress},
+	}, nil, hclog.NewNullLogger())
+	executor.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	parent := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	parent.state.IncrNonce(addr)
+	parent.state.AddBalance(addr, big.NewInt(5))
+
+	transition, err := executor.ContinueTxn(parent, &types.Header{Number: 2, GasLimit: 100}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	// the uncommitted changes of the parent are visible to the next block
+	assert.Equal(t, uint64(2), transition.GetNonce(addr))
+	assert.Equal(t, big.NewInt(15), transition.state.GetBalance(addr))
+	assert.Equal(t, int64(2), transition.ctx.Number)
+	assert.Equal(t, uint64(100), transition.gasPool)
+}
+
+func TestTransition_StoreRevertReasons(t *testing.T) {
+	t.Parallel()
+
+	revertData := []byte{0x1, 0x2}
+	to := types.StringToAddress("2")
+
+	for _, store := range []bool{false, true} {
+		snap := newStateWithPreState(nil)
+
+		transition := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+		transition.storeRevertReasons = store
+
+		txn := &types.Transaction{To: &to, Hash: types.StringToHash("1")}
+
+		require.NoError(t, transition.addReceipt(txn, txn, &runtime.ExecutionResult{
+			Err:         runtime.ErrExecutionReverted,
+			ReturnValue: revertData,
+		}, nil, nil))
+		require.NoError(t, transition.addReceipt(txn, txn, &runtime.ExecutionResult{
+			ReturnValue: revertData,
+		}, nil, nil))
+
+		receipts := transition.Receipts()
+		require.Len(t, receipts, 2)
+
+		if store {
+			assert.Equal(t, revertData, receipts[0].RevertReason)
+		} else {
+			assert.Nil(t, receipts[0].RevertReason)
+		}
+
+		// the return data of the successful transactions is never kept
+		assert.Nil(t, receipts[1].RevertReason)
+	}
+}
+
+func TestTransition_FeeDelegatedTx(t *testing.T) {
+	t.Parallel()
+
+	senderKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	feePayerKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&senderKey.PublicKey)
+	feePayer := crypto.PubKeyToAddress(&feePayerKey.PublicKey)
+	to := types.StringToAddress("2")
+	coinbase := types.StringToAddress("3")
+	burnContract := types.StringToAddress("4")
+
+	signer := crypto.NewLondonSigner(100, true, crypto.NewEIP155Signer(100, true))
+
+	newSignedTx := func(t *testing.T) *types.Transaction {
+		t.Helper()
+
+		tx, err := signer.SignTx(&types.Transaction{
+			Type:      types.FeeDelegatedTx,
+			ChainID:   big.NewInt(100),
+			GasTipCap: big.NewInt(2),
+			GasFeeCap: big.NewInt(20),
+			Gas:       30000,
+			To:        &to,
+			Value:     big.NewInt(1),
+			FeePayer:  &feePayer,
+		}, senderKey)
+		require.NoError(t, err)
+
+		tx, err = signer.SignFeePayerTx(tx, feePayerKey)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	newTransition := func(forks chain.ForksInTime) *Transition {
+		snap := newStateWithPreState(map[types.Address]*PreState{
+			sender:   {Balance: 1},
+			feePayer: {Balance: 1000000},
+		})
+
+		transition := NewTransition(forks, snap, newTxn(snap))
+		transition.logger = hclog.NewNullLogger()
+		transition.gasPool = 100000
+		transition.ctx = runtime.TxContext{
+			ChainID:      100,
+			BaseFee:      big.NewInt(10),
+			Coinbase:     coinbase,
+			BurnContract: burnContract,
+		}
+
+		return transition
+	}
+
+	t.Run("the fee payer pays for the used gas", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(chain.AllForksEnabled.At(0))
+		require.NoError(t, transition.Write(newSignedTx(t)))
+
+		receipts := transition.Receipts()
+		require.Len(t, receipts, 1)
+		assert.Equal(t, types.FeeDelegatedTx, receipts[0].TransactionType)
+
+		// the unused gas is refunded to the fee payer
+		gasUsed := int64(receipts[0].GasUsed)
+		require.Less(t, gasUsed, int64(30000))
+
+		// the gas price is base fee + tip, the sender pays the value only
+		assert.Zero(t, transition.state.GetBalance(sender).Sign())
+		assert.Equal(t, uint64(1), transition.state.GetNonce(sender))
+		assert.Equal(t, big.NewInt(1000000-gasUsed*12), transition.state.GetBalance(feePayer))
+		assert.Equal(t, big.NewInt(1), transition.state.GetBalance(to))
+		assert.Equal(t, big.NewInt(gasUsed*2), transition.state.GetBalance(coinbase))
+		assert.Equal(t, big.NewInt(gasUsed*10), transition.state.GetBalance(burnContract))
+	})
+
+	t.Run("the fee payer signature is verified", func(t *testing.T) {
+		t.Parallel()
+
+		tx := newSignedTx(t)
+		tx.FeePayerR = new(big.Int).Add(tx.FeePayerR, big.NewInt(1))
+
+		transition := newTransition(chain.AllForksEnabled.At(0))
+		require.Error(t, transition.Write(tx))
+		assert.Equal(t, big.NewInt(1000000), transition.state.GetBalance(feePayer))
+	})
+
+	t.Run("fee delegation is not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		forks := chain.AllForksEnabled.At(0)
+		forks.FeeDelegation = false
+
+		transition := newTransition(forks)
+		require.EqualError(t, transition.Write(newSignedTx(t)), ErrFeeDelegationDisabled.Error())
+	})
+}
+
+func TestTransition_GasFeeAllowList(t *testing.T) {
+	t.Parallel()
+
+	senderKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&senderKey.PublicKey)
+	to := types.StringToAddress("2")
+	coinbase := types.StringToAddress("3")
+	burnContract := types.StringToAddress("4")
+
+	signer := crypto.NewLondonSigner(100, true, crypto.NewEIP155Signer(100, true))
+
+	tx, err := signer.SignTx(&types.Transaction{
+		Type:      types.DynamicFeeTx,
+		ChainID:   big.NewInt(100),
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(20),
+		Gas:       30000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	}, senderKey)
+	require.NoError(t, err)
+
+	newTransition := func(discountPercent uint64, role addresslist.Role) *Transition {
+		snap := newStateWithPreState(map[types.Address]*PreState{
+			sender: {Balance: 1000000},
+		})
+
+		transition := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+		transition.logger = hclog.NewNullLogger()
+		transition.gasPool = 100000
+		transition.ctx = runtime.TxContext{
+			ChainID:      100,
+			BaseFee:      big.NewInt(10),
+			Coinbase:     coinbase,
+			BurnContract: burnContract,
+		}
+		transition.gasFeeAllowList = addresslist.NewAddressList(transition, contracts.AllowListGasFeeAddr)
+		transition.gasFeeAllowListConfig = &chain.GasFeeAllowListConfig{DiscountPercent: discountPercent}
+		transition.gasFeeAllowList.SetRole(sender, role)
+
+		return transition
+	}
+
+	cases := []struct {
+		name            string
+		discountPercent uint64
+		role            addresslist.Role
+		// gasPrice and burnPrice are the paid and burnt gas prices
+		gasPrice  int64
+		burnPrice int64
+	}{
+		{"not allow listed", 50, addresslist.NoRole, 20, 10},
+		{"discounted", 50, addresslist.EnabledRole, 10, 5},
+		{"discounted admin", 25, addresslist.AdminRole, 15, 7},
+		{"gas free", 100, addresslist.EnabledRole, 0, 0},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTransition(c.discountPercent, c.role)
+			require.NoError(t, transition.Write(tx.Copy()))
+
+			receipts := transition.Receipts()
+			require.Len(t, receipts, 1)
+
+			gasUsed := int64(receipts[0].GasUsed)
+
+			assertBalance := func(addr types.Address, expected int64) {
+				t.Helper()
+
+				balance := transition.state.GetBalance(addr)
+				assert.Zero(t, big.NewInt(expected).Cmp(balance), "expected %d, got %s", expected, balance)
+			}
+
+			// the unused gas is refunded at the paid gas price, and the paid fee is split
+			// between the burn contract and the coinbase
+			assertBalance(sender, 1000000-1-gasUsed*c.gasPrice)
+			assertBalance(to, 1)
+			assertBalance(burnContract, gasUsed*c.burnPrice)
+			assertBalance(coinbase, gasUsed*(c.gasPrice-c.burnPrice))
+		})
+	}
+}
+
+func TestTransition_BaseFeeDistribution(t *testing.T) {
+	t.Parallel()
+
+	senderKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&senderKey.PublicKey)
+	to := types.StringToAddress("2")
+	coinbase := types.StringToAddress("3")
+	burnContract := types.StringToAddress("4")
+	treasury := types.StringToAddress("5")
+
+	signer := crypto.NewLondonSigner(100, true, crypto.NewEIP155Signer(100, true))
+
+	tx, err := signer.SignTx(&types.Transaction{
+		Type:      types.DynamicFeeTx,
+		ChainID:   big.NewInt(100),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(101),
+		Gas:       30000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	}, senderKey)
+	require.NoError(t, err)
+
+	snap := newStateWithPreState(map[types.Address]*PreState{
+		sender: {Balance: 10000000},
+	})
+
+	transition := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	transition.logger = hclog.NewNullLogger()
+	transition.gasPool = 100000
+	transition.ctx = runtime.TxContext{
+		ChainID:      100,
+		BaseFee:      big.NewInt(100),
+		Coinbase:     coinbase,
+		BurnContract: burnContract,
+	}
+	transition.baseFeeDistribution = &chain.BaseFeeDistributionConfig{
+		BaseFeeDistribution: forkmanager.BaseFeeDistribution{
+			BurnPercent:     50,
+			TreasuryPercent: 30,
+			ProposerPercent: 20,
+		},
+		Treasury: treasury,
+	}
+
+	require.NoError(t, transition.Write(tx))
+
+	receipts := transition.Receipts()
+	require.Len(t, receipts, 1)
+
+	gasUsed := int64(receipts[0].GasUsed)
+	baseFee := gasUsed * 100
+
+	assert.Equal(t, big.NewInt(baseFee), receipts[0].BaseFeePaid)
+	assert.Equal(t, big.NewInt(baseFee*50/100), transition.state.GetBalance(burnContract))
+	assert.Equal(t, big.NewInt(baseFee*30/100), transition.state.GetBalance(treasury))
+	// the proposer gets its part of the base fee and the tip
+	assert.Equal(t, big.NewInt(baseFee*20/100+gasUsed), transition.state.GetBalance(coinbase))
+}
+
+func TestTransition_CallPermissionList(t *testing.T) {
+	t.Parallel()
+
+	permitted := types.StringToAddress("1")
+	other := types.StringToAddress("2")
+	token := types.StringToAddress("0x3333333333333333333333333333333333333333") // not a precompile
+	transfer := chain.Selector{0xa9, 0x05, 0x9c, 0xbb}
+	input := append(transfer[:], make([]byte, 64)...)
+
+	snap := newStateWithPreState(map[types.Address]*PreState{
+		permitted: {Balance: 1},
+		other:     {Balance: 1},
+	})
+
+	transition := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	transition.logger = hclog.NewNullLogger()
+	transition.callPermissionList = addresslist.NewCallPermissionList(transition, contracts.CallPermissionListAddr)
+	transition.callPermissionList.SetCallRestricted(token, transfer, true)
+	transition.callPermissionList.SetCallPermitted(permitted, token, transfer, true)
+	transition.state.SetCode(token, []byte{0x00}) // STOP
+
+	result := transition.Call2(permitted, token, input, big.NewInt(0), 100000)
+	require.NoError(t, result.Err)
+
+	result = transition.Call2(other, token, input, big.NewInt(0), 100000)
+	require.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+	assert.Equal(t, addresslist.CallNotPermittedRevert, result.ReturnValue)
+	assert.Equal(t, 100000-addresslist.CallPermissionCheckCost, result.GasLeft)
+
+	// the caller pays for the permission check
+	result = transition.Call2(other, token, input, big.NewInt(0), addresslist.CallPermissionCheckCost-1)
+	require.ErrorIs(t, result.Err, runtime.ErrOutOfGas)
+
+	// the other functions are not restricted, nor charged for the check
+	result = transition.Call2(other, token, []byte{0x1, 0x2, 0x3, 0x4}, big.NewInt(0), 100000)
+	require.NoError(t, result.Err)
+	assert.Equal(t, uint64(100000), result.GasLeft)
+}
+
+func TestTransition_CallPermissionList_PlainTransfer(t *testing.T) {
+	t.Parallel()
+
+	senderKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&senderKey.PublicKey)
+	to := types.StringToAddress("0x1111111111111111111111111111111111111111")
+
+	signer := crypto.NewLondonSigner(100, true, crypto.NewEIP155Signer(100, true))
+
+	tx, err := signer.SignTx(&types.Transaction{
+		Type:      types.DynamicFeeTx,
+		ChainID:   big.NewInt(100),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(101),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	}, senderKey)
+	require.NoError(t, err)
+
+	snap := newStateWithPreState(map[types.Address]*PreState{
+		sender: {Balance: 10000000},
+	})
+
+	transition := NewTransition(chain.AllForksEnabled.At(0), snap, newTxn(snap))
+	transition.logger = hclog.NewNullLogger()
+	transition.gasPool = 100000
+	transition.ctx = runtime.TxContext{ChainID: 100, BaseFee: big.NewInt(100)}
+	transition.callPermissionList = addresslist.NewCallPermissionList(transition, contracts.CallPermissionListAddr)
+
+	// the accounts without code are not restricted, even if there is a rule for them
+	transition.callPermissionList.SetCallRestricted(to, chain.Selector{}, true)
+
+	require.NoError(t, transition.Write(tx))
+
+	receipts := transition.Receipts()
+	require.Len(t, receipts, 1)
+	require.Equal(t, types.ReceiptSuccess, *receipts[0].Status)
+	require.Equal(t, uint64(21000), receipts[0].GasUsed)
+	require.Equal(t, big.NewInt(1), transition.state.GetBalance(to))
+}
+
+// codeState is the state of a single contract, counting the code reads from the storage
+type codeState struct {
+	code  []byte
+	reads int
+}
+
+func (s *codeState) NewSnapshotAt(types.Hash) (Snapshot, error) {
+	return s.NewSnapshot(), nil
+}
+
+func (s *codeState) NewSnapshot() Snapshot {
+	return &codeSnapshot{state: s}
+}
+
+func (s *codeState) GetCode(hash types.Hash) ([]byte, bool) {
+	if hash != types.BytesToHash(crypto.Keccak256(s.code)) {
+		return nil, false
+	}
+
+	s.reads++
+
+	// the storage returns a new copy of the code on every read
+	return append([]byte(nil), s.code...), true
+}
+
+type codeSnapshot struct {
+	state *codeState
+}
+
+func (s *codeSnapshot) GetStorage(types.Address, types.Hash, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (s *codeSnapshot) GetAccount(addr types.Address) (*Account, error) {
+	account := &Account{Balance: big.NewInt(1000000)}
+	if addr == counterContract {
+		account.CodeHash = crypto.Keccak256(s.state.code)
+	}
+
+	return account, nil
+}
+
+func (s *codeSnapshot) GetCode(hash types.Hash) ([]byte, bool) {
+	return s.state.GetCode(hash)
+}
+
+func (s *codeSnapshot) Commit([]*Object) (Snapshot, []byte) {
+	return s, nil
+}
+
+// newCodeCacheTestExecutor returns the executor of the state with the contract
+// of the given code size, jumping over the code to the end
+func newCodeCacheTestExecutor(codeSize int) (*Executor, *codeState) {
+	code := []byte{0x61, 0x00, 0x00, 0x56} // PUSH2 <end> JUMP
+
+	for len(code) < codeSize-2 {
+		code = append(code, 0x60, 0x5b) // PUSH1 JUMPDEST
+	}
+
+	code[1], code[2] = byte(len(code)>>8), byte(len(code))
+	code = append(code, 0x5b, 0x00) // JUMPDEST STOP
+
+	st := &codeState{code: code}
+
+	executor := NewExecutor(&chain.Params{
+		Forks:        chain.AllForksEnabled,
+		ChainID:      100,
+		BurnContract: map[uint64]types.Address{0: types.ZeroAddress},
+	}, st, hclog.NewNullLogger())
+	executor.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	return executor, st
+}
+
+func TestTransition_CodeCache(t *testing.T) {
+	t.Parallel()
+
+	executor, st := newCodeCacheTestExecutor(1000)
+	caller := types.StringToAddress("a1")
+
+	// the code is read from the storage once, and shared by the transitions of the following blocks
+	for i := uint64(1); i <= 3; i++ {
+		transition, err := executor.BeginTxn(types.ZeroHash, &types.Header{Number: i, GasLimit: 1000000}, parallelCoinbase)
+		require.NoError(t, err)
+
+		res := transition.Call2(caller, counterContract, nil, big.NewInt(0), 100000)
+		require.NoError(t, res.Err)
+
+		assert.Equal(t, st.code, transition.GetCode(counterContract))
+		assert.Equal(t, len(st.code), transition.GetCodeSize(counterContract))
+	}
+
+	assert.Equal(t, 1, st.reads)
+}
+
+func BenchmarkExecutor_CodeCache(b *testing.B) {
+	// a block calling a large contract, like a DEX router
+	const calls = 200
+
+	caller := types.StringToAddress("a1")
+
+	bench := func(b *testing.B, cached bool) {
+		b.Helper()
+
+		executor, _ := newCodeCacheTestExecutor(24000)
+		if !cached {
+			executor.codeCache = nil
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			transition, err := executor.BeginTxn(types.ZeroHash, &types.Header{Number: 1, GasLimit: 30000000}, parallelCoinbase)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for j := 0; j < calls; j++ {
+				if res := transition.Call2(caller, counterContract, nil, big.NewInt(0), 100000); res.Err != nil {
+					b.Fatal(res.Err)
+				}
+			}
+		}
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		bench(b, false)
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		bench(b, true)
+	})
+}
+
+func TestTransition_AccessListGasAndRefunds(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("a1")
+	contract := types.StringToAddress("a2")
+
+	istanbul := chain.ForksInTime{
+		Homestead:      true,
+		EIP150:         true,
+		EIP155:         true,
+		EIP158:         true,
+		Byzantium:      true,
+		Constantinople: true,
+		Petersburg:     true,
+		Istanbul:       true,
+	}
+
+	berlin := istanbul
+	berlin.Berlin = true
+
+	london2 := berlin
+	london2.London2 = true
+
+	tests := []struct {
+		name       string
+		config     chain.ForksInTime
+		code       []byte
+		accessList types.TxAccessList
+		gasUsed    uint64
+	}{
+		{
+			name:   "sload before berlin",
+			config: istanbul,
+			// PUSH1 0 SLOAD POP PUSH1 0 SLOAD POP STOP
+			code:    []byte{0x60, 0x00, 0x54, 0x50, 0x60, 0x00, 0x54, 0x50, 0x00},
+			gasUsed: 21000 + 2*(3+800+2),
+		},
+		{
+			name:    "sload of the cold and then the warm slot",
+			config:  berlin,
+			code:    []byte{0x60, 0x00, 0x54, 0x50, 0x60, 0x00, 0x54, 0x50, 0x00},
+			gasUsed: 21000 + (3 + 2100 + 2) + (3 + 100 + 2),
+		},
+		{
+			name:       "sload of the slot from the access list",
+			config:     berlin,
+			code:       []byte{0x60, 0x00, 0x54, 0x50, 0x60, 0x00, 0x54, 0x50, 0x00},
+			accessList: types.TxAccessList{{Address: contract, StorageKeys: []types.Hash{types.ZeroHash}}},
+			gasUsed:    21000 + 2400 + 1900 + 2*(3+100+2),
+		},
+		{
+			name:   "clearing the slot is refunded up to the half of the gas used before london2",
+			config: berlin,
+			// PUSH1 0 PUSH1 0 SSTORE STOP
+			code:    []byte{0x60, 0x00, 0x60, 0x00, 0x55, 0x00},
+			gasUsed: (21000 + 3 + 3 + 2100 + 2900) - (21000+3+3+2100+2900)/2,
+		},
+		{
+			name:    "clearing the slot is refunded 4800 gas after london2",
+			config:  london2,
+			code:    []byte{0x60, 0x00, 0x60, 0x00, 0x55, 0x00},
+			gasUsed: (21000 + 3 + 3 + 2100 + 2900) - 4800,
+		},
+		{
+			name:   "selfdestruct is not refunded after london2",
+			config: london2,
+			// PUSH1 1 SELFDESTRUCT
+			code:    []byte{0x60, 0x01, 0xff},
+			gasUsed: 21000 + 3 + 5000,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			snap := newStateWithPreState(map[types.Address]*PreState{
+				sender:   {Balance: 1000000},
+				contract: {Nonce: 1, State: map[types.Hash]types.Hash{types.ZeroHash: types.StringToHash("1")}},
+			})
+
+			transition := NewTransition(tt.config, snap, newTxn(snap))
+			transition.logger = hclog.NewNullLogger()
+			transition.gasPool = 100000
+			transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+			transition.state.SetCode(contract, tt.code)
+
+			result, err := transition.Apply(&types.Transaction{
+				From:       sender,
+				To:         &contract,
+				Gas:        100000,
+				GasPrice:   big.NewInt(1),
+				Value:      big.NewInt(0),
+				AccessList: tt.accessList,
+			})
+			require.NoError(t, err)
+			require.NoError(t, result.Err)
+			assert.Equal(t, tt.gasUsed, result.GasUsed)
+		})
+	}
+}
@@ -24,7 +24,7 @@ type LondonFixForkV1 struct{}
 // Basically, makes sure gas tip cap and gas fee cap are good for dynamic and legacy transactions
 // and that GasFeeCap/GasPrice cap is not lower than base fee when London fork is active.
 func (l *LondonFixForkV1) checkDynamicFees(msg *types.Transaction, t *Transition) error {
-	if msg.Type != types.DynamicFeeTx {
+	if !msg.Type.IsDynamicFee() {
 		return nil
 	}
 
@@ -76,7 +76,7 @@ func (l *LondonFixForkV1) getUpfrontGasCost(msg *types.Transaction, baseFee *big
 
 func (l *LondonFixForkV1) getEffectiveTip(msg *types.Transaction, gasPrice *big.Int,
 	baseFee *big.Int, isLondonForkEnabled bool) *big.Int {
-	if isLondonForkEnabled && msg.Type == types.DynamicFeeTx {
+	if isLondonForkEnabled && msg.Type.IsDynamicFee() {
 		return common.BigMin(
 			new(big.Int).Sub(msg.GasFeeCap, baseFee),
 			new(big.Int).Set(msg.GasTipCap),
@@ -93,7 +93,7 @@ func (l *LondonFixForkV2) checkDynamicFees(msg *types.Transaction, t *Transition
 		return nil
 	}
 
-	if msg.Type == types.DynamicFeeTx {
+	if msg.Type.IsDynamicFee() {
 		if msg.GasFeeCap.BitLen() == 0 && msg.GasTipCap.BitLen() == 0 {
 			return nil
 		}
@@ -117,13 +117,6 @@ func (l *LondonFixForkV2) checkDynamicFees(msg *types.Transaction, t *Transition
 	// fmt.Println("--------------line  no 117--------", msg)
 	// This will panic if baseFee is nil, but basefee presence is verified
 	// as part of header validation.
-	gasFeeCap := msg.GetGasFeeCap()
-
-	if gasFeeCap.Cmp(big.NewInt(-1)) == 0 {
-		// fmt.Print("inital tx----------122", gasFeeCap)
-
-		return nil
-	}
 	// fmt.Println("---------line no 125", gasFeeCap, (t.ctx.BaseFee))
 	// important line
 	// if gasFeeCap.Cmp(t.ctx.BaseFee) < 0 {
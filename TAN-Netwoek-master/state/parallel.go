@@ -0,0 +1,482 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	iradix "github.com/hashicorp/go-immutable-radix"
+
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/state/runtime/addresslist"
+	"github.com/tarality/tan-network/state/runtime/evm"
+	"github.com/tarality/tan-network/state/runtime/precompiled"
+	"github.com/tarality/tan-network/types"
+)
+
+// The parallel execution follows Block-STM: the transactions of a batch are executed
+// speculatively by a pool of workers, each one on its own copy of the state of the block,
+// recording the accounts and the storage slots it reads. The transactions are then written
+// in their original order. A transaction is written from its speculative result only if
+// the state it read is still the same after the transactions written before it,
+// otherwise it's executed again on the current state. This way the state root and the
+// receipts are the same as the ones of the sequential execution.
+
+// ParallelBatch is a set of transactions executed speculatively in parallel
+type ParallelBatch struct {
+	txs     []*types.Transaction
+	results []*speculativeResult
+
+	// reexecuted is the number of transactions executed again once written
+	reexecuted int
+}
+
+// speculativeResult is the result of a transaction executed speculatively
+type speculativeResult struct {
+	msg    *types.Transaction
+	result *runtime.ExecutionResult
+	err    error
+
+	state  *Txn
+	access *speculativeAccess
+	logs   []*types.Log
+	fees   []*deferredFee
+	// baseFeePaid is the base fee paid by the transaction
+	baseFeePaid *big.Int
+}
+
+// deferredFee is a fee paid by a transaction executed speculatively
+type deferredFee struct {
+	addr   types.Address
+	amount *big.Int
+}
+
+// ExecuteParallel executes the transactions speculatively in parallel on top of the current state,
+// the state isn't changed until the transactions are written by WriteParallel
+func (t *Transition) ExecuteParallel(txs []*types.Transaction, workers int) *ParallelBatch {
+	batch := &ParallelBatch{
+		txs:     txs,
+		results: make([]*speculativeResult, len(txs)),
+	}
+
+	// the tracer and the post hook observe the transactions one by one,
+	// so they are only executed when written
+	if t.ctx.Tracer != nil || t.PostHook != nil || len(txs) == 0 {
+		return batch
+	}
+
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+
+	view := newSpeculativeView(t.state)
+	next := int64(-1)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				index := int(atomic.AddInt64(&next, 1))
+				if index >= len(txs) {
+					return
+				}
+
+				batch.results[index] = t.speculate(view, txs[index])
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return batch
+}
+
+// WriteParallel writes the transaction of the batch with the given index, the transactions
+// of the batch have to be written in order, but any of them can be skipped
+func (t *Transition) WriteParallel(batch *ParallelBatch, index int) error {
+	txn := batch.txs[index]
+	spec := batch.results[index]
+
+	if spec == nil || !t.validateSpeculative(spec) {
+		if spec != nil {
+			batch.reexecuted++
+		}
+
+		return t.Write(txn)
+	}
+
+	return t.writeSpeculative(txn, spec)
+}
+
+// writeParallel writes the transactions of the block, executing them in parallel
+func (t *Transition) writeParallel(block *types.Block, workers int) error {
+	txs := make([]*types.Transaction, 0, len(block.Transactions))
+
+	for _, txn := range block.Transactions {
+		if txn.Gas > block.Header.GasLimit {
+			continue
+		}
+
+		txs = append(txs, txn)
+	}
+
+	batch := t.ExecuteParallel(txs, workers)
+
+	for i := range txs {
+		if err := t.WriteParallel(batch, i); err != nil {
+			return err
+		}
+	}
+
+	if batch.reexecuted > 0 {
+		t.logger.Debug("parallel execution conflicts", "block", block.Number(),
+			"txs", len(txs), "reexecuted", batch.reexecuted)
+	}
+
+	return nil
+}
+
+// speculate executes the transaction on its own copy of the view
+func (t *Transition) speculate(view *speculativeView, txn *types.Transaction) *speculativeResult {
+	spec := &speculativeResult{}
+
+	if spec.err = t.recoverSender(txn); spec.err != nil {
+		return spec
+	}
+
+	spec.access = newSpeculativeAccess(view)
+	spec.state = newTxn(view)
+	spec.state.access = spec.access
+
+	fork := t.fork(spec.state)
+
+	spec.msg = txn.Copy()
+	spec.result, spec.err = fork.Apply(spec.msg)
+
+	if spec.err == nil {
+		spec.logs = spec.state.Logs()
+		spec.fees = fork.deferredFees
+		spec.baseFeePaid = fork.baseFeePaid
+	}
+
+	return spec
+}
+
+// fork returns a transition executing transactions speculatively
+// on the given state, within the block of the transition
+func (t *Transition) fork(state *Txn) *Transition {
+	fork := &Transition{
+		logger:      t.logger,
+		auxState:    t.auxState,
+		snap:        t.snap,
+		config:      t.config,
+		state:       state,
+		getHash:     t.getHash,
+		ctx:         t.ctx,
+		gasPool:     t.gasPool,
+		evm:         evm.NewEVMWithCodeCache(t.codeCache),
+		precompiles: precompiled.NewPrecompiled(),
+		speculative: true,
+		codeCache:   t.codeCache,
+	}
+
+	fork.deploymentAllowList = forkAddressList(t.deploymentAllowList, fork)
+	fork.deploymentBlockList = forkAddressList(t.deploymentBlockList, fork)
+	fork.txnAllowList = forkAddressList(t.txnAllowList, fork)
+	fork.txnBlockList = forkAddressList(t.txnBlockList, fork)
+	fork.bridgeAllowList = forkAddressList(t.bridgeAllowList, fork)
+	fork.bridgeBlockList = forkAddressList(t.bridgeBlockList, fork)
+	fork.gasFeeAllowList = forkAddressList(t.gasFeeAllowList, fork)
+	fork.gasFeeAllowListConfig = t.gasFeeAllowListConfig
+	fork.baseFeeDistribution = t.baseFeeDistribution
+
+	if t.callPermissionList != nil {
+		fork.callPermissionList = addresslist.NewCallPermissionList(fork, t.callPermissionList.Addr())
+	}
+
+	return fork
+}
+
+func forkAddressList(list *addresslist.AddressList, fork *Transition) *addresslist.AddressList {
+	if list == nil {
+		return nil
+	}
+
+	return addresslist.NewAddressList(fork, list.Addr())
+}
+
+// validateSpeculative checks the speculative result is the one of the execution on the current state
+func (t *Transition) validateSpeculative(spec *speculativeResult) bool {
+	if spec.err != nil || t.gasPool < spec.msg.Gas {
+		return false
+	}
+
+	for addr, read := range spec.access.accounts {
+		object, exists := t.state.getStateObject(addr)
+		if read == nil {
+			if exists {
+				return false
+			}
+
+			continue
+		}
+
+		if !exists || !sameStateObject(read, object) {
+			return false
+		}
+	}
+
+	for slot, value := range spec.access.storage {
+		if t.state.GetState(slot.addr, slot.key) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameStateObject(a, b *StateObject) bool {
+	return a.Account.Nonce == b.Account.Nonce &&
+		a.Account.Balance.Cmp(b.Account.Balance) == 0 &&
+		a.Account.Root == b.Account.Root &&
+		bytes.Equal(a.Account.CodeHash, b.Account.CodeHash) &&
+		a.Suicide == b.Suicide &&
+		a.DirtyCode == b.DirtyCode &&
+		a.withFakeStorage == b.withFakeStorage
+}
+
+// writeSpeculative writes the validated speculative result of the transaction to the state
+func (t *Transition) writeSpeculative(txn *types.Transaction, spec *speculativeResult) error {
+	if err := t.subGasPool(spec.msg.Gas); err != nil {
+		return NewGasLimitReachedTransitionApplicationError(err)
+	}
+
+	t.addGasPool(spec.result.GasLeft)
+
+	spec.state.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		object, ok := v.(*StateObject)
+		if !ok {
+			// logs and refunds
+			return false
+		}
+
+		addr := types.BytesToAddress(k)
+		if _, created := spec.access.created[addr]; !created {
+			t.mergeStorage(addr, object, spec.access.written[addr])
+		}
+
+		t.state.txn.Insert(k, object)
+
+		return false
+	})
+
+	for _, fee := range spec.fees {
+		t.state.AddBalance(fee.addr, fee.amount)
+	}
+
+	return t.addReceipt(txn, spec.msg, spec.result, spec.logs, spec.baseFeePaid)
+}
+
+// mergeStorage sets the storage of the object to the current storage of the account
+// with the slots written by the speculative transaction on top of it
+func (t *Transition) mergeStorage(addr types.Address, object *StateObject, written map[types.Hash]struct{}) {
+	current, exists := t.state.getStateObject(addr)
+	if !exists {
+		return
+	}
+
+	storage := current.Txn
+	if storage == nil {
+		storage = iradix.New().Txn()
+	}
+
+	if object.Txn != nil {
+		for key := range written {
+			if value, ok := object.Txn.Get(key.Bytes()); ok {
+				storage.Insert(key.Bytes(), value)
+			}
+		}
+	}
+
+	object.Txn = storage
+}
+
+// speculativeView is the state the transactions of a parallel batch are executed on,
+// the state written by the block so far on top of the parent state
+type speculativeView struct {
+	objects map[types.Address]*StateObject
+	storage map[types.Address]*iradix.Tree
+
+	// the parent trie resolves its nodes in place, so the reads are serialized
+	lock     sync.Mutex
+	snapshot readSnapshot
+	accounts map[types.Address]*StateObject
+}
+
+func newSpeculativeView(txn *Txn) *speculativeView {
+	view := &speculativeView{
+		objects:  map[types.Address]*StateObject{},
+		storage:  map[types.Address]*iradix.Tree{},
+		snapshot: txn.snapshot,
+		accounts: map[types.Address]*StateObject{},
+	}
+
+	txn.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		object, ok := v.(*StateObject)
+		if !ok {
+			return false
+		}
+
+		addr := types.BytesToAddress(k)
+		view.objects[addr] = object
+
+		if object.Txn != nil {
+			view.storage[addr] = object.Txn.CommitOnly()
+		}
+
+		return false
+	})
+
+	return view
+}
+
+// stateObject returns the object of the account in the view, nil if the account doesn't exist.
+// The returned object is shared and must not be modified
+func (v *speculativeView) stateObject(addr types.Address) *StateObject {
+	if object, ok := v.objects[addr]; ok {
+		if object.Deleted {
+			return nil
+		}
+
+		return object
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if object, ok := v.accounts[addr]; ok {
+		return object
+	}
+
+	var object *StateObject
+
+	if account, err := v.snapshot.GetAccount(addr); err == nil && account != nil {
+		object = &StateObject{Account: account}
+	}
+
+	v.accounts[addr] = object
+
+	return object
+}
+
+// copyObject returns a copy of the object of the view the transaction can modify
+func (v *speculativeView) copyObject(addr types.Address, object *StateObject) *StateObject {
+	obj := &StateObject{
+		Account:         object.Account.Copy(),
+		Code:            object.Code,
+		Suicide:         object.Suicide,
+		DirtyCode:       object.DirtyCode,
+		withFakeStorage: object.withFakeStorage,
+	}
+
+	if storage, ok := v.storage[addr]; ok {
+		obj.Txn = storage.Txn()
+	}
+
+	return obj
+}
+
+func (v *speculativeView) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.snapshot.GetStorage(addr, root, key)
+}
+
+func (v *speculativeView) GetAccount(addr types.Address) (*Account, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.snapshot.GetAccount(addr)
+}
+
+func (v *speculativeView) GetCode(hash types.Hash) ([]byte, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.snapshot.GetCode(hash)
+}
+
+type storageSlot struct {
+	addr types.Address
+	key  types.Hash
+}
+
+// speculativeAccess records the state read from the view and written by a transaction
+type speculativeAccess struct {
+	view *speculativeView
+
+	accounts map[types.Address]*StateObject            // accounts read from the view, nil if missing
+	storage  map[storageSlot]types.Hash                // slots read before being written
+	written  map[types.Address]map[types.Hash]struct{} // slots written
+	created  map[types.Address]struct{}                // accounts created with a new storage
+}
+
+func newSpeculativeAccess(view *speculativeView) *speculativeAccess {
+	return &speculativeAccess{
+		view:     view,
+		accounts: map[types.Address]*StateObject{},
+		storage:  map[storageSlot]types.Hash{},
+		written:  map[types.Address]map[types.Hash]struct{}{},
+		created:  map[types.Address]struct{}{},
+	}
+}
+
+func (a *speculativeAccess) getStateObject(addr types.Address) (*StateObject, bool) {
+	object, ok := a.accounts[addr]
+	if !ok {
+		object = a.view.stateObject(addr)
+		a.accounts[addr] = object
+	}
+
+	if object == nil {
+		return nil, false
+	}
+
+	return a.view.copyObject(addr, object), true
+}
+
+func (a *speculativeAccess) readStorage(addr types.Address, key types.Hash, value types.Hash) {
+	if _, ok := a.created[addr]; ok {
+		return
+	}
+
+	if _, ok := a.written[addr][key]; ok {
+		return
+	}
+
+	slot := storageSlot{addr: addr, key: key}
+	if _, ok := a.storage[slot]; !ok {
+		a.storage[slot] = value
+	}
+}
+
+func (a *speculativeAccess) writeStorage(addr types.Address, key types.Hash) {
+	slots, ok := a.written[addr]
+	if !ok {
+		slots = map[types.Hash]struct{}{}
+		a.written[addr] = slots
+	}
+
+	slots[key] = struct{}{}
+}
+
+func (a *speculativeAccess) createAccount(addr types.Address) {
+	a.created[addr] = struct{}{}
+}
@@ -0,0 +1,222 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/state/runtime/evm"
+	"github.com/tarality/tan-network/state/runtime/precompiled"
+	"github.com/tarality/tan-network/types"
+)
+
+var (
+	parallelCoinbase = types.StringToAddress("c0")
+	counterContract  = types.StringToAddress("c1")
+	slotsContract    = types.StringToAddress("c2")
+
+	// increments the slot 0
+	counterCode = []byte{0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00}
+	// stores the first word of the input in the slot of the caller
+	slotsCode = []byte{0x60, 0x00, 0x35, 0x33, 0x55, 0x00}
+)
+
+func newParallelTestTransition(preState map[types.Address]*PreState) *Transition {
+	snap := newStateWithPreState(preState)
+	txn := newTxn(snap)
+
+	// the contracts are deployed by the block, before the parallel batch
+	txn.SetCode(counterContract, counterCode)
+	txn.SetCode(slotsContract, slotsCode)
+	txn.SetState(counterContract, types.ZeroHash, types.BytesToHash([]byte{5}))
+
+	return &Transition{
+		logger: hclog.NewNullLogger(),
+		ctx: runtime.TxContext{
+			Coinbase: parallelCoinbase,
+			BaseFee:  big.NewInt(1),
+			GasLimit: 30000000,
+			ChainID:  100,
+			Number:   1,
+		},
+		state:       txn,
+		snap:        snap,
+		config:      chain.AllForksEnabled.At(0),
+		gasPool:     30000000,
+		getHash:     func(uint64) types.Hash { return types.ZeroHash },
+		receipts:    []*types.Receipt{},
+		evm:         evm.NewEVM(),
+		precompiles: precompiled.NewPrecompiled(),
+	}
+}
+
+func newParallelTestTx(from types.Address, nonce uint64, to types.Address, value int64, input []byte) *types.Transaction {
+	return &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		From:      from,
+		To:        &to,
+		Nonce:     nonce,
+		Value:     big.NewInt(value),
+		Gas:       100000,
+		GasFeeCap: big.NewInt(10),
+		GasTipCap: big.NewInt(2),
+		Input:     input,
+		Hash:      types.BytesToHash([]byte{byte(nonce), from[19], to[19]}),
+	}
+}
+
+func TestExecuteParallel_MatchesSequential(t *testing.T) {
+	t.Parallel()
+
+	senders := []types.Address{
+		types.StringToAddress("a1"),
+		types.StringToAddress("a2"),
+		types.StringToAddress("a3"),
+		types.StringToAddress("a4"),
+	}
+
+	preState := map[types.Address]*PreState{}
+	for _, sender := range senders {
+		preState[sender] = &PreState{Balance: 1000000000000}
+	}
+
+	receiver := types.StringToAddress("b1")
+
+	cases := []struct {
+		name       string
+		txs        []*types.Transaction
+		reexecuted int
+	}{
+		{
+			name: "independent transfers",
+			txs: []*types.Transaction{
+				newParallelTestTx(senders[0], 0, types.StringToAddress("b1"), 1, nil),
+				newParallelTestTx(senders[1], 0, types.StringToAddress("b2"), 2, nil),
+				newParallelTestTx(senders[2], 0, types.StringToAddress("b3"), 3, nil),
+				newParallelTestTx(senders[3], 0, types.StringToAddress("b4"), 4, nil),
+			},
+		},
+		{
+			name: "same sender",
+			txs: []*types.Transaction{
+				newParallelTestTx(senders[0], 0, receiver, 1, nil),
+				newParallelTestTx(senders[0], 1, receiver, 1, nil),
+				newParallelTestTx(senders[0], 2, receiver, 1, nil),
+			},
+			reexecuted: 2,
+		},
+		{
+			name: "same receiver",
+			txs: []*types.Transaction{
+				newParallelTestTx(senders[0], 0, receiver, 1, nil),
+				newParallelTestTx(senders[1], 0, receiver, 1, nil),
+				newParallelTestTx(senders[2], 0, receiver, 1, nil),
+			},
+			reexecuted: 2,
+		},
+		{
+			name: "coinbase",
+			txs: []*types.Transaction{
+				newParallelTestTx(senders[0], 0, receiver, 1, nil),
+				newParallelTestTx(senders[1], 0, parallelCoinbase, 1, nil),
+				newParallelTestTx(senders[2], 0, types.StringToAddress("b3"), 1, nil),
+			},
+			reexecuted: 1,
+		},
+		{
+			name: "storage conflict",
+			txs: []*types.Transaction{
+				newParallelTestTx(senders[0], 0, counterContract, 0, nil),
+				newParallelTestTx(senders[1], 0, counterContract, 0, nil),
+				newParallelTestTx(senders[2], 0, counterContract, 0, nil),
+			},
+			reexecuted: 2,
+		},
+		{
+			name: "distinct slots",
+			txs: []*types.Transaction{
+				newParallelTestTx(senders[0], 0, slotsContract, 0, types.BytesToHash([]byte{1}).Bytes()),
+				newParallelTestTx(senders[1], 0, slotsContract, 0, types.BytesToHash([]byte{2}).Bytes()),
+				newParallelTestTx(senders[2], 0, counterContract, 0, nil),
+				newParallelTestTx(senders[3], 0, slotsContract, 0, types.BytesToHash([]byte{4}).Bytes()),
+			},
+		},
+		{
+			name: "invalid nonce",
+			txs: []*types.Transaction{
+				newParallelTestTx(senders[0], 1, receiver, 1, nil),
+				newParallelTestTx(senders[1], 0, types.StringToAddress("b2"), 1, nil),
+			},
+			reexecuted: 1,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			sequential := newParallelTestTransition(preState)
+			sequentialErrs := make([]error, len(c.txs))
+
+			for i, txn := range c.txs {
+				sequentialErrs[i] = sequential.Write(txn)
+			}
+
+			parallel := newParallelTestTransition(preState)
+			batch := parallel.ExecuteParallel(c.txs, 4)
+
+			for i := range c.txs {
+				err := parallel.WriteParallel(batch, i)
+				require.Equal(t, sequentialErrs[i], err)
+			}
+
+			require.Equal(t, c.reexecuted, batch.reexecuted)
+			require.Equal(t, sequential.Receipts(), parallel.Receipts())
+			require.Equal(t, sequential.TotalGas(), parallel.TotalGas())
+			require.Equal(t, sequential.gasPool, parallel.gasPool)
+
+			sequentialObjs, err := sequential.state.Commit(true)
+			require.NoError(t, err)
+
+			parallelObjs, err := parallel.state.Commit(true)
+			require.NoError(t, err)
+
+			require.Equal(t, sequentialObjs, parallelObjs)
+		})
+	}
+}
+
+func TestExecuteParallel_SkippedTransaction(t *testing.T) {
+	t.Parallel()
+
+	senders := []types.Address{types.StringToAddress("a1"), types.StringToAddress("a2")}
+	preState := map[types.Address]*PreState{
+		senders[0]: {Balance: 1000000000000},
+		senders[1]: {Balance: 1000000000000},
+	}
+
+	txs := []*types.Transaction{
+		newParallelTestTx(senders[0], 0, counterContract, 0, nil),
+		newParallelTestTx(senders[1], 0, counterContract, 0, nil),
+	}
+
+	// the second transaction is executed after a transaction outside of the batch
+	parallel := newParallelTestTransition(preState)
+	batch := parallel.ExecuteParallel(txs[1:], 2)
+
+	require.NoError(t, parallel.Write(txs[0]))
+	require.NoError(t, parallel.WriteParallel(batch, 0))
+	require.Equal(t, 1, batch.reexecuted)
+
+	sequential := newParallelTestTransition(preState)
+	require.NoError(t, sequential.Write(txs[0]))
+	require.NoError(t, sequential.Write(txs[1]))
+
+	require.Equal(t, sequential.Receipts(), parallel.Receipts())
+	require.Equal(t, types.BytesToHash([]byte{7}), parallel.state.GetState(counterContract, types.ZeroHash))
+}
@@ -0,0 +1,261 @@
+package addresslist
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+)
+
+// list of function methods for the call permission list functionality
+var (
+	SetCallRestrictedFunc = abi.MustNewMethod(
+		"function setCallRestricted(address target, bytes4 selector, bool restricted)")
+	SetCallPermittedFunc = abi.MustNewMethod(
+		"function setCallPermitted(address caller, address target, bytes4 selector, bool permitted)")
+	ReadCallPermissionFunc = abi.MustNewMethod(
+		"function readCallPermission(address caller, address target, bytes4 selector) returns (bool)")
+)
+
+var (
+	// ErrCallNotPermitted is the revert reason of the calls the caller is not permitted to make
+	ErrCallNotPermitted = fmt.Errorf("call not permitted")
+
+	// CallNotPermittedRevert is the revert data of the calls the caller is not permitted to make
+	CallNotPermittedRevert = mustEncodeRevertReason(ErrCallNotPermitted.Error())
+
+	errInvalidCallPermissionInput = fmt.Errorf("invalid call permission input")
+
+	// anySelector restricts or permits calling any function of the contract
+	anySelector = chain.Selector{}
+
+	// noSelector is the storage key selector of the calls without a function selector,
+	// it differs from anySelector, so the plain transfers are restricted by anySelector only
+	noSelector = []byte{}
+
+	// storage value of the restricted calls and the permissions
+	callPermissionSet = types.StringToHash("0x01")
+)
+
+// CallPermissionCheckCost is the gas charged for the storage reads of the call permission check
+const CallPermissionCheckCost = uint64(2100)
+
+// CallPermissionList is the address list restricting the callers of the contract functions.
+// Its admins manage the roles and the calls, its enabled addresses manage the calls only
+type CallPermissionList struct {
+	*AddressList
+}
+
+func NewCallPermissionList(state stateRef, addr types.Address) *CallPermissionList {
+	return &CallPermissionList{AddressList: NewAddressList(state, addr)}
+}
+
+func (l *CallPermissionList) Run(c *runtime.Contract, host runtime.Host,
+	config *chain.ForksInTime) *runtime.ExecutionResult {
+	// the roles are managed as in the other address lists
+	if len(c.Input) >= types.SignatureSize && isAddressListFunc(c.Input[:types.SignatureSize]) {
+		return l.AddressList.Run(c, host, config)
+	}
+
+	ret, gasUsed, err := l.runCallPermissionInput(c.Caller, c.Input, c.Gas, c.Static)
+
+	return &runtime.ExecutionResult{
+		ReturnValue: ret,
+		GasUsed:     gasUsed,
+		GasLeft:     c.Gas - gasUsed,
+		Err:         err,
+	}
+}
+
+func isAddressListFunc(sig []byte) bool {
+	return bytes.Equal(sig, SetAdminFunc.ID()) ||
+		bytes.Equal(sig, SetEnabledFunc.ID()) ||
+		bytes.Equal(sig, SetNoneFunc.ID()) ||
+		bytes.Equal(sig, ReadAddressListFunc.ID())
+}
+
+func (l *CallPermissionList) runCallPermissionInput(caller types.Address, input []byte,
+	gas uint64, isStatic bool) ([]byte, uint64, error) {
+	if len(input) < types.SignatureSize {
+		return nil, 0, errNoFunctionSignature
+	}
+
+	sig, inputBytes := input[:types.SignatureSize], input[types.SignatureSize:]
+
+	var method *abi.Method
+
+	switch {
+	case bytes.Equal(sig, ReadCallPermissionFunc.ID()):
+		method = ReadCallPermissionFunc
+	case bytes.Equal(sig, SetCallRestrictedFunc.ID()):
+		method = SetCallRestrictedFunc
+	case bytes.Equal(sig, SetCallPermittedFunc.ID()):
+		method = SetCallPermittedFunc
+	default:
+		return nil, 0, errFunctionNotFound
+	}
+
+	gasCost := writeAddressListCost
+	if method == ReadCallPermissionFunc {
+		gasCost = readAddressListCost
+	}
+
+	if gas < gasCost {
+		return nil, 0, runtime.ErrOutOfGas
+	}
+
+	args, err := decodeCallPermissionArgs(method, inputBytes)
+	if err != nil {
+		return nil, gasCost, err
+	}
+
+	if method == ReadCallPermissionFunc {
+		if l.isCallPermitted(args.caller, args.target, args.selector[:]) {
+			return callPermissionSet.Bytes(), gasCost, nil
+		}
+
+		return types.ZeroHash.Bytes(), gasCost, nil
+	}
+
+	// we cannot perform any write operation if the call is static
+	if isStatic {
+		return nil, gasCost, errWriteProtection
+	}
+
+	// Both the admin and the enabled accounts can manage the calls
+	if !l.GetRole(caller).Enabled() {
+		return nil, gasCost, runtime.ErrNotAuth
+	}
+
+	if method == SetCallRestrictedFunc {
+		l.SetCallRestricted(args.target, args.selector, args.flag)
+	} else {
+		l.SetCallPermitted(args.caller, args.target, args.selector, args.flag)
+	}
+
+	return nil, gasCost, nil
+}
+
+type callPermissionArgs struct {
+	caller   types.Address
+	target   types.Address
+	selector chain.Selector
+	flag     bool
+}
+
+func decodeCallPermissionArgs(method *abi.Method, input []byte) (*callPermissionArgs, error) {
+	raw, err := abi.Decode(method.Inputs, input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidCallPermissionInput, err)
+	}
+
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, errInvalidCallPermissionInput
+	}
+
+	args := &callPermissionArgs{}
+
+	if caller, ok := values["caller"].(ethgo.Address); ok {
+		args.caller = types.Address(caller)
+	}
+
+	if target, ok := values["target"].(ethgo.Address); ok {
+		args.target = types.Address(target)
+	}
+
+	if selector, ok := values["selector"].([4]byte); ok {
+		args.selector = selector
+	}
+
+	if restricted, ok := values["restricted"].(bool); ok {
+		args.flag = restricted
+	}
+
+	if permitted, ok := values["permitted"].(bool); ok {
+		args.flag = permitted
+	}
+
+	return args, nil
+}
+
+// IsCallRestricted returns true if calling the target contract with the input is restricted
+// to the permitted callers
+func (l *CallPermissionList) IsCallRestricted(target types.Address, input []byte) bool {
+	return l.isCallRestricted(target, callSelector(input))
+}
+
+// IsCallPermitted returns true if the caller can call the target contract with the input.
+// The calls without a function selector are restricted and permitted by the zero selector only
+func (l *CallPermissionList) IsCallPermitted(caller, target types.Address, input []byte) bool {
+	return l.isCallPermitted(caller, target, callSelector(input))
+}
+
+// callSelector returns the function selector of the call input
+func callSelector(input []byte) []byte {
+	if len(input) >= types.SignatureSize {
+		return input[:types.SignatureSize]
+	}
+
+	return noSelector
+}
+
+func (l *CallPermissionList) isCallRestricted(target types.Address, selector []byte) bool {
+	return l.isSet(callRestrictionKey(target, selector)) || l.isSet(callRestrictionKey(target, anySelector[:]))
+}
+
+func (l *CallPermissionList) isCallPermitted(caller, target types.Address, selector []byte) bool {
+	if !l.isCallRestricted(target, selector) {
+		return true
+	}
+
+	return l.isSet(callPermissionKey(caller, target, selector)) ||
+		l.isSet(callPermissionKey(caller, target, anySelector[:]))
+}
+
+// SetCallRestricted restricts calling the function of the target contract to the permitted callers
+func (l *CallPermissionList) SetCallRestricted(target types.Address, selector chain.Selector, restricted bool) {
+	l.set(callRestrictionKey(target, selector[:]), restricted)
+}
+
+// SetCallPermitted permits the caller to call the function of the target contract
+func (l *CallPermissionList) SetCallPermitted(caller, target types.Address, selector chain.Selector, permitted bool) {
+	l.set(callPermissionKey(caller, target, selector[:]), permitted)
+}
+
+func (l *CallPermissionList) isSet(key types.Hash) bool {
+	return l.state.GetStorage(l.addr, key) == callPermissionSet
+}
+
+func (l *CallPermissionList) set(key types.Hash, value bool) {
+	if value {
+		l.state.SetState(l.addr, key, callPermissionSet)
+	} else {
+		l.state.SetState(l.addr, key, types.ZeroHash)
+	}
+}
+
+// callRestrictionKey is the storage key of the call restriction, which doesn't collide with the roles
+// since those are stored by the left padded addresses
+func callRestrictionKey(target types.Address, selector []byte) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(target.Bytes(), selector))
+}
+
+// callPermissionKey is the storage key of the call permission
+func callPermissionKey(caller, target types.Address, selector []byte) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(caller.Bytes(), target.Bytes(), selector))
+}
+
+func mustEncodeRevertReason(reason string) []byte {
+	data, err := abi.MustNewMethod("function Error(string)").Encode([]interface{}{reason})
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
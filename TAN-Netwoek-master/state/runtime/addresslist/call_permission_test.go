@@ -0,0 +1,149 @@
+package addresslist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/types"
+)
+
+func newMockCallPermissionList() *CallPermissionList {
+	state := &mockState{
+		state: map[types.Hash]types.Hash{},
+	}
+
+	return NewCallPermissionList(state, types.Address{})
+}
+
+func TestCallPermissionList_IsCallPermitted(t *testing.T) {
+	l := newMockCallPermissionList()
+
+	caller := types.StringToAddress("1")
+	other := types.StringToAddress("2")
+	token := types.StringToAddress("3")
+	transfer := chain.Selector{0xa9, 0x05, 0x9c, 0xbb}
+	approve := chain.Selector{0x09, 0x5e, 0xa7, 0xb3}
+
+	// nothing is restricted by default
+	require.True(t, l.IsCallPermitted(other, token, transfer[:]))
+
+	// the restricted function can be called by the permitted callers only
+	l.SetCallRestricted(token, transfer, true)
+	l.SetCallPermitted(caller, token, transfer, true)
+
+	require.True(t, l.IsCallPermitted(caller, token, transfer[:]))
+	require.False(t, l.IsCallPermitted(other, token, transfer[:]))
+	require.True(t, l.IsCallPermitted(other, token, approve[:]))
+
+	// the zero selector restricts all the functions and the calls without a selector
+	l.SetCallRestricted(token, anySelector, true)
+
+	require.False(t, l.IsCallPermitted(caller, token, approve[:]))
+	require.False(t, l.IsCallPermitted(caller, token, nil))
+
+	// and permits them
+	l.SetCallPermitted(caller, token, anySelector, true)
+
+	require.True(t, l.IsCallPermitted(caller, token, approve[:]))
+	require.True(t, l.IsCallPermitted(caller, token, nil))
+
+	// the permission is revoked
+	l.SetCallPermitted(caller, token, anySelector, false)
+	l.SetCallPermitted(caller, token, transfer, false)
+
+	require.False(t, l.IsCallPermitted(caller, token, transfer[:]))
+
+	// the calls without a selector have their own keys, which differ from the zero selector ones
+	require.NotEqual(t, callRestrictionKey(token, anySelector[:]), callRestrictionKey(token, noSelector))
+	require.NotEqual(t, callPermissionKey(caller, token, anySelector[:]), callPermissionKey(caller, token, noSelector))
+}
+
+func TestCallPermissionList_Run(t *testing.T) {
+	l := newMockCallPermissionList()
+
+	admin := types.StringToAddress("1")
+	manager := types.StringToAddress("2")
+	caller := types.StringToAddress("3")
+	token := types.StringToAddress("4")
+	transfer := [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+
+	l.SetRole(admin, AdminRole)
+
+	run := func(from types.Address, method *abi.Method, args []interface{}, static bool) *runtime.ExecutionResult {
+		t.Helper()
+
+		input, err := method.Encode(args)
+		require.NoError(t, err)
+
+		return l.Run(&runtime.Contract{Caller: from, Input: input, Gas: writeAddressListCost, Static: static}, nil, nil)
+	}
+
+	readPermission := func(from types.Address) bool {
+		t.Helper()
+
+		res := run(from, ReadCallPermissionFunc, []interface{}{from, token, transfer}, true)
+		require.NoError(t, res.Err)
+		require.Equal(t, readAddressListCost, res.GasUsed)
+
+		return types.BytesToHash(res.ReturnValue) == callPermissionSet
+	}
+
+	// the admin makes the manager
+	require.NoError(t, run(admin, SetEnabledFunc, []interface{}{manager}, false).Err)
+
+	// which manages the calls
+	require.NoError(t, run(manager, SetCallRestrictedFunc, []interface{}{token, transfer, true}, false).Err)
+	require.False(t, readPermission(caller))
+
+	require.NoError(t, run(manager, SetCallPermittedFunc, []interface{}{caller, token, transfer, true}, false).Err)
+	require.True(t, readPermission(caller))
+
+	// but not the roles
+	require.ErrorIs(t, run(manager, SetEnabledFunc, []interface{}{caller}, false).Err, runtime.ErrNotAuth)
+
+	// the others can't manage the calls
+	require.ErrorIs(t,
+		run(caller, SetCallRestrictedFunc, []interface{}{token, transfer, false}, false).Err,
+		runtime.ErrNotAuth,
+	)
+
+	// nor anyone within the static calls
+	require.ErrorIs(t,
+		run(admin, SetCallRestrictedFunc, []interface{}{token, transfer, false}, true).Err,
+		errWriteProtection,
+	)
+
+	// the gas is charged
+	input, err := SetCallRestrictedFunc.Encode([]interface{}{token, transfer, false})
+	require.NoError(t, err)
+
+	res := l.Run(&runtime.Contract{Caller: admin, Input: input, Gas: writeAddressListCost - 1}, nil, nil)
+	require.ErrorIs(t, res.Err, runtime.ErrOutOfGas)
+}
+
+func TestCallPermissionList_ApplyGenesisAllocs(t *testing.T) {
+	listAddr := types.StringToAddress("1")
+	admin := types.StringToAddress("2")
+	caller := types.StringToAddress("3")
+	token := types.StringToAddress("4")
+	transfer := chain.Selector{0xa9, 0x05, 0x9c, 0xbb}
+
+	genesis := &chain.Genesis{Alloc: map[types.Address]*chain.GenesisAccount{}}
+
+	ApplyCallPermissionGenesisAllocs(genesis, listAddr, &chain.CallPermissionListConfig{
+		AddressListConfig: chain.AddressListConfig{AdminAddresses: []types.Address{admin}},
+		Restrictions:      []*chain.CallRestriction{{Target: token, Selector: transfer}},
+		Permissions:       []*chain.CallPermission{{Caller: caller, Target: token, Selector: transfer}},
+	})
+
+	state := &mockState{state: genesis.Alloc[listAddr].Storage}
+	l := NewCallPermissionList(state, listAddr)
+
+	require.Equal(t, AdminRole, l.GetRole(admin))
+	require.True(t, l.IsCallPermitted(caller, token, transfer[:]))
+	require.False(t, l.IsCallPermitted(admin, token, transfer[:]))
+}
@@ -24,6 +24,22 @@ func ApplyGenesisAllocs(chain *chain.Genesis, addressListAddr types.Address, con
 	}
 }
 
+// ApplyCallPermissionGenesisAllocs sets the initial roles, restricted calls and permissions of the call permission list
+func ApplyCallPermissionGenesisAllocs(chain *chain.Genesis, listAddr types.Address,
+	config *chain.CallPermissionListConfig) {
+	ApplyGenesisAllocs(chain, listAddr, &config.AddressListConfig)
+
+	list := NewCallPermissionList(&genesisState{chain}, listAddr)
+
+	for _, restriction := range config.Restrictions {
+		list.SetCallRestricted(restriction.Target, restriction.Selector, true)
+	}
+
+	for _, permission := range config.Permissions {
+		list.SetCallPermitted(permission.Caller, permission.Target, permission.Selector, true)
+	}
+}
+
 type genesisState struct {
 	chain *chain.Genesis
 }
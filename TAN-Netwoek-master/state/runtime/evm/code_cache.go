@@ -0,0 +1,77 @@
+package evm
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/tarality/tan-network/types"
+)
+
+// DefaultCodeCacheSize is the default number of contracts kept in the code cache
+const DefaultCodeCacheSize = 4096
+
+// CodeCache is a bounded LRU cache of the contract code and its jump destination analysis,
+// keyed by the code hash. The code is content addressed, so the cached entries never become stale
+// and the cache can be shared by all the transitions, including the ones executed in parallel
+type CodeCache struct {
+	code      *lru.Cache
+	jumpdests *lru.Cache
+}
+
+// NewCodeCache creates a code cache keeping the given number of contracts
+func NewCodeCache(size int) (*CodeCache, error) {
+	code, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	jumpdests, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CodeCache{
+		code:      code,
+		jumpdests: jumpdests,
+	}, nil
+}
+
+// Code returns the cached code with the given hash
+func (c *CodeCache) Code(hash types.Hash) ([]byte, bool) {
+	v, ok := c.code.Get(hash)
+	if !ok {
+		return nil, false
+	}
+
+	code, ok := v.([]byte)
+
+	return code, ok
+}
+
+// AddCode adds the code with the given hash to the cache
+func (c *CodeCache) AddCode(hash types.Hash, code []byte) {
+	c.code.Add(hash, code)
+}
+
+// Purge removes all the cached entries
+func (c *CodeCache) Purge() {
+	c.code.Purge()
+	c.jumpdests.Purge()
+}
+
+// jumpdestsOf returns the jump destinations of the code with the given hash,
+// analysing the code if they are not cached yet. The returned bitmap must not be modified
+func (c *CodeCache) jumpdestsOf(hash types.Hash, code []byte) *bitmap {
+	if v, ok := c.jumpdests.Get(hash); ok {
+		// the length check guards against a hash not matching the code
+		if jumpdests, ok := v.(*bitmap); ok && len(jumpdests.buf) == len(code)/bitmapSize+1 {
+			return jumpdests
+		}
+	}
+
+	jumpdests := &bitmap{}
+	jumpdests.setCode(code)
+
+	c.jumpdests.Add(hash, jumpdests)
+
+	return jumpdests
+}
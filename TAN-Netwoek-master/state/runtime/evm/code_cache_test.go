@@ -0,0 +1,140 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/crypto"
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/types"
+)
+
+// newJumpCode returns the code of the given size which jumps over its body to the final JUMPDEST.
+// The body is made of pushes containing JUMPDEST bytes, like the code of the large contracts
+func newJumpCode(size int) []byte {
+	code := []byte{PUSH1 + 1, 0x00, 0x00, JUMP}
+
+	for len(code) < size-2 {
+		code = append(code, PUSH1, JUMPDEST)
+	}
+
+	dest := len(code)
+	code[1], code[2] = byte(dest>>8), byte(dest)
+
+	return append(code, JUMPDEST, byte(STOP))
+}
+
+func newCachedContract(code []byte) *runtime.Contract {
+	contract := newMockContract(big.NewInt(0), 100000, code)
+	contract.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+
+	return contract
+}
+
+func TestCodeCache_Jumpdests(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewCodeCache(2)
+	require.NoError(t, err)
+
+	code := newJumpCode(100)
+	hash := types.BytesToHash(crypto.Keccak256(code))
+
+	expected := &bitmap{}
+	expected.setCode(code)
+
+	jumpdests := cache.jumpdestsOf(hash, code)
+	assert.Equal(t, expected.buf, jumpdests.buf)
+
+	// the analysis is reused for the same code
+	assert.Same(t, jumpdests, cache.jumpdestsOf(hash, code))
+
+	// the analysis is not reused for the code not matching the hash
+	otherCode := newJumpCode(200)
+	assert.NotSame(t, jumpdests, cache.jumpdestsOf(hash, otherCode))
+}
+
+func TestCodeCache_Code(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewCodeCache(1)
+	require.NoError(t, err)
+
+	code, ok := cache.Code(types.StringToHash("1"))
+	assert.False(t, ok)
+	assert.Nil(t, code)
+
+	cache.AddCode(types.StringToHash("1"), []byte{0x1})
+	cache.AddCode(types.StringToHash("2"), []byte{0x2})
+
+	// the least recently used code is evicted
+	_, ok = cache.Code(types.StringToHash("1"))
+	assert.False(t, ok)
+
+	code, ok = cache.Code(types.StringToHash("2"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x2}, code)
+
+	cache.Purge()
+
+	_, ok = cache.Code(types.StringToHash("2"))
+	assert.False(t, ok)
+}
+
+func TestRun_CodeCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewCodeCache(2)
+	require.NoError(t, err)
+
+	evm := NewEVMWithCodeCache(cache)
+	code := newJumpCode(100)
+
+	// the cached analysis is not reset when the state is released
+	for i := 0; i < 3; i++ {
+		res := evm.Run(newCachedContract(code), &mockHost{}, &chain.ForksInTime{})
+		require.NoError(t, res.Err)
+	}
+
+	// jumping into the push data is invalid with the cached analysis as well
+	invalidJump := []byte{PUSH1, 0x04, JUMP, PUSH1, JUMPDEST, byte(STOP)}
+
+	res := evm.Run(newCachedContract(invalidJump), &mockHost{}, &chain.ForksInTime{})
+	assert.ErrorIs(t, res.Err, errInvalidJump)
+}
+
+func BenchmarkRun_JumpdestAnalysis(b *testing.B) {
+	// the size of a large contract, close to the code size limit
+	code := newJumpCode(24000)
+
+	cache, err := NewCodeCache(DefaultCodeCacheSize)
+	require.NoError(b, err)
+
+	hash := types.BytesToHash(crypto.Keccak256(code))
+
+	bench := func(b *testing.B, evm *EVM) {
+		b.Helper()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			contract := newMockContract(big.NewInt(0), 100000, code)
+			contract.CodeHash = hash
+
+			if res := evm.Run(contract, &mockHost{}, &chain.ForksInTime{}); res.Err != nil {
+				b.Fatal(res.Err)
+			}
+		}
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		bench(b, NewEVM())
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		bench(b, NewEVMWithCodeCache(cache))
+	})
+}
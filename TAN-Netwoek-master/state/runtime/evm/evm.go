@@ -5,12 +5,15 @@ import (
 
 	"github.com/tarality/tan-network/chain"
 	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/types"
 )
 
 var _ runtime.Runtime = &EVM{}
 
 // EVM is the ethereum virtual machine
 type EVM struct {
+	// codeCache caches the jump destination analysis of the deployed code, if set
+	codeCache *CodeCache
 }
 
 // NewEVM creates a new EVM
@@ -18,6 +21,11 @@ func NewEVM() *EVM {
 	return &EVM{}
 }
 
+// NewEVMWithCodeCache creates a new EVM reusing the jump destination analysis cached in the code cache
+func NewEVMWithCodeCache(codeCache *CodeCache) *EVM {
+	return &EVM{codeCache: codeCache}
+}
+
 // CanRun implements the runtime interface
 func (e *EVM) CanRun(*runtime.Contract, runtime.Host, *chain.ForksInTime) bool {
 	return true
@@ -40,7 +48,7 @@ func (e *EVM) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInT
 	contract.host = host
 	contract.config = config
 
-	contract.bitmap.setCode(c.Code)
+	contract.jumpdests = e.jumpdests(contract, c)
 
 	ret, err := contract.Run()
 
@@ -63,3 +71,15 @@ func (e *EVM) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInT
 		Err:         err,
 	}
 }
+
+// jumpdests returns the jump destinations of the contract code. The analysis of the deployed code
+// is taken from the code cache, the init code is analysed on every run
+func (e *EVM) jumpdests(contract *state, c *runtime.Contract) *bitmap {
+	if e.codeCache == nil || c.CodeHash == types.ZeroHash || c.CodeHash == types.EmptyCodeHash {
+		contract.bitmap.setCode(c.Code)
+
+		return &contract.bitmap
+	}
+
+	return e.codeCache.jumpdestsOf(c.CodeHash, c.Code)
+}
@@ -461,11 +461,47 @@ func opMStore8(c *state) {
 
 // --- storage ---
 
+// eip-2929 gas costs of the state access
+const (
+	coldAccountAccessCost uint64 = 2600
+	coldSloadCost         uint64 = 2100
+	warmStorageReadCost   uint64 = 100
+)
+
+// accessAddress adds the address to the access list of the transaction
+// and returns the eip-2929 cost of accessing it
+func (c *state) accessAddress(addr types.Address) uint64 {
+	if c.host.AddressInAccessList(addr) {
+		return warmStorageReadCost
+	}
+
+	c.host.AddAddressToAccessList(addr)
+
+	return coldAccountAccessCost
+}
+
+// accessSlot adds the storage slot of the contract to the access list of the transaction
+// and returns true if it was not accessed before
+func (c *state) accessSlot(slot types.Hash) bool {
+	if _, ok := c.host.SlotInAccessList(c.msg.Address, slot); ok {
+		return false
+	}
+
+	c.host.AddSlotToAccessList(c.msg.Address, slot)
+
+	return true
+}
+
 func opSload(c *state) {
 	loc := c.top()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		gas = warmStorageReadCost
+		if c.accessSlot(bigToHash(loc)) {
+			gas = coldSloadCost
+		}
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 800
 	} else if c.config.EIP150 {
@@ -500,12 +536,19 @@ func opSStore(c *state) {
 
 	legacyGasMetering := !c.config.Istanbul && (c.config.Petersburg || !c.config.Constantinople)
 
-	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 	cost := uint64(0)
+	if c.config.Berlin && c.accessSlot(key) {
+		cost = coldSloadCost
+	}
+
+	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 
 	switch status {
 	case runtime.StorageUnchanged:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -515,10 +558,18 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageModified:
-		cost = 5000
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
+		} else {
+			cost = 5000
+		}
 
 	case runtime.StorageModifiedAgain:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -528,10 +579,15 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageAdded:
-		cost = 20000
+		cost += 20000
 
 	case runtime.StorageDeleted:
-		cost = 5000
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
+		} else {
+			cost = 5000
+		}
 	}
 
 	if !c.consumeGas(cost) {
@@ -575,7 +631,9 @@ func opBalance(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		gas = c.accessAddress(addr)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 700
 	} else if c.config.EIP150 {
@@ -658,7 +716,9 @@ func opExtCodeSize(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		gas = c.accessAddress(addr)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -693,7 +753,9 @@ func opExtCodeHash(c *state) {
 	address, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		gas = c.accessAddress(address)
+	} else if c.config.Istanbul {
 		gas = 700
 	} else {
 		gas = 400
@@ -767,7 +829,9 @@ func opExtCodeCopy(c *state) {
 	}
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		gas = c.accessAddress(address)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -943,6 +1007,13 @@ func opSelfDestruct(c *state) {
 		}
 	}
 
+	// eip-2929 the beneficiary is charged only if it was not accessed before
+	if c.config.Berlin && !c.host.AddressInAccessList(address) {
+		c.host.AddAddressToAccessList(address)
+
+		gas += coldAccountAccessCost
+	}
+
 	if !c.consumeGas(gas) {
 		return
 	}
@@ -1226,7 +1297,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	}
 
 	var gasCost uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929 the cold access is charged before the gas passed to the call is computed
+		gasCost = c.accessAddress(addr)
+	} else if c.config.EIP150 {
 		gasCost = 700
 	} else {
 		gasCost = 40
@@ -1295,6 +1369,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 		args,
 	)
 
+	if c.evm != nil && c.evm.codeCache != nil {
+		contract.CodeHash = c.host.GetCodeHash(addr)
+	}
+
 	if op == STATICCALL || parent.msg.Static {
 		contract.Static = true
 	}
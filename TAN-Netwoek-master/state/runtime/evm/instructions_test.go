@@ -103,9 +103,45 @@ func TestMStore(t *testing.T) {
 
 type mockHostForInstructions struct {
 	mockHost
-	nonce       uint64
-	code        []byte
-	callxResult *runtime.ExecutionResult
+	nonce         uint64
+	code          []byte
+	callxResult   *runtime.ExecutionResult
+	storageStatus runtime.StorageStatus
+	accessedAddrs map[types.Address]struct{}
+	accessedSlots map[types.Hash]struct{}
+}
+
+func (m *mockHostForInstructions) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHostForInstructions) AddressInAccessList(addr types.Address) bool {
+	_, ok := m.accessedAddrs[addr]
+
+	return ok
+}
+
+func (m *mockHostForInstructions) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	_, slotOk := m.accessedSlots[slot]
+
+	return m.AddressInAccessList(addr), slotOk
+}
+
+func (m *mockHostForInstructions) AddAddressToAccessList(addr types.Address) {
+	if m.accessedAddrs == nil {
+		m.accessedAddrs = map[types.Address]struct{}{}
+	}
+
+	m.accessedAddrs[addr] = struct{}{}
+}
+
+func (m *mockHostForInstructions) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	if m.accessedSlots == nil {
+		m.accessedSlots = map[types.Hash]struct{}{}
+	}
+
+	m.AddAddressToAccessList(addr)
+	m.accessedSlots[slot] = struct{}{}
 }
 
 func (m *mockHostForInstructions) GetNonce(types.Address) uint64 {
@@ -120,6 +156,31 @@ func (m *mockHostForInstructions) GetCode(addr types.Address) []byte {
 	return m.code
 }
 
+func (m *mockHostForInstructions) GetCodeSize(addr types.Address) int {
+	return len(m.code)
+}
+
+func (m *mockHostForInstructions) GetCodeHash(addr types.Address) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHostForInstructions) GetBalance(addr types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func (m *mockHostForInstructions) Empty(addr types.Address) bool {
+	return true
+}
+
+func (m *mockHostForInstructions) SetStorage(
+	addr types.Address,
+	key types.Hash,
+	value types.Hash,
+	config *chain.ForksInTime,
+) runtime.StorageStatus {
+	return m.storageStatus
+}
+
 var (
 	addr1 = types.StringToAddress("1")
 )
@@ -680,7 +741,7 @@ func Test_opCall(t *testing.T) {
 			},
 			config: &allEnabledForks,
 			initState: &state{
-				gas: 1000,
+				gas: 3000, // covers the cold access of the address
 				sp:  6,
 				stack: []*big.Int{
 					big.NewInt(0x00), // outSize
@@ -728,3 +789,318 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+func Test_opSload_AccessList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   chain.ForksInTime
+		expected []uint64
+	}{
+		{
+			name:     "should charge the cold and then the warm access after berlin",
+			config:   chain.ForksInTime{EIP150: true, Istanbul: true, Berlin: true},
+			expected: []uint64{coldSloadCost, warmStorageReadCost},
+		},
+		{
+			name:     "should charge the same gas before berlin",
+			config:   chain.ForksInTime{EIP150: true, Istanbul: true},
+			expected: []uint64{800, 800},
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.msg = &runtime.Contract{Address: addr1}
+			s.config = &test.config
+			s.host = &mockHostForInstructions{}
+
+			for _, expected := range test.expected {
+				s.gas = 10000
+				s.push(big.NewInt(1))
+
+				opSload(s)
+
+				assert.NoError(t, s.err)
+				assert.Equal(t, expected, 10000-s.gas)
+
+				s.pop()
+			}
+		})
+	}
+}
+
+func Test_opSstore_AccessList(t *testing.T) {
+	t.Parallel()
+
+	berlin := chain.ForksInTime{EIP150: true, Constantinople: true, Petersburg: true, Istanbul: true, Berlin: true}
+
+	tests := []struct {
+		name     string
+		config   chain.ForksInTime
+		status   runtime.StorageStatus
+		expected []uint64
+	}{
+		{
+			name:     "should charge the cold slot on the first unchanged write after berlin",
+			config:   berlin,
+			status:   runtime.StorageUnchanged,
+			expected: []uint64{coldSloadCost + warmStorageReadCost, warmStorageReadCost},
+		},
+		{
+			name:     "should charge the cold slot on the first modification after berlin",
+			config:   berlin,
+			status:   runtime.StorageModified,
+			expected: []uint64{5000, 5000 - coldSloadCost},
+		},
+		{
+			name:     "should charge the cold slot on the first deletion after berlin",
+			config:   berlin,
+			status:   runtime.StorageDeleted,
+			expected: []uint64{5000, 5000 - coldSloadCost},
+		},
+		{
+			name:     "should charge the cold slot on the first addition after berlin",
+			config:   berlin,
+			status:   runtime.StorageAdded,
+			expected: []uint64{coldSloadCost + 20000, 20000},
+		},
+		{
+			name:     "should charge the same gas before berlin",
+			config:   chain.ForksInTime{EIP150: true, Constantinople: true, Petersburg: true, Istanbul: true},
+			status:   runtime.StorageUnchanged,
+			expected: []uint64{800, 800},
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.msg = &runtime.Contract{Address: addr1}
+			s.config = &test.config
+			s.host = &mockHostForInstructions{storageStatus: test.status}
+
+			for _, expected := range test.expected {
+				s.gas = 30000
+				s.push(big.NewInt(2)) // value
+				s.push(big.NewInt(1)) // key
+
+				opSStore(s)
+
+				assert.NoError(t, s.err)
+				assert.Equal(t, expected, 30000-s.gas)
+			}
+		})
+	}
+}
+
+func Test_AccountAccess_AccessList(t *testing.T) {
+	t.Parallel()
+
+	berlin := chain.ForksInTime{
+		Homestead: true, EIP150: true, EIP158: true, Byzantium: true,
+		Constantinople: true, Petersburg: true, Istanbul: true, Berlin: true,
+	}
+	istanbul := berlin
+	istanbul.Berlin = false
+
+	// the arguments are pushed in order, the accessed address is pushed last unless it's a call
+	tests := []struct {
+		name     string
+		op       instruction
+		args     []*big.Int
+		config   chain.ForksInTime
+		expected []uint64
+	}{
+		{
+			name:     "BALANCE after berlin",
+			op:       opBalance,
+			config:   berlin,
+			expected: []uint64{coldAccountAccessCost, warmStorageReadCost},
+		},
+		{
+			name:     "BALANCE before berlin",
+			op:       opBalance,
+			config:   istanbul,
+			expected: []uint64{700, 700},
+		},
+		{
+			name:     "EXTCODESIZE after berlin",
+			op:       opExtCodeSize,
+			config:   berlin,
+			expected: []uint64{coldAccountAccessCost, warmStorageReadCost},
+		},
+		{
+			name:     "EXTCODESIZE before berlin",
+			op:       opExtCodeSize,
+			config:   istanbul,
+			expected: []uint64{700, 700},
+		},
+		{
+			name:     "EXTCODEHASH after berlin",
+			op:       opExtCodeHash,
+			config:   berlin,
+			expected: []uint64{coldAccountAccessCost, warmStorageReadCost},
+		},
+		{
+			name:     "EXTCODEHASH before berlin",
+			op:       opExtCodeHash,
+			config:   istanbul,
+			expected: []uint64{700, 700},
+		},
+		{
+			name: "EXTCODECOPY after berlin",
+			op:   opExtCodeCopy,
+			// length, codeOffset, memOffset
+			args:     []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+			config:   berlin,
+			expected: []uint64{coldAccountAccessCost, warmStorageReadCost},
+		},
+		{
+			name:     "EXTCODECOPY before berlin",
+			op:       opExtCodeCopy,
+			args:     []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+			config:   istanbul,
+			expected: []uint64{700, 700},
+		},
+		{
+			name: "CALL after berlin",
+			op:   opCall(CALL),
+			// outSize, outOffset, inSize, inOffset, value, then the address and the gas passed to the call
+			args:     []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+			config:   berlin,
+			expected: []uint64{coldAccountAccessCost, warmStorageReadCost},
+		},
+		{
+			name:     "CALL before berlin",
+			op:       opCall(CALL),
+			args:     []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+			config:   istanbul,
+			expected: []uint64{700, 700},
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			target := types.StringToAddress("0x1234")
+			isCall := len(test.args) == 5
+
+			s.msg = &runtime.Contract{Address: addr1}
+			s.config = &test.config
+			s.host = &mockHostForInstructions{callxResult: &runtime.ExecutionResult{}}
+
+			for _, expected := range test.expected {
+				s.gas = 10000
+				s.sp = 0
+
+				for _, arg := range test.args {
+					s.push(arg)
+				}
+
+				s.push(new(big.Int).SetBytes(target.Bytes()))
+
+				if isCall {
+					s.push(big.NewInt(0)) // gas passed to the call
+				}
+
+				test.op(s)
+
+				assert.NoError(t, s.err)
+				assert.Equal(t, expected, 10000-s.gas)
+			}
+		})
+	}
+}
+
+func Test_AccessList_Prewarm(t *testing.T) {
+	t.Parallel()
+
+	s, closeFn := getState()
+	defer closeFn()
+
+	target := types.StringToAddress("0x1234")
+	slot := types.BytesToHash(big.NewInt(1).Bytes())
+
+	// the addresses and the slots of the transaction access list are added before the execution
+	host := &mockHostForInstructions{}
+	host.AddAddressToAccessList(target)
+	host.AddSlotToAccessList(addr1, slot)
+
+	s.msg = &runtime.Contract{Address: addr1}
+	s.config = &allEnabledForks
+	s.host = host
+
+	s.gas = 10000
+	s.push(new(big.Int).SetBytes(target.Bytes()))
+	opExtCodeSize(s)
+	assert.NoError(t, s.err)
+	assert.Equal(t, warmStorageReadCost, 10000-s.gas)
+
+	s.gas = 10000
+	s.push(big.NewInt(1))
+	opSload(s)
+	assert.NoError(t, s.err)
+	assert.Equal(t, warmStorageReadCost, 10000-s.gas)
+}
+
+func Test_RefundQuotient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		refundQuotient  uint64
+		refund          uint64
+		expectedGasUsed uint64
+	}{
+		{
+			name:            "should cap the refund at the half of the gas used before london",
+			refundQuotient:  runtime.RefundQuotient,
+			refund:          50000,
+			expectedGasUsed: 50000,
+		},
+		{
+			name:            "should cap the refund at the fifth of the gas used after eip-3529",
+			refundQuotient:  runtime.RefundQuotientEIP3529,
+			refund:          50000,
+			expectedGasUsed: 80000,
+		},
+		{
+			name:            "should refund everything below the cap",
+			refundQuotient:  runtime.RefundQuotientEIP3529,
+			refund:          4800,
+			expectedGasUsed: 95200,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			// 100000 gas used out of the 150000 gas limit
+			result := &runtime.ExecutionResult{GasLeft: 50000}
+			result.UpdateGasUsed(150000, test.refund, test.refundQuotient)
+
+			assert.Equal(t, test.expectedGasUsed, result.GasUsed)
+			assert.Equal(t, 150000-test.expectedGasUsed, result.GasLeft)
+		})
+	}
+}
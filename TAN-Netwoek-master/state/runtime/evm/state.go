@@ -76,6 +76,8 @@ type state struct {
 
 	// bitvec bitvec
 	bitmap bitmap
+	// jumpdests are the jump destinations of the code, either the own bitmap or the cached one
+	jumpdests *bitmap
 
 	returnData []byte
 	ret        []byte
@@ -92,6 +94,7 @@ func (c *state) reset() {
 
 	// reset bitmap
 	c.bitmap.reset()
+	c.jumpdests = nil
 
 	// reset memory
 	for i := range c.memory {
@@ -112,7 +115,7 @@ func (c *state) validJumpdest(dest *big.Int) bool {
 		return false
 	}
 
-	return c.bitmap.isSet(udest)
+	return c.jumpdests.isSet(udest)
 }
 
 func (c *state) Halt() {
@@ -84,6 +84,10 @@ type Host interface {
 	Transfer(from types.Address, to types.Address, amount *big.Int) error
 	GetTracer() VMTracer
 	GetRefund() uint64
+	AddressInAccessList(addr types.Address) bool
+	SlotInAccessList(addr types.Address, slot types.Hash) (addressOk bool, slotOk bool)
+	AddAddressToAccessList(addr types.Address)
+	AddSlotToAccessList(addr types.Address, slot types.Hash)
 }
 
 type VMTracer interface {
@@ -123,11 +127,19 @@ func (r *ExecutionResult) Succeeded() bool { return r.Err == nil }
 func (r *ExecutionResult) Failed() bool    { return r.Err != nil }
 func (r *ExecutionResult) Reverted() bool  { return errors.Is(r.Err, ErrExecutionReverted) }
 
-func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64) {
+const (
+	// RefundQuotient is the max refunded fraction of the gas used (1/2)
+	RefundQuotient uint64 = 2
+
+	// RefundQuotientEIP3529 is the max refunded fraction of the gas used after EIP-3529 (1/5)
+	RefundQuotientEIP3529 uint64 = 5
+)
+
+func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64, refundQuotient uint64) {
 	r.GasUsed = gasLimit - r.GasLeft
 
-	// Refund can go up to half the gas used
-	if maxRefund := r.GasUsed / 2; refund > maxRefund {
+	// Refund can go up to the quotient of the gas used
+	if maxRefund := r.GasUsed / refundQuotient; refund > maxRefund {
 		refund = maxRefund
 	}
 
@@ -202,6 +214,9 @@ type Contract struct {
 	Input       []byte
 	Gas         uint64
 	Static      bool
+
+	// CodeHash is the hash of the deployed code, it is not set for the init code
+	CodeHash types.Hash
 }
 
 func NewContract(
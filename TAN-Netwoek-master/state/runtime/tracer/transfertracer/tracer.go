@@ -0,0 +1,114 @@
+package transfertracer
+
+import (
+	"math/big"
+
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/state/runtime/tracer"
+	"github.com/tarality/tan-network/types"
+)
+
+var _ tracer.Tracer = (*TransferTracer)(nil)
+
+// Transfer is a value transfer made by a contract during the transaction execution
+type Transfer struct {
+	From  types.Address `json:"from"`
+	To    types.Address `json:"to"`
+	Value *big.Int      `json:"value"`
+}
+
+// TransferTracer collects the internal value transfers of a transaction.
+// Transfers made inside of the reverted calls are dropped
+type TransferTracer struct {
+	transfers []Transfer
+	// frames holds the number of transfers collected before every open call
+	frames []int
+}
+
+// NewTransferTracer creates the internal value transfers tracer
+func NewTransferTracer() *TransferTracer {
+	return &TransferTracer{}
+}
+
+func (t *TransferTracer) Cancel(error) {}
+
+func (t *TransferTracer) Clear() {
+	t.transfers = nil
+	t.frames = t.frames[:0]
+}
+
+// GetResult returns the collected transfers as []Transfer
+func (t *TransferTracer) GetResult() (interface{}, error) {
+	return t.Transfers(), nil
+}
+
+// Transfers returns the collected transfers
+func (t *TransferTracer) Transfers() []Transfer {
+	return t.transfers
+}
+
+func (t *TransferTracer) TxStart(uint64) {}
+
+func (t *TransferTracer) TxEnd(uint64) {}
+
+func (t *TransferTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	t.frames = append(t.frames, len(t.transfers))
+
+	// top level transfer is the transaction itself
+	if depth <= 1 || value == nil || value.Sign() <= 0 {
+		return
+	}
+
+	switch runtime.CallType(callType) {
+	case runtime.Call, runtime.Create, runtime.Create2:
+		t.transfers = append(t.transfers, Transfer{
+			From:  from,
+			To:    to,
+			Value: new(big.Int).Set(value),
+		})
+	}
+}
+
+func (t *TransferTracer) CallEnd(depth int, output []byte, err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+
+	start := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	if err != nil {
+		t.transfers = t.transfers[:start]
+	}
+}
+
+func (t *TransferTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+}
+
+func (t *TransferTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opcode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
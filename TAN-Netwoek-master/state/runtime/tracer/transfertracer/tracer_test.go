@@ -0,0 +1,52 @@
+package transfertracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarality/tan-network/state/runtime"
+	"github.com/tarality/tan-network/types"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testContract = types.StringToAddress("2")
+	testTo       = types.StringToAddress("3")
+	testOther    = types.StringToAddress("4")
+)
+
+func TestTransferTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTransferTracer()
+
+	// top level call is the transaction itself
+	tracer.CallStart(1, testFrom, testContract, int(runtime.Call), 100000, big.NewInt(10), nil)
+
+	// internal transfer
+	tracer.CallStart(2, testContract, testTo, int(runtime.Call), 1000, big.NewInt(3), nil)
+	tracer.CallEnd(2, nil, nil)
+
+	// delegate calls and zero value calls are not transfers
+	tracer.CallStart(2, testContract, testOther, int(runtime.DelegateCall), 1000, big.NewInt(5), nil)
+	tracer.CallEnd(2, nil, nil)
+	tracer.CallStart(2, testContract, testOther, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tracer.CallEnd(2, nil, nil)
+
+	// transfers of the reverted call are dropped, including the nested ones
+	tracer.CallStart(2, testContract, testOther, int(runtime.Call), 1000, big.NewInt(1), nil)
+	tracer.CallStart(3, testOther, testTo, int(runtime.Call), 500, big.NewInt(1), nil)
+	tracer.CallEnd(3, nil, nil)
+	tracer.CallEnd(2, nil, errors.New("reverted"))
+
+	tracer.CallEnd(1, nil, nil)
+
+	assert.Equal(t, []Transfer{
+		{From: testContract, To: testTo, Value: big.NewInt(3)},
+	}, tracer.Transfers())
+
+	tracer.Clear()
+	assert.Empty(t, tracer.Transfers())
+}
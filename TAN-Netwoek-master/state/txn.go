@@ -28,6 +28,10 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// accessListIndex is the index prefix of the addresses and the storage slots
+	// accessed by the transaction (EIP-2929)
+	accessListIndex = types.BytesToHash([]byte{4}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -36,6 +40,9 @@ type Txn struct {
 	snapshots []*iradix.Tree
 	txn       *iradix.Txn
 	codeCache *lru.Cache
+
+	// access records the state read and written by a transaction executed speculatively
+	access *speculativeAccess
 }
 
 func NewTxn(snapshot Snapshot) *Txn {
@@ -103,6 +110,10 @@ func (txn *Txn) getStateObject(addr types.Address) (*StateObject, bool) {
 		return obj.Copy(), true
 	}
 
+	if txn.access != nil {
+		return txn.access.getStateObject(addr)
+	}
+
 	account, err := txn.snapshot.GetAccount(addr)
 	if err != nil {
 		return nil, false
@@ -129,6 +140,10 @@ func (txn *Txn) upsertAccount(addr types.Address, create bool, f func(object *St
 				Root:     emptyStateHash,
 			},
 		}
+
+		if txn.access != nil {
+			txn.access.createAccount(addr)
+		}
 	}
 
 	// run the callback to modify the account
@@ -142,6 +157,10 @@ func (txn *Txn) upsertAccount(addr types.Address, create bool, f func(object *St
 func (txn *Txn) AddSealingReward(addr types.Address, balance *big.Int) {
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		if object.Suicide {
+			if txn.access != nil {
+				txn.access.createAccount(addr)
+			}
+
 			*object = *newStateObject(txn)
 			object.Account.Balance.SetBytes(balance.Bytes())
 		} else {
@@ -235,6 +254,12 @@ func (txn *Txn) SetStorage(
 
 	legacyGasMetering := !config.Istanbul && (config.Petersburg || !config.Constantinople)
 
+	// eip-3529 reduces the refund of the cleared slots
+	clearsRefund := uint64(15000)
+	if config.London2 {
+		clearsRefund = 4800
+	}
+
 	if legacyGasMetering {
 		if oldValue == types.ZeroHash {
 			return runtime.StorageAdded
@@ -253,7 +278,7 @@ func (txn *Txn) SetStorage(
 		}
 
 		if value == types.ZeroHash { // delete slot (2.1.2b)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearsRefund)
 
 			return runtime.StorageDeleted
 		}
@@ -263,22 +288,28 @@ func (txn *Txn) SetStorage(
 
 	if original != types.ZeroHash { // Storage slot was populated before this transaction started
 		if current == types.ZeroHash { // recreate slot (2.2.1.1)
-			txn.SubRefund(15000)
+			txn.SubRefund(clearsRefund)
 		} else if value == types.ZeroHash { // delete slot (2.2.1.2)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearsRefund)
 		}
 	}
 
 	if original == value {
 		if original == types.ZeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -295,6 +326,10 @@ func (txn *Txn) SetState(
 	key,
 	value types.Hash,
 ) {
+	if txn.access != nil {
+		txn.access.writeStorage(addr, key)
+	}
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		if object.Txn == nil {
 			object.Txn = iradix.New().Txn()
@@ -310,6 +345,16 @@ func (txn *Txn) SetState(
 
 // GetState returns the state of the address at a given key
 func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
+	value := txn.getState(addr, key)
+
+	if txn.access != nil {
+		txn.access.readStorage(addr, key, value)
+	}
+
+	return value
+}
+
+func (txn *Txn) getState(addr types.Address, key types.Hash) types.Hash {
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return types.Hash{}
@@ -456,6 +501,65 @@ func (txn *Txn) SubRefund(gas uint64) {
 	txn.txn.Insert(refundIndex, refund)
 }
 
+// AddressInAccessList returns true if the address was accessed by the transaction
+func (txn *Txn) AddressInAccessList(addr types.Address) bool {
+	_, ok := txn.txn.Get(accessListAddressKey(addr))
+
+	return ok
+}
+
+// SlotInAccessList returns whether the address and the storage slot of it were accessed by the transaction
+func (txn *Txn) SlotInAccessList(addr types.Address, slot types.Hash) (addressOk bool, slotOk bool) {
+	_, addressOk = txn.txn.Get(accessListAddressKey(addr))
+	_, slotOk = txn.txn.Get(accessListSlotKey(addr, slot))
+
+	return addressOk, slotOk
+}
+
+// AddAddressToAccessList marks the address as accessed by the transaction
+func (txn *Txn) AddAddressToAccessList(addr types.Address) {
+	txn.txn.Insert(accessListAddressKey(addr), struct{}{})
+}
+
+// AddSlotToAccessList marks the address and the storage slot of it as accessed by the transaction
+func (txn *Txn) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	txn.txn.Insert(accessListAddressKey(addr), struct{}{})
+	txn.txn.Insert(accessListSlotKey(addr, slot), struct{}{})
+}
+
+// PrepareAccessList resets the access list for the new transaction and adds to it
+// the sender, the recipient (if any), the precompiled contracts and the access list of the transaction (EIP-2930)
+func (txn *Txn) PrepareAccessList(from types.Address, to *types.Address, precompiles []types.Address,
+	txAccessList types.TxAccessList) {
+	txn.txn.DeletePrefix(accessListIndex)
+
+	txn.AddAddressToAccessList(from)
+
+	if to != nil {
+		txn.AddAddressToAccessList(*to)
+	}
+
+	for _, addr := range precompiles {
+		txn.AddAddressToAccessList(addr)
+	}
+
+	for _, tuple := range txAccessList {
+		txn.AddAddressToAccessList(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			txn.AddSlotToAccessList(tuple.Address, key)
+		}
+	}
+}
+
+func accessListAddressKey(addr types.Address) []byte {
+	return append(append([]byte{}, accessListIndex...), addr.Bytes()...)
+}
+
+func accessListSlotKey(addr types.Address, slot types.Hash) []byte {
+	return append(accessListAddressKey(addr), slot.Bytes()...)
+}
+
 func (txn *Txn) Logs() []*types.Log {
 	data, exists := txn.txn.Get(logIndex)
 	if !exists {
@@ -544,6 +648,10 @@ func (txn *Txn) CreateAccount(addr types.Address) {
 		obj.Account.Balance.SetBytes(prev.Account.Balance.Bytes())
 	}
 
+	if txn.access != nil {
+		txn.access.createAccount(addr)
+	}
+
 	txn.txn.Insert(addr.Bytes(), obj)
 }
 
@@ -578,8 +686,9 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 		txn.txn.Insert(k, obj2)
 	}
 
-	// delete refunds
+	// delete refunds and the access list
 	txn.txn.Delete(refundIndex)
+	txn.txn.DeletePrefix(accessListIndex)
 
 	return nil
 }
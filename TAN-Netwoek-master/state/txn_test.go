@@ -70,3 +70,40 @@ func TestSnapshotUpdateData(t *testing.T) {
 	assert.NoError(t, txn.RevertToSnapshot(ss))
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
+
+func TestTxn_AccessList(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.PrepareAccessList(addr1, nil, nil, nil)
+	assert.True(t, txn.AddressInAccessList(addr1))
+	assert.False(t, txn.AddressInAccessList(addr2))
+
+	// the accesses of the reverted calls are reverted
+	ss := txn.Snapshot()
+	txn.AddSlotToAccessList(addr2, hash1)
+
+	addressOk, slotOk := txn.SlotInAccessList(addr2, hash1)
+	assert.True(t, addressOk)
+	assert.True(t, slotOk)
+
+	assert.NoError(t, txn.RevertToSnapshot(ss))
+
+	addressOk, slotOk = txn.SlotInAccessList(addr2, hash1)
+	assert.False(t, addressOk)
+	assert.False(t, slotOk)
+
+	// the access list is reset for the next transaction
+	txn.AddSlotToAccessList(addr1, hash1)
+	assert.NoError(t, txn.CleanDeleteObjects(true))
+
+	addressOk, slotOk = txn.SlotInAccessList(addr1, hash1)
+	assert.False(t, addressOk)
+	assert.False(t, slotOk)
+
+	// the access list of the transaction is accessed upfront
+	txn.PrepareAccessList(addr1, nil, nil, types.TxAccessList{{Address: addr2, StorageKeys: []types.Hash{hash1}}})
+
+	addressOk, slotOk = txn.SlotInAccessList(addr2, hash1)
+	assert.True(t, addressOk)
+	assert.True(t, slotOk)
+}
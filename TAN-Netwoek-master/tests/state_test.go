@@ -137,6 +137,10 @@ func TestState(t *testing.T) {
 		"RevertPrecompiledTouch",
 	}
 
+	if !hasSpecTests(stateTests, legacyStateTests) {
+		t.Skip("spec tests are not checked out")
+	}
+
 	// There are two folders in spec tests, one for the current tests for the Istanbul fork
 	// and one for the legacy tests for the other forks
 	folders, err := listFolders(stateTests, legacyStateTests)
@@ -1,7 +1,6 @@
 package tests
 
 import (
-	"embed"
 	"encoding/json"
 	"fmt"
 	"io/fs"
@@ -262,6 +261,13 @@ type stTransaction struct {
 	Nonce                uint64         `json:"nonce"`
 	From                 types.Address  `json:"secretKey"`
 	To                   *types.Address `json:"to"`
+	AccessLists          []types.TxAccessList
+}
+
+// stAccessTuple is the access list entry of the test transaction (EIP-2930)
+type stAccessTuple struct {
+	Address     types.Address `json:"address"`
+	StorageKeys []types.Hash  `json:"storageKeys"`
 }
 
 func (t *stTransaction) At(i indexes, baseFee *big.Int) (*types.Transaction, error) {
@@ -296,16 +302,22 @@ func (t *stTransaction) At(i indexes, baseFee *big.Int) (*types.Transaction, err
 		gasPrice = common.BigMin(new(big.Int).Add(t.MaxPriorityFeePerGas, baseFee), t.MaxFeePerGas)
 	}
 
+	var accessList types.TxAccessList
+	if i.Data < len(t.AccessLists) {
+		accessList = t.AccessLists[i.Data]
+	}
+
 	return &types.Transaction{
-		From:      t.From,
-		To:        t.To,
-		Nonce:     t.Nonce,
-		Value:     new(big.Int).Set(t.Value[i.Value]),
-		Gas:       t.GasLimit[i.Gas],
-		GasPrice:  new(big.Int).Set(gasPrice),
-		GasFeeCap: t.MaxFeePerGas,
-		GasTipCap: t.MaxPriorityFeePerGas,
-		Input:     hex.MustDecodeHex(t.Data[i.Data]),
+		From:       t.From,
+		To:         t.To,
+		Nonce:      t.Nonce,
+		Value:      new(big.Int).Set(t.Value[i.Value]),
+		Gas:        t.GasLimit[i.Gas],
+		GasPrice:   new(big.Int).Set(gasPrice),
+		GasFeeCap:  t.MaxFeePerGas,
+		GasTipCap:  t.MaxPriorityFeePerGas,
+		Input:      hex.MustDecodeHex(t.Data[i.Data]),
+		AccessList: accessList,
 	}, nil
 }
 
@@ -320,6 +332,8 @@ func (t *stTransaction) UnmarshalJSON(input []byte) error {
 		Nonce                string   `json:"nonce,omitempty"`
 		SecretKey            string   `json:"secretKey,omitempty"`
 		To                   string   `json:"to,omitempty"`
+
+		AccessLists [][]stAccessTuple `json:"accessLists,omitempty"`
 	}
 
 	var dec txUnmarshall
@@ -329,6 +343,15 @@ func (t *stTransaction) UnmarshalJSON(input []byte) error {
 
 	t.Data = dec.Data
 
+	for _, tuples := range dec.AccessLists {
+		accessList := make(types.TxAccessList, len(tuples))
+		for i, tuple := range tuples {
+			accessList[i] = types.AccessTuple{Address: tuple.Address, StorageKeys: tuple.StorageKeys}
+		}
+
+		t.AccessLists = append(t.AccessLists, accessList)
+	}
+
 	for _, i := range dec.GasLimit {
 		j, err := stringToUint64(i)
 		if err != nil {
@@ -436,7 +459,28 @@ var Forks = map[string]*chain.Forks{
 		chain.Byzantium:      chain.NewFork(0),
 		chain.Constantinople: chain.NewFork(0),
 	},
-	"Istchain.anbul": {
+	"Istanbul": {
+		chain.Homestead:      chain.NewFork(0),
+		chain.EIP150:         chain.NewFork(0),
+		chain.EIP155:         chain.NewFork(0),
+		chain.EIP158:         chain.NewFork(0),
+		chain.Byzantium:      chain.NewFork(0),
+		chain.Constantinople: chain.NewFork(0),
+		chain.Petersburg:     chain.NewFork(0),
+		chain.Istanbul:       chain.NewFork(0),
+	},
+	"Berlin": {
+		chain.Homestead:      chain.NewFork(0),
+		chain.EIP150:         chain.NewFork(0),
+		chain.EIP155:         chain.NewFork(0),
+		chain.EIP158:         chain.NewFork(0),
+		chain.Byzantium:      chain.NewFork(0),
+		chain.Constantinople: chain.NewFork(0),
+		chain.Petersburg:     chain.NewFork(0),
+		chain.Istanbul:       chain.NewFork(0),
+		chain.Berlin:         chain.NewFork(0),
+	},
+	"London": {
 		chain.Homestead:      chain.NewFork(0),
 		chain.EIP150:         chain.NewFork(0),
 		chain.EIP155:         chain.NewFork(0),
@@ -445,6 +489,22 @@ var Forks = map[string]*chain.Forks{
 		chain.Constantinople: chain.NewFork(0),
 		chain.Petersburg:     chain.NewFork(0),
 		chain.Istanbul:       chain.NewFork(0),
+		chain.Berlin:         chain.NewFork(0),
+		chain.London:         chain.NewFork(0),
+		chain.London2:        chain.NewFork(0),
+	},
+	"BerlinToLondonAt5": {
+		chain.Homestead:      chain.NewFork(0),
+		chain.EIP150:         chain.NewFork(0),
+		chain.EIP155:         chain.NewFork(0),
+		chain.EIP158:         chain.NewFork(0),
+		chain.Byzantium:      chain.NewFork(0),
+		chain.Constantinople: chain.NewFork(0),
+		chain.Petersburg:     chain.NewFork(0),
+		chain.Istanbul:       chain.NewFork(0),
+		chain.Berlin:         chain.NewFork(0),
+		chain.London:         chain.NewFork(5),
+		chain.London2:        chain.NewFork(5),
 	},
 	"FrontierToHomesteadAt5": {
 		chain.Homestead: chain.NewFork(5),
@@ -488,8 +548,19 @@ func contains(l []string, name string) bool {
 	return false
 }
 
-//go:embed tests
-var testsFS embed.FS
+// testsFS contains the spec tests, which are checked out into the tests directory (ethereum/tests)
+var testsFS = os.DirFS(".")
+
+// hasSpecTests returns true if the spec tests are checked out
+func hasSpecTests(tests ...string) bool {
+	for _, t := range tests {
+		if _, err := fs.Stat(testsFS, t); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
 
 func listFolders(tests ...string) ([]string, error) {
 	var folders []string
@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"math/big"
 	"sync"
 
 	"github.com/tarality/tan-network/types"
@@ -10,6 +11,9 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// feePayerCosts is the total gas cost of the fee delegated transactions of every fee payer
+	feePayerCosts map[types.Address]*big.Int
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -24,6 +28,16 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 
 	m.all[tx.Hash] = tx
 
+	if tx.Type == types.FeeDelegatedTx && tx.FeePayer != nil {
+		cost, ok := m.feePayerCosts[*tx.FeePayer]
+		if !ok {
+			cost = big.NewInt(0)
+			m.feePayerCosts[*tx.FeePayer] = cost
+		}
+
+		cost.Add(cost, tx.GasCost())
+	}
+
 	return true
 }
 
@@ -33,10 +47,35 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 	defer m.Unlock()
 
 	for _, tx := range txs {
+		if _, exists := m.all[tx.Hash]; !exists {
+			continue
+		}
+
 		delete(m.all, tx.Hash)
+
+		if tx.Type == types.FeeDelegatedTx && tx.FeePayer != nil {
+			if cost, ok := m.feePayerCosts[*tx.FeePayer]; ok {
+				if cost.Sub(cost, tx.GasCost()).Sign() <= 0 {
+					delete(m.feePayerCosts, *tx.FeePayer)
+				}
+			}
+		}
 	}
 }
 
+// feePayerCost returns the total gas cost of the fee delegated transactions
+// the fee payer sponsors in the pool. [thread-safe]
+func (m *lookupMap) feePayerCost(feePayer types.Address) *big.Int {
+	m.RLock()
+	defer m.RUnlock()
+
+	if cost, ok := m.feePayerCosts[feePayer]; ok {
+		return new(big.Int).Set(cost)
+	}
+
+	return big.NewInt(0)
+}
+
 // get returns the transaction associated with the given hash. [thread-safe]
 func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 	m.RLock()
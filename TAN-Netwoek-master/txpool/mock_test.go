@@ -41,6 +41,32 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+// balanceMockStore returns the balances of the given accounts, zero for the others
+type balanceMockStore struct {
+	defaultMockStore
+
+	balances map[types.Address]*big.Int
+}
+
+func (m balanceMockStore) GetBalance(_ types.Hash, addr types.Address) (*big.Int, error) {
+	if balance, ok := m.balances[addr]; ok {
+		return new(big.Int).Set(balance), nil
+	}
+
+	return big.NewInt(0), nil
+}
+
+// gasFeeAllowListMockStore returns the balances of the given accounts and the gas fee allow list members
+type gasFeeAllowListMockStore struct {
+	balanceMockStore
+
+	members map[types.Address]bool
+}
+
+func (m gasFeeAllowListMockStore) IsGasFeeAllowListed(_ types.Hash, addr types.Address) bool {
+	return m.members[addr]
+}
+
 type faultyMockStore struct {
 }
 
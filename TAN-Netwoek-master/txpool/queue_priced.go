@@ -13,10 +13,21 @@ type pricedQueue struct {
 
 // newPricesQueue creates the priced queue with initial transactions and base fee
 func newPricesQueue(baseFee uint64, initialTxs []*types.Transaction) *pricedQueue {
+	return newPrioritizedPricesQueue(baseFee, initialTxs, nil)
+}
+
+// newPrioritizedPricesQueue creates the priced queue with initial transactions and base fee,
+// which pops the prioritized transactions before the others. The prioritized function may be nil
+func newPrioritizedPricesQueue(
+	baseFee uint64,
+	initialTxs []*types.Transaction,
+	prioritized func(tx *types.Transaction) bool,
+) *pricedQueue {
 	q := &pricedQueue{
 		queue: &maxPriceQueue{
-			baseFee: new(big.Int).SetUint64(baseFee),
-			txs:     initialTxs,
+			baseFee:     new(big.Int).SetUint64(baseFee),
+			txs:         initialTxs,
+			prioritized: prioritized,
 		},
 	}
 
@@ -54,6 +65,9 @@ func (q *pricedQueue) length() int {
 type maxPriceQueue struct {
 	baseFee *big.Int
 	txs     []*types.Transaction
+
+	// prioritized tells the transactions sorted before the others regardless of the price
+	prioritized func(tx *types.Transaction) bool
 }
 
 /* Queue methods required by the heap interface */
@@ -95,6 +109,12 @@ func (q *maxPriceQueue) Pop() interface{} {
 // @see https://github.com/etclabscore/core-geth/blob/4e2b0e37f89515a4e7b6bafaa40910a296cb38c0/core/txpool/list.go#L458
 // for details why is something implemented like it is
 func (q *maxPriceQueue) Less(i, j int) bool {
+	if q.prioritized != nil {
+		if pi, pj := q.prioritized(q.txs[i]), q.prioritized(q.txs[j]); pi != pj {
+			return pi
+		}
+	}
+
 	switch cmp(q.txs[i], q.txs[j], q.baseFee) {
 	case -1:
 		return false
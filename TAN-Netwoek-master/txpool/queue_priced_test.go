@@ -246,6 +246,38 @@ func Test_maxPriceQueue(t *testing.T) {
 	}
 }
 
+func Test_pricedQueue_Prioritized(t *testing.T) {
+	t.Parallel()
+
+	member := types.StringToAddress("1")
+	other := types.StringToAddress("2")
+
+	txs := []*types.Transaction{
+		{From: other, Type: types.LegacyTx, GasPrice: big.NewInt(300)},
+		{From: member, Type: types.LegacyTx, GasPrice: big.NewInt(0)},
+		{From: other, Type: types.LegacyTx, GasPrice: big.NewInt(200), Nonce: 1},
+		{From: member, Type: types.LegacyTx, GasPrice: big.NewInt(100), Nonce: 1},
+	}
+
+	queue := newPrioritizedPricesQueue(0, txs, func(tx *types.Transaction) bool {
+		return tx.From == member
+	})
+
+	// the prioritized transactions go first, sorted by the price as the others
+	expected := []int64{100, 0, 300, 200}
+
+	for _, price := range expected {
+		tx := queue.pop()
+		if !assert.NotNil(t, tx) {
+			return
+		}
+
+		assert.Equal(t, price, tx.GasPrice.Int64())
+	}
+
+	assert.Nil(t, queue.pop())
+}
+
 func Benchmark_pricedQueue(t *testing.B) {
 	testTable := []struct {
 		name        string
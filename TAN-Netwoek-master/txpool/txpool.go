@@ -13,6 +13,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/tarality/tan-network/blockchain"
 	"github.com/tarality/tan-network/chain"
+	"github.com/tarality/tan-network/crypto"
 	"github.com/tarality/tan-network/forkmanager"
 	"github.com/tarality/tan-network/network"
 	"github.com/tarality/tan-network/state"
@@ -42,27 +43,30 @@ const (
 
 // errors
 var (
-	ErrIntrinsicGas            = errors.New("intrinsic gas too low")
-	ErrBlockLimitExceeded      = errors.New("exceeds block gas limit")
-	ErrNegativeValue           = errors.New("negative value")
-	ErrExtractSignature        = errors.New("cannot extract signature")
-	ErrInvalidSender           = errors.New("invalid sender")
-	ErrTxPoolOverflow          = errors.New("txpool is full")
-	ErrUnderpriced             = errors.New("transaction underpriced")
-	ErrNonceTooLow             = errors.New("nonce too low")
-	ErrInsufficientFunds       = errors.New("insufficient funds for gas * price + value")
-	ErrInvalidAccountState     = errors.New("invalid account state")
-	ErrAlreadyKnown            = errors.New("already known")
-	ErrOversizedData           = errors.New("oversized data")
-	ErrMaxEnqueuedLimitReached = errors.New("maximum number of enqueued transactions reached")
-	ErrRejectFutureTx          = errors.New("rejected future tx due to low slots")
-	ErrInvalidTxType           = errors.New("invalid tx type")
-	ErrTipAboveFeeCap          = errors.New("max priority fee per gas higher than max fee per gas")
-	ErrTipVeryHigh             = errors.New("max priority fee per gas higher than 2^256-1")
-	ErrFeeCapVeryHigh          = errors.New("max fee per gas higher than 2^256-1")
-	ErrNonceExistsInPool       = errors.New("tx with the same nonce is already present")
-	ErrReplacementUnderpriced  = errors.New("replacement tx underpriced")
-	ErrDynamicTxNotAllowed     = errors.New("dynamic tx not allowed currently")
+	ErrIntrinsicGas              = errors.New("intrinsic gas too low")
+	ErrBlockLimitExceeded        = errors.New("exceeds block gas limit")
+	ErrNegativeValue             = errors.New("negative value")
+	ErrExtractSignature          = errors.New("cannot extract signature")
+	ErrInvalidSender             = errors.New("invalid sender")
+	ErrTxPoolOverflow            = errors.New("txpool is full")
+	ErrUnderpriced               = errors.New("transaction underpriced")
+	ErrNonceTooLow               = errors.New("nonce too low")
+	ErrInsufficientFunds         = errors.New("insufficient funds for gas * price + value")
+	ErrInvalidAccountState       = errors.New("invalid account state")
+	ErrAlreadyKnown              = errors.New("already known")
+	ErrOversizedData             = errors.New("oversized data")
+	ErrMaxEnqueuedLimitReached   = errors.New("maximum number of enqueued transactions reached")
+	ErrRejectFutureTx            = errors.New("rejected future tx due to low slots")
+	ErrInvalidTxType             = errors.New("invalid tx type")
+	ErrTipAboveFeeCap            = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrTipVeryHigh               = errors.New("max priority fee per gas higher than 2^256-1")
+	ErrFeeCapVeryHigh            = errors.New("max fee per gas higher than 2^256-1")
+	ErrNonceExistsInPool         = errors.New("tx with the same nonce is already present")
+	ErrReplacementUnderpriced    = errors.New("replacement tx underpriced")
+	ErrDynamicTxNotAllowed       = errors.New("dynamic tx not allowed currently")
+	ErrFeeDelegatedTxNotAllowed  = errors.New("fee delegated tx not allowed currently")
+	ErrInvalidFeePayer           = errors.New("invalid fee payer signature")
+	ErrInsufficientFeePayerFunds = errors.New("insufficient funds of the fee payer for gas * price")
 )
 
 // indicates origin of a transaction
@@ -96,11 +100,18 @@ type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
+// gasFeeAllowListStore is implemented by the stores reading the gas fee allow list
+type gasFeeAllowListStore interface {
+	// IsGasFeeAllowListed returns true if the address is enabled in the gas fee allow list
+	IsGasFeeAllowListed(root types.Hash, addr types.Address) bool
+}
+
 type Config struct {
 	PriceLimit         uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
 	ChainID            *big.Int
+	GasFeeAllowList    *chain.GasFeeAllowListConfig
 }
 
 /* All requests are passed to the main loop
@@ -193,6 +204,9 @@ type TxPool struct {
 
 	// chain id
 	chainID *big.Int
+
+	// gasFeeAllowList is the configuration of the gas fee allow list, nil if it's not enabled
+	gasFeeAllowList *chain.GasFeeAllowListConfig
 }
 
 // NewTxPool returns a new pool for processing incoming transactions.
@@ -210,10 +224,15 @@ func NewTxPool(
 		store:       store,
 		executables: newPricesQueue(0, nil),
 		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
-		chainID:     config.ChainID,
+		index: lookupMap{
+			all:           make(map[types.Hash]*types.Transaction),
+			feePayerCosts: make(map[types.Address]*big.Int),
+		},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit: config.PriceLimit,
+		chainID:    config.ChainID,
+
+		gasFeeAllowList: config.GasFeeAllowList,
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -338,8 +357,46 @@ func (p *TxPool) Prepare(baseFee uint64) {
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
-	// create new executables queue with base fee and initial transactions (primaries)
-	p.executables = newPricesQueue(baseFee, primaries)
+	// create new executables queue with base fee and initial transactions (primaries).
+	// The transactions of the gas fee allow list members go first, since they don't compete on the price
+	p.executables = newPrioritizedPricesQueue(baseFee, primaries, p.gasFeePriority())
+}
+
+// gasFeePriority returns the function telling if the transaction is paid by the gas fee allow list member
+// in the latest state, or nil if there is no gas fee allow list
+func (p *TxPool) gasFeePriority() func(tx *types.Transaction) bool {
+	if p.gasFeeAllowList == nil {
+		return nil
+	}
+
+	stateRoot := p.store.Header().StateRoot
+	members := make(map[types.Address]bool)
+
+	return func(tx *types.Transaction) bool {
+		payer := tx.GasPayer()
+
+		member, ok := members[payer]
+		if !ok {
+			member = p.isGasFeeAllowListed(stateRoot, payer)
+			members[payer] = member
+		}
+
+		return member
+	}
+}
+
+// isGasFeeAllowListed returns true if the address pays the discounted gas price
+func (p *TxPool) isGasFeeAllowListed(stateRoot types.Hash, addr types.Address) bool {
+	if p.gasFeeAllowList == nil {
+		return false
+	}
+
+	allowListStore, ok := p.store.(gasFeeAllowListStore)
+	if !ok {
+		return false
+	}
+
+	return allowListStore.IsGasFeeAllowListed(stateRoot, addr)
 }
 
 // Peek returns the best-price selected
@@ -596,7 +653,19 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return runtime.ErrMaxCodeSizeExceeded
 	}
 
-	if tx.Type == types.DynamicFeeTx {
+	if tx.Type == types.FeeDelegatedTx {
+		if err := p.validateFeePayer(tx); err != nil {
+			return err
+		}
+	}
+
+	// Grab the state root for the latest block
+	stateRoot := p.store.Header().StateRoot
+
+	// The gas fee allow list members pay the discounted gas price, so they are not rejected as underpriced
+	gasFeeDiscounted := p.isGasFeeAllowListed(stateRoot, tx.GasPayer())
+
+	if tx.Type.IsDynamicFee() {
 		// Reject dynamic fee tx if london hardfork is not enabled
 		if !p.forks.London {
 			metrics.IncrCounter([]string{txPoolMetrics, "invalid_tx_type"}, 1)
@@ -638,12 +707,12 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		}
 
 		// Reject underpriced transactions
-		if tx.GasFeeCap.Cmp(new(big.Int).SetUint64(p.GetBaseFee())) < 0 {
+		if !gasFeeDiscounted && tx.GasFeeCap.Cmp(new(big.Int).SetUint64(p.GetBaseFee())) < 0 {
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 			return ErrUnderpriced
 		}
-	} else {
+	} else if !gasFeeDiscounted {
 		// Legacy approach to check if the given tx is not underpriced
 		if tx.GetGasPrice(p.GetBaseFee()).Cmp(big.NewInt(0).SetUint64(p.priceLimit)) < 0 {
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
@@ -652,9 +721,6 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		}
 	}
 
-	// Grab the state root for the latest block
-	stateRoot := p.store.Header().StateRoot
-
 	// Check nonce ordering
 	if p.store.GetNonce(stateRoot, tx.From) > tx.Nonce {
 		metrics.IncrCounter([]string{txPoolMetrics, "nonce_too_low_tx"}, 1)
@@ -669,8 +735,40 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidAccountState
 	}
 
-	// Check if the sender has enough funds to execute the transaction
-	if accountBalance.Cmp(tx.Cost()) < 0 {
+	gasCost := tx.GasCost()
+	if gasFeeDiscounted {
+		gasCost = p.gasFeeAllowList.Discount(gasCost)
+	}
+
+	if tx.Type == types.FeeDelegatedTx {
+		// The sender of the fee delegated transaction pays only the value,
+		// and the fee payer must have enough funds to buy the gas
+		if accountBalance.Cmp(tx.Value) < 0 {
+			metrics.IncrCounter([]string{txPoolMetrics, "insufficient_funds_tx"}, 1)
+
+			return ErrInsufficientFunds
+		}
+
+		feePayerBalance, err := p.store.GetBalance(stateRoot, *tx.FeePayer)
+		if err != nil {
+			metrics.IncrCounter([]string{txPoolMetrics, "invalid_account_state_tx"}, 1)
+
+			return ErrInvalidAccountState
+		}
+
+		// the fee payer must cover the transactions it already sponsors in the pool as well
+		pendingCost := p.index.feePayerCost(*tx.FeePayer)
+		if gasFeeDiscounted {
+			pendingCost = p.gasFeeAllowList.Discount(pendingCost)
+		}
+
+		if feePayerBalance.Cmp(new(big.Int).Add(gasCost, pendingCost)) < 0 {
+			metrics.IncrCounter([]string{txPoolMetrics, "insufficient_fee_payer_funds_tx"}, 1)
+
+			return ErrInsufficientFeePayerFunds
+		}
+	} else if accountBalance.Cmp(new(big.Int).Add(gasCost, tx.Value)) < 0 {
+		// Check if the sender has enough funds to execute the transaction
 		metrics.IncrCounter([]string{txPoolMetrics, "insufficient_funds_tx"}, 1)
 
 		return ErrInsufficientFunds
@@ -702,6 +800,38 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	return nil
 }
 
+// validateFeePayer ensures the fee delegated transaction is allowed
+// and is signed by the fee payer the sender specified
+func (p *TxPool) validateFeePayer(tx *types.Transaction) error {
+	// Reject fee delegated tx if the fee delegation fork is not enabled.
+	// The fork block is known to the fork manager, the genesis forks are used if it's not registered
+	enabled := p.forks.FeeDelegation
+	if blockNumber, err := forkmanager.GetInstance().GetForkBlock(chain.FeeDelegation); err == nil {
+		enabled = blockNumber <= p.store.Header().Number
+	}
+
+	if !enabled {
+		metrics.IncrCounter([]string{txPoolMetrics, "fee_delegated_tx_not_allowed"}, 1)
+
+		return ErrFeeDelegatedTxNotAllowed
+	}
+
+	feePayerSigner, ok := p.signer.(crypto.FeePayerSigner)
+	if !ok {
+		metrics.IncrCounter([]string{txPoolMetrics, "invalid_fee_payer_txs"}, 1)
+
+		return ErrInvalidFeePayer
+	}
+
+	if _, err := feePayerSigner.FeePayer(tx); err != nil {
+		metrics.IncrCounter([]string{txPoolMetrics, "invalid_fee_payer_txs"}, 1)
+
+		return ErrInvalidFeePayer
+	}
+
+	return nil
+}
+
 func (p *TxPool) signalPruning() {
 	select {
 	case p.pruneCh <- struct{}{}:
@@ -756,7 +886,7 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	}
 
 	// add chainID to the tx - only dynamic fee tx
-	if tx.Type == types.DynamicFeeTx {
+	if tx.Type.IsDynamicFee() {
 		tx.ChainID = p.chainID
 	}
 
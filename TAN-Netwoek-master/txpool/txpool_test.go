@@ -2210,6 +2210,140 @@ func Test_TxPool_validateTx(t *testing.T) {
 	})
 }
 
+func Test_TxPool_validateFeeDelegatedTx(t *testing.T) {
+	t.Parallel()
+
+	signer := crypto.NewLondonSigner(100, true, crypto.NewEIP155Signer(100, true))
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	feePayerKey, feePayerAddr := tests.GenerateKeyAndAddr(t)
+
+	// the sender can pay the value only, the fee payer can pay the gas only
+	gasCost := new(big.Int).Mul(big.NewInt(1100), new(big.Int).SetUint64(validGasLimit))
+	store := balanceMockStore{
+		defaultMockStore: defaultMockStore{DefaultHeader: mockHeader},
+		balances: map[types.Address]*big.Int{
+			senderAddr:   big.NewInt(1),
+			feePayerAddr: gasCost,
+		},
+	}
+
+	setupPool := func() *TxPool {
+		pool, err := newTestPool(store)
+		require.NoError(t, err)
+
+		pool.SetSigner(signer)
+		pool.forks.FeeDelegation = true
+		pool.baseFee = 1000
+
+		return pool
+	}
+
+	newFeeDelegatedTx := func(feePayer types.Address) *types.Transaction {
+		tx := newTx(senderAddr, 0, 1)
+		tx.Type = types.FeeDelegatedTx
+		tx.GasPrice = nil
+		tx.GasFeeCap = big.NewInt(1100)
+		tx.GasTipCap = big.NewInt(10)
+		tx.FeePayer = &feePayer
+
+		tx, err := signer.SignTx(tx, senderKey)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	signFeePayer := func(tx *types.Transaction, key *ecdsa.PrivateKey) *types.Transaction {
+		tx, err := signer.SignFeePayerTx(tx, key)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	t.Run("the fee payer pays the gas", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		assert.NoError(t, pool.validateTx(signFeePayer(newFeeDelegatedTx(feePayerAddr), feePayerKey)))
+	})
+
+	t.Run("the fee payer can't pay the gas", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		// the sender has no funds for the gas, but it's the fee payer now
+		assert.ErrorIs(t,
+			pool.validateTx(signFeePayer(newFeeDelegatedTx(senderAddr), senderKey)),
+			ErrInsufficientFeePayerFunds,
+		)
+	})
+
+	t.Run("the fee payer can't pay the gas of its pending transactions", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		pending := signFeePayer(newFeeDelegatedTx(feePayerAddr), feePayerKey)
+		require.True(t, pool.index.add(pending))
+
+		tx := newFeeDelegatedTx(feePayerAddr)
+		tx.Nonce = 1
+
+		tx, err := signer.SignTx(tx, senderKey)
+		require.NoError(t, err)
+
+		tx = signFeePayer(tx, feePayerKey)
+
+		assert.ErrorIs(t, pool.validateTx(tx), ErrInsufficientFeePayerFunds)
+
+		// the fee payer can sponsor the transaction once the pending one leaves the pool
+		pool.index.remove(pending)
+		assert.Equal(t, big.NewInt(0), pool.index.feePayerCost(feePayerAddr))
+		assert.NoError(t, pool.validateTx(tx))
+	})
+
+	t.Run("the sender can't pay the value", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		tx := newFeeDelegatedTx(feePayerAddr)
+		tx.Value = big.NewInt(2)
+
+		tx, err := signer.SignTx(tx, senderKey)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t,
+			pool.validateTx(signFeePayer(tx, feePayerKey)),
+			ErrInsufficientFunds,
+		)
+	})
+
+	t.Run("missing fee payer signature", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		assert.ErrorIs(t,
+			pool.validateTx(newFeeDelegatedTx(feePayerAddr)),
+			ErrInvalidFeePayer,
+		)
+	})
+
+	t.Run("fee delegation is not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+		pool.forks.FeeDelegation = false
+
+		assert.ErrorIs(t,
+			pool.validateTx(signFeePayer(newFeeDelegatedTx(feePayerAddr), feePayerKey)),
+			ErrFeeDelegatedTxNotAllowed,
+		)
+	})
+}
+
 /* "Integrated" tests */
 
 // The following tests ensure that the pool's inner event loop
@@ -3519,3 +3653,88 @@ func BenchmarkAddTxTime(b *testing.B) {
 		}
 	})
 }
+
+func Test_TxPool_GasFeeAllowList(t *testing.T) {
+	t.Parallel()
+
+	member := types.StringToAddress("1")
+	other := types.StringToAddress("2")
+
+	store := gasFeeAllowListMockStore{
+		balanceMockStore: balanceMockStore{
+			defaultMockStore: defaultMockStore{DefaultHeader: mockHeader},
+			balances: map[types.Address]*big.Int{
+				member: big.NewInt(1),
+				other:  big.NewInt(1),
+			},
+		},
+		members: map[types.Address]bool{member: true},
+	}
+
+	setupPool := func(discountPercent uint64) *TxPool {
+		pool, err := newTestPool(store)
+		require.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+		pool.gasFeeAllowList = &chain.GasFeeAllowListConfig{DiscountPercent: discountPercent}
+
+		return pool
+	}
+
+	newFreeTx := func(from types.Address) *types.Transaction {
+		tx := newTx(from, 0, 1)
+		tx.GasPrice = big.NewInt(0)
+
+		return tx
+	}
+
+	t.Run("the member sends the gas free transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(100)
+
+		assert.NoError(t, pool.validateTx(newFreeTx(member)))
+	})
+
+	t.Run("the member pays the discounted gas price", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(50)
+
+		// the member can pay the value only
+		assert.ErrorIs(t, pool.validateTx(newTx(member, 0, 1)), ErrInsufficientFunds)
+	})
+
+	t.Run("the transaction of the others is underpriced", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(100)
+
+		assert.ErrorIs(t, pool.validateTx(newFreeTx(other)), ErrUnderpriced)
+	})
+
+	t.Run("the gas fee allow list is not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(100)
+		pool.gasFeeAllowList = nil
+
+		assert.ErrorIs(t, pool.validateTx(newFreeTx(member)), ErrUnderpriced)
+	})
+
+	t.Run("the member transactions go first", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(100)
+
+		otherTx := newTx(other, 0, 1)
+		memberTx := newFreeTx(member)
+
+		pool.accounts.initOnce(other, 0).promoted.push(otherTx)
+		pool.accounts.initOnce(member, 0).promoted.push(memberTx)
+
+		pool.Prepare(0)
+
+		assert.Equal(t, memberTx, pool.Peek())
+	})
+}
@@ -38,6 +38,13 @@ type TxRelayer interface {
 	Client() *jsonrpc.Client
 }
 
+// TxSigner is implemented by the keys which sign the transactions themselves,
+// rather than signing the hash calculated by the ethgo signer
+type TxSigner interface {
+	// SignTx signs the transaction for the given chain
+	SignTx(txn *ethgo.Transaction, chainID uint64) (*ethgo.Transaction, error)
+}
+
 var _ TxRelayer = (*TxRelayerImpl)(nil)
 
 type TxRelayerImpl struct {
@@ -135,8 +142,13 @@ func (t *TxRelayerImpl) sendTransactionLocked(txn *ethgo.Transaction, key ethgo.
 		return ethgo.ZeroHash, err
 	}
 
-	signer := wallet.NewEIP155Signer(chainID.Uint64())
-	if txn, err = signer.SignTx(txn, key); err != nil {
+	if txSigner, ok := key.(TxSigner); ok {
+		txn, err = txSigner.SignTx(txn, chainID.Uint64())
+	} else {
+		txn, err = wallet.NewEIP155Signer(chainID.Uint64()).SignTx(txn, key)
+	}
+
+	if err != nil {
 		return ethgo.ZeroHash, err
 	}
 
@@ -2,6 +2,7 @@ package types
 
 import (
 	goHex "encoding/hex"
+	"math/big"
 	"strings"
 
 	"github.com/tarality/tan-network/helper/hex"
@@ -31,6 +32,12 @@ type Receipt struct {
 	TxHash          Hash
 
 	TransactionType TxType
+
+	// RevertReason is the return data of the reverted transaction, it is set only if the node stores it
+	RevertReason []byte
+
+	// BaseFeePaid is the base fee part of the transaction fee, it is set only if the base fee is charged
+	BaseFeePaid *big.Int
 }
 
 func (r *Receipt) IsLegacyTx() bool {
@@ -107,6 +114,27 @@ func (b *Bloom) setEncode(hasher *keccak.Keccak, h []byte) {
 	}
 }
 
+// BloomBitIndexes returns the indexes of the three bloom filter bits set for the data
+func BloomBitIndexes(data []byte) (indexes [3]uint) {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	hasher.Reset()
+	hasher.Write(data) //nolint:errcheck
+	buf := hasher.Read()
+
+	for i := 0; i < 6; i += 2 {
+		indexes[i/2] = (uint(buf[i+1]) + (uint(buf[i]) << 8)) & (BloomByteLength*8 - 1)
+	}
+
+	return
+}
+
+// IsBitSet checks if the bloom filter bit with the given index (as returned by BloomBitIndexes) is set
+func (b *Bloom) IsBitSet(bit uint) bool {
+	return b[BloomByteLength-1-bit/8]&(1<<(bit%8)) != 0
+}
+
 // IsLogInBloom checks if the log has a possible presence in the bloom filter
 func (b *Bloom) IsLogInBloom(log *Log) bool {
 	hasher := keccak.DefaultKeccakPool.Get()
@@ -99,6 +99,48 @@ func TestRLPStorage_Marshall_And_Unmarshall_Receipt(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"Marshal receipt with revert reason",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				TxHash:            hash,
+				RevertReason:      []byte{0x1, 0x2},
+			},
+			true,
+		},
+		{
+			"Marshal typed receipt with revert reason",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				TxHash:            hash,
+				TransactionType:   DynamicFeeTx,
+				RevertReason:      []byte{0x1, 0x2},
+			},
+			true,
+		},
+		{
+			"Marshal receipt with base fee paid",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				TxHash:            hash,
+				BaseFeePaid:       big.NewInt(1000),
+			},
+			true,
+		},
+		{
+			"Marshal receipt with revert reason and base fee paid",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				TxHash:            hash,
+				RevertReason:      []byte{0x1, 0x2},
+				BaseFeePaid:       big.NewInt(1000),
+			},
+			true,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -137,6 +179,7 @@ func TestRLPUnmarshal_Header_ComputeHash(t *testing.T) {
 func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	addrTo := StringToAddress("11")
 	addrFrom := StringToAddress("22")
+	addrFeePayer := StringToAddress("33")
 	originalTx := &Transaction{
 		Nonce:     0,
 		GasPrice:  big.NewInt(11),
@@ -150,12 +193,17 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 		V:         big.NewInt(25),
 		S:         big.NewInt(26),
 		R:         big.NewInt(27),
+		FeePayer:  &addrFeePayer,
+		FeePayerV: big.NewInt(1),
+		FeePayerR: big.NewInt(28),
+		FeePayerS: big.NewInt(29),
 	}
 
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
 		DynamicFeeTx,
+		FeeDelegatedTx,
 	}
 
 	for _, v := range txTypes {
@@ -171,6 +219,15 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 			unmarshalledTx.ComputeHash(1)
 			assert.Equal(t, originalTx.Type, unmarshalledTx.Type)
 			assert.Equal(t, originalTx.Hash, unmarshalledTx.Hash)
+
+			if v == FeeDelegatedTx {
+				assert.Equal(t, originalTx.FeePayer, unmarshalledTx.FeePayer)
+				assert.Equal(t, originalTx.FeePayerV, unmarshalledTx.FeePayerV)
+				assert.Equal(t, originalTx.FeePayerR, unmarshalledTx.FeePayerR)
+				assert.Equal(t, originalTx.FeePayerS, unmarshalledTx.FeePayerS)
+			} else {
+				assert.Nil(t, unmarshalledTx.FeePayer)
+			}
 		})
 	}
 }
@@ -274,6 +331,10 @@ func TestRLPMarshall_And_Unmarshall_TxType(t *testing.T) {
 			name:   "DynamicFeeTx",
 			txType: DynamicFeeTx,
 		},
+		{
+			name:   "FeeDelegatedTx",
+			txType: FeeDelegatedTx,
+		},
 		{
 			name:        "undefined type",
 			txType:      TxType(0x09),
@@ -198,13 +198,13 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv := arena.NewArray()
 
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
+	if t.Type.IsDynamicFee() {
 		vv.Set(arena.NewBigInt(t.ChainID))
 	}
 
 	vv.Set(arena.NewUint(t.Nonce))
 
-	if t.Type == DynamicFeeTx {
+	if t.Type.IsDynamicFee() {
 		// Add EIP-1559 related fields.
 		// For non-dynamic-fee-tx gas price is used.
 		vv.Set(arena.NewBigInt(t.GasTipCap))
@@ -229,15 +229,30 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	// This is needed to have the same format as other EVM chains do.
 	// There is no access list feature here, so it is always empty just to be compatible.
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
+	if t.Type.IsDynamicFee() {
 		vv.Set(arena.NewArray())
 	}
 
+	// fee payer the sender signs over, its signature follows the sender one
+	if t.Type == FeeDelegatedTx {
+		if t.FeePayer != nil {
+			vv.Set(arena.NewCopyBytes(t.FeePayer.Bytes()))
+		} else {
+			vv.Set(arena.NewNull())
+		}
+	}
+
 	// signature values
 	vv.Set(arena.NewBigInt(t.V))
 	vv.Set(arena.NewBigInt(t.R))
 	vv.Set(arena.NewBigInt(t.S))
 
+	if t.Type == FeeDelegatedTx {
+		vv.Set(arena.NewBigInt(t.FeePayerV))
+		vv.Set(arena.NewBigInt(t.FeePayerR))
+		vv.Set(arena.NewBigInt(t.FeePayerS))
+	}
+
 	if t.Type == StateTx {
 		vv.Set(arena.NewCopyBytes(t.From.Bytes()))
 	}
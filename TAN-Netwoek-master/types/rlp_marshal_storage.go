@@ -95,5 +95,15 @@ func (r *Receipt) marshalStoreRLPWith(a *fastrlp.Arena) *fastrlp.Value {
 	// TxHash
 	vv.Set(a.NewBytes(r.TxHash.Bytes()))
 
+	// revert reason and base fee paid are optional, so the receipts stored without them remain compatible.
+	// The revert reason is stored empty if only the base fee paid is set
+	if len(r.RevertReason) > 0 || r.BaseFeePaid != nil {
+		vv.Set(a.NewCopyBytes(r.RevertReason))
+	}
+
+	if r.BaseFeePaid != nil {
+		vv.Set(a.NewBigInt(r.BaseFeePaid))
+	}
+
 	return vv
 }
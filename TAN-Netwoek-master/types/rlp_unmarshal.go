@@ -422,6 +422,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		num = 10
 	case DynamicFeeTx:
 		num = 12
+	case FeeDelegatedTx:
+		num = 16
 	default:
 		return fmt.Errorf("transaction type %d not found", t.Type)
 	}
@@ -431,7 +433,7 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	}
 
 	// Load Chain ID for dynamic transactions
-	if t.Type == DynamicFeeTx {
+	if t.Type.IsDynamicFee() {
 		t.ChainID = new(big.Int)
 		if err = getElem().GetBigInt(t.ChainID); err != nil {
 			return err
@@ -443,7 +445,7 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	if t.Type == DynamicFeeTx {
+	if t.Type.IsDynamicFee() {
 		// gasTipCap
 		t.GasTipCap = new(big.Int)
 		if err = getElem().GetBigInt(t.GasTipCap); err != nil {
@@ -492,10 +494,20 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	// Skipping Access List field since we don't support it.
 	// This is needed to be compatible with other EVM chains and have the same format.
 	// Since we don't have access list, just skip it here.
-	if t.Type == DynamicFeeTx {
+	if t.Type.IsDynamicFee() {
 		_ = getElem()
 	}
 
+	// fee payer
+	if t.Type == FeeDelegatedTx {
+		if vv, _ := getElem().Bytes(); len(vv) == AddressLength {
+			feePayer := BytesToAddress(vv)
+			t.FeePayer = &feePayer
+		} else {
+			t.FeePayer = nil
+		}
+	}
+
 	// V
 	t.V = new(big.Int)
 	if err = getElem().GetBigInt(t.V); err != nil {
@@ -514,6 +526,24 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
+	if t.Type == FeeDelegatedTx {
+		// fee payer signature values
+		t.FeePayerV = new(big.Int)
+		if err = getElem().GetBigInt(t.FeePayerV); err != nil {
+			return err
+		}
+
+		t.FeePayerR = new(big.Int)
+		if err = getElem().GetBigInt(t.FeePayerR); err != nil {
+			return err
+		}
+
+		t.FeePayerS = new(big.Int)
+		if err = getElem().GetBigInt(t.FeePayerS); err != nil {
+			return err
+		}
+	}
+
 	if t.Type == StateTx {
 		t.From = ZeroAddress
 
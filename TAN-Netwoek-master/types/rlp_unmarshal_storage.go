@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/tarality/fastrlp"
 )
@@ -152,7 +153,7 @@ func (r *Receipt) unmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 	}
 
 	// come TransactionType first if exist
-	if len(elems) == 5 {
+	if elems[0].Type() == fastrlp.TypeBytes {
 		if err = r.TransactionType.unmarshalRLPFrom(p, elems[0]); err != nil {
 			return err
 		}
@@ -182,7 +183,7 @@ func (r *Receipt) unmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 
 	// tx hash
 	// backwards compatibility, old receipts did not marshal a TxHash
-	if len(elems) == 4 {
+	if len(elems) >= 4 {
 		vv, err = elems[3].Bytes()
 		if err != nil {
 			return err
@@ -191,5 +192,24 @@ func (r *Receipt) unmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 		r.TxHash = BytesToHash(vv)
 	}
 
+	// revert reason
+	if len(elems) >= 5 {
+		if r.RevertReason, err = elems[4].GetBytes(nil); err != nil {
+			return err
+		}
+
+		if len(r.RevertReason) == 0 {
+			r.RevertReason = nil
+		}
+	}
+
+	// base fee paid
+	if len(elems) >= 6 {
+		r.BaseFeePaid = new(big.Int)
+		if err = elems[5].GetBigInt(r.BaseFeePaid); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -21,14 +21,14 @@ const (
 	LegacyTx       TxType = 0x0
 	StateTx        TxType = 0x7f
 	DynamicFeeTx   TxType = 0x02
-	specificTypeTx TxType = 0x03
+	FeeDelegatedTx TxType = 0x03
 )
 
 func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx, DynamicFeeTx:
+	case LegacyTx, StateTx, DynamicFeeTx, FeeDelegatedTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -44,11 +44,18 @@ func (t TxType) String() (s string) {
 		return "StateTx"
 	case DynamicFeeTx:
 		return "DynamicFeeTx"
+	case FeeDelegatedTx:
+		return "FeeDelegatedTx"
 	}
 
 	return
 }
 
+// IsDynamicFee returns true if the transaction type carries the EIP-1559 fee fields
+func (t TxType) IsDynamicFee() bool {
+	return t == DynamicFeeTx || t == FeeDelegatedTx
+}
+
 type Transaction struct {
 	Nonce     uint64
 	GasPrice  *big.Int
@@ -66,15 +73,70 @@ type Transaction struct {
 
 	ChainID *big.Int
 
+	// FeePayer pays the gas of the fee delegated transaction instead of the sender.
+	// The fee payer signs the transaction after the sender, FeePayerV, FeePayerR and FeePayerS are its signature values
+	FeePayer                        *Address
+	FeePayerV, FeePayerR, FeePayerS *big.Int
+
+	// AccessList is the list of the addresses and the storage keys the transaction accesses (EIP-2930).
+	// It isn't a part of the encoded transaction, since the access list transaction type is not supported
+	AccessList TxAccessList
+
 	// Cache
 	size atomic.Pointer[uint64]
 }
 
+// AccessTuple is the address and the storage keys of it accessed by the transaction
+type AccessTuple struct {
+	Address     Address
+	StorageKeys []Hash
+}
+
+// TxAccessList is the access list of the transaction (EIP-2930)
+type TxAccessList []AccessTuple
+
+// Copy returns a deep copy of the access list
+func (l TxAccessList) Copy() TxAccessList {
+	if l == nil {
+		return nil
+	}
+
+	cp := make(TxAccessList, len(l))
+	for i, tuple := range l {
+		cp[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash(nil), tuple.StorageKeys...),
+		}
+	}
+
+	return cp
+}
+
+// StorageKeys returns the number of the storage keys in the access list
+func (l TxAccessList) StorageKeys() int {
+	count := 0
+	for _, tuple := range l {
+		count += len(tuple.StorageKeys)
+	}
+
+	return count
+}
+
 // IsContractCreation checks if tx is contract creation
 func (t *Transaction) IsContractCreation() bool {
 	return t.To == nil
 }
 
+// GasPayer returns the address paying the gas of the transaction,
+// which is the fee payer of the fee delegated transaction and the sender otherwise
+func (t *Transaction) GasPayer() Address {
+	if t.Type == FeeDelegatedTx && t.FeePayer != nil {
+		return *t.FeePayer
+	}
+
+	return t.From
+}
+
 // ComputeHash computes the hash of the transaction
 func (t *Transaction) ComputeHash(blockNumber uint64) *Transaction {
 	GetTransactionHashHandler(blockNumber).ComputeHash(t)
@@ -119,11 +181,35 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	if t.FeePayer != nil {
+		feePayer := *t.FeePayer
+		tt.FeePayer = &feePayer
+	}
+
+	if t.FeePayerV != nil {
+		tt.FeePayerV = new(big.Int).Set(t.FeePayerV)
+	}
+
+	if t.FeePayerR != nil {
+		tt.FeePayerR = new(big.Int).Set(t.FeePayerR)
+	}
+
+	if t.FeePayerS != nil {
+		tt.FeePayerS = new(big.Int).Set(t.FeePayerS)
+	}
+
+	tt.AccessList = t.AccessList.Copy()
+
 	return tt
 }
 
 // Cost returns gas * gasPrice + value
 func (t *Transaction) Cost() *big.Int {
+	return new(big.Int).Add(t.GasCost(), t.Value)
+}
+
+// GasCost returns gas * gasPrice, the amount the gas payer must hold to buy the gas of the transaction
+func (t *Transaction) GasCost() *big.Int {
 	var factor *big.Int
 
 	if t.GasFeeCap != nil && t.GasFeeCap.BitLen() > 0 {
@@ -132,10 +218,7 @@ func (t *Transaction) Cost() *big.Int {
 		factor = new(big.Int).Set(t.GasPrice)
 	}
 
-	total := new(big.Int).Mul(factor, new(big.Int).SetUint64(t.Gas))
-	total = total.Add(total, t.Value)
-
-	return total
+	return new(big.Int).Mul(factor, new(big.Int).SetUint64(t.Gas))
 }
 
 // GetGasPrice returns gas price if not empty, or calculates one based on
@@ -205,7 +288,7 @@ func (t *Transaction) EffectiveGasTip(baseFee *big.Int) *big.Int {
 // Spec: https://eips.ethereum.org/EIPS/eip-1559#specification
 func (t *Transaction) GetGasTipCap() *big.Int {
 	switch t.Type {
-	case DynamicFeeTx:
+	case DynamicFeeTx, FeeDelegatedTx:
 		return t.GasTipCap
 	default:
 		return t.GasPrice
@@ -216,10 +299,8 @@ func (t *Transaction) GetGasTipCap() *big.Int {
 // Spec: https://eips.ethereum.org/EIPS/eip-1559#specification
 func (t *Transaction) GetGasFeeCap() *big.Int {
 	switch t.Type {
-	case DynamicFeeTx:
+	case DynamicFeeTx, FeeDelegatedTx:
 		return t.GasFeeCap
-	case specificTypeTx:
-		return big.NewInt(-1)
 	default:
 		return t.GasPrice
 	}
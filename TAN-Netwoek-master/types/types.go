@@ -193,6 +193,17 @@ type Proof struct {
 	Metadata map[string]interface{}
 }
 
+// ValidatorUptime holds signing and proposing statistics of a validator
+type ValidatorUptime struct {
+	Address             Address
+	Blocks              uint64 // number of blocks the validator was expected to sign
+	Signed              uint64
+	Missed              uint64
+	Proposed            uint64
+	LongestMissedStreak uint64
+	CurrentMissedStreak uint64
+}
+
 type OverrideAccount struct {
 	Nonce     *uint64
 	Code      []byte